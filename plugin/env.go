@@ -0,0 +1,42 @@
+package plugin
+
+import (
+	"os"
+	"strings"
+
+	plug "github.com/iures/daivplug"
+)
+
+// envVarName returns the environment variable overriding a setting: the
+// key's EnvVar when the manifest declares one, otherwise the key in upper
+// case with dots replaced by underscores, e.g. JIRA_QUERY_MAX_RESULTS
+func envVarName(key plug.ConfigKey) string {
+	if key.EnvVar != "" {
+		return key.EnvVar
+	}
+	return strings.ToUpper(strings.ReplaceAll(key.Key, ".", "_"))
+}
+
+// envOverrides returns the settings set through environment variables. Empty
+// variables are ignored, like empty settings.
+func envOverrides(keys []plug.ConfigKey) map[string]interface{} {
+	overrides := make(map[string]interface{})
+	for _, key := range keys {
+		if value, ok := os.LookupEnv(envVarName(key)); ok && value != "" {
+			overrides[key.Key] = value
+		}
+	}
+	return overrides
+}
+
+// withOverrides returns a copy of the settings with the overrides applied
+func withOverrides(settings, overrides map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(settings)+len(overrides))
+	for key, value := range settings {
+		result[key] = value
+	}
+	for key, value := range overrides {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+
+	plug "github.com/iures/daivplug"
+)
+
+func TestEnvVarName(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		key      plug.ConfigKey
+		expected string
+	}{
+		{name: "Derived from the key", key: plug.ConfigKey{Key: "jira.url"}, expected: "JIRA_URL"},
+		{name: "Nested key", key: plug.ConfigKey{Key: "jira.query.max_results"}, expected: "JIRA_QUERY_MAX_RESULTS"},
+		{name: "Declared variable", key: plug.ConfigKey{Key: "jira.token", EnvVar: "JIRA_API_TOKEN"}, expected: "JIRA_API_TOKEN"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if name := envVarName(tc.key); name != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, name)
+			}
+		})
+	}
+}
+
+func TestEnvOverrides(t *testing.T) {
+	t.Setenv("JIRA_PROJECT", "ENV")
+	t.Setenv("JIRA_FORMAT", "")
+	t.Setenv("JIRA_ACTIVE_PROFILE", "clientA")
+
+	keys := []plug.ConfigKey{{Key: "jira.project"}, {Key: "jira.format"}, {Key: "jira.url"}, {Key: "jira.active_profile"}}
+	settings := map[string]interface{}{
+		"jira.project":                  "OWN",
+		"jira.format":                   "markdown",
+		"jira.profiles.clientA.project": "CA",
+		"jira.profiles.clientA.format":  "html",
+	}
+
+	overrides := envOverrides(keys)
+	if len(overrides) != 2 || overrides["jira.project"] != "ENV" {
+		t.Fatalf("Expected only the non-empty variables as overrides, got %v", overrides)
+	}
+
+	// The environment selects the profile and takes precedence over it
+	result, err := applyProfile(withOverrides(settings, overrides))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result = withOverrides(result, overrides)
+
+	expected := map[string]string{"jira.project": "ENV", "jira.format": "html"}
+	for key, value := range expected {
+		if result[key] != value {
+			t.Errorf("Expected %s to be '%s', got '%v'", key, value, result[key])
+		}
+	}
+	if settings["jira.project"] != "OWN" {
+		t.Errorf("Expected the settings to be left unchanged, got %v", settings["jira.project"])
+	}
+}
+
+func TestJiraPlugin_SwitchProfile_PinnedByEnvironment(t *testing.T) {
+	t.Setenv("JIRA_ACTIVE_PROFILE", "clientA")
+
+	// Reloading would apply the environment again and keep clientA active
+	err := New().SwitchProfile("clientB")
+	if err == nil || !strings.Contains(err.Error(), `JIRA_ACTIVE_PROFILE selects profile "clientA"`) {
+		t.Errorf("Expected an error about the pinned profile, got %v", err)
+	}
+}
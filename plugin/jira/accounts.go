@@ -0,0 +1,56 @@
+package jira
+
+import (
+	"strings"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// formerUser is how Jira Cloud shows an account deleted or anonymized under GDPR
+const formerUser = "Former user"
+
+// displayName returns the name to show for a user. Jira Cloud leaves the name
+// out for accounts deleted or anonymized under GDPR, and the whole author out
+// of their comments and changes; those are shown as a former user, as Jira does.
+func displayName(user extJira.User) string {
+	if strings.TrimSpace(user.DisplayName) == "" {
+		return formerUser
+	}
+	return user.DisplayName
+}
+
+// isAccount reports whether the account ID, as returned by userID, is the
+// user's. Display names are never compared since users can rename themselves,
+// and an author without an account ID, such as an anonymized one, is nobody.
+func isAccount(accountID, userAccountID string) bool {
+	return accountID != "" && accountID == userAccountID
+}
+
+// userID identifies a user in comparisons with User.ID: by account ID, or by
+// username when the account ID is missing, as on Jira Server, whose
+// changelogs and mentions also record users by username
+func userID(user extJira.User) string {
+	if user.AccountID != "" {
+		return user.AccountID
+	}
+	return user.Name
+}
+
+// sameUser reports whether two users recorded by account ID and name are the
+// same. Names are only compared when an account ID is missing, as on Jira
+// Server, which identifies users by username instead.
+func sameUser(accountID, name, otherAccountID, otherName string) bool {
+	if accountID != "" && otherAccountID != "" {
+		return accountID == otherAccountID
+	}
+	return name != "" && name == otherName
+}
+
+// userKey identifies a user among the authors of a report: the account ID, or
+// the name of an author recorded without one
+func userKey(accountID, name string) string {
+	if accountID != "" {
+		return accountID
+	}
+	return "name:" + name
+}
@@ -0,0 +1,161 @@
+package jira
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestDisplayName(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		user     extJira.User
+		expected string
+	}{
+		{name: "Named", user: extJira.User{AccountID: "user123", DisplayName: "Test User"}, expected: "Test User"},
+		{name: "Anonymized", user: extJira.User{AccountID: "5b10ac8d82e05b22cc7d4ef5"}, expected: formerUser},
+		{name: "Left out", user: extJira.User{}, expected: formerUser},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if name := displayName(tc.user); name != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, name)
+			}
+		})
+	}
+}
+
+func TestSameUser(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name      string
+		accountID string
+		userName  string
+		otherID   string
+		otherName string
+		expected  bool
+	}{
+		{name: "Same account renamed", accountID: "user123", userName: "Test U.", otherID: "user123", otherName: "Test User", expected: true},
+		{name: "Namesakes", accountID: "user123", userName: "Test User", otherID: "dev9", otherName: "Test User", expected: false},
+		{name: "Without account IDs", userName: "Test User", otherName: "Test User", expected: true},
+		{name: "Anonymized", otherID: "user123", otherName: "Test User", expected: false},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if same := sameUser(tc.accountID, tc.userName, tc.otherID, tc.otherName); same != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, same)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_AnonymizedAuthors(t *testing.T) {
+	options := DefaultReportOptions()
+	options.IncludeOthersChanges = true
+	repo := &JiraAPIRepository{config: &JiraConfig{ReportOptions: options}}
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	comments := repo.processComments([]*extJira.Comment{
+		{ID: "1", Created: "2023-01-01T10:00:00.000+0000", Author: extJira.User{AccountID: "5b10ac8d82e05b22cc7d4ef5"}, Body: "Reviewed"},
+	}, timeRange)
+	if len(comments) != 1 || comments[0].Author != formerUser || comments[0].AuthorAccountID != "5b10ac8d82e05b22cc7d4ef5" {
+		t.Errorf("Expected a comment by a former user, got %+v", comments)
+	}
+
+	// An author left out is nobody, not even a user without an account ID
+	changes := repo.processChangelog([]extJira.ChangelogHistory{
+		{Created: "2023-01-01T11:00:00.000+0000", Items: []extJira.ChangelogItems{{Field: "status", FromString: "Open", ToString: "Done"}}},
+	}, timeRange, "", Issue{})
+	if len(changes) != 1 || changes[0].Author != formerUser || changes[0].AuthorRole != RoleThirdParty {
+		t.Errorf("Expected a change by a former user as a third party, got %+v", changes)
+	}
+}
+
+func TestActivityService_JiraServerUsers(t *testing.T) {
+	// Jira Server identifies users by username and key, without account IDs
+	self := extJira.User{Name: "jdoe", Key: "JIRAUSER10000", DisplayName: "Jane Doe"}
+	colleague := extJira.User{Name: "bsmith", Key: "JIRAUSER10001", DisplayName: "Bob Smith"}
+	history := func(author extJira.User, created, to string) extJira.ChangelogHistory {
+		return extJira.ChangelogHistory{
+			Created: created,
+			Author:  author,
+			Items:   []extJira.ChangelogItems{{Field: "status", FromString: "Open", ToString: to}},
+		}
+	}
+
+	config := &JiraConfig{Project: "TEST", QueryOptions: DefaultQueryOptions(), ReportOptions: DefaultReportOptions()}
+	repo, server := newServerRepository(t, config)
+	server.DeploymentType = "Server"
+	server.Self = &self
+	server.Issues = []extJira.Issue{
+		{
+			Key: "TEST-1",
+			Fields: &extJira.IssueFields{
+				Summary: "Server issue",
+				Status:  &extJira.Status{Name: "In Review"},
+				Comments: &extJira.Comments{Comments: []*extJira.Comment{
+					{ID: "1", Created: "2023-01-01T09:00:00.000+0000", Author: self, Body: "Ready for review"},
+					{ID: "2", Created: "2023-01-01T12:00:00.000+0000", Author: colleague, Body: "[~jdoe] can you look at this?"},
+				}},
+			},
+			Changelog: &extJira.Changelog{Histories: []extJira.ChangelogHistory{
+				history(self, "2023-01-01T10:00:00.000+0000", "In Review"),
+				history(colleague, "2023-01-01T11:00:00.000+0000", "Blocked"),
+			}},
+		},
+	}
+
+	service := NewActivityService(repo)
+	service.SetReportOptions(config.ReportOptions)
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Issues) != 1 {
+		t.Fatalf("Expected one issue, got %d", len(report.Issues))
+	}
+	issue := report.Issues[0]
+
+	// The user's own change is matched by username and the colleague's left out
+	if len(issue.Changes) != 1 || issue.Changes[0].ToValue != "In Review" || !issue.Changes[0].IsCurrentUser {
+		t.Errorf("Expected only the user's own change, got %+v", issue.Changes)
+	}
+
+	// The colleague's mention of the username is flagged, the user's own comment is not
+	if len(issue.Comments) != 2 || issue.Comments[0].MentionsUser || !issue.Comments[1].MentionsUser {
+		t.Errorf("Expected only the colleague's comment to mention the user, got %+v", issue.Comments)
+	}
+}
+
+func TestDigestByComponent_Namesakes(t *testing.T) {
+	issues := []Issue{
+		{
+			Key:        "JIRA-1",
+			Components: []string{"API"},
+			Comments: []Comment{
+				{Author: "Alex Kim", AuthorAccountID: "dev1"},
+				{Author: "Alex Kim", AuthorAccountID: "dev2"},
+				{Author: "Alex Kim", AuthorAccountID: "dev2"},
+			},
+		},
+	}
+
+	digests := DigestByComponent(issues)
+	if len(digests) != 1 || !reflect.DeepEqual(digests[0].Contributors, []string{"Alex Kim", "Alex Kim"}) {
+		t.Errorf("Expected both people named Alex Kim as contributors, got %+v", digests)
+	}
+}
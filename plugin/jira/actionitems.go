@@ -0,0 +1,116 @@
+package jira
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Patterns recognised as action items inside comment and description text
+var (
+	// Checkbox list entries such as "- [ ] write tests" or "* [x] deploy"
+	checkboxPattern = regexp.MustCompile(`^\s*(?:[-*+#]\s+)?\[([ xX]?)\]\s+(\S.*?)\s*$`)
+
+	// TODO markers such as "TODO: update docs" or "TODO rotate keys"
+	todoPattern = regexp.MustCompile(`\bTODO\b[:\-\s]*(\S.*?)\s*$`)
+)
+
+// ExtractActionItems finds checkbox entries and TODO markers in the given text
+func ExtractActionItems(text string) []ActionItem {
+	items := make([]ActionItem, 0)
+
+	for _, line := range strings.Split(text, "\n") {
+		if match := checkboxPattern.FindStringSubmatch(line); match != nil {
+			// Unicode spaces such as non-breaking spaces are not matched by \s
+			if text := strings.TrimSpace(match[2]); text != "" {
+				items = append(items, ActionItem{
+					Text: text,
+					Done: strings.EqualFold(match[1], "x"),
+				})
+			}
+			continue
+		}
+
+		if match := todoPattern.FindStringSubmatch(line); match != nil {
+			if text := strings.TrimSpace(match[1]); text != "" {
+				items = append(items, ActionItem{
+					Text: text,
+					Done: false,
+				})
+			}
+		}
+	}
+
+	return items
+}
+
+// SummarizeActionItems collects the action items added or completed on an issue
+// from its in-range comments and description edits
+func SummarizeActionItems(issue Issue) ActionItemSummary {
+	summary := ActionItemSummary{}
+	seen := make(map[string]bool)
+
+	add := func(item ActionItem) {
+		key := actionItemKey(item)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+
+		if item.Done {
+			summary.Completed = append(summary.Completed, item)
+		} else {
+			summary.Added = append(summary.Added, item)
+		}
+	}
+
+	// Items written in comments during the range are new by definition
+	for _, comment := range issue.Comments {
+		for _, item := range ExtractActionItems(comment.Content) {
+			add(item)
+		}
+	}
+
+	// Description edits are compared against the previous version
+	for _, change := range issue.Changes {
+		if change.Field != "description" {
+			continue
+		}
+
+		for _, item := range diffActionItems(ExtractActionItems(change.FromValue), ExtractActionItems(change.ToValue)) {
+			add(item)
+		}
+	}
+
+	return summary
+}
+
+// diffActionItems returns the items that are new or newly checked in after
+func diffActionItems(before, after []ActionItem) []ActionItem {
+	previous := make(map[string]ActionItem, len(before))
+	for _, item := range before {
+		previous[normalizeActionItemText(item.Text)] = item
+	}
+
+	result := make([]ActionItem, 0)
+	for _, item := range after {
+		old, existed := previous[normalizeActionItemText(item.Text)]
+		if !existed || (item.Done && !old.Done) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// actionItemKey identifies an action item for deduplication
+func actionItemKey(item ActionItem) string {
+	if item.Done {
+		return "x:" + normalizeActionItemText(item.Text)
+	}
+	return " :" + normalizeActionItemText(item.Text)
+}
+
+// normalizeActionItemText lowercases and collapses whitespace for comparisons
+func normalizeActionItemText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
@@ -0,0 +1,154 @@
+package jira
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractActionItems(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		text     string
+		expected []ActionItem
+	}{
+		{
+			name:     "No action items",
+			text:     "Just a regular comment",
+			expected: []ActionItem{},
+		},
+		{
+			name: "Markdown checkboxes",
+			text: "Plan:\n- [ ] write tests\n- [x] fix parser\n* [X] update docs",
+			expected: []ActionItem{
+				{Text: "write tests", Done: false},
+				{Text: "fix parser", Done: true},
+				{Text: "update docs", Done: true},
+			},
+		},
+		{
+			name: "Bare checkbox without bullet",
+			text: "[] ping infra team",
+			expected: []ActionItem{
+				{Text: "ping infra team", Done: false},
+			},
+		},
+		{
+			name: "TODO markers",
+			text: "Looks good.\nTODO: rotate the keys\nTODO - bump version",
+			expected: []ActionItem{
+				{Text: "rotate the keys", Done: false},
+				{Text: "bump version", Done: false},
+			},
+		},
+		{
+			name:     "Lowercase todo is ignored",
+			text:     "nothing todo here",
+			expected: []ActionItem{},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ExtractActionItems(tc.text)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestSummarizeActionItems(t *testing.T) {
+	timestamp := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Setup test cases
+	testCases := []struct {
+		name              string
+		issue             Issue
+		expectedAdded     []string
+		expectedCompleted []string
+	}{
+		{
+			name: "Items from comments",
+			issue: Issue{
+				Comments: []Comment{
+					{Timestamp: timestamp, Content: "- [ ] add retries\n- [x] reproduce bug"},
+					{Timestamp: timestamp, Content: "TODO: add retries"},
+				},
+			},
+			expectedAdded:     []string{"add retries"},
+			expectedCompleted: []string{"reproduce bug"},
+		},
+		{
+			name: "Items from description edits",
+			issue: Issue{
+				Changes: []Change{
+					{
+						Timestamp: timestamp,
+						Field:     "description",
+						FromValue: "- [ ] design\n- [ ] implement",
+						ToValue:   "- [x] design\n- [ ] implement\n- [ ] review",
+					},
+				},
+			},
+			expectedAdded:     []string{"review"},
+			expectedCompleted: []string{"design"},
+		},
+		{
+			name: "Other field changes are ignored",
+			issue: Issue{
+				Changes: []Change{
+					{Timestamp: timestamp, Field: "summary", FromValue: "", ToValue: "- [ ] not an item"},
+				},
+			},
+			expectedAdded:     nil,
+			expectedCompleted: nil,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			summary := SummarizeActionItems(tc.issue)
+
+			added := make([]string, 0)
+			for _, item := range summary.Added {
+				added = append(added, item.Text)
+			}
+			completed := make([]string, 0)
+			for _, item := range summary.Completed {
+				completed = append(completed, item.Text)
+			}
+
+			if len(added) != len(tc.expectedAdded) || (len(added) > 0 && !reflect.DeepEqual(added, tc.expectedAdded)) {
+				t.Errorf("Expected added items %v, got %v", tc.expectedAdded, added)
+			}
+			if len(completed) != len(tc.expectedCompleted) || (len(completed) > 0 && !reflect.DeepEqual(completed, tc.expectedCompleted)) {
+				t.Errorf("Expected completed items %v, got %v", tc.expectedCompleted, completed)
+			}
+		})
+	}
+}
+
+func FuzzExtractActionItems(f *testing.F) {
+	for _, seed := range []string{
+		"- [ ] write tests\n* [x] deploy\nTODO: update docs",
+		"# [X] heading item\r\nTODO\n[]",
+		"TODO:-  \n- [ ]   ",
+		"{task}[ ] Jira markup{task}",
+		"- [ ] \u00a0\nTODO \u3000",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		for _, item := range ExtractActionItems(text) {
+			if strings.TrimSpace(item.Text) == "" || strings.Contains(item.Text, "\n") {
+				t.Errorf("Expected a non-empty single-line item, got %q from %q", item.Text, text)
+			}
+		}
+	})
+}
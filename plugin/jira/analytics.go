@@ -0,0 +1,226 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// computesStats reports whether the options need velocity statistics, either
+// for the report itself or for the analytics export
+func (o ReportOptions) computesStats() bool {
+	return o.IncludeStats || o.AnalyticsPath != ""
+}
+
+// Analytics is the machine-readable analytics of a report window, exported
+// separately from the report for ingestion into dashboards
+type Analytics struct {
+	Window     TimeRange
+	Throughput VelocityStats
+	Trailing   []VelocityStats // Most recent first
+	Issues     []IssueAnalytics
+}
+
+// IssueAnalytics holds the flow metrics of a single issue
+type IssueAnalytics struct {
+	Key          string
+	Summary      string
+	Status       string
+	CycleTime    time.Duration // Zero when not measured
+	LeadTime     time.Duration // Zero when not measured
+	TimeInStatus map[string]time.Duration
+}
+
+// BuildAnalytics collects the analytics of the report's issues from their
+// status history and the report window's statistics
+func BuildAnalytics(timeRange TimeRange, issues []Issue, stats *StatsBlock) Analytics {
+	analytics := Analytics{
+		Window:     timeRange,
+		Throughput: VelocityStats{WindowStart: timeRange.Start, WindowEnd: timeRange.End},
+		Issues:     make([]IssueAnalytics, 0, len(issues)),
+	}
+	if stats != nil {
+		analytics.Throughput = stats.Current
+		analytics.Trailing = stats.Trailing
+	}
+
+	for _, issue := range issues {
+		analytics.Issues = append(analytics.Issues, IssueAnalytics{
+			Key:          issue.Key,
+			Summary:      issue.Summary,
+			Status:       issue.Status,
+			CycleTime:    issue.CycleTime,
+			LeadTime:     issue.LeadTime,
+			TimeInStatus: timeInStatus(issue, timeRange.End),
+		})
+	}
+	return analytics
+}
+
+// timeInStatus sums how long an issue spent in each status up to the given
+// time. Time before the first recorded transition is counted from the issue's
+// creation when it is known.
+func timeInStatus(issue Issue, until time.Time) map[string]time.Duration {
+	result := make(map[string]time.Duration)
+
+	changes := make([]Change, 0)
+	for _, change := range statusChanges(issue) {
+		if strings.EqualFold(change.Field, statusField) && !change.Timestamp.After(until) {
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 {
+		return result
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Timestamp.Before(changes[j].Timestamp)
+	})
+
+	current := changes[0].FromValue
+	since := issue.Created
+	for _, change := range changes {
+		if current != "" && !since.IsZero() && change.Timestamp.After(since) {
+			result[current] += change.Timestamp.Sub(since)
+		}
+		current = change.ToValue
+		since = change.Timestamp
+	}
+	if current != "" && until.After(since) {
+		result[current] += until.Sub(since)
+	}
+	return result
+}
+
+// WriteAnalytics writes the analytics to the given path, as CSV when the path
+// ends in .csv and as JSON otherwise
+func WriteAnalytics(path string, analytics Analytics) error {
+	var data []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		data, err = analytics.CSV()
+	} else {
+		data, err = analytics.JSON()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode analytics: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create analytics directory: %w", err)
+	}
+
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write analytics: %w", err)
+	}
+	return nil
+}
+
+// JSON renders the analytics as an indented JSON document with durations in hours
+func (a Analytics) JSON() ([]byte, error) {
+	type jsonThroughput struct {
+		WindowStart           string  `json:"windowStart"`
+		WindowEnd             string  `json:"windowEnd"`
+		IssuesCompleted       int     `json:"issuesCompleted"`
+		PointsCompleted       float64 `json:"pointsCompleted"`
+		AverageCycleTimeHours float64 `json:"averageCycleTimeHours"`
+	}
+
+	type jsonIssueAnalytics struct {
+		Key               string             `json:"key"`
+		Summary           string             `json:"summary"`
+		Status            string             `json:"status"`
+		CycleTimeHours    float64            `json:"cycleTimeHours,omitempty"`
+		LeadTimeHours     float64            `json:"leadTimeHours,omitempty"`
+		TimeInStatusHours map[string]float64 `json:"timeInStatusHours"`
+	}
+
+	type jsonAnalytics struct {
+		Throughput jsonThroughput       `json:"throughput"`
+		Trailing   []jsonThroughput     `json:"trailing"`
+		Issues     []jsonIssueAnalytics `json:"issues"`
+	}
+
+	toJSONThroughput := func(stats VelocityStats) jsonThroughput {
+		return jsonThroughput{
+			WindowStart:           stats.WindowStart.Format(time.RFC3339),
+			WindowEnd:             stats.WindowEnd.Format(time.RFC3339),
+			IssuesCompleted:       stats.IssuesCompleted,
+			PointsCompleted:       stats.PointsCompleted,
+			AverageCycleTimeHours: stats.AverageCycleTime.Hours(),
+		}
+	}
+
+	result := jsonAnalytics{
+		Throughput: toJSONThroughput(a.Throughput),
+		Trailing:   make([]jsonThroughput, 0, len(a.Trailing)),
+		Issues:     make([]jsonIssueAnalytics, 0, len(a.Issues)),
+	}
+	for _, window := range a.Trailing {
+		result.Trailing = append(result.Trailing, toJSONThroughput(window))
+	}
+	for _, issue := range a.Issues {
+		hours := make(map[string]float64, len(issue.TimeInStatus))
+		for status, duration := range issue.TimeInStatus {
+			hours[status] = duration.Hours()
+		}
+		result.Issues = append(result.Issues, jsonIssueAnalytics{
+			Key:               issue.Key,
+			Summary:           issue.Summary,
+			Status:            issue.Status,
+			CycleTimeHours:    issue.CycleTime.Hours(),
+			LeadTimeHours:     issue.LeadTime.Hours(),
+			TimeInStatusHours: hours,
+		})
+	}
+
+	return json.MarshalIndent(result, "", "  ")
+}
+
+// CSV renders the analytics in long format, one metric per row, so that
+// dashboards can pivot on the metric, issue and status columns
+func (a Analytics) CSV() ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	start := a.Window.Start.Format(time.RFC3339)
+	end := a.Window.End.Format(time.RFC3339)
+	hours := func(d time.Duration) string {
+		return strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+	}
+
+	rows := [][]string{
+		{"metric", "window_start", "window_end", "issue", "status", "value"},
+		{"issues_completed", start, end, "", "", strconv.Itoa(a.Throughput.IssuesCompleted)},
+		{"points_completed", start, end, "", "", strconv.FormatFloat(a.Throughput.PointsCompleted, 'f', -1, 64)},
+		{"average_cycle_time_hours", start, end, "", "", hours(a.Throughput.AverageCycleTime)},
+	}
+	for _, issue := range a.Issues {
+		if issue.CycleTime > 0 {
+			rows = append(rows, []string{"cycle_time_hours", start, end, issue.Key, issue.Status, hours(issue.CycleTime)})
+		}
+		if issue.LeadTime > 0 {
+			rows = append(rows, []string{"lead_time_hours", start, end, issue.Key, issue.Status, hours(issue.LeadTime)})
+		}
+
+		statuses := make([]string, 0, len(issue.TimeInStatus))
+		for status := range issue.TimeInStatus {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			rows = append(rows, []string{"time_in_status_hours", start, end, issue.Key, status, hours(issue.TimeInStatus[status])})
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
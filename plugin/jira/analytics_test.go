@@ -0,0 +1,221 @@
+package jira
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestTimeInStatus(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 1, d, 9, 0, 0, 0, time.UTC) }
+	history := []Change{
+		{Field: "status", FromValue: "Open", ToValue: "In Progress", Timestamp: day(3)},
+		{Field: "status", FromValue: "In Progress", ToValue: "In Review", Timestamp: day(5)},
+		{Field: "status", FromValue: "In Review", ToValue: "In Progress", Timestamp: day(6)},
+		{Field: "status", FromValue: "In Progress", ToValue: "Done", Timestamp: day(7)},
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		issue    Issue
+		until    time.Time
+		expected map[string]time.Duration
+	}{
+		{
+			name:  "Creation time known",
+			issue: Issue{Created: day(1), StatusHistory: history},
+			until: day(8),
+			expected: map[string]time.Duration{
+				"Open":        48 * time.Hour,
+				"In Progress": 72 * time.Hour,
+				"In Review":   24 * time.Hour,
+				"Done":        24 * time.Hour,
+			},
+		},
+		{
+			name:  "Creation time unknown skips the initial status",
+			issue: Issue{StatusHistory: history},
+			until: day(8),
+			expected: map[string]time.Duration{
+				"In Progress": 72 * time.Hour,
+				"In Review":   24 * time.Hour,
+				"Done":        24 * time.Hour,
+			},
+		},
+		{
+			name:  "Transitions after the end are ignored",
+			issue: Issue{Created: day(1), StatusHistory: history},
+			until: day(4),
+			expected: map[string]time.Duration{
+				"Open":        48 * time.Hour,
+				"In Progress": 24 * time.Hour,
+			},
+		},
+		{
+			name:     "No status changes",
+			issue:    Issue{Created: day(1), Changes: []Change{{Field: "summary", Timestamp: day(2)}}},
+			until:    day(8),
+			expected: map[string]time.Duration{},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := timeInStatus(tc.issue, tc.until)
+
+			if len(result) != len(tc.expected) {
+				t.Errorf("Expected %d statuses, got %v", len(tc.expected), result)
+			}
+			for status, expected := range tc.expected {
+				if result[status] != expected {
+					t.Errorf("Expected %v in %s, got %v", expected, status, result[status])
+				}
+			}
+		})
+	}
+}
+
+func TestWriteAnalytics(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC),
+	}
+	analytics := Analytics{
+		Window:     timeRange,
+		Throughput: VelocityStats{WindowStart: timeRange.Start, WindowEnd: timeRange.End, IssuesCompleted: 1, PointsCompleted: 2.5, AverageCycleTime: 36 * time.Hour},
+		Issues: []IssueAnalytics{
+			{
+				Key:          "JIRA-1",
+				Summary:      "Shipped",
+				Status:       "Done",
+				CycleTime:    36 * time.Hour,
+				LeadTime:     72 * time.Hour,
+				TimeInStatus: map[string]time.Duration{"In Progress": 36 * time.Hour, "Open": 36 * time.Hour},
+			},
+		},
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "exports", "analytics.csv")
+		if err := WriteAnalytics(path, analytics); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer file.Close()
+
+		rows, err := csv.NewReader(file).ReadAll()
+		if err != nil {
+			t.Fatalf("Expected valid CSV, got %v", err)
+		}
+
+		expected := [][]string{
+			{"metric", "window_start", "window_end", "issue", "status", "value"},
+			{"issues_completed", "2023-01-02T00:00:00Z", "2023-01-09T00:00:00Z", "", "", "1"},
+			{"points_completed", "2023-01-02T00:00:00Z", "2023-01-09T00:00:00Z", "", "", "2.5"},
+			{"average_cycle_time_hours", "2023-01-02T00:00:00Z", "2023-01-09T00:00:00Z", "", "", "36.00"},
+			{"cycle_time_hours", "2023-01-02T00:00:00Z", "2023-01-09T00:00:00Z", "JIRA-1", "Done", "36.00"},
+			{"lead_time_hours", "2023-01-02T00:00:00Z", "2023-01-09T00:00:00Z", "JIRA-1", "Done", "72.00"},
+			{"time_in_status_hours", "2023-01-02T00:00:00Z", "2023-01-09T00:00:00Z", "JIRA-1", "In Progress", "36.00"},
+			{"time_in_status_hours", "2023-01-02T00:00:00Z", "2023-01-09T00:00:00Z", "JIRA-1", "Open", "36.00"},
+		}
+		if len(rows) != len(expected) {
+			t.Fatalf("Expected %d rows, got %d: %v", len(expected), len(rows), rows)
+		}
+		for i := range expected {
+			if strings.Join(rows[i], ",") != strings.Join(expected[i], ",") {
+				t.Errorf("Expected row %d to be %v, got %v", i, expected[i], rows[i])
+			}
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "analytics.json")
+		if err := WriteAnalytics(path, analytics); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var decoded struct {
+			Throughput struct {
+				IssuesCompleted       int     `json:"issuesCompleted"`
+				AverageCycleTimeHours float64 `json:"averageCycleTimeHours"`
+			} `json:"throughput"`
+			Issues []struct {
+				Key               string             `json:"key"`
+				LeadTimeHours     float64            `json:"leadTimeHours"`
+				TimeInStatusHours map[string]float64 `json:"timeInStatusHours"`
+			} `json:"issues"`
+		}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+
+		if decoded.Throughput.IssuesCompleted != 1 || decoded.Throughput.AverageCycleTimeHours != 36 {
+			t.Errorf("Expected the throughput, got %+v", decoded.Throughput)
+		}
+		if len(decoded.Issues) != 1 || decoded.Issues[0].LeadTimeHours != 72 || decoded.Issues[0].TimeInStatusHours["Open"] != 36 {
+			t.Errorf("Expected the issue analytics, got %+v", decoded.Issues)
+		}
+	})
+}
+
+func TestActivityService_AnalyticsExport(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{Key: "JIRA-1", Summary: "Shipped", Status: "Done", Changes: []Change{
+					statusChange("In Progress", time.Date(2023, 1, 9, 9, 0, 0, 0, time.UTC)),
+					statusChange("Done", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC)),
+				}},
+			}, nil
+		},
+	}
+
+	// Export analytics without adding statistics to the report
+	options := DefaultReportOptions()
+	options.AnalyticsPath = filepath.Join(t.TempDir(), "analytics.csv")
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 16, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if report.Stats != nil {
+		t.Errorf("Expected no stats in the report, got %+v", report.Stats)
+	}
+
+	data, err := os.ReadFile(options.AnalyticsPath)
+	if err != nil {
+		t.Fatalf("Expected the analytics file to be written, got %v", err)
+	}
+	for _, expected := range []string{"issues_completed,2023-01-09T00:00:00Z,2023-01-16T00:00:00Z,,,1", "cycle_time_hours,2023-01-09T00:00:00Z,2023-01-16T00:00:00Z,JIRA-1,Done,24.00"} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expected the export to contain '%s', got '%s'", expected, string(data))
+		}
+	}
+}
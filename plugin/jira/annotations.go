@@ -0,0 +1,154 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Note is a side note the user pinned to an issue, such as "waiting on infra
+// team", shown with the issue in every report until it is cleared
+type Note struct {
+	Text    string    `json:"text"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// AnnotationStore keeps the notes pinned to each issue between reports
+type AnnotationStore interface {
+	Notes(key string) ([]Note, error)
+	AddNote(key string, note Note) error
+	ClearNotes(key string) error
+}
+
+// FileAnnotationStore is an AnnotationStore persisted as a JSON file
+type FileAnnotationStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileAnnotationStore creates an annotation store stored at the given path
+func NewFileAnnotationStore(path string) *FileAnnotationStore {
+	return &FileAnnotationStore{path: path}
+}
+
+// DefaultAnnotationStorePath returns the default location of the annotation store
+func DefaultAnnotationStorePath() (string, error) {
+	return defaultStorePath(os.UserConfigDir, "notes.json")
+}
+
+// Notes returns the notes pinned to an issue, oldest first
+func (s *FileAnnotationStore) Notes(key string) ([]Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notes, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return notes[strings.ToUpper(key)], nil
+}
+
+// AddNote pins a note to an issue and writes the store file
+func (s *FileAnnotationStore) AddNote(key string, note Note) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notes, err := s.load()
+	if err != nil {
+		return err
+	}
+	key = strings.ToUpper(key)
+	notes[key] = append(notes[key], note)
+	return s.save(notes)
+}
+
+// ClearNotes removes every note pinned to an issue and writes the store file
+func (s *FileAnnotationStore) ClearNotes(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notes, err := s.load()
+	if err != nil {
+		return err
+	}
+	delete(notes, strings.ToUpper(key))
+	return s.save(notes)
+}
+
+// load reads the notes by issue key; a missing file is an empty store
+func (s *FileAnnotationStore) load() (map[string][]Note, error) {
+	notes := make(map[string][]Note)
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return notes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notes: %w", err)
+	}
+	if err := json.Unmarshal(data, &notes); err != nil {
+		return nil, fmt.Errorf("failed to decode notes: %w", err)
+	}
+	return notes, nil
+}
+
+// save writes the notes by issue key
+func (s *FileAnnotationStore) save(notes map[string][]Note) error {
+	data, err := json.MarshalIndent(notes, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode notes: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create notes directory: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to write notes: %w", err)
+	}
+	return nil
+}
+
+// noteTexts returns the text of each note
+func noteTexts(notes []Note) []string {
+	texts := make([]string, 0, len(notes))
+	for _, note := range notes {
+		texts = append(texts, note.Text)
+	}
+	return texts
+}
+
+// AddNote pins a note to an issue, to be shown with it in subsequent reports
+func (s *ActivityService) AddNote(issueKey, text string) error {
+	if s.notes == nil {
+		return fmt.Errorf("no annotation store is configured")
+	}
+	issueKey, text = strings.TrimSpace(issueKey), strings.TrimSpace(text)
+	if issueKey == "" || text == "" {
+		return fmt.Errorf("a note needs an issue key and text")
+	}
+	return s.notes.AddNote(issueKey, Note{Text: text, AddedAt: s.Now()})
+}
+
+// ClearNotes removes every note pinned to an issue
+func (s *ActivityService) ClearNotes(issueKey string) error {
+	if s.notes == nil {
+		return fmt.Errorf("no annotation store is configured")
+	}
+	return s.notes.ClearNotes(strings.TrimSpace(issueKey))
+}
+
+// attachNotes adds the notes pinned to each issue. Failures are logged, since
+// the report is still useful without the notes.
+func (s *ActivityService) attachNotes(issues []Issue) {
+	for i := range issues {
+		notes, err := s.notes.Notes(issues[i].Key)
+		if err != nil {
+			s.logger.Printf("failed to read the notes of %s: %v", issues[i].Key, err)
+			return
+		}
+		issues[i].Notes = notes
+	}
+}
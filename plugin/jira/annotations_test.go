@@ -0,0 +1,122 @@
+package jira
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestFileAnnotationStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daiv-jira", "notes.json")
+	store := NewFileAnnotationStore(path)
+	at := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	if err := store.AddNote("test-1", Note{Text: "waiting on infra team", AddedAt: at}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := store.AddNote("TEST-1", Note{Text: "pairing with Alice", AddedAt: at.Add(time.Hour)}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A new store reads the notes back, whatever the case of the key
+	notes, err := NewFileAnnotationStore(path).Notes("Test-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(notes) != 2 || notes[0].Text != "waiting on infra team" || !notes[0].AddedAt.Equal(at) {
+		t.Errorf("Expected both notes oldest first, got %+v", notes)
+	}
+
+	if err := store.ClearNotes("TEST-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if notes, _ := store.Notes("TEST-1"); len(notes) != 0 {
+		t.Errorf("Expected no notes after clearing, got %+v", notes)
+	}
+}
+
+func TestActivityService_AddNote(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		store       bool
+		key         string
+		text        string
+		expectError bool
+	}{
+		{name: "Pinned", store: true, key: " TEST-1 ", text: "waiting on infra team"},
+		{name: "Empty text", store: true, key: "TEST-1", text: "  ", expectError: true},
+		{name: "No store", store: false, key: "TEST-1", text: "waiting on infra team", expectError: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewActivityService(&MockJiraRepository{})
+			store := NewFileAnnotationStore(filepath.Join(t.TempDir(), "notes.json"))
+			if tc.store {
+				service.SetAnnotationStore(store)
+			}
+
+			err := service.AddNote(tc.key, tc.text)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if notes, _ := store.Notes("TEST-1"); len(notes) != 1 || notes[0].Text != tc.text {
+				t.Errorf("Expected the note to be stored, got %+v", notes)
+			}
+		})
+	}
+}
+
+func TestActivityService_Notes(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "TEST-1", Status: "In Progress"}, {Key: "TEST-2", Status: "In Progress"}}, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "TEST-3", Status: "In Progress"}}, nil
+		},
+	}
+
+	store := NewFileAnnotationStore(filepath.Join(t.TempDir(), "notes.json"))
+	store.AddNote("TEST-1", Note{Text: "waiting on infra team"})
+	store.AddNote("TEST-3", Note{Text: "blocked on review"})
+
+	options := DefaultReportOptions()
+	options.IncludeCarryOver = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+	service.SetAnnotationStore(store)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(report.Issues[0].Notes) != 1 || report.Issues[0].Notes[0].Text != "waiting on infra team" {
+		t.Errorf("Expected the note on TEST-1, got %+v", report.Issues[0].Notes)
+	}
+	if len(report.Issues[1].Notes) != 0 {
+		t.Errorf("Expected no notes on TEST-2, got %+v", report.Issues[1].Notes)
+	}
+	if len(report.CarryOver) != 1 || len(report.CarryOver[0].Notes) != 1 {
+		t.Errorf("Expected the note on the carry-over issue, got %+v", report.CarryOver)
+	}
+}
@@ -0,0 +1,33 @@
+package jira
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic replaces the file at path with data, readable by the owner
+// only. It writes through a temporary file in the same directory and renames
+// it over the target, so that a crash never leaves a truncated file and
+// readers see either the old or the new content. Each write gets a temporary
+// file of its own, so that processes saving the same file at once cannot
+// clobber each other's half-written data.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,51 @@
+package jira
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "store.json")
+
+	// Concurrent writers each leave a complete file behind
+	var wg sync.WaitGroup
+	for _, content := range []string{`{"writer":1}`, `{"writer":2}`, `{"writer":3}`} {
+		wg.Add(1)
+		go func(content string) {
+			defer wg.Done()
+			if err := writeFileAtomic(path, []byte(content)); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}(content)
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if content := string(data); content != `{"writer":1}` && content != `{"writer":2}` && content != `{"writer":3}` {
+		t.Errorf("Expected the content of one writer, got %q", content)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("Expected the file to be readable by its owner only, got %v", perm)
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected only the store in the directory, got %d entries", len(entries))
+	}
+}
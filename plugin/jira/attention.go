@@ -0,0 +1,213 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time")
+
+// Attention is a snapshot of who watches and how many people voted for an issue
+type Attention struct {
+	WatchCount int       `json:"watchCount"`
+	Watchers   []string  `json:"watchers,omitempty"` // Account IDs; empty when the watcher list is not visible
+	Votes      int       `json:"votes"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// AttentionChange describes how the attention on an issue changed since the
+// previous snapshot. Jira keeps no history of watchers or votes, so changes are
+// measured between reports.
+type AttentionChange struct {
+	Since          time.Time // When the previous snapshot was recorded
+	Watchers       int
+	Votes          int
+	WatchersGained int
+	WatchersLost   int
+	VotesChange    int
+}
+
+// Line renders the change, e.g. "gained 3 watchers, lost 1 vote"; it is empty
+// when nothing changed
+func (c *AttentionChange) Line() string {
+	parts := make([]string, 0, 3)
+	if c.WatchersGained > 0 {
+		parts = append(parts, "gained "+pluralize(c.WatchersGained, "watcher", "watchers"))
+	}
+	if c.WatchersLost > 0 {
+		parts = append(parts, "lost "+pluralize(c.WatchersLost, "watcher", "watchers"))
+	}
+	if c.VotesChange > 0 {
+		parts = append(parts, "gained "+pluralize(c.VotesChange, "vote", "votes"))
+	}
+	if c.VotesChange < 0 {
+		parts = append(parts, "lost "+pluralize(-c.VotesChange, "vote", "votes"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// attentionLine renders an issue's attention change with the time of the
+// previous snapshot, e.g. "gained 3 watchers since 2023-01-02"; it is empty
+// when attention was not tracked or did not change
+func attentionLine(change *AttentionChange) string {
+	if change == nil {
+		return ""
+	}
+	line := change.Line()
+	if line == "" || change.Since.IsZero() {
+		return line
+	}
+	return fmt.Sprintf("%s since %s", line, change.Since.Format("2006-01-02"))
+}
+
+// compareAttention measures the change between two snapshots. Watchers are
+// compared by account when both lists are visible and by count otherwise.
+func compareAttention(previous, current Attention) *AttentionChange {
+	change := &AttentionChange{
+		Since:       previous.RecordedAt,
+		Watchers:    current.WatchCount,
+		Votes:       current.Votes,
+		VotesChange: current.Votes - previous.Votes,
+	}
+
+	if len(previous.Watchers) > 0 && len(current.Watchers) > 0 {
+		before := make(map[string]bool, len(previous.Watchers))
+		for _, accountID := range previous.Watchers {
+			before[accountID] = true
+		}
+		after := make(map[string]bool, len(current.Watchers))
+		for _, accountID := range current.Watchers {
+			after[accountID] = true
+			if !before[accountID] {
+				change.WatchersGained++
+			}
+		}
+		for _, accountID := range previous.Watchers {
+			if !after[accountID] {
+				change.WatchersLost++
+			}
+		}
+		return change
+	}
+
+	if delta := current.WatchCount - previous.WatchCount; delta > 0 {
+		change.WatchersGained = delta
+	} else {
+		change.WatchersLost = -delta
+	}
+	return change
+}
+
+// AttentionStore keeps the latest attention snapshot of each issue between reports
+type AttentionStore interface {
+	Get(key string) (Attention, bool)
+	Put(snapshots map[string]Attention) error
+}
+
+// FileAttentionStore is an AttentionStore persisted as a JSON file
+type FileAttentionStore struct {
+	path      string
+	mu        sync.Mutex
+	snapshots map[string]Attention
+	loaded    bool
+}
+
+// NewFileAttentionStore creates an attention store stored at the given path
+func NewFileAttentionStore(path string) *FileAttentionStore {
+	return &FileAttentionStore{path: path}
+}
+
+// DefaultAttentionStorePath returns the default location of the attention store
+func DefaultAttentionStorePath() (string, error) {
+	return defaultStorePath(os.UserCacheDir, "attention.json")
+}
+
+// Get returns the latest snapshot of an issue
+func (s *FileAttentionStore) Get(key string) (Attention, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	snapshot, ok := s.snapshots[key]
+	return snapshot, ok
+}
+
+// Put stores the given snapshots and writes the store file
+func (s *FileAttentionStore) Put(snapshots map[string]Attention) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	for key, snapshot := range snapshots {
+		s.snapshots[key] = snapshot
+	}
+
+	data, err := json.MarshalIndent(s.snapshots, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode attention snapshots: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create attention store directory: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to write attention snapshots: %w", err)
+	}
+	return nil
+}
+
+// load reads the store file once; a missing or corrupt file starts an empty store
+func (s *FileAttentionStore) load() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	s.snapshots = make(map[string]Attention)
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &s.snapshots); err != nil {
+		s.snapshots = make(map[string]Attention)
+	}
+}
+
+// GetAttention retrieves the watchers and vote count of an issue
+func (r *JiraAPIRepository) GetAttention(key string) (Attention, error) {
+	var watches struct {
+		WatchCount int `json:"watchCount"`
+		Watchers   []struct {
+			AccountID string `json:"accountId"`
+			Name      string `json:"name"`
+		} `json:"watchers"`
+	}
+	if err := r.getJSON(fmt.Sprintf("rest/api/2/issue/%s/watchers", url.PathEscape(key)), &watches); err != nil {
+		return Attention{}, fmt.Errorf("failed to fetch the watchers of %s: %w", key, err)
+	}
+
+	var votes struct {
+		Votes int `json:"votes"`
+	}
+	if err := r.getJSON(fmt.Sprintf("rest/api/2/issue/%s/votes", url.PathEscape(key)), &votes); err != nil {
+		return Attention{}, fmt.Errorf("failed to fetch the votes of %s: %w", key, err)
+	}
+
+	attention := Attention{WatchCount: watches.WatchCount, Votes: votes.Votes}
+	for _, watcher := range watches.Watchers {
+		// Jira Data Center identifies users by name instead of account ID
+		if watcher.AccountID != "" {
+			attention.Watchers = append(attention.Watchers, watcher.AccountID)
+		} else if watcher.Name != "" {
+			attention.Watchers = append(attention.Watchers, watcher.Name)
+		}
+	}
+	return attention, nil
+}
+
+// getJSON issues a GET request against the Jira API and decodes the response.
+// Errors carry the messages from Jira's error body, like the client's own calls.
+func (r *JiraAPIRepository) getJSON(endpoint string, v interface{}) error {
+	return r.api.Get(endpoint, v)
+}
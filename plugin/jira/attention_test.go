@@ -0,0 +1,199 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestCompareAttention(t *testing.T) {
+	since := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	// Setup test cases
+	testCases := []struct {
+		name         string
+		previous     Attention
+		current      Attention
+		expectedLine string
+	}{
+		{
+			name:         "Watchers compared by account",
+			previous:     Attention{WatchCount: 2, Watchers: []string{"a", "b"}, Votes: 1, RecordedAt: since},
+			current:      Attention{WatchCount: 4, Watchers: []string{"b", "c", "d", "e"}, Votes: 3},
+			expectedLine: "gained 3 watchers, lost 1 watcher, gained 2 votes since 2023-01-02",
+		},
+		{
+			name:         "Watchers compared by count when the list is hidden",
+			previous:     Attention{WatchCount: 5, Votes: 2, RecordedAt: since},
+			current:      Attention{WatchCount: 3, Votes: 1},
+			expectedLine: "lost 2 watchers, lost 1 vote since 2023-01-02",
+		},
+		{
+			name:         "No change",
+			previous:     Attention{WatchCount: 1, Watchers: []string{"a"}, RecordedAt: since},
+			current:      Attention{WatchCount: 1, Watchers: []string{"a"}},
+			expectedLine: "",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			change := compareAttention(tc.previous, tc.current)
+
+			if line := attentionLine(change); line != tc.expectedLine {
+				t.Errorf("Expected '%s', got '%s'", tc.expectedLine, line)
+			}
+			if change.Watchers != tc.current.WatchCount || change.Votes != tc.current.Votes {
+				t.Errorf("Expected the current counts, got %+v", change)
+			}
+		})
+	}
+}
+
+func TestFileAttentionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daiv-jira", "attention.json")
+	store := NewFileAttentionStore(path)
+
+	if _, ok := store.Get("JIRA-1"); ok {
+		t.Errorf("Expected an empty store")
+	}
+
+	recorded := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	err := store.Put(map[string]Attention{
+		"JIRA-1": {WatchCount: 2, Watchers: []string{"a", "b"}, Votes: 1, RecordedAt: recorded},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A new store reads the same file
+	snapshot, ok := NewFileAttentionStore(path).Get("JIRA-1")
+	if !ok {
+		t.Fatal("Expected the snapshot to be persisted")
+	}
+	if snapshot.WatchCount != 2 || len(snapshot.Watchers) != 2 || snapshot.Votes != 1 || !snapshot.RecordedAt.Equal(recorded) {
+		t.Errorf("Expected the stored snapshot, got %+v", snapshot)
+	}
+}
+
+func TestJiraAPIRepository_GetAttention(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions()})
+	server.Watchers = map[string][]extJira.User{
+		// Jira Data Center identifies users by name instead of account ID
+		"JIRA-1": {{AccountID: "a"}, {Name: "b"}},
+	}
+	server.Votes = map[string]int{"JIRA-1": 3}
+	server.Fail("/rest/api/2/issue/JIRA-2/votes", http.StatusForbidden, "Voting is disabled.")
+
+	attention, err := repo.GetAttention("JIRA-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if attention.WatchCount != 2 || !reflect.DeepEqual(attention.Watchers, []string{"a", "b"}) || attention.Votes != 3 {
+		t.Errorf("Expected 2 watchers and 3 votes, got %+v", attention)
+	}
+
+	if _, err := repo.GetAttention("JIRA-2"); err == nil || !strings.Contains(err.Error(), "Voting is disabled.") {
+		t.Errorf("Expected the Jira error message, got %v", err)
+	}
+}
+
+func TestActivityService_Attention(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{Key: "JIRA-1", Summary: "Popular", Status: "In Progress", Comments: []Comment{{Author: "Test User", Content: "Update"}}},
+				{Key: "JIRA-2", Summary: "Hidden", Status: "In Progress", Comments: []Comment{{Author: "Test User", Content: "Update"}}},
+			}, nil
+		},
+		MockGetAttention: func(key string) (Attention, error) {
+			if key == "JIRA-2" {
+				return Attention{}, errors.New("forbidden")
+			}
+			return Attention{WatchCount: 4, Watchers: []string{"a", "b", "c", "d"}, Votes: 2}, nil
+		},
+	}
+
+	store := NewFileAttentionStore(filepath.Join(t.TempDir(), "attention.json"))
+	err := store.Put(map[string]Attention{
+		"JIRA-1": {WatchCount: 1, Watchers: []string{"a"}, Votes: 2, RecordedAt: time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	options := DefaultReportOptions()
+	options.IncludeAttention = true
+
+	var logged []string
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+	service.SetAttentionStore(store)
+	service.SetLogger(LoggerFunc(func(format string, args ...interface{}) {
+		logged = append(logged, format)
+	}))
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, issue := range report.Issues {
+		switch issue.Key {
+		case "JIRA-1":
+			if issue.Attention == nil || issue.Attention.WatchersGained != 3 || issue.Attention.VotesChange != 0 {
+				t.Errorf("Expected 3 watchers gained, got %+v", issue.Attention)
+			}
+		case "JIRA-2":
+			if issue.Attention != nil {
+				t.Errorf("Expected no attention for a failed lookup, got %+v", issue.Attention)
+			}
+		}
+	}
+	if len(logged) != 1 {
+		t.Errorf("Expected the failed lookup to be logged, got %v", logged)
+	}
+
+	// The new snapshot replaces the previous one
+	if snapshot, ok := store.Get("JIRA-1"); !ok || snapshot.WatchCount != 4 {
+		t.Errorf("Expected the new snapshot to be recorded, got %+v", snapshot)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		expected  string
+	}{
+		{formatter: NewMarkdownFormatter(), expected: "**Attention:** gained 3 watchers since 2023-01-02"},
+		{formatter: NewHTMLFormatter(), expected: "<p class=\"attention\"><strong>Attention:</strong> gained 3 watchers since 2023-01-02</p>"},
+		{formatter: NewJSONFormatter(), expected: `"watchersGained": 3`},
+		{formatter: NewXMLFormatter(), expected: `<attention watchers="4" votes="2" watchers_gained="3" watchers_lost="0" votes_change="0" since="2023-01-02T09:00:00.000Z"></attention>`},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(result.Content, tc.expected) {
+				t.Errorf("Expected content to contain '%s', got '%s'", tc.expected, result.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// ErrRefreshNotSupported is returned by auth providers whose credentials are static
+var ErrRefreshNotSupported = errors.New("credentials cannot be refreshed")
+
+// AuthProvider authenticates the requests sent to Jira. Basic auth, personal
+// access tokens and OAuth all plug into NewJiraClient through it, and tests can
+// inject a provider whose transport never touches the network.
+type AuthProvider interface {
+	// BuildTransport returns a transport that adds credentials to each request
+	BuildTransport() http.RoundTripper
+	// Refresh renews expired credentials; it is called once when Jira answers 401
+	Refresh() error
+}
+
+// BasicAuth authenticates with a username and API token, as used by Jira Cloud
+type BasicAuth struct {
+	Username  string
+	Token     string
+	Transport http.RoundTripper // Defaults to http.DefaultTransport
+}
+
+// BuildTransport returns a transport sending the username and token
+func (a BasicAuth) BuildTransport() http.RoundTripper {
+	return &extJira.BasicAuthTransport{
+		Username:  a.Username,
+		Password:  a.Token,
+		Transport: a.Transport,
+	}
+}
+
+// Refresh is not supported, an API token does not expire
+func (a BasicAuth) Refresh() error {
+	return ErrRefreshNotSupported
+}
+
+// PATAuth authenticates with a personal access token, as used by Jira Data Center
+type PATAuth struct {
+	Token     string
+	Transport http.RoundTripper // Defaults to http.DefaultTransport
+}
+
+// BuildTransport returns a transport sending the token as a bearer token
+func (a PATAuth) BuildTransport() http.RoundTripper {
+	return &extJira.PATAuthTransport{
+		Token:     a.Token,
+		Transport: a.Transport,
+	}
+}
+
+// Refresh is not supported, a personal access token is renewed by its owner
+func (a PATAuth) Refresh() error {
+	return ErrRefreshNotSupported
+}
+
+// OAuthAuth authenticates with short-lived OAuth 2.0 access tokens obtained
+// from a token source, which is asked for a new token whenever Jira rejects
+// the current one
+type OAuthAuth struct {
+	TokenSource func() (string, error)
+	Transport   http.RoundTripper // Defaults to http.DefaultTransport
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewOAuthAuth creates an OAuth provider that fetches its tokens from the source
+func NewOAuthAuth(tokenSource func() (string, error)) *OAuthAuth {
+	return &OAuthAuth{TokenSource: tokenSource}
+}
+
+// BuildTransport returns a transport sending the current access token
+func (a *OAuthAuth) BuildTransport() http.RoundTripper {
+	return &bearerTransport{token: a.currentToken, base: a.Transport}
+}
+
+// Refresh fetches a new access token from the token source
+func (a *OAuthAuth) Refresh() error {
+	token, err := a.TokenSource()
+	if err != nil {
+		return fmt.Errorf("failed to refresh the OAuth token: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = token
+	return nil
+}
+
+// currentToken returns the access token, fetching the first one on demand
+func (a *OAuthAuth) currentToken() (string, error) {
+	a.mu.Lock()
+	token := a.token
+	a.mu.Unlock()
+	if token != "" {
+		return token, nil
+	}
+
+	if err := a.Refresh(); err != nil {
+		return "", err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.token, nil
+}
+
+// bearerTransport sends a token obtained per request in the Authorization header
+type bearerTransport struct {
+	token func() (string, error)
+	base  http.RoundTripper
+}
+
+// RoundTrip adds the bearer token, then performs the request with the base transport
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.token()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// NewAuthProvider creates the provider for a configured auth type: "basic"
+// (the default) or "pat"
+func NewAuthProvider(authType, username, token string) (AuthProvider, error) {
+	switch strings.ToLower(strings.TrimSpace(authType)) {
+	case "basic", "":
+		return BasicAuth{Username: username, Token: token}, nil
+	case "pat":
+		return PATAuth{Token: token}, nil
+	default:
+		return nil, fmt.Errorf("unknown auth type %q (expected basic or pat)", authType)
+	}
+}
+
+// refreshingTransport asks the auth provider to refresh its credentials when
+// Jira answers 401 Unauthorized and retries the request once
+type refreshingTransport struct {
+	auth AuthProvider
+	base http.RoundTripper
+}
+
+// newRefreshingTransport wraps the provider's transport with a retry on 401
+func newRefreshingTransport(auth AuthProvider) http.RoundTripper {
+	base := auth.BuildTransport()
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &refreshingTransport{
+		auth: auth,
+		base: base,
+	}
+}
+
+// RoundTrip performs the request, refreshing the credentials and retrying once
+// when it is rejected as unauthorized
+func (t *refreshingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// A consumed body can only be sent again when the request can recreate it
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	if t.auth.Refresh() != nil {
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+
+	return t.base.RoundTrip(retry)
+}
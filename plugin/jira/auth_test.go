@@ -0,0 +1,220 @@
+package jira
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc is a transport answering requests without network access
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls the function
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// jsonResponse builds a response with the given status and JSON body
+func jsonResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+// fakeAuth is an auth provider with a canned transport and refresh result
+type fakeAuth struct {
+	transport  http.RoundTripper
+	refreshErr error
+	refreshes  int
+}
+
+func (a *fakeAuth) BuildTransport() http.RoundTripper {
+	return a.transport
+}
+
+func (a *fakeAuth) Refresh() error {
+	a.refreshes++
+	return a.refreshErr
+}
+
+func TestAuthProviders(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		auth     func(base http.RoundTripper) AuthProvider
+		expected string
+	}{
+		{
+			name: "Basic auth",
+			auth: func(base http.RoundTripper) AuthProvider {
+				return BasicAuth{Username: "test", Token: "secret", Transport: base}
+			},
+			expected: "Basic dGVzdDpzZWNyZXQ=",
+		},
+		{
+			name: "Personal access token",
+			auth: func(base http.RoundTripper) AuthProvider {
+				return PATAuth{Token: "secret", Transport: base}
+			},
+			expected: "Bearer secret",
+		},
+		{
+			name: "OAuth",
+			auth: func(base http.RoundTripper) AuthProvider {
+				provider := NewOAuthAuth(func() (string, error) { return "access", nil })
+				provider.Transport = base
+				return provider
+			},
+			expected: "Bearer access",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var header string
+			base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				header = req.Header.Get("Authorization")
+				return jsonResponse(req, http.StatusOK, "{}"), nil
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "https://test.atlassian.net/rest/api/2/myself", nil)
+			if _, err := tc.auth(base).BuildTransport().RoundTrip(req); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if header != tc.expected {
+				t.Errorf("Expected Authorization '%s', got '%s'", tc.expected, header)
+			}
+		})
+	}
+}
+
+func TestNewAuthProvider(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		authType    string
+		expected    AuthProvider
+		expectError bool
+	}{
+		{name: "Default", authType: "", expected: BasicAuth{Username: "test", Token: "secret"}},
+		{name: "Basic", authType: "Basic", expected: BasicAuth{Username: "test", Token: "secret"}},
+		{name: "PAT", authType: "pat", expected: PATAuth{Token: "secret"}},
+		{name: "Unknown", authType: "kerberos", expectError: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			auth, err := NewAuthProvider(tc.authType, "test", "secret")
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if auth != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, auth)
+			}
+		})
+	}
+}
+
+func TestRefreshingTransport(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name              string
+		refreshErr        error
+		expectedStatus    int
+		expectedRequests  int
+		expectedRefreshes int
+	}{
+		{
+			name:              "Retried after refreshing",
+			expectedStatus:    http.StatusOK,
+			expectedRequests:  2,
+			expectedRefreshes: 1,
+		},
+		{
+			name:              "Static credentials are not retried",
+			refreshErr:        ErrRefreshNotSupported,
+			expectedStatus:    http.StatusUnauthorized,
+			expectedRequests:  1,
+			expectedRefreshes: 1,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			auth := &fakeAuth{refreshErr: tc.refreshErr}
+			auth.transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				requests++
+				if auth.refreshes == 0 {
+					return jsonResponse(req, http.StatusUnauthorized, "{}"), nil
+				}
+				return jsonResponse(req, http.StatusOK, "{}"), nil
+			})
+
+			req, _ := http.NewRequest(http.MethodPost, "https://test.atlassian.net/rest/api/2/search", strings.NewReader(`{"jql":""}`))
+			resp, err := newRefreshingTransport(auth).RoundTrip(req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+			if requests != tc.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tc.expectedRequests, requests)
+			}
+			if auth.refreshes != tc.expectedRefreshes {
+				t.Errorf("Expected %d refreshes, got %d", tc.expectedRefreshes, auth.refreshes)
+			}
+		})
+	}
+}
+
+func TestNewJiraClient_Auth(t *testing.T) {
+	auth := &fakeAuth{refreshErr: errors.New("not used")}
+	auth.transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/rest/api/2/myself" {
+			return jsonResponse(req, http.StatusNotFound, "{}"), nil
+		}
+		return jsonResponse(req, http.StatusOK, `{"accountId": "user123", "displayName": "Test User"}`), nil
+	})
+
+	config := &JiraConfig{
+		URL:          "https://test.atlassian.net",
+		Project:      "TEST",
+		QueryOptions: DefaultQueryOptions(),
+		Auth:         auth,
+	}
+	config.QueryOptions.ResolveEmail = false
+
+	client, err := NewJiraClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	user, err := client.GetRepository().GetUser()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user.AccountID != "user123" || user.DisplayName != "Test User" {
+		t.Errorf("Expected the user served by the fake transport, got %+v", user)
+	}
+	if client.GetMetrics().Snapshot().Requests != 1 {
+		t.Errorf("Expected the request to be metered, got %+v", client.GetMetrics().Snapshot())
+	}
+}
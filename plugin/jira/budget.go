@@ -0,0 +1,61 @@
+package jira
+
+// charsPerToken is the rough number of characters of report text per token
+// of a language model
+const charsPerToken = 4
+
+// Fixed costs, in characters, of the markup around each issue and event
+const (
+	issueOverheadChars = 80
+	eventOverheadChars = 40
+)
+
+// reportBudget bounds the size of the activity taken into a report, so that
+// the activity search stops paging once the report is full instead of
+// fetching issues that would be discarded
+type reportBudget struct {
+	maxTokens int // 0 means unlimited
+	used      int
+	full      bool
+}
+
+// newReportBudget creates a budget of about maxTokens tokens; 0 is unlimited
+func newReportBudget(maxTokens int) *reportBudget {
+	return &reportBudget{maxTokens: maxTokens}
+}
+
+// admit takes the issue into the report if it fits in what is left of the
+// budget. Once an issue does not fit the budget is full and admits nothing
+// more, so that the report keeps the order of the search.
+func (b *reportBudget) admit(issue Issue) bool {
+	if b.maxTokens <= 0 {
+		return true
+	}
+	if b.full {
+		return false
+	}
+
+	tokens := estimateTokens(issue)
+	if b.used+tokens > b.maxTokens {
+		b.full = true
+		return false
+	}
+	b.used += tokens
+	return true
+}
+
+// estimateTokens approximates the tokens an issue's activity takes up in a
+// report from the length of its text
+func estimateTokens(issue Issue) int {
+	chars := issueOverheadChars + len(issue.Key) + len(issue.Summary) + len(issue.Status) + len(issue.Description)
+	for _, comment := range issue.Comments {
+		chars += eventOverheadChars + len(comment.Author) + len(comment.Content)
+	}
+	for _, change := range issue.Changes {
+		chars += eventOverheadChars + len(change.Author) + len(change.Field) + len(change.FromValue) + len(change.ToValue)
+	}
+	for _, worklog := range issue.Worklogs {
+		chars += eventOverheadChars + len(worklog.Author) + len(worklog.Comment)
+	}
+	return (chars + charsPerToken - 1) / charsPerToken
+}
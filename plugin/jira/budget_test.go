@@ -0,0 +1,117 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestReportBudget(t *testing.T) {
+	issue := Issue{Key: "TEST-1", Comments: []Comment{{Author: "Dev", Content: strings.Repeat("x", 100)}}}
+	tokens := estimateTokens(issue)
+
+	budget := newReportBudget(2*tokens + 1)
+	for i := 0; i < 2; i++ {
+		if !budget.admit(issue) {
+			t.Fatalf("Expected issue %d to fit the budget", i+1)
+		}
+	}
+	if budget.admit(issue) || !budget.full {
+		t.Error("Expected the third issue not to fit the budget")
+	}
+	if budget.admit(Issue{Key: "TEST-2"}) {
+		t.Error("Expected a full budget to admit nothing more, even small issues")
+	}
+
+	unlimited := newReportBudget(0)
+	for i := 0; i < 100; i++ {
+		if !unlimited.admit(issue) {
+			t.Fatal("Expected an unlimited budget to admit every issue")
+		}
+	}
+}
+
+// commentedIssues returns issues each with a comment by the user in January 2023
+func commentedIssues(n int) []extJira.Issue {
+	issues := make([]extJira.Issue, 0, n)
+	for i := 1; i <= n; i++ {
+		issues = append(issues, extJira.Issue{
+			Key: fmt.Sprintf("JIRA-%d", i),
+			Fields: &extJira.IssueFields{
+				Summary: "Issue",
+				Status:  &extJira.Status{Name: "In Progress"},
+				Comments: &extJira.Comments{Comments: []*extJira.Comment{
+					{Created: "2023-01-01T12:00:00.000+0000", Author: extJira.User{AccountID: "user123", DisplayName: "Dev"}, Body: strings.Repeat("x", 200)},
+				}},
+			},
+		})
+	}
+	return issues
+}
+
+func TestJiraAPIRepository_GetIssues_Paging(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	perIssue := estimateTokens(Issue{
+		Key: "JIRA-1", Summary: "Issue", Status: "In Progress",
+		Comments: []Comment{{Author: "Dev", Content: strings.Repeat("x", 200)}},
+	})
+
+	// Setup test cases
+	testCases := []struct {
+		name             string
+		maxResults       int
+		maxTokens        int
+		expectedIssues   int
+		expectedSearches int
+		expectedNotice   string
+	}{
+		{
+			name:             "Pages up to the maximum results",
+			maxResults:       250,
+			expectedIssues:   250,
+			expectedSearches: 3,
+			expectedNotice:   "Showing 250 of 342 issues; raise jira.query.max_results to include the rest",
+		},
+		{
+			name:             "Stops paging once the budget is full",
+			maxResults:       342,
+			maxTokens:        120 * perIssue,
+			expectedIssues:   120,
+			expectedSearches: 2,
+			expectedNotice:   "Showing 120 of 342 issues; the report reached its jira.report.max_tokens budget",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := DefaultQueryOptions()
+			options.MaxResults = tc.maxResults
+			reportOptions := DefaultReportOptions()
+			reportOptions.MaxTokens = tc.maxTokens
+			repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: options, ReportOptions: reportOptions})
+			server.MaxPageSize = 100
+			server.Issues = commentedIssues(342)
+
+			issues, truncation, err := repo.GetIssues(timeRange, "user123")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(issues) != tc.expectedIssues {
+				t.Errorf("Expected %d issues, got %d", tc.expectedIssues, len(issues))
+			}
+			if searches := len(server.Requests("/rest/api/2/search")); searches != tc.expectedSearches {
+				t.Errorf("Expected %d search requests, got %d", tc.expectedSearches, searches)
+			}
+			if truncation == nil || truncation.Notice() != tc.expectedNotice {
+				t.Errorf("Expected notice '%s', got %+v", tc.expectedNotice, truncation)
+			}
+		})
+	}
+}
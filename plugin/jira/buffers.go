@@ -0,0 +1,34 @@
+package jira
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledBuffer is the capacity above which a buffer is left to the garbage
+// collector instead of being pooled, so that one exceptionally large report
+// does not pin its memory for the life of the host
+const maxPooledBuffer = 4 << 20
+
+// bufferPool holds the buffers formatters render reports into, reused across
+// reports to spare long-running hosts the garbage of regrowing them each time
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer returns an empty buffer from the pool
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns a buffer to the pool once its content has been copied out
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
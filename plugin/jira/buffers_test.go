@@ -0,0 +1,44 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatters_ReusedBuffers(t *testing.T) {
+	formatters := []ReportFormatter{NewXMLFormatter(), NewJSONFormatter(), NewMarkdownFormatter(), NewHTMLFormatter()}
+	first := &ActivityReport{Issues: []Issue{{Key: "LARGE-1", Summary: strings.Repeat("lengthy ", 1000), Status: "Open"}}}
+	second := &ActivityReport{Issues: []Issue{{Key: "SMALL-1", Summary: "Short", Status: "Open"}}}
+
+	for _, formatter := range formatters {
+		t.Run(formatter.Name(), func(t *testing.T) {
+			if _, err := formatter.Format(first); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			output, err := formatter.Format(second)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if strings.Contains(output.Content, "LARGE-1") || strings.Contains(output.Content, "lengthy") {
+				t.Errorf("Expected no content of the previous report, got:\n%s", output.Content)
+			}
+			if !strings.Contains(output.Content, "SMALL-1") {
+				t.Errorf("Expected SMALL-1 in the report, got:\n%s", output.Content)
+			}
+		})
+	}
+}
+
+func TestPutBuffer_SkipsOversizedBuffers(t *testing.T) {
+	buf := getBuffer()
+	buf.Grow(maxPooledBuffer + 1)
+	putBuffer(buf)
+
+	// The pool may drop buffers at any time, so only an oversized one coming back is wrong
+	for i := 0; i < 10; i++ {
+		if reused := getBuffer(); reused == buf {
+			t.Fatal("Expected an oversized buffer not to be pooled")
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package jira
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// embeddedChangelogLimit is the number of histories Jira embeds in search
+// results; an issue with this many may have more that must be paged in
+const embeddedChangelogLimit = 100
+
+// changelogPageSize is the number of histories requested per changelog page
+const changelogPageSize = 100
+
+// changelogPage is a page of the issue changelog API
+type changelogPage struct {
+	StartAt    int                        `json:"startAt"`
+	MaxResults int                        `json:"maxResults"`
+	Total      int                        `json:"total"`
+	IsLast     bool                       `json:"isLast"`
+	Values     []extJira.ChangelogHistory `json:"values"`
+}
+
+// fullChangelog fetches every history of an issue, paging through the
+// changelog API past the histories embedded in search results
+func (r *JiraAPIRepository) fullChangelog(key string) ([]extJira.ChangelogHistory, error) {
+	histories := make([]extJira.ChangelogHistory, 0)
+	for {
+		page, err := r.changelogPage(key, len(histories))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the changelog of %s: %w", key, err)
+		}
+		histories = append(histories, page.Values...)
+
+		if page.IsLast || len(page.Values) == 0 || (page.Total > 0 && len(histories) >= page.Total) {
+			return histories, nil
+		}
+	}
+}
+
+// GetStatusHistory retrieves every status change of an issue from its full changelog
+func (r *JiraAPIRepository) GetStatusHistory(key string) ([]Change, error) {
+	histories, err := r.fullChangelog(key)
+	if err != nil {
+		return nil, err
+	}
+	return statusHistory(histories), nil
+}
+
+// GetFieldHistory retrieves every change of the given fields of an issue from
+// its full changelog
+func (r *JiraAPIRepository) GetFieldHistory(key string, fields ...string) ([]Change, error) {
+	histories, err := r.fullChangelog(key)
+	if err != nil {
+		return nil, err
+	}
+	return fieldHistory(histories, fields...), nil
+}
+
+// changelogPage fetches a single page of an issue's changelog
+func (r *JiraAPIRepository) changelogPage(key string, startAt int) (changelogPage, error) {
+	params := url.Values{}
+	params.Set("startAt", fmt.Sprintf("%d", startAt))
+	params.Set("maxResults", fmt.Sprintf("%d", changelogPageSize))
+
+	endpoint := fmt.Sprintf("rest/api/2/issue/%s/changelog?%s", url.PathEscape(key), params.Encode())
+
+	var page changelogPage
+	if err := r.getJSON(endpoint, &page); err != nil {
+		return changelogPage{}, err
+	}
+	return page, nil
+}
+
+// statusHistory returns every status change in the histories, regardless of
+// the time range or author, ordered oldest first
+func statusHistory(histories []extJira.ChangelogHistory) []Change {
+	return fieldHistory(histories, statusField)
+}
+
+// fieldHistory returns every change of the fields in the histories,
+// regardless of the time range or author, ordered oldest first
+func fieldHistory(histories []extJira.ChangelogHistory, fields ...string) []Change {
+	result := make([]Change, 0)
+	for _, history := range histories {
+		createdTime, err := parseJiraTime(history.Created)
+		if err != nil {
+			continue
+		}
+
+		for _, item := range history.Items {
+			if !containsFold(fields, item.Field) {
+				continue
+			}
+			result = append(result, Change{
+				Timestamp:       createdTime,
+				Author:          displayName(history.Author),
+				AuthorAccountID: history.Author.AccountID,
+				Field:           item.Field,
+				FromValue:       item.FromString,
+				ToValue:         item.ToString,
+				FromID:          changelogValue(item.From),
+				ToID:            changelogValue(item.To),
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+	return result
+}
@@ -0,0 +1,275 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+// statusHistoryEntry returns a changelog history moving an issue between statuses
+func statusHistoryEntry(created time.Time, from, to string) extJira.ChangelogHistory {
+	return extJira.ChangelogHistory{
+		Author:  extJira.User{AccountID: "other", DisplayName: "Other User"},
+		Created: created.Format("2006-01-02T15:04:05.000-0700"),
+		Items: []extJira.ChangelogItems{
+			{Field: "status", FromString: from, ToString: to},
+		},
+	}
+}
+
+func TestJiraAPIRepository_GetStatusHistory(t *testing.T) {
+	base := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	// 230 histories, only every tenth of which changes the status
+	all := make([]extJira.ChangelogHistory, 0, 230)
+	for i := 0; i < 230; i++ {
+		history := extJira.ChangelogHistory{
+			Created: base.Add(time.Duration(i) * time.Hour).Format("2006-01-02T15:04:05.000-0700"),
+			Items:   []extJira.ChangelogItems{{Field: "summary", ToString: fmt.Sprintf("Summary %d", i)}},
+		}
+		if i%10 == 0 {
+			history = statusHistoryEntry(base.Add(time.Duration(i)*time.Hour), "Open", fmt.Sprintf("Status %d", i))
+		}
+		all = append(all, history)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name          string
+		hideTotals    bool
+		failure       bool
+		expectError   bool
+		expectedCount int
+		expectedPages int
+	}{
+		{
+			name:          "Pages until the last page",
+			expectedCount: 23,
+			expectedPages: 3,
+		},
+		{
+			name:          "Stops on an empty page when the total is unknown",
+			hideTotals:    true,
+			expectedCount: 23,
+			expectedPages: 4,
+		},
+		{
+			name:          "Page error",
+			failure:       true,
+			expectError:   true,
+			expectedPages: 1,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions()})
+			server.Changelogs = map[string][]extJira.ChangelogHistory{"JIRA-1": all}
+			server.HideChangelogTotals = tc.hideTotals
+			if tc.failure {
+				server.Fail("/rest/api/2/issue/JIRA-1/changelog", http.StatusForbidden, "You do not have permission to view this issue.")
+			}
+
+			history, err := repo.GetStatusHistory("JIRA-1")
+
+			if tc.expectError && err == nil {
+				t.Errorf("Expected error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if len(history) != tc.expectedCount {
+				t.Errorf("Expected %d status changes, got %d", tc.expectedCount, len(history))
+			}
+			if pages := server.Requests("/rest/api/2/issue/JIRA-1/changelog"); len(pages) != tc.expectedPages {
+				t.Errorf("Expected %d pages, got %d", tc.expectedPages, len(pages))
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_StatusHistory(t *testing.T) {
+	reportOptions := DefaultReportOptions()
+	reportOptions.IncludeStats = true
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions(), ReportOptions: reportOptions})
+
+	created := time.Date(2022, 12, 1, 9, 0, 0, 0, time.UTC)
+	server.Issues = []extJira.Issue{
+		{
+			Key: "JIRA-1",
+			Fields: &extJira.IssueFields{
+				Summary: "Long-running issue",
+				Created: extJira.Time(created),
+			},
+			Changelog: &extJira.Changelog{
+				Histories: []extJira.ChangelogHistory{
+					// Started by someone else before the report range
+					statusHistoryEntry(time.Date(2022, 12, 5, 9, 0, 0, 0, time.UTC), "Open", "In Progress"),
+					{
+						Author:  extJira.User{AccountID: "user123", DisplayName: "Test User"},
+						Created: "2023-01-01T10:00:00.000+0000",
+						Items: []extJira.ChangelogItems{
+							{Field: "status", FromString: "In Progress", ToString: "Done"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	issues, _, err := repo.GetIssues(TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if len(issues[0].Changes) != 1 {
+		t.Errorf("Expected only the in-range change, got %d", len(issues[0].Changes))
+	}
+	if len(issues[0].StatusHistory) != 2 || issues[0].StatusHistory[0].ToValue != "In Progress" {
+		t.Errorf("Expected the full status history, got %+v", issues[0].StatusHistory)
+	}
+	if !issues[0].Created.Equal(created) {
+		t.Errorf("Expected created %v, got %v", created, issues[0].Created)
+	}
+	if issues[0].historyTruncated {
+		t.Errorf("Expected a short changelog not to be marked truncated")
+	}
+}
+
+func TestJiraAPIRepository_TruncatedChangelog(t *testing.T) {
+	reportOptions := DefaultReportOptions()
+	reportOptions.IncludeStats = true
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions(), ReportOptions: reportOptions})
+
+	// Jira embeds only the first 100 of the 150 histories in search results
+	base := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	histories := make([]extJira.ChangelogHistory, 0, 150)
+	for i := 0; i < 150; i++ {
+		histories = append(histories, statusHistoryEntry(base.Add(time.Duration(i)*time.Hour), "Open", fmt.Sprintf("Status %d", i)))
+	}
+	histories[0].Author = extJira.User{AccountID: "user123", DisplayName: "Test User"}
+	server.Issues = []extJira.Issue{{Key: "JIRA-1", Fields: &extJira.IssueFields{Summary: "Busy issue"}}}
+	server.Changelogs = map[string][]extJira.ChangelogHistory{"JIRA-1": histories}
+
+	issues, _, err := repo.GetIssues(TimeRange{
+		Start: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 1 || !issues[0].historyTruncated || len(issues[0].StatusHistory) != 100 {
+		t.Fatalf("Expected the embedded changelog to be marked truncated, got %+v", issues)
+	}
+
+	history, err := repo.GetStatusHistory("JIRA-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(history) != 150 || history[149].ToValue != "Status 149" {
+		t.Errorf("Expected the full status history, got %d changes", len(history))
+	}
+}
+
+func TestActivityService_CycleTimes(t *testing.T) {
+	created := time.Date(2022, 12, 1, 9, 0, 0, 0, time.UTC)
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{
+					Key: "JIRA-1", Summary: "Truncated", Status: "Done", Created: created,
+					Changes:          []Change{statusChange("Done", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC))},
+					StatusHistory:    []Change{statusChange("Done", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC))},
+					historyTruncated: true,
+				},
+				{
+					Key: "JIRA-2", Summary: "Still open", Status: "In Progress",
+					Changes:       []Change{statusChange("In Progress", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC))},
+					StatusHistory: []Change{statusChange("In Progress", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC))},
+				},
+			}, nil
+		},
+		MockGetStatusHistory: func(key string) ([]Change, error) {
+			if key != "JIRA-1" {
+				t.Errorf("Expected only the truncated issue to be fetched, got %s", key)
+			}
+			return []Change{
+				statusChange("In Progress", time.Date(2022, 12, 8, 9, 0, 0, 0, time.UTC)),
+				statusChange("Done", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC)),
+			}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.IncludeStats = true
+	options.TrailingWindows = 0
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 16, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var shipped Issue
+	for _, issue := range report.Issues {
+		if issue.Key == "JIRA-1" {
+			shipped = issue
+		}
+		if issue.Key == "JIRA-2" && (issue.CycleTime != 0 || issue.LeadTime != 0) {
+			t.Errorf("Expected no times for an open issue, got %v and %v", issue.CycleTime, issue.LeadTime)
+		}
+	}
+	if shipped.CycleTime != 33*24*time.Hour {
+		t.Errorf("Expected a cycle time of 33 days, got %v", shipped.CycleTime)
+	}
+	if shipped.LeadTime != 40*24*time.Hour {
+		t.Errorf("Expected a lead time of 40 days, got %v", shipped.LeadTime)
+	}
+	if report.Stats == nil || report.Stats.Current.AverageCycleTime != 33*24*time.Hour {
+		t.Errorf("Expected the full history in the average cycle time, got %+v", report.Stats)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		expected  string
+	}{
+		{formatter: NewMarkdownFormatter(), expected: "| JIRA-1 | 33d | 40d |"},
+		{formatter: NewHTMLFormatter(), expected: "<tr><td>JIRA-1</td><td>33d</td><td>40d</td></tr>"},
+		{formatter: NewJSONFormatter(), expected: `"cycleTimeHours": 792`},
+		{formatter: NewXMLFormatter(), expected: "<lead_time_hours>960</lead_time_hours>"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(result.Content, tc.expected) {
+				t.Errorf("Expected content to contain '%s', got '%s'", tc.expected, result.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package jira
+
+import "strings"
+
+// chatItem is an issue listed in a chat digest, with a short detail such as
+// its status or the amount of activity on it
+type chatItem struct {
+	Issue  Issue
+	Detail string
+	Note   string // The activity summary, when one was produced
+}
+
+// chatSection is a titled list of issues in a chat digest
+type chatSection struct {
+	Title    string
+	Subtitle string
+	Category string // The status category of a section of issues in one status, when known
+	Items    []chatItem
+}
+
+// chatNotices returns the notes a chat digest opens with: a truncated search
+// and reconstructed statuses, disclosed whatever the verbosity
+func chatNotices(report *ActivityReport) []string {
+	notices := make([]string, 0, 2)
+	if report.Truncation != nil {
+		notices = append(notices, report.Truncation.Notice())
+	}
+	if !report.AsOf.IsZero() {
+		notices = append(notices, asOfNotice(report.AsOf))
+	}
+	return notices
+}
+
+// chatSections lays out a report as the compact digest posted to chat
+// services: the issues worked on by status, or the rollup of the report mode,
+// followed by the supplementary sections. The Slack and Teams formatters
+// render the same sections in their own markup.
+func chatSections(report *ActivityReport) []chatSection {
+	sections := make([]chatSection, 0)
+
+	if report.Sprint != nil {
+		section := chatSection{Title: "Sprint: " + report.Sprint.Sprint.Name, Subtitle: "Scope change: " + report.Sprint.ScopeLine()}
+		for _, group := range report.Sprint.Sections() {
+			for _, issue := range group.Issues {
+				section.Items = append(section.Items, chatItem{Issue: issue, Detail: group.Title + ", " + issue.Status})
+			}
+		}
+		sections = append(sections, section)
+	}
+
+	// In the rollup modes the rollup replaces the per-status issues
+	detailedIssues := report.Issues
+	switch report.Options.Mode {
+	case ReportModeEpic:
+		for _, rollup := range report.Epics {
+			sections = append(sections, chatSection{Title: rollup.Title(), Subtitle: rollup.CountsLine(), Items: statusItems(rollup.Issues)})
+		}
+		detailedIssues = nil
+	case ReportModeInitiative:
+		for _, initiative := range report.Initiatives {
+			section := chatSection{Title: initiative.Title(), Subtitle: initiative.CountsLine()}
+			for _, rollup := range initiative.Epics {
+				section.Items = append(section.Items, statusItems(rollup.Issues)...)
+			}
+			sections = append(sections, section)
+		}
+		detailedIssues = nil
+	case ReportModeComponent:
+		for _, digest := range report.Components {
+			sections = append(sections, chatSection{Title: digest.Component, Subtitle: digest.CountsLine(), Items: statusItems(digest.Issues)})
+		}
+		detailedIssues = nil
+	case ReportModeRelease:
+		if report.Release != nil {
+			section := chatSection{Title: "Release Notes: " + report.Release.Version}
+			for _, group := range report.Release.Groups {
+				for _, issue := range group.Issues {
+					section.Items = append(section.Items, chatItem{Issue: issue, Detail: group.Type + ", " + ResolutionLabel(issue)})
+				}
+			}
+			sections = append(sections, section)
+		}
+		detailedIssues = nil
+	case ReportModeTriage:
+		if len(report.Triage) > 0 {
+			section := chatSection{Title: "New Bugs and Incidents"}
+			for _, issue := range report.Triage {
+				section.Items = append(section.Items, chatItem{Issue: issue, Detail: TriageLabel(issue)})
+			}
+			sections = append(sections, section)
+		}
+		detailedIssues = nil
+	}
+
+	// List the issues by status, each with the amount of activity on it
+	for _, group := range groupByStatus(detailedIssues) {
+		section := chatSection{Title: group.Status, Category: group.Category}
+		for _, issue := range group.Issues {
+			section.Items = append(section.Items, chatItem{Issue: issue, Detail: activityCountsLine(issue), Note: issue.ActivitySummary})
+		}
+		sections = append(sections, section)
+	}
+
+	for _, supplementary := range supplementarySections(report) {
+		sections = append(sections, chatSection{Title: supplementary.Title, Items: statusItems(supplementary.Issues)})
+	}
+
+	if len(report.DueSoon) > 0 {
+		section := chatSection{Title: "Due Soon"}
+		for _, issue := range report.DueSoon {
+			section.Items = append(section.Items, chatItem{Issue: issue, Detail: dueLine(issue, report.TimeRange)})
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// statusItems lists issues with their status as detail
+func statusItems(issues []Issue) []chatItem {
+	items := make([]chatItem, 0, len(issues))
+	for _, issue := range issues {
+		items = append(items, chatItem{Issue: issue, Detail: issue.Status})
+	}
+	return items
+}
+
+// activityCountsLine counts the activity on an issue, e.g. "2 comments, 1 change"
+func activityCountsLine(issue Issue) string {
+	parts := make([]string, 0, 4)
+	if count := len(issue.Comments); count > 0 {
+		parts = append(parts, pluralize(count, "comment", "comments"))
+	}
+	if count := len(issue.Changes); count > 0 {
+		parts = append(parts, pluralize(count, "change", "changes"))
+	}
+	if count := len(issue.RemoteLinks); count > 0 {
+		parts = append(parts, pluralize(count, "link", "links"))
+	}
+	if count := len(issue.Worklogs); count > 0 {
+		parts = append(parts, pluralize(count, "worklog", "worklogs"))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -2,6 +2,7 @@ package jira
 
 import (
 	"fmt"
+	"net/http"
 
 	extJira "github.com/andygrunwald/go-jira"
 	plugin "github.com/iures/daivplug"
@@ -13,26 +14,32 @@ type JiraConfig struct {
 	URL      string
 	Project  string
 	QueryOptions QueryOptions
+	ReportOptions ReportOptions
+	HTTPOptions HTTPOptions
+	Auth AuthProvider // Defaults to basic auth with Username and Token
 }
 
 // JiraClient provides a client for interacting with Jira
 type JiraClient struct {
-	client     *extJira.Client
+	api        jiraAPI
 	config     *JiraConfig
-	repository JiraRepository
+	repository *JiraAPIRepository
+	metrics    *MetricsRecorder
 }
 
 // NewJiraClient creates a new JiraClient
 func NewJiraClient(config *JiraConfig) (*JiraClient, error) {
-	tp := extJira.BasicAuthTransport{
-		Username: config.Username,
-		Password: config.Token,
-	}
+	// Request gzip-compressed responses and record the bytes transferred
+	metrics := NewMetricsRecorder()
 
-	client, err := extJira.NewClient(tp.Client(), config.URL)
-	if err != nil {
-		return nil, err
+	auth := config.Auth
+	if auth == nil {
+		auth = BasicAuth{Username: config.Username, Token: config.Token}
 	}
+	transport := newCompressedTransport(newRefreshingTransport(auth), metrics)
+
+	// Cap concurrent requests to stay under Atlassian's concurrency limits
+	httpClient := &http.Client{Transport: newLimitedTransport(transport, config.HTTPOptions.MaxConcurrent)}
 
 	// Set project in query options if not already set
 	if config.QueryOptions.Project == "" {
@@ -40,12 +47,27 @@ func NewJiraClient(config *JiraConfig) (*JiraClient, error) {
 	}
 
 	jiraClient := &JiraClient{
-		client: client,
-		config: config,
+		config:  config,
+		metrics: metrics,
 	}
 
-	// Create the repository
-	repository := NewJiraAPIRepository(client, config)
+	// Create the repository on the configured HTTP client
+	var repository *JiraAPIRepository
+	switch config.HTTPOptions.Client {
+	case ClientNative:
+		native, err := NewNativeJiraAPIRepository(httpClient, config.URL, config)
+		if err != nil {
+			return nil, err
+		}
+		repository = native
+	default:
+		client, err := extJira.NewClient(httpClient, config.URL)
+		if err != nil {
+			return nil, err
+		}
+		repository = NewJiraAPIRepository(client, config)
+	}
+	jiraClient.api = repository.api
 	jiraClient.repository = repository
 
 	return jiraClient, nil
@@ -56,8 +78,13 @@ func (j *JiraClient) GetRepository() JiraRepository {
 	return j.repository
 }
 
+// GetMetrics returns the recorder of the HTTP traffic exchanged with Jira
+func (j *JiraClient) GetMetrics() *MetricsRecorder {
+	return j.metrics
+}
+
 func (j *JiraClient) GetSelf() (*extJira.User, error) {
-	user, _, err := j.client.User.GetSelf()
+	user, err := j.api.Self()
 	if err != nil {
 		return nil, err
 	}
@@ -66,8 +93,8 @@ func (j *JiraClient) GetSelf() (*extJira.User, error) {
 }
 
 func (j *JiraClient) fetchUpdatedIssues(timeRange plugin.TimeRange) ([]extJira.Issue, error) {
-	fromTime := timeRange.Start.Format("2006-01-02")
-	toTime := timeRange.End.Format("2006-01-02")
+	fromTime := j.config.QueryOptions.jqlTime(timeRange.Start, jqlDateLayout)
+	toTime := j.config.QueryOptions.jqlTime(timeRange.End, jqlDateLayout)
 
 	searchString := fmt.Sprintf(
 		`assignee = currentUser() AND project = %s AND status != Closed AND sprint IN openSprints() AND (updatedDate >= %s AND updatedDate < %s)`,
@@ -82,7 +109,7 @@ func (j *JiraClient) fetchUpdatedIssues(timeRange plugin.TimeRange) ([]extJira.I
 		Fields:     []string{"summary", "description", "status", "changelog", "comment"},
 	}
 
-	issues, _, err := j.client.Issue.Search(searchString, opt)
+	issues, _, err := j.api.Search(searchString, opt)
 
 	if err != nil {
 		return nil, err
@@ -33,6 +33,30 @@ func TestNewJiraClient(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Native client",
+			config: &JiraConfig{
+				Username: "test",
+				Token:    "test",
+				URL:      "https://test.atlassian.net",
+				Project:  "TEST",
+				QueryOptions: DefaultQueryOptions(),
+				HTTPOptions: HTTPOptions{Client: ClientNative},
+			},
+			expectError: false,
+		},
+		{
+			name: "Native client with an invalid URL",
+			config: &JiraConfig{
+				Username: "test",
+				Token:    "test",
+				URL:      "://invalid-url",
+				Project:  "TEST",
+				QueryOptions: DefaultQueryOptions(),
+				HTTPOptions: HTTPOptions{Client: ClientNative},
+			},
+			expectError: true,
+		},
 	}
 
 	// Run tests
@@ -57,8 +81,8 @@ func TestNewJiraClient(t *testing.T) {
 				if client.config != tc.config {
 					t.Errorf("Expected client config to be %v, got %v", tc.config, client.config)
 				}
-				if client.client == nil {
-					t.Errorf("Expected a non-nil extJira.Client but got nil")
+				if client.api == nil {
+					t.Errorf("Expected a non-nil Jira API client but got nil")
 				}
 				if client.repository == nil {
 					t.Errorf("Expected a non-nil JiraRepository but got nil")
@@ -0,0 +1,30 @@
+package jira
+
+import "time"
+
+// Clock tells the current time. The service reads it for the default range of
+// a report, whether a range ended in the past, how old cached users are, and
+// when notes and reports were made, so that tests and replays of past reports
+// can run against a frozen time.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the clock of the system, used unless another one is set
+var SystemClock Clock = systemClock{}
+
+// systemClock reads the time from the system
+type systemClock struct{}
+
+// Now returns the current time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FrozenClock is a clock stopped at a point in time
+type FrozenClock time.Time
+
+// Now returns the time the clock is stopped at
+func (c FrozenClock) Now() time.Time {
+	return time.Time(c)
+}
@@ -0,0 +1,54 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestActivityService_SetClock(t *testing.T) {
+	// Monday 2023-01-09 09:30
+	now := time.Date(2023, 1, 9, 9, 30, 0, 0, time.UTC)
+
+	var queried TimeRange
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			queried = timeRange
+			return []Issue{}, nil
+		},
+	}
+	service := NewActivityService(mockRepo)
+	service.SetClock(FrozenClock(now))
+
+	report, err := service.GetActivityReport(plugin.TimeRange{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Without a last standup the default range starts on the previous workday
+	expected := TimeRange{Start: time.Date(2023, 1, 6, 9, 30, 0, 0, time.UTC), End: now}
+	if !queried.Start.Equal(expected.Start) || !queried.End.Equal(expected.End) {
+		t.Errorf("Expected %v to %v, got %v to %v", expected.Start, expected.End, queried.Start, queried.End)
+	}
+	if report.Generation == nil || !report.Generation.GeneratedAt.Equal(now) {
+		t.Errorf("Expected the report to be generated at %v, got %+v", now, report.Generation)
+	}
+
+	// The user directory measures the age of cached profiles on the same clock
+	cache := NewMemoryUserCache()
+	if err := cache.Put([]CachedUser{{User: User{AccountID: "user123", DisplayName: "Cached"}, FetchedAt: now.Add(-time.Hour)}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	directory := NewUserDirectory(func(accountIDs []string) ([]User, error) {
+		t.Errorf("Expected the cached profile to be fresh, fetched %v", accountIDs)
+		return nil, nil
+	}, cache, 2*time.Hour)
+	service.SetUserDirectory(directory)
+	if _, err := directory.Resolve([]string{"user123"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Collection fields tracked as additions and removals rather than from/to values
+const (
+	LabelsField     = "labels"
+	ComponentsField = "components"
+)
+
+// collectionField maps a changelog field name to the tracked collection field
+func collectionField(field string) string {
+	switch strings.ToLower(field) {
+	case "labels":
+		return LabelsField
+	case "component", "components":
+		return ComponentsField
+	default:
+		return ""
+	}
+}
+
+// ExtractCollectionChanges splits label and component changes out of the given
+// changes, returning them as additions/removals and the remaining changes
+func ExtractCollectionChanges(changes []Change) ([]CollectionChange, []Change) {
+	collections := make([]CollectionChange, 0)
+	remaining := make([]Change, 0, len(changes))
+
+	for _, change := range changes {
+		field := collectionField(change.Field)
+		if field == "" {
+			remaining = append(remaining, change)
+			continue
+		}
+
+		collectionChange := CollectionChange{
+			Timestamp:      change.Timestamp,
+			Author:         change.Author,
+			AuthorTimeZone: change.AuthorTimeZone,
+			Field:          field,
+			Source:         change,
+		}
+
+		if field == LabelsField {
+			// Labels are reported as space-separated lists of the full before/after sets
+			collectionChange.Added = difference(strings.Fields(change.ToValue), strings.Fields(change.FromValue))
+			collectionChange.Removed = difference(strings.Fields(change.FromValue), strings.Fields(change.ToValue))
+		} else {
+			// Components are reported as one item per added or removed component
+			if change.ToValue != "" {
+				collectionChange.Added = []string{change.ToValue}
+			}
+			if change.FromValue != "" {
+				collectionChange.Removed = []string{change.FromValue}
+			}
+		}
+
+		if len(collectionChange.Added) > 0 || len(collectionChange.Removed) > 0 {
+			collections = append(collections, collectionChange)
+		}
+	}
+
+	return collections, remaining
+}
+
+// extractIssueCollectionChanges separates label and component changes on each issue
+func extractIssueCollectionChanges(issues []Issue) {
+	for i := range issues {
+		issues[i].CollectionChanges, issues[i].Changes = ExtractCollectionChanges(issues[i].Changes)
+	}
+}
+
+// difference returns the values in a that are not in b, preserving order
+func difference(a, b []string) []string {
+	exclude := make(map[string]bool, len(b))
+	for _, value := range b {
+		exclude[value] = true
+	}
+
+	result := make([]string, 0)
+	for _, value := range a {
+		if !exclude[value] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// String renders the change, e.g. "labels: +backend, -frontend"
+func (c CollectionChange) String() string {
+	parts := make([]string, 0, len(c.Added)+len(c.Removed))
+	for _, value := range c.Added {
+		parts = append(parts, "+"+value)
+	}
+	for _, value := range c.Removed {
+		parts = append(parts, "-"+value)
+	}
+	return fmt.Sprintf("%s: %s", c.Field, strings.Join(parts, ", "))
+}
@@ -0,0 +1,83 @@
+package jira
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtractCollectionChanges(t *testing.T) {
+	timestamp := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Setup test cases
+	testCases := []struct {
+		name              string
+		changes           []Change
+		expected          []CollectionChange
+		expectedRemaining int
+	}{
+		{
+			name: "Label additions and removals",
+			changes: []Change{
+				{Timestamp: timestamp, Author: "Test User", Field: "labels", FromValue: "backend urgent", ToValue: "backend api"},
+			},
+			expected: []CollectionChange{
+				{Timestamp: timestamp, Author: "Test User", Field: LabelsField, Added: []string{"api"}, Removed: []string{"urgent"}},
+			},
+		},
+		{
+			name: "Component added and removed",
+			changes: []Change{
+				{Timestamp: timestamp, Author: "Test User", Field: "Component", FromValue: "", ToValue: "Billing"},
+				{Timestamp: timestamp, Author: "Test User", Field: "Component", FromValue: "Search", ToValue: ""},
+				{Timestamp: timestamp, Author: "Test User", Field: "status", FromValue: "Open", ToValue: "Done"},
+			},
+			expected: []CollectionChange{
+				{Timestamp: timestamp, Author: "Test User", Field: ComponentsField, Added: []string{"Billing"}},
+				{Timestamp: timestamp, Author: "Test User", Field: ComponentsField, Removed: []string{"Search"}},
+			},
+			expectedRemaining: 1,
+		},
+		{
+			name: "Reordered labels are not a change",
+			changes: []Change{
+				{Timestamp: timestamp, Field: "labels", FromValue: "a b", ToValue: "b a"},
+			},
+			expected: []CollectionChange{},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			collections, remaining := ExtractCollectionChanges(tc.changes)
+
+			if len(collections) != len(tc.expected) {
+				t.Fatalf("Expected %d collection changes, got %d", len(tc.expected), len(collections))
+			}
+			for i, expected := range tc.expected {
+				actual := collections[i]
+				if actual.Field != expected.Field || actual.Author != expected.Author {
+					t.Errorf("Expected change %v, got %v", expected, actual)
+				}
+				if len(expected.Added) > 0 && !reflect.DeepEqual(actual.Added, expected.Added) {
+					t.Errorf("Expected added %v, got %v", expected.Added, actual.Added)
+				}
+				if len(expected.Removed) > 0 && !reflect.DeepEqual(actual.Removed, expected.Removed) {
+					t.Errorf("Expected removed %v, got %v", expected.Removed, actual.Removed)
+				}
+			}
+
+			if len(remaining) != tc.expectedRemaining {
+				t.Errorf("Expected %d remaining changes, got %d", tc.expectedRemaining, len(remaining))
+			}
+		})
+	}
+}
+
+func TestCollectionChange_String(t *testing.T) {
+	change := CollectionChange{Field: LabelsField, Added: []string{"api"}, Removed: []string{"urgent"}}
+	if result := change.String(); result != "labels: +api, -urgent" {
+		t.Errorf("Unexpected string '%s'", result)
+	}
+}
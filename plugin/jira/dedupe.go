@@ -0,0 +1,107 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// commentEventID identifies a comment across reports
+func commentEventID(key string, comment Comment) string {
+	if comment.ID != "" {
+		return fmt.Sprintf("comment:%s:%s", key, comment.ID)
+	}
+	return fmt.Sprintf("comment:%s:%d:%s", key, comment.Timestamp.Unix(), comment.AuthorAccountID)
+}
+
+// changeEventID identifies a field change across reports. Changes carry no ID
+// of their own, so the field, time and values identify them.
+func changeEventID(key string, change Change) string {
+	return fmt.Sprintf("change:%s:%s:%d:%s:%s", key, strings.ToLower(change.Field), change.Timestamp.Unix(), change.FromValue, change.ToValue)
+}
+
+// remoteLinkEventID identifies a remote link across reports
+func remoteLinkEventID(key string, link RemoteLink) string {
+	return fmt.Sprintf("remotelink:%s:%s", key, link.ID)
+}
+
+// issueEventIDs returns the IDs of every event reported for an issue
+func issueEventIDs(issue Issue) []string {
+	events := issue.Events()
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID())
+	}
+	return ids
+}
+
+// reportedEventIDs returns the IDs of every event reported for the issues
+func reportedEventIDs(issues []Issue) []string {
+	ids := make([]string, 0)
+	for _, issue := range issues {
+		ids = append(ids, issueEventIDs(issue)...)
+	}
+	return ids
+}
+
+// dedupeIssues leaves out the comments, changes, remote links and worklogs already
+// included in a previous report. Issues whose every event was reported before
+// are dropped; issues that had no events to begin with are kept.
+func dedupeIssues(issues []Issue, reported map[string]bool) []Issue {
+	result := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		events := len(issue.Comments) + len(issue.Changes) + len(issue.RemoteLinks) + len(issue.Worklogs)
+
+		comments := make([]Comment, 0, len(issue.Comments))
+		for _, comment := range issue.Comments {
+			if !reported[commentEventID(issue.Key, comment)] {
+				comments = append(comments, comment)
+			}
+		}
+		changes := make([]Change, 0, len(issue.Changes))
+		for _, change := range issue.Changes {
+			if !reported[changeEventID(issue.Key, change)] {
+				changes = append(changes, change)
+			}
+		}
+		links := make([]RemoteLink, 0, len(issue.RemoteLinks))
+		for _, link := range issue.RemoteLinks {
+			if !reported[remoteLinkEventID(issue.Key, link)] {
+				links = append(links, link)
+			}
+		}
+
+		worklogs := make([]Worklog, 0, len(issue.Worklogs))
+		for _, worklog := range issue.Worklogs {
+			if !reported[worklogEventID(issue.Key, worklog)] {
+				worklogs = append(worklogs, worklog)
+			}
+		}
+
+		remaining := len(comments) + len(changes) + len(links) + len(worklogs)
+		if events > 0 && remaining == 0 {
+			continue
+		}
+		issue.Comments = comments
+		issue.Changes = changes
+		issue.RemoteLinks = links
+		issue.Worklogs = worklogs
+		result = append(result, issue)
+	}
+	return result
+}
+
+// dedupe leaves out the events already included in a previous report. When the
+// store cannot be read, every event is kept.
+func (s *ActivityService) dedupe(issues []Issue) []Issue {
+	reportedAt, err := s.standup.ReportedEvents()
+	if err != nil {
+		s.logger.Printf("failed to read the reported events: %v", err)
+		return issues
+	}
+
+	reported := make(map[string]bool, len(reportedAt))
+	for id := range reportedAt {
+		reported[id] = true
+	}
+	return dedupeIssues(issues, reported)
+}
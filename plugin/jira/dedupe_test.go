@@ -0,0 +1,133 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestDedupeIssues(t *testing.T) {
+	at := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	comment := Comment{ID: "100", Timestamp: at, Author: "Test User", Content: "Yesterday's update"}
+	newComment := Comment{ID: "101", Timestamp: at.Add(time.Hour), Author: "Test User", Content: "Today's update"}
+	change := Change{Timestamp: at, Author: "Test User", Field: "status", FromValue: "To Do", ToValue: "In Progress"}
+
+	// Setup test cases
+	testCases := []struct {
+		name             string
+		issues           []Issue
+		reported         map[string]bool
+		expectedKeys     []string
+		expectedComments int
+		expectedChanges  int
+	}{
+		{
+			name:             "Nothing reported before",
+			issues:           []Issue{{Key: "TEST-1", Comments: []Comment{comment, newComment}, Changes: []Change{change}}},
+			reported:         map[string]bool{},
+			expectedKeys:     []string{"TEST-1"},
+			expectedComments: 2,
+			expectedChanges:  1,
+		},
+		{
+			name:   "Reported events are left out",
+			issues: []Issue{{Key: "TEST-1", Comments: []Comment{comment, newComment}, Changes: []Change{change}}},
+			reported: map[string]bool{
+				commentEventID("TEST-1", comment): true,
+				changeEventID("TEST-1", change):   true,
+			},
+			expectedKeys:     []string{"TEST-1"},
+			expectedComments: 1,
+		},
+		{
+			name:         "Fully reported issues are dropped",
+			issues:       []Issue{{Key: "TEST-1", Comments: []Comment{comment}}, {Key: "TEST-2"}},
+			reported:     map[string]bool{commentEventID("TEST-1", comment): true},
+			expectedKeys: []string{"TEST-2"},
+		},
+		{
+			name:             "Same comment ID on another issue",
+			issues:           []Issue{{Key: "TEST-2", Comments: []Comment{comment}}},
+			reported:         map[string]bool{commentEventID("TEST-1", comment): true},
+			expectedKeys:     []string{"TEST-2"},
+			expectedComments: 1,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := dedupeIssues(tc.issues, tc.reported)
+
+			keys := issueKeys(result)
+			if len(keys) != len(tc.expectedKeys) {
+				t.Fatalf("Expected issues %v, got %v", tc.expectedKeys, keys)
+			}
+			for i := range keys {
+				if keys[i] != tc.expectedKeys[i] {
+					t.Fatalf("Expected issues %v, got %v", tc.expectedKeys, keys)
+				}
+			}
+			if len(result) > 0 && tc.expectedKeys[0] == result[0].Key {
+				if len(result[0].Comments) != tc.expectedComments {
+					t.Errorf("Expected %d comments, got %d", tc.expectedComments, len(result[0].Comments))
+				}
+				if len(result[0].Changes) != tc.expectedChanges {
+					t.Errorf("Expected %d changes, got %d", tc.expectedChanges, len(result[0].Changes))
+				}
+			}
+		})
+	}
+}
+
+func TestActivityService_Dedupe(t *testing.T) {
+	first := Comment{ID: "100", Timestamp: time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC), Author: "Test User", AuthorAccountID: "user123", Content: "Yesterday's update"}
+	second := Comment{ID: "101", Timestamp: time.Date(2023, 1, 3, 9, 0, 0, 0, time.UTC), Author: "Test User", AuthorAccountID: "user123", Content: "Today's update"}
+	comments := []Comment{first}
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "TEST-1", Status: "In Progress", Comments: append([]Comment{}, comments...)}}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.Dedupe = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+	service.SetStandupStore(&memoryStandupStore{})
+
+	// Overlapping ranges, the second covering both comments
+	yesterday := plugin.TimeRange{Start: time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)}
+	today := plugin.TimeRange{Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC)}
+
+	report, err := service.GetActivityReport(yesterday)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Issues) != 1 || len(report.Issues[0].Comments) != 1 {
+		t.Fatalf("Expected the first comment, got %+v", report.Issues)
+	}
+
+	comments = []Comment{first, second}
+	report, err = service.GetActivityReport(today)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Issues) != 1 || len(report.Issues[0].Comments) != 1 || report.Issues[0].Comments[0].ID != "101" {
+		t.Errorf("Expected only the new comment, got %+v", report.Issues)
+	}
+
+	// Nothing new since the last report
+	report, err = service.GetActivityReport(today)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("Expected no issues, got %+v", report.Issues)
+	}
+}
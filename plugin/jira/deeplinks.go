@@ -0,0 +1,58 @@
+package jira
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Issue tab panels that Jira opens through the page query parameter
+const (
+	commentTabPanel       = "com.atlassian.jira.plugin.system.issuetabpanels:comment-tabpanel"
+	changeHistoryTabPanel = "com.atlassian.jira.plugin.system.issuetabpanels:changehistory-tabpanel"
+)
+
+// DeepLinks builds links to an issue and to specific events on it
+type DeepLinks struct {
+	BaseURL string // Jira site URL without a trailing slash; empty disables links
+}
+
+// NewDeepLinks creates deep links for the given Jira site URL
+func NewDeepLinks(baseURL string) DeepLinks {
+	return DeepLinks{BaseURL: strings.TrimRight(strings.TrimSpace(baseURL), "/")}
+}
+
+// Enabled reports whether links can be built
+func (d DeepLinks) Enabled() bool {
+	return d.BaseURL != ""
+}
+
+// Issue returns the link to an issue
+func (d DeepLinks) Issue(key string) string {
+	if !d.Enabled() {
+		return ""
+	}
+	return fmt.Sprintf("%s/browse/%s", d.BaseURL, url.PathEscape(key))
+}
+
+// Comment returns the link that opens an issue scrolled to and highlighting the
+// comment, or the issue link when the comment ID is unknown
+func (d DeepLinks) Comment(key, commentID string) string {
+	if !d.Enabled() || commentID == "" {
+		return d.Issue(key)
+	}
+	params := url.Values{}
+	params.Set("focusedCommentId", commentID)
+	params.Set("page", commentTabPanel)
+	return fmt.Sprintf("%s?%s#comment-%s", d.Issue(key), params.Encode(), url.PathEscape(commentID))
+}
+
+// History returns the link that opens an issue on its change history tab
+func (d DeepLinks) History(key string) string {
+	if !d.Enabled() {
+		return ""
+	}
+	params := url.Values{}
+	params.Set("page", changeHistoryTabPanel)
+	return fmt.Sprintf("%s?%s", d.Issue(key), params.Encode())
+}
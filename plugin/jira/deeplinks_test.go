@@ -0,0 +1,126 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeepLinks(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name            string
+		baseURL         string
+		commentID       string
+		expectedIssue   string
+		expectedComment string
+		expectedHistory string
+	}{
+		{
+			name:            "Comment link",
+			baseURL:         "https://example.atlassian.net/",
+			commentID:       "10042",
+			expectedIssue:   "https://example.atlassian.net/browse/JIRA-1",
+			expectedComment: "https://example.atlassian.net/browse/JIRA-1?focusedCommentId=10042&page=com.atlassian.jira.plugin.system.issuetabpanels%3Acomment-tabpanel#comment-10042",
+			expectedHistory: "https://example.atlassian.net/browse/JIRA-1?page=com.atlassian.jira.plugin.system.issuetabpanels%3Achangehistory-tabpanel",
+		},
+		{
+			name:            "Unknown comment ID falls back to the issue",
+			baseURL:         "https://jira.example.com/jira",
+			expectedIssue:   "https://jira.example.com/jira/browse/JIRA-1",
+			expectedComment: "https://jira.example.com/jira/browse/JIRA-1",
+			expectedHistory: "https://jira.example.com/jira/browse/JIRA-1?page=com.atlassian.jira.plugin.system.issuetabpanels%3Achangehistory-tabpanel",
+		},
+		{
+			name:      "Disabled without a base URL",
+			baseURL:   "",
+			commentID: "10042",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			links := NewDeepLinks(tc.baseURL)
+
+			if result := links.Issue("JIRA-1"); result != tc.expectedIssue {
+				t.Errorf("Expected issue link '%s', got '%s'", tc.expectedIssue, result)
+			}
+			if result := links.Comment("JIRA-1", tc.commentID); result != tc.expectedComment {
+				t.Errorf("Expected comment link '%s', got '%s'", tc.expectedComment, result)
+			}
+			if result := links.History("JIRA-1"); result != tc.expectedHistory {
+				t.Errorf("Expected history link '%s', got '%s'", tc.expectedHistory, result)
+			}
+		})
+	}
+}
+
+func TestFormatters_DeepLinks(t *testing.T) {
+	issues := []Issue{
+		{
+			Key:      "JIRA-1",
+			Summary:  "Test Issue",
+			Status:   "In Progress",
+			Comments: []Comment{{ID: "10042", Author: "Test User", Content: "Looks good", Timestamp: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)}},
+		},
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name       string
+		formatter  ReportFormatter
+		baseURL    string
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:      "Markdown",
+			formatter: NewMarkdownFormatter(),
+			baseURL:   "https://example.atlassian.net",
+			expected: []string{
+				"**Links:** [Issue](https://example.atlassian.net/browse/JIRA-1) · [History](https://example.atlassian.net/browse/JIRA-1?page=com.atlassian.jira.plugin.system.issuetabpanels%3Achangehistory-tabpanel)",
+				"**Test User** - [2023-01-01 10:00](https://example.atlassian.net/browse/JIRA-1?focusedCommentId=10042&page=com.atlassian.jira.plugin.system.issuetabpanels%3Acomment-tabpanel#comment-10042)",
+			},
+		},
+		{
+			name:      "HTML",
+			formatter: NewHTMLFormatter(),
+			baseURL:   "https://example.atlassian.net",
+			expected: []string{
+				"<p class=\"permalinks\"><a href=\"https://example.atlassian.net/browse/JIRA-1\">Issue</a>",
+				"<a href=\"https://example.atlassian.net/browse/JIRA-1?focusedCommentId=10042&amp;page=com.atlassian.jira.plugin.system.issuetabpanels%3Acomment-tabpanel#comment-10042\">2023-01-01 10:00:00</a>",
+			},
+		},
+		{
+			name:       "Markdown without a base URL",
+			formatter:  NewMarkdownFormatter(),
+			expected:   []string{"**Test User** - 2023-01-01 10:00"},
+			unexpected: []string{"**Links:**", "browse"},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := DefaultReportOptions()
+			options.LinkBaseURL = tc.baseURL
+			report := &ActivityReport{User: User{DisplayName: "Test User"}, Issues: issues, Options: options}
+
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected content to contain '%s', got '%s'", expected, result.Content)
+				}
+			}
+			for _, unexpected := range tc.unexpected {
+				if strings.Contains(result.Content, unexpected) {
+					t.Errorf("Expected content not to contain '%s'", unexpected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package jira
+
+import (
+	"sort"
+)
+
+// noComponent is the digest name for issues without a component
+const noComponent = "No Component"
+
+// ComponentDigest aggregates the activity on a component's issues, regardless of assignee
+type ComponentDigest struct {
+	Component    string
+	Issues       []Issue
+	Contributors []string // Authors of the activity events, sorted
+	RollupCounts
+}
+
+// DigestByComponent groups issues under each of their components, ordered by
+// component name with issues without a component last. An issue with several
+// components appears in each of their digests.
+func DigestByComponent(issues []Issue) []ComponentDigest {
+	digests := make(map[string]*ComponentDigest)
+	// Authors by account ID, so that two people sharing a name are both listed
+	contributors := make(map[string]map[string]string)
+	names := make([]string, 0)
+
+	for _, issue := range issues {
+		components := issue.Components
+		if len(components) == 0 {
+			components = []string{noComponent}
+		}
+
+		for _, component := range components {
+			digest, ok := digests[component]
+			if !ok {
+				digest = &ComponentDigest{Component: component}
+				digests[component] = digest
+				contributors[component] = make(map[string]string)
+				names = append(names, component)
+			}
+
+			digest.Issues = append(digest.Issues, issue)
+			digest.add(issue)
+			for _, event := range issue.Events() {
+				contributors[component][userKey(event.AuthorAccountID, event.Author)] = event.Author
+			}
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == noComponent || names[j] == noComponent {
+			return names[j] == noComponent
+		}
+		return names[i] < names[j]
+	})
+
+	result := make([]ComponentDigest, 0, len(names))
+	for _, name := range names {
+		digest := digests[name]
+		for _, author := range contributors[name] {
+			if author != "" {
+				digest.Contributors = append(digest.Contributors, author)
+			}
+		}
+		sort.Strings(digest.Contributors)
+		result = append(result, *digest)
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package jira
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestDigestByComponent(t *testing.T) {
+	issues := []Issue{
+		{
+			Key:        "JIRA-1",
+			Components: []string{"Search", "Billing API"},
+			Comments:   []Comment{{Author: "Alice"}},
+			Changes:    []Change{{Author: "Bob", Field: "status"}},
+		},
+		{
+			Key:      "JIRA-2",
+			Comments: []Comment{{Author: "Carol"}},
+		},
+		{
+			Key:        "JIRA-3",
+			Components: []string{"Billing API"},
+			Changes:    []Change{{Author: "Alice", Field: "summary"}},
+		},
+	}
+
+	digests := DigestByComponent(issues)
+
+	names := make([]string, 0, len(digests))
+	for _, digest := range digests {
+		names = append(names, digest.Component)
+	}
+	expectedNames := []string{"Billing API", "Search", "No Component"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("Expected components %v, got %v", expectedNames, names)
+	}
+
+	billing := digests[0]
+	if len(billing.Issues) != 2 {
+		t.Errorf("Expected 2 Billing API issues, got %d", len(billing.Issues))
+	}
+	if !reflect.DeepEqual(billing.Contributors, []string{"Alice", "Bob"}) {
+		t.Errorf("Expected contributors [Alice Bob], got %v", billing.Contributors)
+	}
+	if billing.CountsLine() != "1 issue advanced, 1 comment, 2 changes" {
+		t.Errorf("Expected counts line '1 issue advanced, 1 comment, 2 changes', got '%s'", billing.CountsLine())
+	}
+}
+
+func TestJiraAPIRepository_ComponentModeQuery(t *testing.T) {
+	options := DefaultQueryOptions()
+	options.Project = "TEST"
+	options.ExcludeStatuses = nil
+	options.InOpenSprints = false
+
+	reportOptions := DefaultReportOptions()
+	reportOptions.Mode = ReportModeComponent
+
+	repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: options, ReportOptions: reportOptions}}
+
+	jql, err := repo.buildJQLQuery("2023-01-01", "2023-01-02")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(jql, "currentUser()") {
+		t.Errorf("Expected component mode to ignore the assignee filter, got '%s'", jql)
+	}
+	if !strings.Contains(strings.Join(repo.searchFields(), ","), "components") {
+		t.Errorf("Expected components to be requested, got %v", repo.searchFields())
+	}
+}
+
+func TestActivityService_ComponentMode(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{Key: "JIRA-1", Summary: "Fix invoices", Status: "In Progress", Components: []string{"Billing API"},
+					Comments: []Comment{{Author: "Alice", Content: "On it"}}},
+			}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.Mode = ReportModeComponent
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	formatters := []ReportFormatter{
+		NewXMLFormatter(),
+		NewJSONFormatter(),
+		NewMarkdownFormatter(),
+		NewHTMLFormatter(),
+	}
+	for _, formatter := range formatters {
+		t.Run(formatter.Name(), func(t *testing.T) {
+			result, err := formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(result.Content, "Billing API") || !strings.Contains(result.Content, "Alice") {
+				t.Errorf("Expected content to contain the component digest, got '%s'", result.Content)
+			}
+		})
+	}
+}
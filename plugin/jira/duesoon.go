@@ -0,0 +1,35 @@
+package jira
+
+import (
+	"fmt"
+	"time"
+)
+
+// dueDateLayout formats due dates, which Jira stores without a time of day
+const dueDateLayout = "2006-01-02"
+
+// dueHorizon returns the last due date listed in the due-soon section, the
+// given number of days after the end of the time range
+func dueHorizon(timeRange TimeRange, days int) time.Time {
+	return timeRange.End.AddDate(0, 0, days)
+}
+
+// isOverdue reports whether an issue was due before the day the time range ends
+func isOverdue(issue Issue, timeRange TimeRange) bool {
+	if issue.DueDate.IsZero() {
+		return false
+	}
+	end := timeRange.End
+	today := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	due := time.Date(issue.DueDate.Year(), issue.DueDate.Month(), issue.DueDate.Day(), 0, 0, 0, 0, time.UTC)
+	return due.Before(today)
+}
+
+// dueLine renders when an issue is due along with its status, e.g.
+// "due 2023-01-05, In Progress" or "overdue since 2023-01-01, To Do"
+func dueLine(issue Issue, timeRange TimeRange) string {
+	if isOverdue(issue, timeRange) {
+		return fmt.Sprintf("overdue since %s, %s", issue.DueDate.Format(dueDateLayout), issue.Status)
+	}
+	return fmt.Sprintf("due %s, %s", issue.DueDate.Format(dueDateLayout), issue.Status)
+}
@@ -0,0 +1,149 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestDueLine(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 9, 0, 0, 0, time.UTC),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name            string
+		issue           Issue
+		expectedLine    string
+		expectedOverdue bool
+	}{
+		{
+			name:         "Due today",
+			issue:        Issue{Status: "In Progress", DueDate: time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)},
+			expectedLine: "due 2023-01-03, In Progress",
+		},
+		{
+			name:         "Due later this week",
+			issue:        Issue{Status: "To Do", DueDate: time.Date(2023, 1, 6, 0, 0, 0, 0, time.UTC)},
+			expectedLine: "due 2023-01-06, To Do",
+		},
+		{
+			name:            "Overdue",
+			issue:           Issue{Status: "To Do", DueDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+			expectedLine:    "overdue since 2023-01-01, To Do",
+			expectedOverdue: true,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if line := dueLine(tc.issue, timeRange); line != tc.expectedLine {
+				t.Errorf("Expected '%s', got '%s'", tc.expectedLine, line)
+			}
+			if overdue := isOverdue(tc.issue, timeRange); overdue != tc.expectedOverdue {
+				t.Errorf("Expected overdue %v, got %v", tc.expectedOverdue, overdue)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_DueSoonIssues(t *testing.T) {
+	reportOptions := DefaultReportOptions()
+	reportOptions.DueWithinDays = 1
+	repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: DefaultQueryOptions(), ReportOptions: reportOptions})
+	server.Issues = []extJira.Issue{
+		{Key: "TEST-1", Fields: &extJira.IssueFields{
+			Summary: "Renew certificates",
+			Status:  &extJira.Status{Name: "To Do"},
+			Duedate: extJira.Date(time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)),
+		}},
+	}
+
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	issues, err := repo.GetSupplementaryIssues(SupplementaryDueSoon, timeRange, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].DueDate.Format(dueDateLayout) != "2023-01-03" {
+		t.Errorf("Expected the issue with its due date, got %+v", issues)
+	}
+
+	requests := server.Requests("/rest/api/2/search")
+	if len(requests) != 1 {
+		t.Fatalf("Expected one search, got %d", len(requests))
+	}
+	if jql := requests[0].Query.Get("jql"); !strings.Contains(jql, `duedate <= "2023-01-03"`) {
+		t.Errorf("Expected issues due by the horizon, got '%s'", jql)
+	}
+	if fields := requests[0].Query.Get("fields"); !strings.Contains(fields, "duedate") {
+		t.Errorf("Expected the due date to be requested, got '%s'", fields)
+	}
+}
+
+func TestActivityService_DueSoon(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name          string
+		dueWithinDays int
+		expectedKeys  []string
+	}{
+		{name: "Disabled", dueWithinDays: 0, expectedKeys: []string{}},
+		{name: "Enabled", dueWithinDays: 7, expectedKeys: []string{"JIRA-1", "JIRA-2"}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queried := false
+			mockRepo := &MockJiraRepository{
+				MockGetUser: func() (*User, error) {
+					return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+				},
+				MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+					return []Issue{{Key: "JIRA-1", Status: "In Progress"}}, nil
+				},
+				MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+					if kind != SupplementaryDueSoon {
+						t.Errorf("Expected due soon query, got %s", kind)
+					}
+					queried = true
+					return []Issue{
+						{Key: "JIRA-1", Status: "In Progress", DueDate: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+						{Key: "JIRA-2", Status: "To Do", DueDate: time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC)},
+					}, nil
+				},
+			}
+
+			options := DefaultReportOptions()
+			options.DueWithinDays = tc.dueWithinDays
+
+			service := NewActivityService(mockRepo)
+			service.SetReportOptions(options)
+
+			report, err := service.GetActivityReport(plugin.TimeRange{
+				Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if queried != (tc.dueWithinDays > 0) {
+				t.Errorf("Expected the due soon query only when enabled, queried %v", queried)
+			}
+			// Issues with activity are still previewed when they are due
+			if keys := issueKeys(report.DueSoon); strings.Join(keys, ",") != strings.Join(tc.expectedKeys, ",") {
+				t.Errorf("Expected due issues %v, got %v", tc.expectedKeys, keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EmptyReport is what a format produces for a report without activity
+type EmptyReport string
+
+const (
+	// EmptyReportMessage produces a short "no activity" message, the default
+	EmptyReportMessage EmptyReport = "message"
+	// EmptyReportDocument produces the regular document without issues, so
+	// that a consumer parses every report the same way
+	EmptyReportDocument EmptyReport = "document"
+	// EmptyReportOmit produces no content, which leaves the plugin out of the
+	// standup, and posts nothing to chat
+	EmptyReportOmit EmptyReport = "omit"
+)
+
+// formatNames are the names of the formats an empty-report behavior can be set for
+var formatNames = []string{"xml", "json", "markdown", "html", "slack", "teams"}
+
+// EmptyReportPolicy is the empty-report behavior of each format
+type EmptyReportPolicy struct {
+	Default EmptyReport
+	Formats map[string]EmptyReport // By format name, overriding the default
+}
+
+// For returns the behavior of the named format
+func (p EmptyReportPolicy) For(format string) EmptyReport {
+	if behavior, ok := p.Formats[format]; ok {
+		return behavior
+	}
+	if p.Default == "" {
+		return EmptyReportMessage
+	}
+	return p.Default
+}
+
+// parseEmptyReport parses a single empty-report behavior
+func parseEmptyReport(value string) (EmptyReport, error) {
+	switch behavior := EmptyReport(strings.ToLower(strings.TrimSpace(value))); behavior {
+	case EmptyReportMessage, EmptyReportDocument, EmptyReportOmit:
+		return behavior, nil
+	default:
+		return "", fmt.Errorf("unknown empty-report behavior %q (expected message, document or omit)", value)
+	}
+}
+
+// ParseEmptyReportPolicy parses the empty-report behavior of every format, such
+// as "omit", or comma-separated overrides per format with an optional default,
+// such as "message, json:document, xml:document"
+func ParseEmptyReportPolicy(value string) (EmptyReportPolicy, error) {
+	policy := EmptyReportPolicy{Default: EmptyReportMessage}
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		format, behaviorStr, scoped := strings.Cut(part, ":")
+		behavior, err := parseEmptyReport(behaviorStr)
+		if !scoped {
+			behavior, err = parseEmptyReport(format)
+		}
+		if err != nil {
+			return EmptyReportPolicy{}, err
+		}
+		if !scoped {
+			policy.Default = behavior
+			continue
+		}
+
+		format = strings.ToLower(strings.TrimSpace(format))
+		if !containsFold(formatNames, format) {
+			return EmptyReportPolicy{}, fmt.Errorf("unknown format %q (expected %s)", format, strings.Join(formatNames, ", "))
+		}
+		if policy.Formats == nil {
+			policy.Formats = make(map[string]EmptyReport)
+		}
+		policy.Formats[format] = behavior
+	}
+	return policy, nil
+}
+
+// IsEmpty reports whether the report has nothing to render
+func (r *ActivityReport) IsEmpty() bool {
+	return !r.hasContent()
+}
+
+// emptyMessage reports whether the named format renders the report as the
+// short "no activity" message
+func (r *ActivityReport) emptyMessage(format string) bool {
+	return r.IsEmpty() && r.Options.EmptyReports.For(format) != EmptyReportDocument
+}
+
+// Omitted reports whether the named format leaves the report out entirely
+func (r *ActivityReport) Omitted(format string) bool {
+	return r.IsEmpty() && r.Options.EmptyReports.For(format) == EmptyReportOmit
+}
@@ -0,0 +1,138 @@
+package jira
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseEmptyReportPolicy(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		value    string
+		expected EmptyReportPolicy
+		wantErr  bool
+	}{
+		{name: "Unset", value: "", expected: EmptyReportPolicy{Default: EmptyReportMessage}},
+		{name: "Every format", value: " Omit ", expected: EmptyReportPolicy{Default: EmptyReportOmit}},
+		{
+			name:  "Per format",
+			value: "json:document, XML:document, omit",
+			expected: EmptyReportPolicy{
+				Default: EmptyReportOmit,
+				Formats: map[string]EmptyReport{"json": EmptyReportDocument, "xml": EmptyReportDocument},
+			},
+		},
+		{name: "Unknown behavior", value: "json:skip", wantErr: true},
+		{name: "Unknown format", value: "yaml:document", wantErr: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := ParseEmptyReportPolicy(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if !reflect.DeepEqual(policy, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, policy)
+			}
+		})
+	}
+}
+
+func TestEmptyReportPolicy_Formatters(t *testing.T) {
+	policy := EmptyReportPolicy{Default: EmptyReportOmit, Formats: map[string]EmptyReport{"markdown": EmptyReportDocument, "html": EmptyReportMessage}}
+	report := &ActivityReport{User: User{DisplayName: "Test User"}, Options: DefaultReportOptions()}
+	report.Options.EmptyReports = policy
+
+	if policy.For("slack") != EmptyReportOmit || !report.Omitted("slack") {
+		t.Errorf("Expected the default to apply to formats without an override")
+	}
+	if (EmptyReportPolicy{}).For("json") != EmptyReportMessage {
+		t.Errorf("Expected the zero policy to produce the message")
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		expected  string
+	}{
+		{formatter: NewMarkdownFormatter(), expected: "# Jira Activity Report\n\n**Time Range:** 0001-01-01 to 0001-01-01\n\n**User:** Test User\n\n"},
+		{formatter: NewHTMLFormatter(), expected: "<html><body><h1>Jira Activity Report</h1><p>No activity found for the specified time range.</p></body></html>"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			content, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if report.Omitted(tc.formatter.Name()) {
+				t.Errorf("Expected %s not to omit the report", tc.formatter.Name())
+			}
+			if content.Content != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, content.Content)
+			}
+		})
+	}
+
+	// A report with activity is never omitted
+	report.Issues = []Issue{{Key: "PAY-12"}}
+	if report.Omitted("slack") {
+		t.Errorf("Expected a report with activity to be kept")
+	}
+}
+
+func TestJSONFormatter_EmptyReport(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		behavior        EmptyReport
+		expectedMessage string
+	}{
+		{behavior: EmptyReportMessage, expectedMessage: "No activity found for the specified time range."},
+		{behavior: EmptyReportDocument},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(string(tc.behavior), func(t *testing.T) {
+			report := &ActivityReport{
+				TimeRange: TimeRange{Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)},
+				User:      User{DisplayName: "Test User"},
+				Options:   DefaultReportOptions(),
+			}
+			report.Options.EmptyReports = EmptyReportPolicy{Default: tc.behavior}
+
+			content, err := NewJSONFormatter().Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			// The empty report has the structure of any other
+			var decoded struct {
+				TimeRange *struct{ Start, End string } `json:"timeRange"`
+				User      *struct {
+					DisplayName string `json:"displayName"`
+				} `json:"user"`
+				Message string            `json:"message"`
+				Issues  []json.RawMessage `json:"issues"`
+			}
+			if err := json.Unmarshal([]byte(content.Content), &decoded); err != nil {
+				t.Fatalf("Expected valid JSON, got %v", err)
+			}
+			if decoded.TimeRange == nil || decoded.TimeRange.Start != "2023-01-02T00:00:00.000Z" || decoded.User == nil || decoded.User.DisplayName != "Test User" {
+				t.Errorf("Expected the time range and user, got %s", content.Content)
+			}
+			if decoded.Issues == nil || len(decoded.Issues) != 0 {
+				t.Errorf("Expected an empty issues array, got %s", content.Content)
+			}
+			if decoded.Message != tc.expectedMessage {
+				t.Errorf("Expected message %q, got %q", tc.expectedMessage, decoded.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package jira
+
+import (
+	"fmt"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+const (
+	// estimatedCallDuration is the rough time a Jira API call takes, from the
+	// request to the decoded response
+	estimatedCallDuration = 400 * time.Millisecond
+	// estimatedConcurrency is the number of per-issue calls assumed in flight
+	// at once, the default HTTP concurrency limit
+	estimatedConcurrency = 4
+)
+
+// SearchEstimate is the expected size of the activity search, measured with a
+// count-only search per project before fetching anything
+type SearchEstimate struct {
+	Matching int // Issues matching the query
+	Fetched  int // Issues the search fetches, within the maximum results per project
+	Calls    int // API calls the search makes, including JQL validation
+}
+
+// ReportEstimate is the expected cost of a report, so that the host or user
+// can confirm before launching a long one, such as a month of team activity
+type ReportEstimate struct {
+	TimeRange      TimeRange
+	Issues         int // Issues the report is expected to cover at most
+	MatchingIssues int // Issues matching the query, beyond the maximum results
+	APICalls       int
+	Duration       time.Duration // Rough time the report takes to generate
+}
+
+// Line renders the estimate in one line, e.g. "Up to 240 issues (312
+// matching), about 290 API calls, about 1m"
+func (e *ReportEstimate) Line() string {
+	line := "Up to " + pluralize(e.Issues, "issue", "issues")
+	if e.MatchingIssues > e.Issues {
+		line += fmt.Sprintf(" (%d matching)", e.MatchingIssues)
+	}
+	line += fmt.Sprintf(", about %s", pluralize(e.APICalls, "API call", "API calls"))
+	if e.Duration < time.Minute {
+		return line + ", under a minute"
+	}
+	return line + ", about " + formatDuration(e.Duration.Round(time.Minute))
+}
+
+// EstimateSearch counts the issues the activity search would fetch over the
+// time range, asking each project for a single issue without its changelog,
+// which Jira answers with the number of matching issues. In multi-project
+// mode projects Jira refuses access to are left out of the estimate.
+func (r *JiraAPIRepository) EstimateSearch(timeRange TimeRange) (SearchEstimate, error) {
+	fromTime, toTime := r.config.QueryOptions.jqlRange(timeRange)
+
+	limit := r.config.QueryOptions.MaxResults
+	if limit <= 0 {
+		limit = searchPageSize
+	}
+
+	var estimate SearchEstimate
+	projects := r.searchedProjects()
+	for _, project := range projects {
+		jql, err := r.buildProjectJQLQuery(project, fromTime, toTime)
+		if err != nil {
+			return SearchEstimate{}, err
+		}
+
+		_, total, err := r.searchIssuesWithTotal(jql, &extJira.SearchOptions{MaxResults: 1, Fields: []string{"summary"}})
+		if err != nil {
+			if len(projects) > 1 && projectDenied(err) {
+				continue
+			}
+			return SearchEstimate{}, err
+		}
+
+		fetched := min(total, limit)
+		estimate.Matching += total
+		estimate.Fetched += fetched
+		estimate.Calls += max(1, (fetched+searchPageSize-1)/searchPageSize)
+		if r.config.QueryOptions.ValidateJQL {
+			estimate.Calls++
+		}
+	}
+	return estimate, nil
+}
+
+// EstimateReport estimates the cost of the activity report over the time
+// range with the configured options: the issues it covers, from a count-only
+// search, the API calls its search and enabled sections make, and roughly how
+// long that takes. Calls made per issue are counted for every issue fetched,
+// so the estimate errs on the high side.
+func (s *ActivityService) EstimateReport(pluginTimeRange plugin.TimeRange) (*ReportEstimate, error) {
+	s.mu.RLock()
+	options := s.options
+	s.mu.RUnlock()
+
+	if options.Mode == ReportModeRelease || options.Mode == ReportModeTriage {
+		return nil, fmt.Errorf("estimates are only available for activity reports, not in %s mode", options.Mode)
+	}
+
+	timeRange := TimeRange{Start: pluginTimeRange.Start, End: pluginTimeRange.End}
+	if timeRange.Start.IsZero() && timeRange.End.IsZero() {
+		timeRange = s.SinceLastStandupRange(s.Now())
+	}
+	timeRange.EndInclusive = options.EndInclusive
+	if err := timeRange.Validate(options.MaxRangeDays); err != nil {
+		return nil, err
+	}
+
+	search, err := s.repository.EstimateSearch(timeRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate the search: %w", err)
+	}
+	issues := search.Fetched
+
+	// The user and the search run one after the other, as do the
+	// supplementary queries and the lookups of issues by key
+	sequential := 1 + search.Calls
+	for _, enabled := range []bool{options.IncludeEscalations, options.IncludeCarryOver, options.IncludeFlagged, options.IncludeFiled, options.DueWithinDays > 0, options.IncludeHandoffs} {
+		if enabled {
+			sequential++
+		}
+	}
+	if options.SprintBoardID > 0 {
+		sequential += 2 // The sprint and its issues
+	}
+	sequential += lookupPages(len(options.PinnedIssues))
+	sequential += lookupPages(options.ReferenceLookups)
+	switch {
+	case options.resolvesHierarchy():
+		sequential += 2 * lookupPages(issues) // Epics and the levels above them
+	case options.Mode == ReportModeEpic:
+		sequential += lookupPages(issues)
+	}
+
+	// Watchers, remote links and past states are fetched for each issue, in parallel
+	parallel := 0
+	if options.IncludeAttention {
+		parallel += issues
+	}
+	if options.IncludeRemoteLinks {
+		parallel += issues
+	}
+	if options.Historical && timeRange.End.Before(s.Now()) {
+		parallel += issues
+	}
+
+	rounds := (parallel + estimatedConcurrency - 1) / estimatedConcurrency
+	return &ReportEstimate{
+		TimeRange:      timeRange,
+		Issues:         issues,
+		MatchingIssues: search.Matching,
+		APICalls:       sequential + parallel,
+		Duration:       time.Duration(sequential+rounds) * estimatedCallDuration,
+	}, nil
+}
+
+// lookupPages returns the number of searches looking up the given number of
+// issues by key
+func lookupPages(count int) int {
+	return (count + keyLookupPageSize - 1) / keyLookupPageSize
+}
@@ -0,0 +1,159 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestJiraAPIRepository_EstimateSearch(t *testing.T) {
+	issues := func(project string, count int) []extJira.Issue {
+		result := make([]extJira.Issue, count)
+		for i := range result {
+			result[i] = extJira.Issue{Key: fmt.Sprintf("%s-%d", project, i+1), Fields: &extJira.IssueFields{Summary: "Issue"}}
+		}
+		return result
+	}
+
+	options := DefaultQueryOptions()
+	options.Projects = []string{"PAY", "OPS", "SEC"}
+	options.MaxResults = 200
+	options.ValidateJQL = false
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: options, ReportOptions: DefaultReportOptions()})
+	server.Projects = map[string][]extJira.Issue{"PAY": issues("PAY", 250), "OPS": issues("OPS", 30)}
+
+	estimate, err := repo.EstimateSearch(TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// SEC is refused and left out; PAY is capped at the maximum results
+	expected := SearchEstimate{Matching: 280, Fetched: 230, Calls: 3}
+	if estimate != expected {
+		t.Errorf("Expected %+v, got %+v", expected, estimate)
+	}
+	for _, request := range server.Requests("/rest/api/2/search") {
+		if request.Query.Get("maxResults") != "1" || request.Query.Get("expand") != "" {
+			t.Errorf("Expected a count-only search, got %v", request.Query)
+		}
+	}
+}
+
+func TestActivityService_EstimateReport(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name             string
+		modify           func(options *ReportOptions)
+		expectedCalls    int
+		expectedDuration time.Duration
+		expectedErr      string
+	}{
+		{
+			name:             "Default options",
+			expectedCalls:    5,
+			expectedDuration: 5 * estimatedCallDuration,
+		},
+		{
+			name: "Per-issue calls",
+			modify: func(options *ReportOptions) {
+				options.IncludeAttention = true
+				options.IncludeRemoteLinks = true
+			},
+			expectedCalls:    5 + 480,
+			expectedDuration: (5 + 120) * estimatedCallDuration,
+		},
+		{
+			name: "Supplementary queries and lookups",
+			modify: func(options *ReportOptions) {
+				options.IncludeCarryOver = true
+				options.IncludeFlagged = true
+				options.SprintBoardID = 7
+				options.PinnedIssues = []string{"PAY-1"}
+				options.Mode = ReportModeEpic
+			},
+			expectedCalls:    5 + 2 + 2 + 1 + 5,
+			expectedDuration: 15 * estimatedCallDuration,
+		},
+		{
+			name: "Release notes",
+			modify: func(options *ReportOptions) {
+				options.Mode = ReportModeRelease
+			},
+			expectedErr: "only available for activity reports",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := &MockJiraRepository{
+				MockEstimateSearch: func(timeRange TimeRange) (SearchEstimate, error) {
+					return SearchEstimate{Matching: 312, Fetched: 240, Calls: 4}, nil
+				},
+			}
+			options := DefaultReportOptions()
+			if tc.modify != nil {
+				tc.modify(&options)
+			}
+			service := NewActivityService(mockRepo)
+			service.SetReportOptions(options)
+
+			estimate, err := service.EstimateReport(plugin.TimeRange{
+				Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+			})
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if estimate.Issues != 240 || estimate.MatchingIssues != 312 {
+				t.Errorf("Expected 240 of 312 issues, got %d of %d", estimate.Issues, estimate.MatchingIssues)
+			}
+			if estimate.APICalls != tc.expectedCalls {
+				t.Errorf("Expected %d API calls, got %d", tc.expectedCalls, estimate.APICalls)
+			}
+			if estimate.Duration != tc.expectedDuration {
+				t.Errorf("Expected %v, got %v", tc.expectedDuration, estimate.Duration)
+			}
+		})
+	}
+}
+
+func TestReportEstimate_Line(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		estimate ReportEstimate
+		expected string
+	}{
+		{
+			estimate: ReportEstimate{Issues: 240, MatchingIssues: 312, APICalls: 485, Duration: 100 * time.Second},
+			expected: "Up to 240 issues (312 matching), about 485 API calls, about 2m",
+		},
+		{
+			estimate: ReportEstimate{Issues: 1, MatchingIssues: 1, APICalls: 3, Duration: 1200 * time.Millisecond},
+			expected: "Up to 1 issue, about 3 API calls, under a minute",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if result := tc.estimate.Line(); result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package jira
+
+import (
+	"sort"
+	"time"
+)
+
+// EventKind is the kind of an activity event
+type EventKind string
+
+const (
+	EventComment EventKind = "comment"
+	EventChange  EventKind = "change"
+	EventLink    EventKind = "link"
+	// EventWorklog is the kind of work logged, fetched with the worklogs section
+	EventWorklog EventKind = "worklog"
+	// EventAttachment is the kind of files attached, for the activity the
+	// report does not fetch yet
+	EventAttachment EventKind = "attachment"
+)
+
+// Event is one piece of activity on an issue, whatever its kind, so that the
+// activity can be counted, ordered and exported without a case per kind. The
+// payload holds the Comment, Change, RemoteLink or Worklog behind the event.
+type Event struct {
+	Kind            EventKind
+	IssueKey        string
+	Timestamp       time.Time
+	Author          string
+	AuthorAccountID string // Empty for kinds Jira records by name only, such as links
+	AuthorTimeZone  string
+	Payload         interface{}
+}
+
+// Events returns the activity of the issue as events in chronological order.
+// The comments, changes, remote links and worklogs of the issue are the views of each
+// kind; a new kind of activity is added here to reach every consumer of events.
+// Label and component changes and the status changes of a journey, which the
+// report keeps apart from the other changes, are change events too.
+func (i Issue) Events() []Event {
+	changes := i.activityChanges()
+	events := make([]Event, 0, len(i.Comments)+len(changes)+len(i.RemoteLinks)+len(i.Worklogs))
+	for _, comment := range i.Comments {
+		events = append(events, Event{
+			Kind:            EventComment,
+			IssueKey:        i.Key,
+			Timestamp:       comment.Timestamp,
+			Author:          comment.Author,
+			AuthorAccountID: comment.AuthorAccountID,
+			AuthorTimeZone:  comment.AuthorTimeZone,
+			Payload:         comment,
+		})
+	}
+	for _, change := range changes {
+		events = append(events, Event{
+			Kind:            EventChange,
+			IssueKey:        i.Key,
+			Timestamp:       change.Timestamp,
+			Author:          change.Author,
+			AuthorAccountID: change.AuthorAccountID,
+			AuthorTimeZone:  change.AuthorTimeZone,
+			Payload:         change,
+		})
+	}
+	for _, link := range i.RemoteLinks {
+		events = append(events, Event{
+			Kind:      EventLink,
+			IssueKey:  i.Key,
+			Timestamp: link.AddedAt,
+			Author:    link.AddedBy,
+			Payload:   link,
+		})
+	}
+	for _, worklog := range i.Worklogs {
+		events = append(events, Event{
+			Kind:            EventWorklog,
+			IssueKey:        i.Key,
+			Timestamp:       worklog.Started,
+			Author:          worklog.Author,
+			AuthorAccountID: worklog.AuthorAccountID,
+			AuthorTimeZone:  worklog.AuthorTimeZone,
+			Payload:         worklog,
+		})
+	}
+
+	sort.SliceStable(events, func(a, b int) bool {
+		return events[a].Timestamp.Before(events[b].Timestamp)
+	})
+	return events
+}
+
+// activityChanges returns every change of the issue, including those moved out
+// of the changes into the label and component changes and the status journey
+func (i Issue) activityChanges() []Change {
+	changes := append([]Change{}, i.Changes...)
+	for _, collection := range i.CollectionChanges {
+		changes = append(changes, collection.Source)
+	}
+	if i.Transitions != nil {
+		changes = append(changes, i.Transitions.Changes...)
+	}
+	return changes
+}
+
+// Events returns the activity of every issue of the report as events, issue
+// by issue in the order of the report
+func (r *ActivityReport) Events() []Event {
+	events := make([]Event, 0)
+	for _, issue := range r.Issues {
+		events = append(events, issue.Events()...)
+	}
+	return events
+}
+
+// ID identifies the event across reports
+func (e Event) ID() string {
+	switch payload := e.Payload.(type) {
+	case Comment:
+		return commentEventID(e.IssueKey, payload)
+	case Change:
+		return changeEventID(e.IssueKey, payload)
+	case RemoteLink:
+		return remoteLinkEventID(e.IssueKey, payload)
+	case Worklog:
+		return worklogEventID(e.IssueKey, payload)
+	default:
+		return ""
+	}
+}
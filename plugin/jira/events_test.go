@@ -0,0 +1,67 @@
+package jira
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIssue_Events(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2023, 1, 2, hour, 0, 0, 0, time.UTC) }
+	comment := Comment{ID: "10042", Timestamp: at(11), Author: "QA", AuthorAccountID: "qa1", Content: "Found an edge case"}
+	change := Change{Timestamp: at(9), Author: "Test User", AuthorAccountID: "user123", Field: "status", FromValue: "To Do", ToValue: "In Progress"}
+	link := RemoteLink{ID: "10000", Title: "Design doc", AddedAt: at(10), AddedBy: "Test User"}
+	issue := Issue{Key: "PAY-12", Comments: []Comment{comment}, Changes: []Change{change}, RemoteLinks: []RemoteLink{link}}
+
+	expected := []Event{
+		{Kind: EventChange, IssueKey: "PAY-12", Timestamp: at(9), Author: "Test User", AuthorAccountID: "user123", Payload: change},
+		{Kind: EventLink, IssueKey: "PAY-12", Timestamp: at(10), Author: "Test User", Payload: link},
+		{Kind: EventComment, IssueKey: "PAY-12", Timestamp: at(11), Author: "QA", AuthorAccountID: "qa1", Payload: comment},
+	}
+	events := issue.Events()
+	if !reflect.DeepEqual(events, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, events)
+	}
+
+	// Events are identified as their items are
+	ids := []string{changeEventID("PAY-12", change), remoteLinkEventID("PAY-12", link), commentEventID("PAY-12", comment)}
+	for i, event := range events {
+		if event.ID() != ids[i] {
+			t.Errorf("Expected event %d to be identified as %s, got %s", i, ids[i], event.ID())
+		}
+	}
+
+	report := &ActivityReport{Issues: []Issue{issue, {Key: "PAY-13"}}}
+	if got := report.Events(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected the report events to be those of its issues, got %+v", got)
+	}
+}
+
+func TestIssue_Events_SeparatedChanges(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2023, 1, 2, hour, 0, 0, 0, time.UTC) }
+	label := Change{Timestamp: at(9), Author: "Test User", AuthorAccountID: "user123", Field: "labels", FromValue: "triage", ToValue: "backend"}
+	toReview := Change{Timestamp: at(10), Author: "Test User", AuthorAccountID: "user123", Field: "status", FromValue: "In Progress", ToValue: "In Review"}
+	toDone := Change{Timestamp: at(12), Author: "QA", AuthorAccountID: "qa1", Field: "status", FromValue: "In Review", ToValue: "Done"}
+	priority := Change{Timestamp: at(11), Author: "QA", AuthorAccountID: "qa1", Field: "priority", FromValue: "Medium", ToValue: "High"}
+	issue := Issue{Key: "PAY-12", Changes: []Change{label, toReview, priority, toDone}}
+	before := issue.Events()
+
+	// Label changes and status transitions are kept apart from the other
+	// changes, as the report does, yet remain the same events
+	issues := []Issue{issue}
+	extractIssueCollectionChanges(issues)
+	summarizeIssueTransitions(issues)
+	if len(issues[0].Changes) != 1 || len(issues[0].CollectionChanges) != 1 || issues[0].Transitions == nil {
+		t.Fatalf("Expected the changes to be separated, got %+v", issues[0])
+	}
+
+	after := issues[0].Events()
+	if !reflect.DeepEqual(after, before) {
+		t.Fatalf("Expected %+v, got %+v", before, after)
+	}
+	for i, event := range after {
+		if event.Kind != EventChange || event.ID() != before[i].ID() {
+			t.Errorf("Expected event %d to be the change %s, got %s", i, before[i].ID(), event.ID())
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// rankField is the changelog field of backlog rank changes
+const rankField = "Rank"
+
+// estimateFields are the time tracking fields whose changes Jira records in seconds
+var estimateFields = map[string]bool{
+	"timeestimate":         true,
+	"timeoriginalestimate": true,
+	"timespent":            true,
+}
+
+// renderChangeFields rewrites the values of known fields that Jira records in
+// an unreadable form: sprint IDs become sprint names, assignee account IDs
+// become display names, estimates become durations and rank changes become
+// the direction the issue moved. Sprints are looked up once per report, and
+// users are only resolved when users is set. Values that cannot be resolved
+// are left as they are.
+func renderChangeFields(issues []Issue, sprint func(id int) (*Sprint, error), users func(accountIDs []string) (map[string]User, error)) error {
+	var errs []error
+
+	// Resolve every account ID left without a display name in one batch
+	var resolved map[string]User
+	if users != nil {
+		if accountIDs := unnamedAssignees(issues); len(accountIDs) > 0 {
+			var err error
+			if resolved, err = users(accountIDs); err != nil {
+				errs = append(errs, fmt.Errorf("failed to resolve assignees: %w", err))
+			}
+		}
+	}
+
+	sprintNames := make(map[string]string)
+	sprintName := func(id string) string {
+		if name, ok := sprintNames[id]; ok {
+			return name
+		}
+		name := id
+		if number, err := strconv.Atoi(id); err == nil {
+			if found, err := sprint(number); err != nil {
+				errs = append(errs, err)
+			} else if found != nil && found.Name != "" {
+				name = found.Name
+			}
+		}
+		sprintNames[id] = name
+		return name
+	}
+
+	for i := range issues {
+		for j := range issues[i].Changes {
+			change := &issues[i].Changes[j]
+			switch {
+			case strings.EqualFold(change.Field, sprintField):
+				change.FromValue = renderSprints(change.FromValue, change.FromID, sprintName)
+				change.ToValue = renderSprints(change.ToValue, change.ToID, sprintName)
+			case strings.EqualFold(change.Field, assigneeField):
+				change.FromValue = renderUser(change.FromValue, change.FromID, resolved)
+				change.ToValue = renderUser(change.ToValue, change.ToID, resolved)
+			case estimateFields[strings.ToLower(change.Field)]:
+				change.FromValue = renderEstimate(change.FromValue)
+				change.ToValue = renderEstimate(change.ToValue)
+			case strings.EqualFold(change.Field, rankField):
+				change.FromValue, change.ToValue = "", renderRank(*change)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// unnamedAssignees returns the account IDs of assignee changes that Jira
+// recorded without a display name
+func unnamedAssignees(issues []Issue) []string {
+	accountIDs := make([]string, 0)
+	for _, issue := range issues {
+		for _, change := range issue.Changes {
+			if !strings.EqualFold(change.Field, assigneeField) {
+				continue
+			}
+			if needsName(change.FromValue, change.FromID) {
+				accountIDs = appendMissing(accountIDs, change.FromID)
+			}
+			if needsName(change.ToValue, change.ToID) {
+				accountIDs = appendMissing(accountIDs, change.ToID)
+			}
+		}
+	}
+	return accountIDs
+}
+
+// needsName reports whether a changelog value is missing or only repeats the raw ID
+func needsName(value, id string) bool {
+	return id != "" && (value == "" || value == id)
+}
+
+// renderUser returns the display name of the account behind a changelog value
+func renderUser(value, accountID string, users map[string]User) string {
+	if !needsName(value, accountID) {
+		return value
+	}
+	if user, ok := users[accountID]; ok && user.DisplayName != "" {
+		return user.DisplayName
+	}
+	return value
+}
+
+// renderSprints returns the names of the comma-separated sprint IDs behind a
+// changelog value, e.g. "Sprint 5, Sprint 6"
+func renderSprints(value, ids string, sprintName func(id string) string) string {
+	if !needsName(value, ids) && !isIDList(value) {
+		return value
+	}
+
+	names := make([]string, 0)
+	for _, id := range strings.Split(ids, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			names = append(names, sprintName(id))
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// isIDList reports whether a value is a comma-separated list of numeric IDs
+func isIDList(value string) bool {
+	if strings.TrimSpace(value) == "" {
+		return false
+	}
+	for _, id := range strings.Split(value, ",") {
+		if _, err := strconv.Atoi(strings.TrimSpace(id)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// renderEstimate renders an estimate recorded in seconds as hours and minutes,
+// e.g. "2h 30m"; estimates are work time, so they are not rolled up into days
+func renderEstimate(value string) string {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return value
+	}
+
+	hours, minutes := seconds/3600, seconds%3600/60
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// renderRank describes a rank change as the direction the issue moved. Jira
+// Cloud records "Ranked higher" or "Ranked lower"; otherwise the raw LexoRank
+// values are compared, a lower rank sorting first.
+func renderRank(change Change) string {
+	switch {
+	case strings.Contains(strings.ToLower(change.ToValue), "higher"):
+		return "Moved up"
+	case strings.Contains(strings.ToLower(change.ToValue), "lower"):
+		return "Moved down"
+	}
+
+	from, to := change.FromValue, change.ToValue
+	if from == "" || to == "" {
+		from, to = change.FromID, change.ToID
+	}
+	switch {
+	case from == "" || to == "" || from == to:
+		return "Reranked"
+	case to < from:
+		return "Moved up"
+	default:
+		return "Moved down"
+	}
+}
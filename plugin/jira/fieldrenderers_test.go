@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestRenderChangeFields(t *testing.T) {
+	issues := []Issue{{
+		Key: "TEST-1",
+		Changes: []Change{
+			{Field: "Sprint", FromValue: "", FromID: "", ToValue: "", ToID: "12, 13"},
+			{Field: "Sprint", FromValue: "Sprint 4", FromID: "11", ToValue: "12", ToID: "12"},
+			{Field: "assignee", FromValue: "Alice", FromID: "alice1", ToValue: "", ToID: "bob1"},
+			{Field: "timeestimate", FromValue: "3600", ToValue: "9000"},
+			{Field: "timeoriginalestimate", FromValue: "", ToValue: "1800"},
+			{Field: "Rank", FromValue: "", ToValue: "Ranked higher"},
+			{Field: "Rank", FromValue: "0|i0001b:", ToValue: "0|i0003z:"},
+			{Field: "status", FromValue: "Open", ToValue: "3600"},
+		},
+	}}
+
+	lookups := 0
+	sprint := func(id int) (*Sprint, error) {
+		lookups++
+		if id == 13 {
+			return nil, fmt.Errorf("sprint %d not found", id)
+		}
+		return &Sprint{ID: id, Name: fmt.Sprintf("Sprint %d", id-7)}, nil
+	}
+	users := func(accountIDs []string) (map[string]User, error) {
+		if strings.Join(accountIDs, ",") != "bob1" {
+			t.Errorf("Expected only bob1 to be resolved, got %v", accountIDs)
+		}
+		return map[string]User{"bob1": {AccountID: "bob1", DisplayName: "Bob"}}, nil
+	}
+
+	err := renderChangeFields(issues, sprint, users)
+	if err == nil || !strings.Contains(err.Error(), "sprint 13 not found") {
+		t.Errorf("Expected the failed sprint lookup to be reported, got %v", err)
+	}
+	if lookups != 2 {
+		t.Errorf("Expected each sprint to be looked up once, got %d lookups", lookups)
+	}
+
+	expected := []string{
+		" → Sprint 5, 13",
+		"Sprint 4 → Sprint 5",
+		"Alice → Bob",
+		"1h → 2h 30m",
+		" → 30m",
+		" → Moved up",
+		" → Moved down",
+		"Open → 3600",
+	}
+	for i, change := range issues[0].Changes {
+		if rendered := change.FromValue + " → " + change.ToValue; rendered != expected[i] {
+			t.Errorf("Expected %s change '%s', got '%s'", change.Field, expected[i], rendered)
+		}
+	}
+}
+
+func TestRenderChangeFields_WithoutUserDirectory(t *testing.T) {
+	issues := []Issue{{Key: "TEST-1", Changes: []Change{{Field: "assignee", ToValue: "", ToID: "bob1"}}}}
+
+	if err := renderChangeFields(issues, nil, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if issues[0].Changes[0].ToValue != "" {
+		t.Errorf("Expected the assignee to be left unresolved, got '%s'", issues[0].Changes[0].ToValue)
+	}
+}
+
+func TestJiraAPIRepository_GetSprint(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: DefaultQueryOptions()})
+	server.Sprints = map[int][]extJira.Sprint{7: {{ID: 12, Name: "Sprint 5", State: "active"}}}
+
+	sprint, err := repo.GetSprint(12)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sprint.ID != 12 || sprint.Name != "Sprint 5" {
+		t.Errorf("Expected Sprint 5, got %+v", sprint)
+	}
+
+	if _, err := repo.GetSprint(99); err == nil || !strings.Contains(err.Error(), "failed to fetch sprint 99") {
+		t.Errorf("Expected an error for a missing sprint, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// searchFlight is a search in flight, whose result is shared with every
+// caller that asked for the same search while it ran
+type searchFlight struct {
+	done   chan struct{}
+	issues []extJira.Issue
+	total  int
+	err    error
+}
+
+// searchGroup collapses identical searches in flight into one request, so
+// that reports produced concurrently, such as a standup and a weekly summary,
+// do not run the same JQL twice at the same time. The zero value is ready to
+// use.
+type searchGroup struct {
+	mu      sync.Mutex
+	flights map[string]*searchFlight
+}
+
+// do runs the search unless an identical one is already in flight, in which
+// case it waits for that one and returns its result. Each caller gets its own
+// copy of the issue slice.
+func (g *searchGroup) do(key string, search func() ([]extJira.Issue, int, error)) ([]extJira.Issue, int, error) {
+	g.mu.Lock()
+	if flight, ok := g.flights[key]; ok {
+		g.mu.Unlock()
+		<-flight.done
+		return append([]extJira.Issue(nil), flight.issues...), flight.total, flight.err
+	}
+	if g.flights == nil {
+		g.flights = make(map[string]*searchFlight)
+	}
+	flight := &searchFlight{done: make(chan struct{})}
+	g.flights[key] = flight
+	g.mu.Unlock()
+
+	// A completed search is forgotten, so the next one fetches fresh results
+	defer func() {
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		close(flight.done)
+	}()
+
+	flight.issues, flight.total, flight.err = search()
+	return append([]extJira.Issue(nil), flight.issues...), flight.total, flight.err
+}
+
+// searchKey identifies a search by everything that shapes its response
+func searchKey(jql string, options *extJira.SearchOptions) string {
+	if options == nil {
+		return jql
+	}
+	return fmt.Sprintf("%s\x00%d\x00%d\x00%s\x00%s", jql, options.StartAt, options.MaxResults,
+		options.Expand, strings.Join(options.Fields, ","))
+}
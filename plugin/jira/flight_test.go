@@ -0,0 +1,85 @@
+package jira
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestJiraAPIRepository_CollapsesIdenticalSearches(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions()})
+	server.Issues = []extJira.Issue{{Key: "TEST-1", Fields: &extJira.IssueFields{Summary: "Shared"}}}
+	gate := make(chan struct{})
+	server.SearchGate = gate
+	options := &extJira.SearchOptions{MaxResults: 50, Fields: []string{"summary"}}
+
+	// Start one search and wait until it is in flight before adding the others
+	var wg sync.WaitGroup
+	results := make([][]extJira.Issue, 3)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0], _, _ = repo.searchIssuesWithTotal("project = TEST", options)
+	}()
+	for len(server.Requests("/rest/api/2/search")) == 0 {
+		runtime.Gosched()
+	}
+
+	var joined sync.WaitGroup
+	for i := 1; i < len(results); i++ {
+		wg.Add(1)
+		joined.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			joined.Done()
+			results[i], _, _ = repo.searchIssuesWithTotal("project = TEST", options)
+		}(i)
+	}
+
+	// Let the search complete once the others had time to wait for it
+	joined.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(gate)
+	wg.Wait()
+
+	if n := len(server.Requests("/rest/api/2/search")); n != 1 {
+		t.Fatalf("Expected the identical searches to reach Jira once, got %d", n)
+	}
+	for i, issues := range results {
+		if len(issues) != 1 || issues[0].Key != "TEST-1" {
+			t.Errorf("Expected caller %d to get TEST-1, got %v", i, issues)
+		}
+	}
+
+	// Each caller owns its slice
+	results[0][0].Key = "CHANGED"
+	if results[1][0].Key != "TEST-1" {
+		t.Error("Expected callers not to share the issue slice")
+	}
+
+	// A completed search is not cached
+	if _, _, err := repo.searchIssuesWithTotal("project = TEST", options); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := len(server.Requests("/rest/api/2/search")); n != 2 {
+		t.Errorf("Expected a new search once the first completed, got %d searches", n)
+	}
+}
+
+func TestSearchKey(t *testing.T) {
+	base := &extJira.SearchOptions{MaxResults: 50, Fields: []string{"summary", "status"}}
+	expanded := &extJira.SearchOptions{MaxResults: 50, Fields: []string{"summary", "status"}, Expand: "changelog"}
+
+	if searchKey("project = TEST", base) != searchKey("project = TEST", &extJira.SearchOptions{MaxResults: 50, Fields: []string{"summary", "status"}}) {
+		t.Error("Expected identical searches to share a key")
+	}
+	if searchKey("project = TEST", base) == searchKey("project = TEST", expanded) {
+		t.Error("Expected searches with different options to have different keys")
+	}
+	if searchKey("project = TEST", base) == searchKey("project = OPS", base) {
+		t.Error("Expected searches with different JQL to have different keys")
+	}
+}
@@ -0,0 +1,100 @@
+package jira
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormatterOptions are the options of one formatter by key, as set through
+// the jira.format.options.<formatter>.<key> settings, e.g. allow_raw for
+// jira.format.options.markdown.allow_raw. Values are kept as given and parsed
+// by the formatter with the typed accessors, which fall back to a default
+// when the option is not set.
+type FormatterOptions map[string]string
+
+// ConfigurableFormatter is a formatter that takes options
+type ConfigurableFormatter interface {
+	ReportFormatter
+	// SetOptions applies the options, returning an error naming an unknown
+	// option or an invalid value
+	SetOptions(options FormatterOptions) error
+}
+
+// CheckKeys returns an error naming the options that are not among the known ones
+func (o FormatterOptions) CheckKeys(known ...string) error {
+	unknown := make([]string, 0)
+	for key := range o {
+		if !containsFold(known, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	if len(known) == 0 {
+		return fmt.Errorf("unknown option %s: this format takes no options", strings.Join(unknown, ", "))
+	}
+	return fmt.Errorf("unknown option %s (known options: %s)", strings.Join(unknown, ", "), strings.Join(known, ", "))
+}
+
+// value returns the trimmed value of an option, and whether it is set
+func (o FormatterOptions) value(key string) (string, bool) {
+	value := strings.TrimSpace(o[key])
+	return value, value != ""
+}
+
+// String returns the value of an option, or the fallback when it is not set
+func (o FormatterOptions) String(key, fallback string) string {
+	if value, ok := o.value(key); ok {
+		return value
+	}
+	return fallback
+}
+
+// Bool returns the value of a boolean option such as true/false
+func (o FormatterOptions) Bool(key string, fallback bool) (bool, error) {
+	value, ok := o.value(key)
+	if !ok {
+		return fallback, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback, fmt.Errorf("%s: expected true or false, got %q", key, value)
+	}
+	return parsed, nil
+}
+
+// Int returns the value of a whole number option, which must lie between min
+// and max
+func (o FormatterOptions) Int(key string, fallback, min, max int) (int, error) {
+	value, ok := o.value(key)
+	if !ok {
+		return fallback, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fallback, fmt.Errorf("%s: expected a whole number, got %q", key, value)
+	}
+	if parsed < min || parsed > max {
+		return fallback, fmt.Errorf("%s: expected %d to %d, got %d", key, min, max, parsed)
+	}
+	return parsed, nil
+}
+
+// OneOf returns the value of an option that must be one of the choices,
+// compared without regard to case
+func (o FormatterOptions) OneOf(key, fallback string, choices ...string) (string, error) {
+	value, ok := o.value(key)
+	if !ok {
+		return fallback, nil
+	}
+	for _, choice := range choices {
+		if strings.EqualFold(value, choice) {
+			return choice, nil
+		}
+	}
+	return fallback, fmt.Errorf("%s: expected %s, got %q", key, strings.Join(choices, " or "), value)
+}
@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatterOptions(t *testing.T) {
+	options := FormatterOptions{"raw": " TRUE ", "level": "3", "theme": "Dark", "blank": " "}
+
+	if value := options.String("theme", "light"); value != "Dark" {
+		t.Errorf("Expected 'Dark', got '%s'", value)
+	}
+	if value := options.String("blank", "light"); value != "light" {
+		t.Errorf("Expected a blank option to fall back, got '%s'", value)
+	}
+	if value, err := options.Bool("raw", false); err != nil || !value {
+		t.Errorf("Expected true, got %v (%v)", value, err)
+	}
+	if value, err := options.Int("level", 1, 1, 6); err != nil || value != 3 {
+		t.Errorf("Expected 3, got %d (%v)", value, err)
+	}
+	if value, err := options.OneOf("theme", "light", "light", "dark"); err != nil || value != "dark" {
+		t.Errorf("Expected 'dark', got '%s' (%v)", value, err)
+	}
+	if value, err := options.Int("missing", 2, 1, 6); err != nil || value != 2 {
+		t.Errorf("Expected the fallback 2, got %d (%v)", value, err)
+	}
+}
+
+func TestFormatterOptions_Errors(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		check    func(options FormatterOptions) error
+		expected string
+	}{
+		{
+			name:     "Not a boolean",
+			check:    func(options FormatterOptions) error { _, err := options.Bool("value", false); return err },
+			expected: `value: expected true or false, got "sometimes"`,
+		},
+		{
+			name:     "Not a number",
+			check:    func(options FormatterOptions) error { _, err := options.Int("value", 1, 1, 6); return err },
+			expected: `value: expected a whole number, got "sometimes"`,
+		},
+		{
+			name:     "Out of range",
+			check:    func(options FormatterOptions) error { _, err := options.Int("level", 1, 1, 6); return err },
+			expected: "level: expected 1 to 6, got 9",
+		},
+		{
+			name:     "Not a choice",
+			check:    func(options FormatterOptions) error { _, err := options.OneOf("value", "a", "a", "b"); return err },
+			expected: `value: expected a or b, got "sometimes"`,
+		},
+		{
+			name:     "Unknown options",
+			check:    func(options FormatterOptions) error { return options.CheckKeys("value") },
+			expected: "unknown option level (known options: value)",
+		},
+		{
+			name:     "No options taken",
+			check:    func(options FormatterOptions) error { return options.CheckKeys() },
+			expected: "unknown option level, value: this format takes no options",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.check(FormatterOptions{"value": "sometimes", "level": "9"})
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected an error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestMarkdownFormatter_SetOptions(t *testing.T) {
+	formatter := NewMarkdownFormatter()
+	if err := formatter.SetOptions(FormatterOptions{"allow_raw": "true"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !formatter.allowRaw {
+		t.Errorf("Expected raw passthrough to be enabled")
+	}
+
+	if err := formatter.SetOptions(FormatterOptions{"heading": "2"}); err == nil {
+		t.Errorf("Expected an error for an unknown option")
+	}
+}
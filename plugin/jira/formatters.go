@@ -1,9 +1,11 @@
 package jira
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"html"
 	"strings"
 	"time"
 )
@@ -12,6 +14,8 @@ import (
 type FormattedContent struct {
 	ContentType string // MIME type of the content
 	Content     string // The formatted content
+	Part        int    // Number of this part when split by SplitContent, from 1; 0 when whole
+	Parts       int    // Number of parts the content was split into; 0 when whole
 }
 
 // ReportFormatter is an interface for formatting activity reports
@@ -35,61 +39,421 @@ func (f *XMLFormatter) Name() string {
 
 // Format formats an activity report as XML
 func (f *XMLFormatter) Format(report *ActivityReport) (*FormattedContent, error) {
-	if len(report.Issues) == 0 {
+	// Record how the report was generated as attributes of the root, kept
+	// on an empty report
+	var xmlReport jiraXMLReport
+	if generation := report.Generation; generation != nil {
+		xmlReport.xmlGeneration = xmlGeneration{
+			GeneratedAt:   generation.GeneratedAt.UTC().Format(machineTimeLayout),
+			PluginVersion: generation.PluginVersion,
+			InstanceURL:   generation.InstanceURL,
+			Query:         generation.Query,
+			IssueCount:    &generation.IssueCount,
+		}
+	}
+
+	if report.emptyMessage(f.Name()) {
+		empty, err := xml.Marshal(struct {
+			XMLName xml.Name `xml:"jira_report"`
+			xmlGeneration
+		}{xmlGeneration: xmlReport.xmlGeneration})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal XML: %w", err)
+		}
 		return &FormattedContent{
 			ContentType: "application/xml",
-			Content:     "<jira_report></jira_report>",
+			Content:     string(empty),
 		}, nil
 	}
 
-	xmlReport := jiraXMLReport{
-		Issues: make([]xmlIssue, 0, len(report.Issues)),
-	}
+	xmlReport.Issues = make([]xmlIssue, 0, len(report.Issues))
 
 	for _, issue := range report.Issues {
 		xmlIssue := xmlIssue{
 			Key:     issue.Key,
 			Status:  issue.Status,
 			Summary: issue.Summary,
+			ActivitySummary: issue.ActivitySummary,
+			Description:     issue.Description,
+			CycleTimeHours:  issue.CycleTime.Hours(),
+			LeadTimeHours:   issue.LeadTime.Hours(),
+		}
+		for _, ancestor := range issue.Hierarchy {
+			xmlIssue.Hierarchy = append(xmlIssue.Hierarchy, xmlIssueRef{
+				Key:     ancestor.Key,
+				Status:  ancestor.Status,
+				Summary: ancestor.Summary,
+				Type:    ancestor.Type,
+			})
+		}
+		for _, link := range issue.RemoteLinks {
+			xmlIssue.RemoteLinks = append(xmlIssue.RemoteLinks, xmlRemoteLink{
+				URL:          link.URL,
+				Application:  link.Application,
+				Relationship: link.Relationship,
+				Title:        link.Title,
+				AddedAt:      newXMLTime(report.Options, link.AddedAt, ""),
+				AddedBy:      link.AddedBy,
+			})
+		}
+		if issue.Attention != nil {
+			xmlIssue.Attention = &xmlAttention{
+				Watchers:       issue.Attention.Watchers,
+				Votes:          issue.Attention.Votes,
+				WatchersGained: issue.Attention.WatchersGained,
+				WatchersLost:   issue.Attention.WatchersLost,
+				VotesChange:    issue.Attention.VotesChange,
+			}
+			if !issue.Attention.Since.IsZero() {
+				xmlIssue.Attention.Since = issue.Attention.Since.Format(machineTimeLayout)
+			}
+		}
+		if issue.Reopened != nil {
+			xmlIssue.Reopened = &xmlReopening{
+				At:         machineTime(report.Options, issue.Reopened.Timestamp, ""),
+				AtMillis:   epochMillis(issue.Reopened.Timestamp),
+				By:         issue.Reopened.Author,
+				Role:       issue.Reopened.AuthorRole,
+				FromStatus: issue.Reopened.FromStatus,
+				ToStatus:   issue.Reopened.ToStatus,
+			}
+		}
+		if len(issue.Notes) > 0 {
+			xmlIssue.Notes = noteTexts(issue.Notes)
+		}
+		for _, reference := range issue.ReferencedIssues {
+			xmlIssue.References = append(xmlIssue.References, xmlIssueRef{
+				Key:     reference.Key,
+				Status:  reference.Status,
+				Summary: reference.Summary,
+				Type:    reference.Type,
+			})
+		}
+		if issue.Escalation != nil {
+			xmlIssue.Escalation = &xmlEscalation{
+				At:       machineTime(report.Options, issue.Escalation.Timestamp, ""),
+				AtMillis: epochMillis(issue.Escalation.Timestamp),
+				By:       issue.Escalation.Author,
+				Role:     issue.Escalation.AuthorRole,
+				From:     issue.Escalation.FromPriority,
+				To:       issue.Escalation.ToPriority,
+			}
+		}
+
+		// In summary-only mode the summary line replaces the raw activity
+		if showSummaryOnly(report, issue) {
+			xmlReport.Issues = append(xmlReport.Issues, xmlIssue)
+			continue
 		}
 
 		// Process comments
 		comments := make([]xmlComment, 0, len(issue.Comments))
 		for _, comment := range issue.Comments {
 			comments = append(comments, xmlComment{
-				Timestamp: comment.Timestamp.Format("2006-01-02 15:04:05"),
+				Timestamp: newXMLTime(report.Options, comment.Timestamp, comment.AuthorTimeZone),
 				Author:    comment.Author,
 				Content:   comment.Content,
+				Edited:    comment.Edited,
+				Mentions:  comment.MentionsUser,
 			})
 		}
 		xmlIssue.Comments = xmlComments{Comments: comments}
 
+		// Process worklogs
+		for _, worklog := range issue.Worklogs {
+			xmlIssue.Worklogs = append(xmlIssue.Worklogs, xmlWorklog{
+				TimeSpentSeconds: int(worklog.TimeSpent.Seconds()),
+				Started:          newXMLTime(report.Options, worklog.Started, worklog.AuthorTimeZone),
+				Author:           worklog.Author,
+				Comment:          worklog.Comment,
+			})
+		}
+
 		// Process changes
 		changes := make([]xmlChange, 0, len(issue.Changes))
 		for _, change := range issue.Changes {
-			changes = append(changes, xmlChange{
-				Timestamp: change.Timestamp.Format("2006-01-02 15:04:05"),
+			xmlChange := xmlChange{
+				Timestamp: newXMLTime(report.Options, change.Timestamp, change.AuthorTimeZone),
 				Author:    change.Author,
+				Role:      change.AuthorRole,
+				Own:       change.IsCurrentUser,
 				Field:     change.Field,
 				From:      change.FromValue,
 				To:        change.ToValue,
-			})
+			}
+			if change.Diff != nil {
+				// The diff replaces both full bodies of an edited text
+				xmlChange.From, xmlChange.To, xmlChange.Diff = "", "", change.Diff.String()
+			}
+			changes = append(changes, xmlChange)
 		}
 		xmlIssue.Changelog = xmlChangelog{Changes: changes}
 
+		// Process label and component changes
+		for _, change := range issue.CollectionChanges {
+			xmlIssue.CollectionChanges = append(xmlIssue.CollectionChanges, xmlCollectionChange{
+				Timestamp: newXMLTime(report.Options, change.Timestamp, change.AuthorTimeZone),
+				Author:    change.Author,
+				Field:     change.Field,
+				Added:     change.Added,
+				Removed:   change.Removed,
+			})
+		}
+
+		// Process status journey
+		if issue.Transitions != nil {
+			xmlIssue.Transitions = &xmlTransitions{
+				Count:    issue.Transitions.Count,
+				Statuses: issue.Transitions.Journey,
+			}
+		}
+
+		// Process action items
+		if !issue.ActionItems.IsEmpty() {
+			xmlIssue.ActionItems = &xmlActionItems{
+				Completed: actionItemTexts(issue.ActionItems.Completed),
+				Added:     actionItemTexts(issue.ActionItems.Added),
+			}
+		}
+
 		xmlReport.Issues = append(xmlReport.Issues, xmlIssue)
 	}
 
-	// Marshal to XML with proper indentation
-	output, err := xml.MarshalIndent(xmlReport, "", "  ")
-	if err != nil {
+	// Process pinned issues, blockers and carry-over work
+	for _, issue := range report.Pinned {
+		xmlReport.Pinned = append(xmlReport.Pinned, xmlIssueRef{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+			Notes:   noteTexts(issue.Notes),
+		})
+	}
+	for _, issue := range report.Blockers {
+		xmlReport.Blockers = append(xmlReport.Blockers, xmlIssueRef{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+			Notes:   noteTexts(issue.Notes),
+		})
+	}
+	for _, issue := range report.CarryOver {
+		xmlReport.CarryOver = append(xmlReport.CarryOver, xmlIssueRef{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+			Notes:   noteTexts(issue.Notes),
+		})
+	}
+	for _, issue := range report.Filed {
+		xmlReport.Filed = append(xmlReport.Filed, xmlIssueRef{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+			Type:    issue.Type,
+			Notes:   noteTexts(issue.Notes),
+		})
+	}
+
+	// Process the work due soon
+	for _, issue := range report.DueSoon {
+		xmlReport.DueSoon = append(xmlReport.DueSoon, xmlDueIssue{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+			DueDate: issue.DueDate.Format(dueDateLayout),
+			Overdue: isOverdue(issue, report.TimeRange),
+		})
+	}
+
+	// Process the sprint scope change
+	if report.Sprint != nil {
+		xmlSprint := &xmlSprint{
+			ID:            report.Sprint.Sprint.ID,
+			Name:          report.Sprint.Sprint.Name,
+			PointsAdded:   report.Sprint.PointsAdded,
+			PointsRemoved: report.Sprint.PointsRemoved,
+		}
+		for _, issue := range report.Sprint.Added {
+			xmlSprint.Added = append(xmlSprint.Added, xmlIssueRef{Key: issue.Key, Status: issue.Status, Summary: issue.Summary, Type: issue.Type})
+		}
+		for _, issue := range report.Sprint.Removed {
+			xmlSprint.Removed = append(xmlSprint.Removed, xmlIssueRef{Key: issue.Key, Status: issue.Status, Summary: issue.Summary, Type: issue.Type})
+		}
+		xmlReport.Sprint = xmlSprint
+	}
+
+	// Disclose a truncated search
+	if report.Truncation != nil {
+		xmlReport.Truncation = &xmlTruncation{
+			Shown:  report.Truncation.Shown,
+			Total:  report.Truncation.Total,
+			Notice: report.Truncation.Notice(),
+		}
+	}
+
+	// Disclose statuses and assignees reconstructed from the changelog
+	if !report.AsOf.IsZero() {
+		xmlReport.AsOf = report.AsOf.Format(machineTimeLayout)
+	}
+
+	// Process handoffs
+	if !report.Handoffs.IsEmpty() {
+		xmlHandoffs := &xmlHandoffs{}
+		for _, issue := range report.Handoffs.Incoming {
+			xmlHandoffs.Incoming = append(xmlHandoffs.Incoming, newXMLHandoff(report, issue))
+		}
+		for _, issue := range report.Handoffs.Outgoing {
+			xmlHandoffs.Outgoing = append(xmlHandoffs.Outgoing, newXMLHandoff(report, issue))
+		}
+		xmlReport.Handoffs = xmlHandoffs
+	}
+
+	// Process epic rollups
+	for _, rollup := range report.Epics {
+		xmlReport.Epics = append(xmlReport.Epics, newXMLEpicRollup(rollup))
+	}
+
+	// Process initiative rollups
+	for _, initiative := range report.Initiatives {
+		xmlInitiative := xmlInitiativeRollup{
+			IssuesAdvanced: initiative.IssuesAdvanced,
+			Comments:       initiative.Comments,
+			Changes:        initiative.Changes,
+		}
+		if initiative.Initiative != nil {
+			xmlInitiative.Key = initiative.Initiative.Key
+			xmlInitiative.Summary = initiative.Initiative.Summary
+		}
+		for _, rollup := range initiative.Epics {
+			xmlInitiative.Epics = append(xmlInitiative.Epics, newXMLEpicRollup(rollup))
+		}
+		xmlReport.Initiatives = append(xmlReport.Initiatives, xmlInitiative)
+	}
+
+	// Process component digests
+	for _, digest := range report.Components {
+		xmlDigest := xmlComponentDigest{
+			Name:           digest.Component,
+			IssuesAdvanced: digest.IssuesAdvanced,
+			Comments:       digest.Comments,
+			Changes:        digest.Changes,
+			Contributors:   digest.Contributors,
+		}
+		for _, issue := range digest.Issues {
+			xmlDigest.Issues = append(xmlDigest.Issues, xmlIssueRef{
+				Key:     issue.Key,
+				Status:  issue.Status,
+				Summary: issue.Summary,
+			})
+		}
+		xmlReport.Components = append(xmlReport.Components, xmlDigest)
+	}
+
+	// Process release notes
+	if report.Release != nil {
+		xmlRelease := &xmlReleaseNotes{Version: report.Release.Version}
+		for _, group := range report.Release.Groups {
+			xmlGroup := xmlReleaseGroup{Type: group.Type}
+			for _, issue := range group.Issues {
+				xmlGroup.Issues = append(xmlGroup.Issues, xmlReleaseNote{
+					Key:        issue.Key,
+					Status:     issue.Status,
+					Summary:    issue.Summary,
+					Resolution: ResolutionLabel(issue),
+				})
+			}
+			xmlRelease.Groups = append(xmlRelease.Groups, xmlGroup)
+		}
+		xmlReport.Release = xmlRelease
+	}
+
+	// Process the release comparison
+	if report.Comparison != nil {
+		issueRefs := func(issues []Issue) []xmlIssueRef {
+			refs := make([]xmlIssueRef, 0, len(issues))
+			for _, issue := range issues {
+				refs = append(refs, xmlIssueRef{Key: issue.Key, Status: issue.Status, Summary: issue.Summary, Type: issue.Type})
+			}
+			return refs
+		}
+		xmlReport.Comparison = &xmlReleaseComparison{
+			From:      report.Comparison.From,
+			To:        report.Comparison.To,
+			Completed: issueRefs(report.Comparison.Completed),
+			New:       issueRefs(report.Comparison.New),
+			Reopened:  issueRefs(report.Comparison.Reopened),
+			Slipped:   issueRefs(report.Comparison.Slipped),
+		}
+	}
+
+	// Process triage issues
+	for _, issue := range report.Triage {
+		xmlReport.Triage = append(xmlReport.Triage, xmlTriageIssue{
+			Key:      issue.Key,
+			Status:   issue.Status,
+			Summary:  issue.Summary,
+			Type:     issue.Type,
+			Priority: issue.Priority,
+			Reporter: issue.Reporter.DisplayName,
+		})
+	}
+
+	// Process resolved authors
+	for _, author := range report.Authors {
+		xmlReport.Authors = append(xmlReport.Authors, xmlAuthor{
+			AccountID:   author.AccountID,
+			DisplayName: author.DisplayName,
+			Email:       author.Email,
+			TimeZone:    author.TimeZone,
+		})
+	}
+
+	// Process velocity statistics, current window first
+	if report.Stats != nil {
+		for i, window := range report.Stats.Windows() {
+			xmlReport.Stats = append(xmlReport.Stats, xmlVelocity{
+				Start:                 window.WindowStart.Format("2006-01-02"),
+				End:                   window.WindowEnd.Format("2006-01-02"),
+				Current:               i == 0,
+				IssuesCompleted:       window.IssuesCompleted,
+				PointsCompleted:       window.PointsCompleted,
+				AverageCycleTimeHours: window.AverageCycleTime.Hours(),
+			})
+		}
+	}
+
+	// Process the activity heatmap, listing only hours with activity
+	if report.Heatmap != nil {
+		xmlReport.Heatmap = &xmlHeatmap{
+			TimeZone:   report.Heatmap.TimeZone,
+			Total:      report.Heatmap.Total,
+			AfterHours: report.Heatmap.AfterHours,
+		}
+		for hour, count := range report.Heatmap.Hours {
+			if count > 0 {
+				xmlReport.Heatmap.Hours = append(xmlReport.Heatmap.Hours, xmlHourSlot{Hour: hour, Count: count})
+			}
+		}
+	}
+
+	// List the problems that left data out of the report
+	for _, warning := range report.Warnings {
+		xmlReport.Warnings = append(xmlReport.Warnings, xmlWarning{Kind: string(warning.Kind), Message: warning.Message})
+	}
+
+	// Marshal to XML with proper indentation, after the XML header
+	buf := getBuffer()
+	defer putBuffer(buf)
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(xmlReport); err != nil {
 		return nil, fmt.Errorf("failed to marshal XML: %w", err)
 	}
 
-	// Add XML header and return
 	return &FormattedContent{
 		ContentType: "application/xml",
-		Content:     xml.Header + string(output),
+		Content:     buf.String(),
 	}, nil
 }
 
@@ -108,179 +472,1377 @@ func (f *JSONFormatter) Name() string {
 
 // Format formats an activity report as JSON
 func (f *JSONFormatter) Format(report *ActivityReport) (*FormattedContent, error) {
-	if len(report.Issues) == 0 {
-		return &FormattedContent{
-			ContentType: "application/json",
-			Content:     "{}",
-		}, nil
-	}
-
 	// Create a JSON-friendly structure
 	type jsonComment struct {
-		Timestamp string `json:"timestamp"`
-		Author    string `json:"author"`
-		Content   string `json:"content"`
+		Timestamp       string `json:"timestamp"`
+		TimestampMillis int64  `json:"timestampMs"`
+		Author          string `json:"author"`
+		Content         string `json:"content"`
+		AvatarURL       string `json:"authorAvatarUrl,omitempty"`
+		Edited          bool   `json:"edited,omitempty"`
+		Mentions        bool   `json:"mentionsUser,omitempty"`
 	}
 
 	type jsonChange struct {
-		Timestamp string `json:"timestamp"`
-		Author    string `json:"author"`
-		Field     string `json:"field"`
-		From      string `json:"from"`
-		To        string `json:"to"`
+		Timestamp       string `json:"timestamp"`
+		TimestampMillis int64  `json:"timestampMs"`
+		Author          string `json:"author"`
+		Role            string `json:"authorRole,omitempty"`
+		Own             bool   `json:"byCurrentUser,omitempty"`
+		Field           string `json:"field"`
+		From            string `json:"from"`
+		To              string `json:"to"`
+		Diff            string `json:"diff,omitempty"`
+	}
+
+	type jsonActionItems struct {
+		Completed []string `json:"completed"`
+		Added     []string `json:"added"`
+	}
+
+	type jsonCollectionChange struct {
+		Timestamp       string   `json:"timestamp"`
+		TimestampMillis int64    `json:"timestampMs"`
+		Author          string   `json:"author"`
+		Field           string   `json:"field"`
+		Added           []string `json:"added"`
+		Removed         []string `json:"removed"`
+	}
+
+	type jsonTransitions struct {
+		Journey []string `json:"journey,omitempty"`
+		Count   int      `json:"count"`
+	}
+
+	type jsonRemoteLink struct {
+		Title         string `json:"title"`
+		URL           string `json:"url"`
+		Application   string `json:"application,omitempty"`
+		Relationship  string `json:"relationship,omitempty"`
+		AddedAt       string `json:"addedAt"`
+		AddedAtMillis int64  `json:"addedAtMs"`
+		AddedBy       string `json:"addedBy"`
+	}
+
+	type jsonAttention struct {
+		Watchers       int    `json:"watchers"`
+		Votes          int    `json:"votes"`
+		WatchersGained int    `json:"watchersGained"`
+		WatchersLost   int    `json:"watchersLost"`
+		VotesChange    int    `json:"votesChange"`
+		Since          string `json:"since,omitempty"`
+	}
+
+	type jsonReopening struct {
+		At       string `json:"at"`
+		AtMillis int64  `json:"atMs"`
+		By       string `json:"by"`
+		Role     string `json:"role,omitempty"`
+		From     string `json:"from"`
+		To       string `json:"to"`
+	}
+
+	type jsonHandoff struct {
+		Key      string `json:"key"`
+		Status   string `json:"status"`
+		Summary  string `json:"summary"`
+		At       string `json:"at"`
+		AtMillis int64  `json:"atMs"`
+		By       string `json:"by"`
+		From     string `json:"from"`
+		To       string `json:"to"`
+	}
+
+	type jsonHandoffs struct {
+		Incoming []jsonHandoff `json:"incoming"`
+		Outgoing []jsonHandoff `json:"outgoing"`
+	}
+
+	type jsonEscalation struct {
+		At       string `json:"at"`
+		AtMillis int64  `json:"atMs"`
+		By       string `json:"by"`
+		Role     string `json:"role,omitempty"`
+		From     string `json:"from"`
+		To       string `json:"to"`
+	}
+
+	type jsonIssueRef struct {
+		Key     string   `json:"key"`
+		Status  string   `json:"status"`
+		Summary string   `json:"summary"`
+		Type    string   `json:"type,omitempty"`
+		Notes   []string `json:"notes,omitempty"`
+	}
+
+	type jsonWorklog struct {
+		Started          string `json:"started"`
+		StartedMillis    int64  `json:"startedMs"`
+		Author           string `json:"author"`
+		TimeSpent        string `json:"timeSpent"`
+		TimeSpentSeconds int    `json:"timeSpentSeconds"`
+		Comment          string `json:"comment,omitempty"`
 	}
 
 	type jsonIssue struct {
-		Key      string        `json:"key"`
-		Status   string        `json:"status"`
-		Summary  string        `json:"summary"`
-		Comments []jsonComment `json:"comments"`
-		Changes  []jsonChange  `json:"changes"`
+		Key         string           `json:"key"`
+		Status      string           `json:"status"`
+		Summary     string           `json:"summary"`
+		Activity    string           `json:"activitySummary,omitempty"`
+		Description string           `json:"description,omitempty"`
+		Comments    []jsonComment    `json:"comments"`
+		Changes     []jsonChange     `json:"changes"`
+		ActionItems *jsonActionItems `json:"actionItems,omitempty"`
+		Transitions *jsonTransitions `json:"statusJourney,omitempty"`
+		Collections []jsonCollectionChange `json:"collectionChanges,omitempty"`
+		Hierarchy   []jsonIssueRef     `json:"hierarchy,omitempty"`
+		CycleTime   float64            `json:"cycleTimeHours,omitempty"`
+		LeadTime    float64            `json:"leadTimeHours,omitempty"`
+		Attention   *jsonAttention     `json:"attention,omitempty"`
+		RemoteLinks []jsonRemoteLink   `json:"remoteLinks,omitempty"`
+		Worklogs    []jsonWorklog      `json:"worklogs,omitempty"`
+		Reopened    *jsonReopening     `json:"reopened,omitempty"`
+		Escalation  *jsonEscalation    `json:"priorityEscalation,omitempty"`
+		Notes       []string           `json:"notes,omitempty"`
+		References  []jsonIssueRef     `json:"referencedIssues,omitempty"`
+	}
+
+	type jsonTimeRange struct {
+		Start       string `json:"start"`
+		End         string `json:"end"`
+		StartMillis int64  `json:"startMs"`
+		EndMillis   int64  `json:"endMs"`
+	}
+
+	type jsonUser struct {
+		DisplayName string `json:"displayName"`
+		AccountID   string `json:"accountId,omitempty"`
+		Email       string `json:"email,omitempty"`
+		AvatarURL   string `json:"avatarUrl,omitempty"`
+		TimeZone    string `json:"timeZone,omitempty"`
+	}
+
+	type jsonEpicRollup struct {
+		Key            string         `json:"key,omitempty"`
+		Summary        string         `json:"summary,omitempty"`
+		IssuesAdvanced int            `json:"issuesAdvanced"`
+		Comments       int            `json:"comments"`
+		Changes        int            `json:"changes"`
+		Issues         []jsonIssueRef `json:"issues"`
+	}
+
+	type jsonInitiativeRollup struct {
+		Key            string           `json:"key,omitempty"`
+		Summary        string           `json:"summary,omitempty"`
+		IssuesAdvanced int              `json:"issuesAdvanced"`
+		Comments       int              `json:"comments"`
+		Changes        int              `json:"changes"`
+		Epics          []jsonEpicRollup `json:"epics"`
+	}
+
+	type jsonComponentDigest struct {
+		Component      string         `json:"component"`
+		IssuesAdvanced int            `json:"issuesAdvanced"`
+		Comments       int            `json:"comments"`
+		Changes        int            `json:"changes"`
+		Contributors   []string       `json:"contributors"`
+		Issues         []jsonIssueRef `json:"issues"`
+	}
+
+	type jsonReleaseNote struct {
+		Key        string `json:"key"`
+		Status     string `json:"status"`
+		Summary    string `json:"summary"`
+		Resolution string `json:"resolution"`
+	}
+
+	type jsonReleaseGroup struct {
+		Type   string            `json:"type"`
+		Issues []jsonReleaseNote `json:"issues"`
+	}
+
+	type jsonReleaseNotes struct {
+		Version string             `json:"version"`
+		Groups  []jsonReleaseGroup `json:"groups"`
+	}
+
+	type jsonReleaseComparison struct {
+		From      string         `json:"from"`
+		To        string         `json:"to"`
+		Completed []jsonIssueRef `json:"completed"`
+		New       []jsonIssueRef `json:"new"`
+		Reopened  []jsonIssueRef `json:"reopened"`
+		Slipped   []jsonIssueRef `json:"slipped"`
+	}
+
+	type jsonTriageIssue struct {
+		Key      string `json:"key"`
+		Status   string `json:"status"`
+		Summary  string `json:"summary"`
+		Type     string `json:"type,omitempty"`
+		Priority string `json:"priority,omitempty"`
+		Reporter string `json:"reporter,omitempty"`
+	}
+
+	type jsonHeatmap struct {
+		TimeZone   string `json:"timeZone"`
+		Hours      []int  `json:"hours"`
+		Total      int    `json:"total"`
+		AfterHours int    `json:"afterHours"`
+	}
+
+	type jsonVelocity struct {
+		WindowStart           string  `json:"windowStart"`
+		WindowEnd             string  `json:"windowEnd"`
+		IssuesCompleted       int     `json:"issuesCompleted"`
+		PointsCompleted       float64 `json:"pointsCompleted"`
+		AverageCycleTimeHours float64 `json:"averageCycleTimeHours,omitempty"`
+	}
+
+	type jsonStats struct {
+		Current  jsonVelocity   `json:"current"`
+		Trailing []jsonVelocity `json:"trailing,omitempty"`
+	}
+
+	type jsonDueIssue struct {
+		Key     string `json:"key"`
+		Status  string `json:"status"`
+		Summary string `json:"summary"`
+		DueDate string `json:"dueDate"`
+		Overdue bool   `json:"overdue"`
+	}
+
+	type jsonWarning struct {
+		Kind    string `json:"kind"`
+		Message string `json:"message"`
+	}
+
+	type jsonTruncation struct {
+		Shown  int    `json:"shown"`
+		Total  int    `json:"total"`
+		Notice string `json:"notice"`
+	}
+
+	type jsonGeneration struct {
+		GeneratedAt   string `json:"generatedAt"`
+		PluginVersion string `json:"pluginVersion,omitempty"`
+		InstanceURL   string `json:"instanceUrl,omitempty"`
+		Query         string `json:"query,omitempty"`
+		IssueCount    int    `json:"issueCount"`
+	}
+
+	type jsonSprint struct {
+		ID            int            `json:"id"`
+		Name          string         `json:"name"`
+		Added         []jsonIssueRef `json:"added"`
+		Removed       []jsonIssueRef `json:"removed"`
+		PointsAdded   float64        `json:"pointsAdded"`
+		PointsRemoved float64        `json:"pointsRemoved"`
 	}
 
 	type jsonReport struct {
-		TimeRange struct {
-			Start string `json:"start"`
-			End   string `json:"end"`
-		} `json:"timeRange"`
-		User struct {
-			DisplayName string `json:"displayName"`
-			Email       string `json:"email"`
-		} `json:"user"`
-		Issues []jsonIssue `json:"issues"`
-	}
-
-	// Convert domain model to JSON structure
-	jReport := jsonReport{}
-	jReport.TimeRange.Start = report.TimeRange.Start.Format(time.RFC3339)
-	jReport.TimeRange.End = report.TimeRange.End.Format(time.RFC3339)
-	jReport.User.DisplayName = report.User.DisplayName
-	jReport.User.Email = report.User.Email
+		Generation  *jsonGeneration        `json:"generation,omitempty"`
+		TimeRange   *jsonTimeRange         `json:"timeRange,omitempty"`
+		User        *jsonUser              `json:"user,omitempty"`
+		Truncation  *jsonTruncation        `json:"truncation,omitempty"`
+		AsOf        string                 `json:"asOf,omitempty"`
+		Sprint      *jsonSprint            `json:"sprint,omitempty"`
+		Message     string                 `json:"message,omitempty"`
+		Issues      []jsonIssue            `json:"issues"`
+		Pinned      []jsonIssueRef         `json:"pinned,omitempty"`
+		Blockers    []jsonIssueRef         `json:"blockers,omitempty"`
+		CarryOver   []jsonIssueRef         `json:"carryOver,omitempty"`
+		Filed       []jsonIssueRef         `json:"filed,omitempty"`
+		DueSoon     []jsonDueIssue         `json:"dueSoon,omitempty"`
+		Handoffs    *jsonHandoffs          `json:"handoffs,omitempty"`
+		Epics       []jsonEpicRollup       `json:"epics,omitempty"`
+		Initiatives []jsonInitiativeRollup `json:"initiatives,omitempty"`
+		Components  []jsonComponentDigest  `json:"components,omitempty"`
+		Release     *jsonReleaseNotes      `json:"release,omitempty"`
+		Comparison  *jsonReleaseComparison `json:"comparison,omitempty"`
+		Triage      []jsonTriageIssue      `json:"triage,omitempty"`
+		Authors     []jsonUser             `json:"authors,omitempty"`
+		Heatmap     *jsonHeatmap           `json:"heatmap,omitempty"`
+		Stats       *jsonStats             `json:"stats,omitempty"`
+		Warnings    []jsonWarning          `json:"warnings,omitempty"`
+	}
+
+	// Convert domain model to JSON structure. An empty report has the same
+	// structure, with an empty issues array, so that consumers parse every
+	// report alike; the message says why it is empty unless only the
+	// document is asked for.
+	jReport := jsonReport{
+		// Issues are built in place, sparing a copy of each as the slice grows
+		Issues: make([]jsonIssue, 0, len(report.Issues)),
+	}
+	if report.emptyMessage(f.Name()) {
+		jReport.Message = "No activity found for the specified time range."
+	}
+	if generation := report.Generation; generation != nil {
+		jReport.Generation = &jsonGeneration{
+			GeneratedAt:   generation.GeneratedAt.UTC().Format(machineTimeLayout),
+			PluginVersion: generation.PluginVersion,
+			InstanceURL:   generation.InstanceURL,
+			Query:         generation.Query,
+			IssueCount:    generation.IssueCount,
+		}
+	}
+	if report.Options.Verbosity.IncludeMetadata() {
+		jReport.TimeRange = &jsonTimeRange{
+			Start:       report.TimeRange.Start.Format(machineTimeLayout),
+			End:         report.TimeRange.End.Format(machineTimeLayout),
+			StartMillis: epochMillis(report.TimeRange.Start),
+			EndMillis:   epochMillis(report.TimeRange.End),
+		}
+		jReport.User = &jsonUser{
+			DisplayName: report.User.DisplayName,
+			Email:       reportEmail(report),
+			AvatarURL:   report.User.AvatarURL,
+			TimeZone:    report.User.TimeZone,
+		}
+	}
 	
-	for _, issue := range report.Issues {
-		jIssue := jsonIssue{
+	// RFC 3339 timestamps carry their offset, so author local times need no annotation
+	jsonTime := func(timestamp time.Time, timeZone string) string {
+		return machineTime(report.Options, timestamp, timeZone)
+	}
+
+	for i := range report.Issues {
+		issue := &report.Issues[i]
+		jReport.Issues = append(jReport.Issues, jsonIssue{
 			Key:      issue.Key,
 			Status:   issue.Status,
 			Summary:  issue.Summary,
+			Activity: issue.ActivitySummary,
+			Description: issue.Description,
 			Comments: make([]jsonComment, 0, len(issue.Comments)),
 			Changes:  make([]jsonChange, 0, len(issue.Changes)),
+			CycleTime: issue.CycleTime.Hours(),
+			LeadTime:  issue.LeadTime.Hours(),
+		})
+		jIssue := &jReport.Issues[len(jReport.Issues)-1]
+		for _, ancestor := range issue.Hierarchy {
+			jIssue.Hierarchy = append(jIssue.Hierarchy, jsonIssueRef{
+				Key:     ancestor.Key,
+				Status:  ancestor.Status,
+				Summary: ancestor.Summary,
+				Type:    ancestor.Type,
+			})
+		}
+		for _, link := range issue.RemoteLinks {
+			jIssue.RemoteLinks = append(jIssue.RemoteLinks, jsonRemoteLink{
+				Title:        link.Title,
+				URL:          link.URL,
+				Application:  link.Application,
+				Relationship: link.Relationship,
+				AddedAt:       jsonTime(link.AddedAt, ""),
+				AddedAtMillis: epochMillis(link.AddedAt),
+				AddedBy:       link.AddedBy,
+			})
+		}
+		if issue.Attention != nil {
+			jIssue.Attention = &jsonAttention{
+				Watchers:       issue.Attention.Watchers,
+				Votes:          issue.Attention.Votes,
+				WatchersGained: issue.Attention.WatchersGained,
+				WatchersLost:   issue.Attention.WatchersLost,
+				VotesChange:    issue.Attention.VotesChange,
+			}
+			if !issue.Attention.Since.IsZero() {
+				jIssue.Attention.Since = issue.Attention.Since.Format(machineTimeLayout)
+			}
+		}
+		if issue.Reopened != nil {
+			jIssue.Reopened = &jsonReopening{
+				At:       jsonTime(issue.Reopened.Timestamp, ""),
+				AtMillis: epochMillis(issue.Reopened.Timestamp),
+				By:       issue.Reopened.Author,
+				Role:     issue.Reopened.AuthorRole,
+				From:     issue.Reopened.FromStatus,
+				To:       issue.Reopened.ToStatus,
+			}
+		}
+		if len(issue.Notes) > 0 {
+			jIssue.Notes = noteTexts(issue.Notes)
+		}
+		for _, reference := range issue.ReferencedIssues {
+			jIssue.References = append(jIssue.References, jsonIssueRef{
+				Key:     reference.Key,
+				Status:  reference.Status,
+				Summary: reference.Summary,
+				Type:    reference.Type,
+			})
+		}
+		if issue.Escalation != nil {
+			jIssue.Escalation = &jsonEscalation{
+				At:       jsonTime(issue.Escalation.Timestamp, ""),
+				AtMillis: epochMillis(issue.Escalation.Timestamp),
+				By:       issue.Escalation.Author,
+				Role:     issue.Escalation.AuthorRole,
+				From:     issue.Escalation.FromPriority,
+				To:       issue.Escalation.ToPriority,
+			}
+		}
+
+		// In summary-only mode the summary line replaces the raw activity
+		if showSummaryOnly(report, *issue) {
+			continue
 		}
 
 		for _, comment := range issue.Comments {
 			jIssue.Comments = append(jIssue.Comments, jsonComment{
-				Timestamp: comment.Timestamp.Format(time.RFC3339),
-				Author:    comment.Author,
-				Content:   comment.Content,
+				Timestamp:       jsonTime(comment.Timestamp, comment.AuthorTimeZone),
+				TimestampMillis: epochMillis(comment.Timestamp),
+				Author:          comment.Author,
+				Content:         comment.Content,
+				AvatarURL:       comment.AuthorAvatarURL,
+				Edited:          comment.Edited,
+				Mentions:        comment.MentionsUser,
+			})
+		}
+
+		for _, worklog := range issue.Worklogs {
+			jIssue.Worklogs = append(jIssue.Worklogs, jsonWorklog{
+				Started:          jsonTime(worklog.Started, worklog.AuthorTimeZone),
+				StartedMillis:    epochMillis(worklog.Started),
+				Author:           worklog.Author,
+				TimeSpent:        formatDuration(worklog.TimeSpent),
+				TimeSpentSeconds: int(worklog.TimeSpent.Seconds()),
+				Comment:          worklog.Comment,
 			})
 		}
 
 		for _, change := range issue.Changes {
-			jIssue.Changes = append(jIssue.Changes, jsonChange{
-				Timestamp: change.Timestamp.Format(time.RFC3339),
-				Author:    change.Author,
-				Field:     change.Field,
-				From:      change.FromValue,
-				To:        change.ToValue,
+			jChange := jsonChange{
+				Timestamp:       jsonTime(change.Timestamp, change.AuthorTimeZone),
+				TimestampMillis: epochMillis(change.Timestamp),
+				Author:          change.Author,
+				Role:            change.AuthorRole,
+				Own:             change.IsCurrentUser,
+				Field:           change.Field,
+				From:            change.FromValue,
+				To:              change.ToValue,
+			}
+			if change.Diff != nil {
+				// The diff replaces both full bodies of an edited text
+				jChange.From, jChange.To, jChange.Diff = "", "", change.Diff.String()
+			}
+			jIssue.Changes = append(jIssue.Changes, jChange)
+		}
+
+		for _, change := range issue.CollectionChanges {
+			jIssue.Collections = append(jIssue.Collections, jsonCollectionChange{
+				Timestamp:       jsonTime(change.Timestamp, change.AuthorTimeZone),
+				TimestampMillis: epochMillis(change.Timestamp),
+				Author:          change.Author,
+				Field:           change.Field,
+				Added:           change.Added,
+				Removed:         change.Removed,
+			})
+		}
+
+		if issue.Transitions != nil {
+			jIssue.Transitions = &jsonTransitions{
+				Journey: issue.Transitions.Journey,
+				Count:   issue.Transitions.Count,
+			}
+		}
+
+		if !issue.ActionItems.IsEmpty() {
+			jIssue.ActionItems = &jsonActionItems{
+				Completed: actionItemTexts(issue.ActionItems.Completed),
+				Added:     actionItemTexts(issue.ActionItems.Added),
+			}
+		}
+	}
+
+	for _, issue := range report.Pinned {
+		jReport.Pinned = append(jReport.Pinned, jsonIssueRef{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+			Notes:   noteTexts(issue.Notes),
+		})
+	}
+
+	for _, issue := range report.Blockers {
+		jReport.Blockers = append(jReport.Blockers, jsonIssueRef{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+			Notes:   noteTexts(issue.Notes),
+		})
+	}
+
+	for _, issue := range report.CarryOver {
+		jReport.CarryOver = append(jReport.CarryOver, jsonIssueRef{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+			Notes:   noteTexts(issue.Notes),
+		})
+	}
+
+	for _, issue := range report.Filed {
+		jReport.Filed = append(jReport.Filed, jsonIssueRef{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+			Type:    issue.Type,
+			Notes:   noteTexts(issue.Notes),
+		})
+	}
+
+	for _, issue := range report.DueSoon {
+		jReport.DueSoon = append(jReport.DueSoon, jsonDueIssue{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+			DueDate: issue.DueDate.Format(dueDateLayout),
+			Overdue: isOverdue(issue, report.TimeRange),
+		})
+	}
+
+	if report.Sprint != nil {
+		toJSONRefs := func(issues []Issue) []jsonIssueRef {
+			refs := make([]jsonIssueRef, 0, len(issues))
+			for _, issue := range issues {
+				refs = append(refs, jsonIssueRef{Key: issue.Key, Status: issue.Status, Summary: issue.Summary, Type: issue.Type})
+			}
+			return refs
+		}
+		jReport.Sprint = &jsonSprint{
+			ID:            report.Sprint.Sprint.ID,
+			Name:          report.Sprint.Sprint.Name,
+			Added:         toJSONRefs(report.Sprint.Added),
+			Removed:       toJSONRefs(report.Sprint.Removed),
+			PointsAdded:   report.Sprint.PointsAdded,
+			PointsRemoved: report.Sprint.PointsRemoved,
+		}
+	}
+
+	if report.Truncation != nil {
+		jReport.Truncation = &jsonTruncation{
+			Shown:  report.Truncation.Shown,
+			Total:  report.Truncation.Total,
+			Notice: report.Truncation.Notice(),
+		}
+	}
+	if !report.AsOf.IsZero() {
+		jReport.AsOf = report.AsOf.Format(machineTimeLayout)
+	}
+
+	if !report.Handoffs.IsEmpty() {
+		toJSONHandoffs := func(issues []Issue) []jsonHandoff {
+			handoffs := make([]jsonHandoff, 0, len(issues))
+			for _, issue := range issues {
+				handoffs = append(handoffs, jsonHandoff{
+					Key:      issue.Key,
+					Status:   issue.Status,
+					Summary:  issue.Summary,
+					At:       jsonTime(issue.Handoff.Timestamp, ""),
+					AtMillis: epochMillis(issue.Handoff.Timestamp),
+					By:       issue.Handoff.Author,
+					From:     assigneeName(issue.Handoff.FromAssignee),
+					To:       assigneeName(issue.Handoff.ToAssignee),
+				})
+			}
+			return handoffs
+		}
+		jReport.Handoffs = &jsonHandoffs{
+			Incoming: toJSONHandoffs(report.Handoffs.Incoming),
+			Outgoing: toJSONHandoffs(report.Handoffs.Outgoing),
+		}
+	}
+
+	toJSONEpicRollup := func(rollup EpicRollup) jsonEpicRollup {
+		jRollup := jsonEpicRollup{
+			IssuesAdvanced: rollup.IssuesAdvanced,
+			Comments:       rollup.Comments,
+			Changes:        rollup.Changes,
+			Issues:         make([]jsonIssueRef, 0, len(rollup.Issues)),
+		}
+		if rollup.Epic != nil {
+			jRollup.Key = rollup.Epic.Key
+			jRollup.Summary = rollup.Epic.Summary
+		}
+		for _, issue := range rollup.Issues {
+			jRollup.Issues = append(jRollup.Issues, jsonIssueRef{
+				Key:     issue.Key,
+				Status:  issue.Status,
+				Summary: issue.Summary,
+			})
+		}
+		return jRollup
+	}
+
+	for _, rollup := range report.Epics {
+		jReport.Epics = append(jReport.Epics, toJSONEpicRollup(rollup))
+	}
+
+	for _, initiative := range report.Initiatives {
+		jInitiative := jsonInitiativeRollup{
+			IssuesAdvanced: initiative.IssuesAdvanced,
+			Comments:       initiative.Comments,
+			Changes:        initiative.Changes,
+			Epics:          make([]jsonEpicRollup, 0, len(initiative.Epics)),
+		}
+		if initiative.Initiative != nil {
+			jInitiative.Key = initiative.Initiative.Key
+			jInitiative.Summary = initiative.Initiative.Summary
+		}
+		for _, rollup := range initiative.Epics {
+			jInitiative.Epics = append(jInitiative.Epics, toJSONEpicRollup(rollup))
+		}
+		jReport.Initiatives = append(jReport.Initiatives, jInitiative)
+	}
+
+	for _, digest := range report.Components {
+		jDigest := jsonComponentDigest{
+			Component:      digest.Component,
+			IssuesAdvanced: digest.IssuesAdvanced,
+			Comments:       digest.Comments,
+			Changes:        digest.Changes,
+			Contributors:   append([]string{}, digest.Contributors...),
+			Issues:         make([]jsonIssueRef, 0, len(digest.Issues)),
+		}
+		for _, issue := range digest.Issues {
+			jDigest.Issues = append(jDigest.Issues, jsonIssueRef{
+				Key:     issue.Key,
+				Status:  issue.Status,
+				Summary: issue.Summary,
 			})
 		}
+		jReport.Components = append(jReport.Components, jDigest)
+	}
+
+	if report.Release != nil {
+		jRelease := &jsonReleaseNotes{
+			Version: report.Release.Version,
+			Groups:  make([]jsonReleaseGroup, 0, len(report.Release.Groups)),
+		}
+		for _, group := range report.Release.Groups {
+			jGroup := jsonReleaseGroup{Type: group.Type, Issues: make([]jsonReleaseNote, 0, len(group.Issues))}
+			for _, issue := range group.Issues {
+				jGroup.Issues = append(jGroup.Issues, jsonReleaseNote{
+					Key:        issue.Key,
+					Status:     issue.Status,
+					Summary:    issue.Summary,
+					Resolution: ResolutionLabel(issue),
+				})
+			}
+			jRelease.Groups = append(jRelease.Groups, jGroup)
+		}
+		jReport.Release = jRelease
+	}
+
+	if report.Comparison != nil {
+		issueRefs := func(issues []Issue) []jsonIssueRef {
+			refs := make([]jsonIssueRef, 0, len(issues))
+			for _, issue := range issues {
+				refs = append(refs, jsonIssueRef{Key: issue.Key, Status: issue.Status, Summary: issue.Summary, Type: issue.Type})
+			}
+			return refs
+		}
+		jReport.Comparison = &jsonReleaseComparison{
+			From:      report.Comparison.From,
+			To:        report.Comparison.To,
+			Completed: issueRefs(report.Comparison.Completed),
+			New:       issueRefs(report.Comparison.New),
+			Reopened:  issueRefs(report.Comparison.Reopened),
+			Slipped:   issueRefs(report.Comparison.Slipped),
+		}
+	}
+
+	for _, issue := range report.Triage {
+		jReport.Triage = append(jReport.Triage, jsonTriageIssue{
+			Key:      issue.Key,
+			Status:   issue.Status,
+			Summary:  issue.Summary,
+			Type:     issue.Type,
+			Priority: issue.Priority,
+			Reporter: issue.Reporter.DisplayName,
+		})
+	}
+
+	for _, author := range report.Authors {
+		jReport.Authors = append(jReport.Authors, jsonUser{
+			DisplayName: author.DisplayName,
+			AccountID:   author.AccountID,
+			Email:       author.Email,
+			AvatarURL:   author.AvatarURL,
+			TimeZone:    author.TimeZone,
+		})
+	}
+
+	if report.Stats != nil {
+		toJSONVelocity := func(stats VelocityStats) jsonVelocity {
+			return jsonVelocity{
+				WindowStart:           stats.WindowStart.Format(machineTimeLayout),
+				WindowEnd:             stats.WindowEnd.Format(machineTimeLayout),
+				IssuesCompleted:       stats.IssuesCompleted,
+				PointsCompleted:       stats.PointsCompleted,
+				AverageCycleTimeHours: stats.AverageCycleTime.Hours(),
+			}
+		}
+		jReport.Stats = &jsonStats{Current: toJSONVelocity(report.Stats.Current)}
+		for _, window := range report.Stats.Trailing {
+			jReport.Stats.Trailing = append(jReport.Stats.Trailing, toJSONVelocity(window))
+		}
+	}
+
+	if report.Heatmap != nil {
+		jReport.Heatmap = &jsonHeatmap{
+			TimeZone:   report.Heatmap.TimeZone,
+			Hours:      report.Heatmap.Hours[:],
+			Total:      report.Heatmap.Total,
+			AfterHours: report.Heatmap.AfterHours,
+		}
+	}
+
+	for _, warning := range report.Warnings {
+		jReport.Warnings = append(jReport.Warnings, jsonWarning{Kind: string(warning.Kind), Message: warning.Message})
+	}
+
+	// Marshal to JSON with proper indentation
+	buf := getBuffer()
+	defer putBuffer(buf)
+	encoder := json.NewEncoder(buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(jReport); err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return &FormattedContent{
+		ContentType: "application/json",
+		// The encoder ends the document with a newline
+		Content:     strings.TrimSuffix(buf.String(), "\n"),
+	}, nil
+}
+
+// MarkdownFormatter formats activity reports as Markdown
+type MarkdownFormatter struct {
+	allowRaw bool
+	// headingOffset is added to the level of every heading, so that the
+	// report nests under the headings of the document it is embedded in
+	headingOffset int
+	// issuesAsList renders issues as list items with their activity nested
+	// under them, instead of as headings
+	issuesAsList bool
+	// compact renders one line per issue summing up its activity, instead of
+	// the activity itself
+	compact bool
+}
+
+// NewMarkdownFormatter creates a new Markdown formatter
+func NewMarkdownFormatter() *MarkdownFormatter {
+	return &MarkdownFormatter{}
+}
+
+// SetAllowRaw controls whether Jira content is passed through without escaping,
+// which lets Markdown written in Jira render as-is at the risk of broken layout
+func (f *MarkdownFormatter) SetAllowRaw(allowRaw bool) {
+	f.allowRaw = allowRaw
+}
+
+// SetOptions applies the jira.format.options.markdown settings: allow_raw
+// passes Jira content through without escaping, like SetAllowRaw;
+// heading_level is the level of the report title, from 1 to 6, the other
+// headings following below it; issues is heading or list, for issues as
+// headings or as list items; style is full, or compact for one line per issue
+func (f *MarkdownFormatter) SetOptions(options FormatterOptions) error {
+	if err := options.CheckKeys("allow_raw", "heading_level", "issues", "style"); err != nil {
+		return err
+	}
+	allowRaw, err := options.Bool("allow_raw", f.allowRaw)
+	if err != nil {
+		return err
+	}
+	headingLevel, err := options.Int("heading_level", f.headingOffset+1, 1, 6)
+	if err != nil {
+		return err
+	}
+	issues := "heading"
+	if f.issuesAsList {
+		issues = "list"
+	}
+	if issues, err = options.OneOf("issues", issues, "heading", "list"); err != nil {
+		return err
+	}
+	style := "full"
+	if f.compact {
+		style = "compact"
+	}
+	if style, err = options.OneOf("style", style, "full", "compact"); err != nil {
+		return err
+	}
+
+	f.allowRaw = allowRaw
+	f.headingOffset = headingLevel - 1
+	f.issuesAsList = issues == "list"
+	f.compact = style == "compact"
+	return nil
+}
+
+// heading renders a heading at the depth below the report title, which is at
+// depth 0. Markdown has six levels of headings; deeper ones are rendered as
+// bold lines.
+func (f *MarkdownFormatter) heading(depth int, title string) string {
+	level := f.headingOffset + depth + 1
+	if level > 6 {
+		return "**" + title + "**\n\n"
+	}
+	return strings.Repeat("#", level) + " " + title + "\n\n"
+}
+
+// inline escapes a single-line value unless raw passthrough is enabled
+func (f *MarkdownFormatter) inline(value string) string {
+	if f.allowRaw {
+		return value
+	}
+	return escapeMarkdownInline(value)
+}
+
+// diff renders a word-level diff with removed words struck through and added words in bold
+func (f *MarkdownFormatter) diff(diff TextDiff) string {
+	parts := make([]string, 0, len(diff))
+	for _, segment := range diff {
+		switch segment.Op {
+		case DiffDelete:
+			parts = append(parts, "~~"+f.inline(segment.Text)+"~~")
+		case DiffInsert:
+			parts = append(parts, "**"+f.inline(segment.Text)+"**")
+		default:
+			parts = append(parts, f.inline(segment.Text))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// block escapes multi-line content unless raw passthrough is enabled
+func (f *MarkdownFormatter) block(value string) string {
+	if f.allowRaw {
+		return value
+	}
+	return escapeMarkdownBlock(value)
+}
+
+// markdownURLReplacer percent-encodes the characters that would end a Markdown link target
+var markdownURLReplacer = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+
+// markdownURL makes a URL safe to use as a Markdown link target
+func markdownURL(url string) string {
+	return markdownURLReplacer.Replace(url)
+}
+
+// Name returns the name of the formatter
+func (f *MarkdownFormatter) Name() string {
+	return "markdown"
+}
+
+// Format formats an activity report as Markdown
+func (f *MarkdownFormatter) Format(report *ActivityReport) (*FormattedContent, error) {
+	if report.emptyMessage(f.Name()) {
+		return &FormattedContent{
+			ContentType: "text/markdown",
+			Content:     "No activity found for the specified time range.",
+		}, nil
+	}
+
+	sb := getBuffer()
+	defer putBuffer(sb)
+	links := NewDeepLinks(report.Options.LinkBaseURL)
+
+	// Add report header
+	sb.WriteString(f.heading(0, "Jira Activity Report"))
+	if report.Options.Verbosity.IncludeMetadata() {
+		sb.WriteString(fmt.Sprintf("**Time Range:** %s to %s\n\n", 
+			report.TimeRange.Start.Format("2006-01-02"),
+			report.TimeRange.End.Format("2006-01-02")))
+		if email := reportEmail(report); email != "" {
+			sb.WriteString(fmt.Sprintf("**User:** %s (%s)\n\n", 
+				f.inline(report.User.DisplayName), 
+				f.inline(email)))
+		} else {
+			sb.WriteString(fmt.Sprintf("**User:** %s\n\n", f.inline(report.User.DisplayName)))
+		}
+	}
+
+	// Disclose a truncated search, whatever the verbosity
+	if report.Truncation != nil {
+		sb.WriteString(fmt.Sprintf("> **Note:** %s\n\n", report.Truncation.Notice()))
+	}
+
+	// Disclose statuses and assignees reconstructed from the changelog
+	if !report.AsOf.IsZero() {
+		sb.WriteString(fmt.Sprintf("> **Note:** %s\n\n", asOfNotice(report.AsOf)))
+	}
+
+	// Add the active sprint with its scope change
+	if report.Sprint != nil {
+		sb.WriteString(f.heading(1, "Sprint: "+f.inline(report.Sprint.Sprint.Name)))
+		sb.WriteString(fmt.Sprintf("_Scope change: %s_\n\n", report.Sprint.ScopeLine()))
+		for _, section := range report.Sprint.Sections() {
+			sb.WriteString(f.heading(2, section.Title))
+			for _, issue := range section.Issues {
+				sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", f.inline(issue.Key), f.inline(issue.Summary), f.inline(issue.Status)))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	
+	// In epic and component modes the rollup replaces the per-status issue details
+	detailedIssues := report.Issues
+	switch report.Options.Mode {
+	case ReportModeEpic:
+		for _, rollup := range report.Epics {
+			sb.WriteString(f.heading(1, f.inline(rollup.Title())))
+			sb.WriteString(fmt.Sprintf("_%s_\n\n", rollup.CountsLine()))
+			for _, issue := range rollup.Issues {
+				sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", f.inline(issue.Key), f.inline(issue.Summary), f.inline(issue.Status)))
+			}
+			sb.WriteString("\n")
+		}
+		detailedIssues = nil
+	case ReportModeInitiative:
+		for _, initiative := range report.Initiatives {
+			sb.WriteString(f.heading(1, f.inline(initiative.Title())))
+			sb.WriteString(fmt.Sprintf("_%s_\n\n", initiative.CountsLine()))
+			for _, rollup := range initiative.Epics {
+				sb.WriteString(f.heading(2, f.inline(rollup.Title())))
+				sb.WriteString(fmt.Sprintf("_%s_\n\n", rollup.CountsLine()))
+				for _, issue := range rollup.Issues {
+					sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", f.inline(issue.Key), f.inline(issue.Summary), f.inline(issue.Status)))
+				}
+				sb.WriteString("\n")
+			}
+		}
+		detailedIssues = nil
+	case ReportModeComponent:
+		for _, digest := range report.Components {
+			sb.WriteString(f.heading(1, f.inline(digest.Component)))
+			sb.WriteString(fmt.Sprintf("_%s_\n\n", digest.CountsLine()))
+			if len(digest.Contributors) > 0 {
+				sb.WriteString(fmt.Sprintf("**Contributors:** %s\n\n", f.inline(strings.Join(digest.Contributors, ", "))))
+			}
+			for _, issue := range digest.Issues {
+				sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", f.inline(issue.Key), f.inline(issue.Summary), f.inline(issue.Status)))
+			}
+			sb.WriteString("\n")
+		}
+		detailedIssues = nil
+	case ReportModeRelease:
+		if report.Release != nil {
+			sb.WriteString(f.heading(1, "Release Notes: "+f.inline(report.Release.Version)))
+			for _, group := range report.Release.Groups {
+				sb.WriteString(f.heading(2, f.inline(group.Type)))
+				for _, issue := range group.Issues {
+					sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", f.inline(issue.Key), f.inline(issue.Summary), f.inline(ResolutionLabel(issue))))
+				}
+				sb.WriteString("\n")
+			}
+		}
+		if report.Comparison != nil {
+			sb.WriteString(f.heading(1, "Compared with "+f.inline(report.Comparison.From)))
+			for _, section := range report.Comparison.Sections() {
+				sb.WriteString(f.heading(2, section.Title))
+				for _, issue := range section.Issues {
+					sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", f.inline(issue.Key), f.inline(issue.Summary), f.inline(issue.Status)))
+				}
+				sb.WriteString("\n")
+			}
+		}
+		detailedIssues = nil
+	case ReportModeTriage:
+		if len(report.Triage) > 0 {
+			sb.WriteString(f.heading(1, "New Bugs and Incidents"))
+			for _, issue := range report.Triage {
+				sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", f.inline(issue.Key), f.inline(issue.Summary), f.inline(TriageLabel(issue))))
+			}
+			sb.WriteString("\n")
+		}
+		detailedIssues = nil
+	}
+
+	// In the compact style each issue is one line, whatever its status
+	if f.compact && len(detailedIssues) > 0 {
+		sb.WriteString(f.heading(1, "Issues"))
+		for _, group := range groupByStatus(detailedIssues) {
+			for _, issue := range group.Issues {
+				sb.WriteString(fmt.Sprintf("- %s (%s)", f.inline(issue.Key), f.inline(group.Status)))
+				if line := compactActivityLine(issue); line != "" {
+					sb.WriteString(": " + f.inline(line))
+				}
+				sb.WriteString("\n")
+			}
+		}
+		sb.WriteString("\n")
+		detailedIssues = nil
+	}
+
+	// Add issues by status
+	for _, group := range groupByStatus(detailedIssues) {
+		status, issues := group.Status, group.Issues
+		sb.WriteString(f.heading(1, f.inline(status)+" Issues"))
+		
+		for _, issue := range issues {
+			title := fmt.Sprintf("[%s] %s", f.inline(issue.Key), f.inline(issue.Summary))
+			if line := statusSinceLine(issue, report.TimeRange.End, report.User.TimeZone); line != "" {
+				title += fmt.Sprintf(" _(%s)_", f.inline(line))
+			}
+
+			if !f.issuesAsList {
+				sb.WriteString(f.heading(2, title))
+				f.writeIssueDetails(sb, report, issue, links)
+				sb.WriteString("---\n\n")
+				continue
+			}
+
+			// Nest the activity under the list item by indenting it
+			sb.WriteString("- " + title + "\n")
+			details := getBuffer()
+			f.writeIssueDetails(details, report, issue, links)
+			if details.Len() > 0 {
+				sb.WriteString("\n" + indentLines(details.String(), "  "))
+			}
+			putBuffer(details)
+		}
+		if f.issuesAsList && !bytes.HasSuffix(sb.Bytes(), []byte("\n\n")) {
+			sb.WriteString("\n")
+		}
+	}
+
+	// Add supplementary sections such as blockers and carry-over work
+	for _, section := range supplementarySections(report) {
+		sb.WriteString(f.heading(1, section.Title))
+		for _, issue := range section.Issues {
+			sb.WriteString(fmt.Sprintf("- [%s] %s (%s)", f.inline(issue.Key), f.inline(issue.Summary), f.inline(issue.Status)))
+			for _, note := range issue.Notes {
+				sb.WriteString(fmt.Sprintf(" — _%s_", f.inline(note.Text)))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Add the work due soon
+	if len(report.DueSoon) > 0 {
+		sb.WriteString(f.heading(1, "Due Soon"))
+		for _, issue := range report.DueSoon {
+			sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", f.inline(issue.Key), f.inline(issue.Summary), f.inline(dueLine(issue, report.TimeRange))))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Add the work handed to and away from the user
+	if !report.Handoffs.IsEmpty() {
+		sb.WriteString(f.heading(1, "Handoffs"))
+		for _, section := range report.Handoffs.Sections() {
+			sb.WriteString(f.heading(2, section.Title))
+			for _, issue := range section.Issues {
+				sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", f.inline(issue.Key), f.inline(issue.Summary), f.inline(handoffLine(issue.Handoff, report.User))))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	// Add the velocity statistics
+	if report.Stats != nil {
+		sb.WriteString(f.heading(1, "Stats"))
+		sb.WriteString("| Window | Issues Completed | Points Completed | Avg Cycle Time |\n")
+		sb.WriteString("|--------|------------------|------------------|----------------|\n")
+		for _, window := range report.Stats.Windows() {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s |\n", window.WindowLabel(), window.IssuesCompleted,
+				formatPoints(window.PointsCompleted), formatDuration(window.AverageCycleTime)))
+		}
+		sb.WriteString("\n")
+
+		if measured := measuredIssues(report.Issues); len(measured) > 0 {
+			sb.WriteString(f.heading(2, "Cycle Time per Issue"))
+			sb.WriteString("| Issue | Cycle Time | Lead Time |\n")
+			sb.WriteString("|-------|------------|-----------|\n")
+			for _, issue := range measured {
+				sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", f.inline(issue.Key),
+					formatDuration(issue.CycleTime), formatDuration(issue.LeadTime)))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	// Add the activity heatmap
+	if report.Heatmap != nil {
+		sb.WriteString(f.heading(1, "Activity by Hour"))
+		sb.WriteString(fmt.Sprintf("```text\n%s\n```\n\n", report.Heatmap.Text()))
+		sb.WriteString(fmt.Sprintf("_%s_\n\n", report.Heatmap.SummaryLine()))
+	}
+
+	// List the problems that left data out of the report
+	if warnings := footerWarnings(report.Warnings); len(warnings) > 0 {
+		sb.WriteString(f.heading(1, "Warnings"))
+		for _, warning := range warnings {
+			sb.WriteString(fmt.Sprintf("- %s\n", f.inline(warning.Message)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Close with how the report was generated, the query as an indented
+	// code block so that it is shown as is
+	if generation := report.Generation; generation != nil && report.Options.Verbosity.IncludeMetadata() {
+		sb.WriteString("---\n\n")
+		sb.WriteString(fmt.Sprintf("_%s_\n\n", f.inline(generation.Line())))
+		if generation.Query != "" {
+			sb.WriteString("Query:\n\n")
+			for _, line := range strings.Split(normalizeLineEndings(generation.Query), "\n") {
+				sb.WriteString("    " + line + "\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return &FormattedContent{
+		ContentType: "text/markdown",
+		Content:     sb.String(),
+	}, nil
+}
+
+// writeIssueDetails writes the activity of an issue under its heading or
+// list item
+func (f *MarkdownFormatter) writeIssueDetails(sb *bytes.Buffer, report *ActivityReport, issue Issue, links DeepLinks) {
+	// Call out issues that were reopened before anything else
+	if line := reopeningLine(issue.Reopened); line != "" {
+		sb.WriteString(fmt.Sprintf("**Reopened** %s\n\n", f.inline(line)))
+	}
+	if line := escalationLine(issue.Escalation); line != "" {
+		sb.WriteString(fmt.Sprintf("**Priority escalated:** %s\n\n", f.inline(line)))
+	}
+
+	// Add the notes the user pinned to the issue
+	for _, note := range issue.Notes {
+		sb.WriteString(fmt.Sprintf("**Note:** %s\n\n", f.inline(note.Text)))
+	}
+
+	// Add permalinks to the issue and its change history
+	if links.Enabled() {
+		sb.WriteString(fmt.Sprintf("**Links:** [Issue](%s) · [History](%s)\n\n",
+			markdownURL(links.Issue(issue.Key)), markdownURL(links.History(issue.Key))))
+	}
+
+	// Add the hierarchy path if it was resolved
+	if len(issue.Hierarchy) > 0 {
+		sb.WriteString(fmt.Sprintf("**Hierarchy:** %s\n\n", f.inline(HierarchyLine(issue.Hierarchy))))
+	}
+
+	// Add the other issues mentioned in comments and changes
+	if len(issue.ReferencedIssues) > 0 {
+		sb.WriteString(fmt.Sprintf("**References:** %s\n\n", f.linkKeys(f.inline(strings.Join(referenceKeys(issue.ReferencedIssues), ", ")), issue, links)))
+	}
+
+	// Add the change in watchers and votes if there was any
+	if line := attentionLine(issue.Attention); line != "" {
+		sb.WriteString(fmt.Sprintf("**Attention:** %s\n\n", f.inline(line)))
+	}
+
+	// Add the activity summary if one was produced
+	if issue.ActivitySummary != "" {
+		sb.WriteString(fmt.Sprintf("_%s_\n\n", f.inline(issue.ActivitySummary)))
+	}
+
+	// In summary-only mode the summary line replaces the raw activity
+	if showSummaryOnly(report, issue) {
+		return
+	}
+	
+	// Add the status journey if transitions were summarized
+	if issue.Transitions != nil {
+		sb.WriteString(fmt.Sprintf("**Status journey:** %s\n\n", f.inline(transitionLine(issue.Transitions))))
+	}
+
+	// Add the description if the verbosity includes it
+	if issue.Description != "" {
+		sb.WriteString(f.issueSection("Description"))
+		sb.WriteString(fmt.Sprintf("%s\n\n", f.block(issue.Description)))
+	}
+	
+	// Add changes section if there are any
+	if len(issue.Changes) > 0 {
+		sb.WriteString(f.issueSection("Changes"))
+
+		// Columns depend on whether other authors and change details are shown
+		headers := []string{"Time"}
+		if report.Options.IncludeOthersChanges {
+			headers = append(headers, "Author")
+		}
+		headers = append(headers, "Field")
+		if report.Options.Verbosity.IncludeChangeDetails() {
+			headers = append(headers, "From", "To")
+		}
+		separators := make([]string, 0, len(headers))
+		for _, header := range headers {
+			separators = append(separators, strings.Repeat("-", len(header)+2))
+		}
+		sb.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+		sb.WriteString("|" + strings.Join(separators, "|") + "|\n")
+		
+		// One row of cells is reused for every change
+		cells := make([]string, 0, len(headers))
+		for _, change := range issue.Changes {
+			cells = append(cells[:0], eventTime(report.Options, change.Timestamp, change.AuthorTimeZone, "2006-01-02 15:04"))
+			if report.Options.IncludeOthersChanges {
+				cells = append(cells, f.inline(changeAuthorLabel(report.Options, change)))
+			}
+			cells = append(cells, f.inline(change.Field))
+			if report.Options.Verbosity.IncludeChangeDetails() && change.Diff != nil {
+				// The diff replaces both full bodies of an edited text
+				cells = append(cells, "", f.diff(change.Diff))
+			} else if report.Options.Verbosity.IncludeChangeDetails() {
+				cells = append(cells, f.linkKeys(f.inline(change.FromValue), issue, links), f.linkKeys(f.inline(change.ToValue), issue, links))
+			}
+			sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+		sb.WriteString("\n")
+	}
+	
+	// Add label and component changes if there are any
+	if len(issue.CollectionChanges) > 0 {
+		sb.WriteString(f.issueSection("Labels & Components"))
+		for _, change := range issue.CollectionChanges {
+			sb.WriteString(fmt.Sprintf("- %s (%s)\n", f.inline(change.String()), eventTime(report.Options, change.Timestamp, change.AuthorTimeZone, "2006-01-02 15:04")))
+		}
+		sb.WriteString("\n")
+	}
+	
+	// Add comments section if there are any
+	if len(issue.Comments) > 0 {
+		sb.WriteString(f.issueSection("Comments"))
+		
+		for _, comment := range issue.Comments {
+			timestamp := eventTime(report.Options, comment.Timestamp, comment.AuthorTimeZone, "2006-01-02 15:04")
+			if links.Enabled() {
+				// Link straight to the comment so reviewers can jump to it
+				timestamp = fmt.Sprintf("[%s](%s)", timestamp, markdownURL(links.Comment(issue.Key, comment.ID)))
+			}
+			if comment.Edited {
+				timestamp += " _(edited)_"
+			}
+			if comment.MentionsUser {
+				timestamp += " _(mentions you)_"
+			}
+			sb.WriteString(fmt.Sprintf("**%s** - %s\n\n", 
+				f.inline(comment.Author),
+				timestamp))
+			if comment.Content != "" {
+				sb.WriteString(fmt.Sprintf("%s\n\n", f.linkKeys(f.block(comment.Content), issue, links)))
+			}
+		}
+	}
+
+	// Add the work logged within the range
+	if len(issue.Worklogs) > 0 {
+		sb.WriteString(f.issueSection("Work Logged"))
 
-		jReport.Issues = append(jReport.Issues, jIssue)
+		for _, worklog := range issue.Worklogs {
+			sb.WriteString(fmt.Sprintf("- %s - %s", f.inline(worklogLine(worklog)),
+				eventTime(report.Options, worklog.Started, worklog.AuthorTimeZone, "2006-01-02 15:04")))
+			if worklog.Comment != "" {
+				sb.WriteString(": " + f.inline(worklog.Comment))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
 	}
 
-	// Marshal to JSON with proper indentation
-	output, err := json.MarshalIndent(jReport, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
-	}
+	// Add remote links added within the range, such as a linked design doc
+	if len(issue.RemoteLinks) > 0 {
+		sb.WriteString(f.issueSection("Links Added"))
 
-	return &FormattedContent{
-		ContentType: "application/json",
-		Content:     string(output),
-	}, nil
-}
+		for _, link := range issue.RemoteLinks {
+			sb.WriteString(fmt.Sprintf("- [%s](%s) - %s, %s\n", f.inline(link.Line()), markdownURL(link.URL),
+				f.inline(link.AddedBy), eventTime(report.Options, link.AddedAt, "", "2006-01-02 15:04")))
+		}
+		sb.WriteString("\n")
+	}
 
-// MarkdownFormatter formats activity reports as Markdown
-type MarkdownFormatter struct{}
+	// Add action items section if there are any
+	if !issue.ActionItems.IsEmpty() {
+		sb.WriteString(f.issueSection("Action Items"))
 
-// NewMarkdownFormatter creates a new Markdown formatter
-func NewMarkdownFormatter() *MarkdownFormatter {
-	return &MarkdownFormatter{}
+		for _, item := range issue.ActionItems.Completed {
+			sb.WriteString(fmt.Sprintf("- [x] %s\n", f.inline(item.Text)))
+		}
+		for _, item := range issue.ActionItems.Added {
+			sb.WriteString(fmt.Sprintf("- [ ] %s\n", f.inline(item.Text)))
+		}
+		sb.WriteString("\n")
+	}
 }
 
-// Name returns the name of the formatter
-func (f *MarkdownFormatter) Name() string {
-	return "markdown"
+// linkKeys links the keys of the issues referenced by the issue in escaped
+// text to their browse pages, when deep links are enabled, and follows those
+// that were resolved with their status
+func (f *MarkdownFormatter) linkKeys(text string, issue Issue, links DeepLinks) string {
+	return linkReferences(text, issue.ReferencedIssues, func(reference IssueRef) string {
+		label := reference.Key
+		if links.Enabled() {
+			label = fmt.Sprintf("[%s](%s)", reference.Key, markdownURL(links.Issue(reference.Key)))
+		}
+		return label + f.inline(referenceLabel(reference))
+	})
 }
 
-// Format formats an activity report as Markdown
-func (f *MarkdownFormatter) Format(report *ActivityReport) (*FormattedContent, error) {
-	if len(report.Issues) == 0 {
-		return &FormattedContent{
-			ContentType: "text/markdown",
-			Content:     "No activity found for the specified time range.",
-		}, nil
+// issueSection renders the title of a section of an issue, such as its
+// comments: a heading below the issue's, or a bold line within a list item
+func (f *MarkdownFormatter) issueSection(title string) string {
+	if f.issuesAsList {
+		return "**" + title + "**\n\n"
 	}
+	return f.heading(3, title)
+}
 
-	var sb strings.Builder
-
-	// Add report header
-	sb.WriteString(fmt.Sprintf("# Jira Activity Report\n\n"))
-	sb.WriteString(fmt.Sprintf("**Time Range:** %s to %s\n\n", 
-		report.TimeRange.Start.Format("2006-01-02"),
-		report.TimeRange.End.Format("2006-01-02")))
-	sb.WriteString(fmt.Sprintf("**User:** %s (%s)\n\n", 
-		report.User.DisplayName, 
-		report.User.Email))
-	
-	// Group issues by status
-	statusGroups := make(map[string][]Issue)
-	for _, issue := range report.Issues {
-		statusGroups[issue.Status] = append(statusGroups[issue.Status], issue)
+// compactActivityLine sums up the activity on an issue in one line, e.g.
+// "moved from In Progress; 2 comments"
+func compactActivityLine(issue Issue) string {
+	parts := make([]string, 0, 5)
+	changes := 0
+	for _, change := range issue.Changes {
+		if change.Field != statusField {
+			changes++
+		}
 	}
-
-	// Add issues by status
-	for status, issues := range statusGroups {
-		sb.WriteString(fmt.Sprintf("## %s Issues\n\n", status))
-		
-		for _, issue := range issues {
-			sb.WriteString(fmt.Sprintf("### [%s] %s\n\n", issue.Key, issue.Summary))
-			
-			// Add changes section if there are any
-			if len(issue.Changes) > 0 {
-				sb.WriteString("#### Changes\n\n")
-				sb.WriteString("| Time | Field | From | To |\n")
-				sb.WriteString("|------|-------|------|----|\n")
-				
-				for _, change := range issue.Changes {
-					sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
-						change.Timestamp.Format("2006-01-02 15:04"),
-						change.Field,
-						change.FromValue,
-						change.ToValue))
-				}
-				sb.WriteString("\n")
-			}
-			
-			// Add comments section if there are any
-			if len(issue.Comments) > 0 {
-				sb.WriteString("#### Comments\n\n")
-				
-				for _, comment := range issue.Comments {
-					sb.WriteString(fmt.Sprintf("**%s** - %s\n\n", 
-						comment.Author,
-						comment.Timestamp.Format("2006-01-02 15:04")))
-					sb.WriteString(fmt.Sprintf("%s\n\n", comment.Content))
-				}
-			}
-			
-			sb.WriteString("---\n\n")
+	for _, change := range issue.Changes {
+		// The status the issue was in first within the range
+		if change.Field == statusField {
+			parts = append(parts, "moved from "+change.FromValue)
+			break
+		}
+	}
+	if count := len(issue.Comments); count > 0 {
+		parts = append(parts, pluralize(count, "comment", "comments"))
+	}
+	if changes > 0 {
+		parts = append(parts, pluralize(changes, "change", "changes"))
+	}
+	if count := len(issue.RemoteLinks); count > 0 {
+		parts = append(parts, pluralize(count, "link", "links"))
+	}
+	if len(issue.Worklogs) > 0 {
+		var spent time.Duration
+		for _, worklog := range issue.Worklogs {
+			spent += worklog.TimeSpent
 		}
+		parts = append(parts, "logged "+formatDuration(spent))
 	}
+	return strings.Join(parts, "; ")
+}
 
-	return &FormattedContent{
-		ContentType: "text/markdown",
-		Content:     sb.String(),
-	}, nil
+// indentLines indents every line of the text that is not blank
+func indentLines(text, indent string) string {
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "")
 }
 
 // HTMLFormatter formats activity reports as HTML
@@ -298,14 +1860,16 @@ func (f *HTMLFormatter) Name() string {
 
 // Format formats an activity report as HTML
 func (f *HTMLFormatter) Format(report *ActivityReport) (*FormattedContent, error) {
-	if len(report.Issues) == 0 {
+	if report.emptyMessage(f.Name()) {
 		return &FormattedContent{
 			ContentType: "text/html",
 			Content:     "<html><body><h1>Jira Activity Report</h1><p>No activity found for the specified time range.</p></body></html>",
 		}, nil
 	}
 
-	var sb strings.Builder
+	sb := getBuffer()
+	defer putBuffer(sb)
+	links := NewDeepLinks(report.Options.LinkBaseURL)
 
 	// Start HTML document
 	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n")
@@ -319,73 +1883,433 @@ func (f *HTMLFormatter) Format(report *ActivityReport) (*FormattedContent, error
 	sb.WriteString(".issue-key { color: #0052CC; font-weight: bold; }\n")
 	sb.WriteString(".issue-summary { font-size: 16px; margin-bottom: 10px; }\n")
 	sb.WriteString(".metadata { color: #6B778C; font-size: 14px; margin-bottom: 15px; }\n")
+	sb.WriteString(".truncation { background-color: #FFFAE6; border-left: 3px solid #FFAB00; padding: 8px 12px; }\n")
+	sb.WriteString(".warnings { color: #BF2600; }\n")
 	sb.WriteString(".changes, .comments { margin-top: 10px; }\n")
 	sb.WriteString(".change, .comment { background-color: white; border: 1px solid #DFE1E6; padding: 10px; margin-bottom: 8px; }\n")
 	sb.WriteString(".author { color: #0052CC; font-weight: bold; }\n")
+	sb.WriteString(".change.own { border-left: 3px solid #0052CC; }\n")
+	sb.WriteString(".avatar { width: 24px; height: 24px; border-radius: 50%; vertical-align: middle; margin-right: 6px; }\n")
 	sb.WriteString(".timestamp { color: #6B778C; font-size: 12px; }\n")
+	sb.WriteString(".diff del { background-color: #FFEBE6; } .diff ins { background-color: #E3FCEF; text-decoration: none; }\n")
+	sb.WriteString(".status-since { color: #6B778C; font-size: 13px; font-weight: normal; }\n")
+	sb.WriteString(".action-items li.done { color: #006644; }\n")
+	sb.WriteString(".activity-summary { font-style: italic; color: #42526E; }\n")
+	sb.WriteString(".heatmap, .stats { border-collapse: collapse; font-size: 12px; }\n")
+	sb.WriteString(".stats th, .stats td { border: 1px solid #DFE1E6; padding: 4px 8px; }\n")
+	sb.WriteString(".generation { color: #6B778C; font-size: 12px; border-top: 1px solid #DFE1E6; margin-top: 30px; }\n")
+	sb.WriteString(".heatmap th, .heatmap td { border: 1px solid #DFE1E6; padding: 4px; text-align: center; min-width: 20px; }\n")
+	sb.WriteString(htmlStatusBadgeStyles())
 	sb.WriteString("</style>\n")
 	sb.WriteString("</head>\n<body>\n")
 
 	// Add report header
 	sb.WriteString("<h1>Jira Activity Report</h1>\n")
-	sb.WriteString("<div class=\"metadata\">\n")
-	sb.WriteString(fmt.Sprintf("<p><strong>Time Range:</strong> %s to %s</p>\n", 
-		report.TimeRange.Start.Format("2006-01-02"),
-		report.TimeRange.End.Format("2006-01-02")))
-	sb.WriteString(fmt.Sprintf("<p><strong>User:</strong> %s (%s)</p>\n", 
-		report.User.DisplayName, 
-		report.User.Email))
-	sb.WriteString("</div>\n")
+	if report.Options.Verbosity.IncludeMetadata() {
+		sb.WriteString("<div class=\"metadata\">\n")
+		sb.WriteString(fmt.Sprintf("<p><strong>Time Range:</strong> %s to %s</p>\n", 
+			report.TimeRange.Start.Format("2006-01-02"),
+			report.TimeRange.End.Format("2006-01-02")))
+		if email := reportEmail(report); email != "" {
+			sb.WriteString(fmt.Sprintf("<p><strong>User:</strong> %s%s (%s)</p>\n", 
+				htmlAvatar(report.User.AvatarURL),
+				report.User.DisplayName, 
+				email))
+		} else {
+			sb.WriteString(fmt.Sprintf("<p><strong>User:</strong> %s%s</p>\n", htmlAvatar(report.User.AvatarURL), report.User.DisplayName))
+		}
+		sb.WriteString("</div>\n")
+	}
+
+	// Disclose a truncated search, whatever the verbosity
+	if report.Truncation != nil {
+		sb.WriteString(fmt.Sprintf("<p class=\"truncation\"><strong>Note:</strong> %s</p>\n", report.Truncation.Notice()))
+	}
+
+	// Disclose statuses and assignees reconstructed from the changelog
+	if !report.AsOf.IsZero() {
+		sb.WriteString(fmt.Sprintf("<p class=\"as-of\"><strong>Note:</strong> %s</p>\n", asOfNotice(report.AsOf)))
+	}
+
+	// Add the active sprint with its scope change
+	if report.Sprint != nil {
+		sb.WriteString(fmt.Sprintf("<h2>Sprint: %s</h2>\n", report.Sprint.Sprint.Name))
+		sb.WriteString(fmt.Sprintf("<p class=\"activity-summary\">Scope change: %s</p>\n", report.Sprint.ScopeLine()))
+		for _, section := range report.Sprint.Sections() {
+			sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", section.Title))
+			sb.WriteString("<ul class=\"sprint-scope\">\n")
+			for _, issue := range section.Issues {
+				sb.WriteString(fmt.Sprintf("<li><span class=\"issue-key\">[%s]</span> %s <span class=\"timestamp\">(%s)</span></li>\n",
+					issue.Key, issue.Summary, issue.Status))
+			}
+			sb.WriteString("</ul>\n")
+		}
+	}
 	
-	// Group issues by status
-	statusGroups := make(map[string][]Issue)
-	for _, issue := range report.Issues {
-		statusGroups[issue.Status] = append(statusGroups[issue.Status], issue)
+	// In epic and component modes the rollup replaces the per-status issue details
+	detailedIssues := report.Issues
+	switch report.Options.Mode {
+	case ReportModeEpic:
+		for _, rollup := range report.Epics {
+			sb.WriteString(fmt.Sprintf("<h2>%s</h2>\n", rollup.Title()))
+			sb.WriteString(fmt.Sprintf("<p class=\"activity-summary\">%s</p>\n", rollup.CountsLine()))
+			sb.WriteString("<ul class=\"epic-issues\">\n")
+			for _, issue := range rollup.Issues {
+				sb.WriteString(fmt.Sprintf("<li><span class=\"issue-key\">[%s]</span> %s <span class=\"timestamp\">(%s)</span></li>\n",
+					issue.Key, issue.Summary, issue.Status))
+			}
+			sb.WriteString("</ul>\n")
+		}
+		detailedIssues = nil
+	case ReportModeInitiative:
+		for _, initiative := range report.Initiatives {
+			sb.WriteString(fmt.Sprintf("<h2>%s</h2>\n", initiative.Title()))
+			sb.WriteString(fmt.Sprintf("<p class=\"activity-summary\">%s</p>\n", initiative.CountsLine()))
+			for _, rollup := range initiative.Epics {
+				sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", rollup.Title()))
+				sb.WriteString(fmt.Sprintf("<p class=\"activity-summary\">%s</p>\n", rollup.CountsLine()))
+				sb.WriteString("<ul class=\"epic-issues\">\n")
+				for _, issue := range rollup.Issues {
+					sb.WriteString(fmt.Sprintf("<li><span class=\"issue-key\">[%s]</span> %s <span class=\"timestamp\">(%s)</span></li>\n",
+						issue.Key, issue.Summary, issue.Status))
+				}
+				sb.WriteString("</ul>\n")
+			}
+		}
+		detailedIssues = nil
+	case ReportModeComponent:
+		for _, digest := range report.Components {
+			sb.WriteString(fmt.Sprintf("<h2>%s</h2>\n", digest.Component))
+			sb.WriteString(fmt.Sprintf("<p class=\"activity-summary\">%s</p>\n", digest.CountsLine()))
+			if len(digest.Contributors) > 0 {
+				sb.WriteString(fmt.Sprintf("<p><strong>Contributors:</strong> %s</p>\n", strings.Join(digest.Contributors, ", ")))
+			}
+			sb.WriteString("<ul class=\"component-issues\">\n")
+			for _, issue := range digest.Issues {
+				sb.WriteString(fmt.Sprintf("<li><span class=\"issue-key\">[%s]</span> %s <span class=\"timestamp\">(%s)</span></li>\n",
+					issue.Key, issue.Summary, issue.Status))
+			}
+			sb.WriteString("</ul>\n")
+		}
+		detailedIssues = nil
+	case ReportModeRelease:
+		if report.Release != nil {
+			sb.WriteString(fmt.Sprintf("<h2>Release Notes: %s</h2>\n", report.Release.Version))
+			for _, group := range report.Release.Groups {
+				sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", group.Type))
+				sb.WriteString("<ul class=\"release-issues\">\n")
+				for _, issue := range group.Issues {
+					sb.WriteString(fmt.Sprintf("<li><span class=\"issue-key\">[%s]</span> %s <span class=\"timestamp\">(%s)</span></li>\n",
+						issue.Key, issue.Summary, ResolutionLabel(issue)))
+				}
+				sb.WriteString("</ul>\n")
+			}
+		}
+		if report.Comparison != nil {
+			sb.WriteString(fmt.Sprintf("<h2>Compared with %s</h2>\n", report.Comparison.From))
+			for _, section := range report.Comparison.Sections() {
+				sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", section.Title))
+				sb.WriteString("<ul class=\"release-issues\">\n")
+				for _, issue := range section.Issues {
+					sb.WriteString(fmt.Sprintf("<li><span class=\"issue-key\">[%s]</span> %s <span class=\"timestamp\">(%s)</span></li>\n",
+						issue.Key, issue.Summary, issue.Status))
+				}
+				sb.WriteString("</ul>\n")
+			}
+		}
+		detailedIssues = nil
+	case ReportModeTriage:
+		if len(report.Triage) > 0 {
+			sb.WriteString("<h2>New Bugs and Incidents</h2>\n")
+			sb.WriteString("<ul class=\"triage-issues\">\n")
+			for _, issue := range report.Triage {
+				sb.WriteString(fmt.Sprintf("<li><span class=\"issue-key\">[%s]</span> %s <span class=\"timestamp\">(%s)</span></li>\n",
+					issue.Key, issue.Summary, TriageLabel(issue)))
+			}
+			sb.WriteString("</ul>\n")
+		}
+		detailedIssues = nil
 	}
 
 	// Add issues by status
-	for status, issues := range statusGroups {
-		sb.WriteString(fmt.Sprintf("<h2>%s Issues</h2>\n", status))
+	for _, group := range groupByStatus(detailedIssues) {
+		status, issues := group.Status, group.Issues
+		sb.WriteString(fmt.Sprintf("<h2>%s Issues</h2>\n", htmlStatusBadge(status, group.Category)))
 		
 		for _, issue := range issues {
 			sb.WriteString("<div class=\"issue\">\n")
-			sb.WriteString(fmt.Sprintf("<h3><span class=\"issue-key\">[%s]</span> <span class=\"issue-summary\">%s</span></h3>\n", 
-				issue.Key, issue.Summary))
+			statusSince := ""
+			if line := statusSinceLine(issue, report.TimeRange.End, report.User.TimeZone); line != "" {
+				statusSince = fmt.Sprintf(" <span class=\"status-since\">%s</span>", line)
+			}
+			sb.WriteString(fmt.Sprintf("<h3><span class=\"issue-key\">[%s]</span> <span class=\"issue-summary\">%s</span>%s</h3>\n", 
+				issue.Key, issue.Summary, statusSince))
+
+			// Call out issues that were reopened before anything else
+			if line := reopeningLine(issue.Reopened); line != "" {
+				sb.WriteString(fmt.Sprintf("<p class=\"reopened\"><strong>Reopened</strong> %s</p>\n", line))
+			}
+			if line := escalationLine(issue.Escalation); line != "" {
+				sb.WriteString(fmt.Sprintf("<p class=\"escalated\"><strong>Priority escalated:</strong> %s</p>\n", line))
+			}
+
+			// Add the notes the user pinned to the issue
+			for _, note := range issue.Notes {
+				sb.WriteString(fmt.Sprintf("<p class=\"note\"><strong>Note:</strong> %s</p>\n", note.Text))
+			}
+
+			// Add permalinks to the issue and its change history
+			if links.Enabled() {
+				sb.WriteString(fmt.Sprintf("<p class=\"permalinks\"><a href=\"%s\">Issue</a> · <a href=\"%s\">History</a></p>\n",
+					html.EscapeString(links.Issue(issue.Key)), html.EscapeString(links.History(issue.Key))))
+			}
+
+			// Add the hierarchy path if it was resolved
+			if len(issue.Hierarchy) > 0 {
+				sb.WriteString(fmt.Sprintf("<p class=\"hierarchy\"><strong>Hierarchy:</strong> %s</p>\n", HierarchyLine(issue.Hierarchy)))
+			}
+
+			// Add the other issues mentioned in comments and changes
+			if len(issue.ReferencedIssues) > 0 {
+				sb.WriteString(fmt.Sprintf("<p class=\"references\"><strong>References:</strong> %s</p>\n",
+					htmlLinkKeys(strings.Join(referenceKeys(issue.ReferencedIssues), ", "), issue, links)))
+			}
+
+			// Add the change in watchers and votes if there was any
+			if line := attentionLine(issue.Attention); line != "" {
+				sb.WriteString(fmt.Sprintf("<p class=\"attention\"><strong>Attention:</strong> %s</p>\n", line))
+			}
+
+			// Add the activity summary if one was produced
+			if issue.ActivitySummary != "" {
+				sb.WriteString(fmt.Sprintf("<p class=\"activity-summary\">%s</p>\n", issue.ActivitySummary))
+			}
+
+			// In summary-only mode the summary line replaces the raw activity
+			if showSummaryOnly(report, issue) {
+				sb.WriteString("</div>\n")
+				continue
+			}
+			
+			// Add the status journey if transitions were summarized
+			if issue.Transitions != nil {
+				sb.WriteString(fmt.Sprintf("<p class=\"journey\"><strong>Status journey:</strong> %s</p>\n", transitionLine(issue.Transitions)))
+			}
+
+			// Add the description if the verbosity includes it
+			if issue.Description != "" {
+				sb.WriteString("<div class=\"description\">\n")
+				sb.WriteString("<h4>Description</h4>\n")
+				sb.WriteString(fmt.Sprintf("<p>%s</p>\n", issue.Description))
+				sb.WriteString("</div>\n")
+			}
 			
 			// Add changes section if there are any
 			if len(issue.Changes) > 0 {
 				sb.WriteString("<div class=\"changes\">\n")
 				sb.WriteString("<h4>Changes</h4>\n")
 				for _, change := range issue.Changes {
-					sb.WriteString("<div class=\"change\">\n")
-					sb.WriteString(fmt.Sprintf("<p><span class=\"author\">%s</span> changed <strong>%s</strong> from \"%s\" to \"%s\"</p>\n", 
-						change.Author, change.Field, change.FromValue, change.ToValue))
+					if change.IsCurrentUser && report.Options.IncludeOthersChanges {
+						sb.WriteString("<div class=\"change own\">\n")
+					} else {
+						sb.WriteString("<div class=\"change\">\n")
+					}
+					if report.Options.Verbosity.IncludeChangeDetails() && change.Diff != nil {
+						sb.WriteString(fmt.Sprintf("<p><span class=\"author\">%s</span> edited <strong>%s</strong>: <span class=\"diff\">%s</span></p>\n", 
+							changeAuthorLabel(report.Options, change), change.Field, htmlDiff(change.Diff)))
+					} else if report.Options.Verbosity.IncludeChangeDetails() {
+						sb.WriteString(fmt.Sprintf("<p><span class=\"author\">%s</span> changed <strong>%s</strong> from \"%s\" to \"%s\"</p>\n", 
+							changeAuthorLabel(report.Options, change), change.Field, htmlLinkKeys(change.FromValue, issue, links), htmlLinkKeys(change.ToValue, issue, links)))
+					} else {
+						sb.WriteString(fmt.Sprintf("<p><span class=\"author\">%s</span> changed <strong>%s</strong></p>\n", 
+							changeAuthorLabel(report.Options, change), change.Field))
+					}
 					sb.WriteString(fmt.Sprintf("<p class=\"timestamp\">%s</p>\n", 
-						change.Timestamp.Format("2006-01-02 15:04:05")))
+						eventTime(report.Options, change.Timestamp, change.AuthorTimeZone, "2006-01-02 15:04:05")))
 					sb.WriteString("</div>\n")
 				}
 				sb.WriteString("</div>\n")
 			}
 			
+			// Add label and component changes if there are any
+			if len(issue.CollectionChanges) > 0 {
+				sb.WriteString("<div class=\"collections\">\n")
+				sb.WriteString("<h4>Labels &amp; Components</h4>\n")
+				sb.WriteString("<ul>\n")
+				for _, change := range issue.CollectionChanges {
+					sb.WriteString(fmt.Sprintf("<li><span class=\"author\">%s</span> %s <span class=\"timestamp\">%s</span></li>\n",
+						change.Author, change.String(), eventTime(report.Options, change.Timestamp, change.AuthorTimeZone, "2006-01-02 15:04:05")))
+				}
+				sb.WriteString("</ul>\n")
+				sb.WriteString("</div>\n")
+			}
+			
 			// Add comments section if there are any
 			if len(issue.Comments) > 0 {
 				sb.WriteString("<div class=\"comments\">\n")
 				sb.WriteString("<h4>Comments</h4>\n")
 				for _, comment := range issue.Comments {
 					sb.WriteString("<div class=\"comment\">\n")
-					sb.WriteString(fmt.Sprintf("<p><span class=\"author\">%s</span></p>\n", comment.Author))
-					sb.WriteString(fmt.Sprintf("<p>%s</p>\n", comment.Content))
-					sb.WriteString(fmt.Sprintf("<p class=\"timestamp\">%s</p>\n", 
-						comment.Timestamp.Format("2006-01-02 15:04:05")))
+					sb.WriteString(fmt.Sprintf("<p>%s<span class=\"author\">%s</span></p>\n", htmlAvatar(comment.AuthorAvatarURL), comment.Author))
+					if comment.Content != "" {
+						sb.WriteString(fmt.Sprintf("<p>%s</p>\n", htmlLinkKeys(comment.Content, issue, links)))
+					}
+					timestamp := eventTime(report.Options, comment.Timestamp, comment.AuthorTimeZone, "2006-01-02 15:04:05")
+					if links.Enabled() {
+						// Link straight to the comment so reviewers can jump to it
+						timestamp = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(links.Comment(issue.Key, comment.ID)), timestamp)
+					}
+					if comment.Edited {
+						timestamp += " (edited)"
+					}
+					if comment.MentionsUser {
+						timestamp += " (mentions you)"
+					}
+					sb.WriteString(fmt.Sprintf("<p class=\"timestamp\">%s</p>\n", timestamp))
 					sb.WriteString("</div>\n")
 				}
 				sb.WriteString("</div>\n")
 			}
+
+			// Add the work logged within the range
+			if len(issue.Worklogs) > 0 {
+				sb.WriteString("<div class=\"worklogs\">\n")
+				sb.WriteString("<h4>Work Logged</h4>\n")
+				sb.WriteString("<ul>\n")
+				for _, worklog := range issue.Worklogs {
+					sb.WriteString(fmt.Sprintf("<li><span class=\"author\">%s</span> logged %s <span class=\"timestamp\">%s</span>",
+						html.EscapeString(worklog.Author), formatDuration(worklog.TimeSpent),
+						eventTime(report.Options, worklog.Started, worklog.AuthorTimeZone, "2006-01-02 15:04:05")))
+					if worklog.Comment != "" {
+						sb.WriteString(": " + html.EscapeString(worklog.Comment))
+					}
+					sb.WriteString("</li>\n")
+				}
+				sb.WriteString("</ul>\n")
+				sb.WriteString("</div>\n")
+			}
+
+			// Add remote links added within the range, such as a linked design doc
+			if len(issue.RemoteLinks) > 0 {
+				sb.WriteString("<div class=\"remote-links\">\n")
+				sb.WriteString("<h4>Links Added</h4>\n")
+				sb.WriteString("<ul>\n")
+				for _, link := range issue.RemoteLinks {
+					sb.WriteString(fmt.Sprintf("<li><a href=\"%s\">%s</a> <span class=\"author\">%s</span> <span class=\"timestamp\">%s</span></li>\n",
+						html.EscapeString(link.URL), link.Line(), link.AddedBy, eventTime(report.Options, link.AddedAt, "", "2006-01-02 15:04:05")))
+				}
+				sb.WriteString("</ul>\n")
+				sb.WriteString("</div>\n")
+			}
+
+			// Add action items section if there are any
+			if !issue.ActionItems.IsEmpty() {
+				sb.WriteString("<div class=\"action-items\">\n")
+				sb.WriteString("<h4>Action Items</h4>\n")
+				sb.WriteString("<ul>\n")
+				for _, item := range issue.ActionItems.Completed {
+					sb.WriteString(fmt.Sprintf("<li class=\"done\">&#9745; %s</li>\n", item.Text))
+				}
+				for _, item := range issue.ActionItems.Added {
+					sb.WriteString(fmt.Sprintf("<li class=\"open\">&#9744; %s</li>\n", item.Text))
+				}
+				sb.WriteString("</ul>\n")
+				sb.WriteString("</div>\n")
+			}
 			
 			sb.WriteString("</div>\n")
 		}
 	}
 	
+	// Add supplementary sections such as blockers and carry-over work
+	for _, section := range supplementarySections(report) {
+		sb.WriteString(fmt.Sprintf("<h2>%s</h2>\n", section.Title))
+		sb.WriteString("<ul class=\"supplementary\">\n")
+		for _, issue := range section.Issues {
+			sb.WriteString(fmt.Sprintf("<li><span class=\"issue-key\">[%s]</span> %s <span class=\"timestamp\">(%s)</span>",
+				issue.Key, issue.Summary, issue.Status))
+			for _, note := range issue.Notes {
+				sb.WriteString(fmt.Sprintf(" <span class=\"note\">%s</span>", note.Text))
+			}
+			sb.WriteString("</li>\n")
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	// Add the work due soon
+	if len(report.DueSoon) > 0 {
+		sb.WriteString("<h2>Due Soon</h2>\n")
+		sb.WriteString("<ul class=\"due-soon\">\n")
+		for _, issue := range report.DueSoon {
+			sb.WriteString(fmt.Sprintf("<li><span class=\"issue-key\">[%s]</span> %s <span class=\"timestamp\">(%s)</span></li>\n",
+				issue.Key, issue.Summary, dueLine(issue, report.TimeRange)))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	// Add the work handed to and away from the user
+	if !report.Handoffs.IsEmpty() {
+		sb.WriteString("<h2>Handoffs</h2>\n")
+		for _, section := range report.Handoffs.Sections() {
+			sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", section.Title))
+			sb.WriteString("<ul class=\"handoffs\">\n")
+			for _, issue := range section.Issues {
+				sb.WriteString(fmt.Sprintf("<li><span class=\"issue-key\">[%s]</span> %s <span class=\"timestamp\">(%s)</span></li>\n",
+					issue.Key, issue.Summary, handoffLine(issue.Handoff, report.User)))
+			}
+			sb.WriteString("</ul>\n")
+		}
+	}
+
+	// Add the velocity statistics
+	if report.Stats != nil {
+		sb.WriteString("<h2>Stats</h2>\n")
+		sb.WriteString("<table class=\"stats\">\n")
+		sb.WriteString("<tr><th>Window</th><th>Issues Completed</th><th>Points Completed</th><th>Avg Cycle Time</th></tr>\n")
+		for _, window := range report.Stats.Windows() {
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%s</td><td>%s</td></tr>\n", window.WindowLabel(), window.IssuesCompleted,
+				formatPoints(window.PointsCompleted), formatDuration(window.AverageCycleTime)))
+		}
+		sb.WriteString("</table>\n")
+
+		if measured := measuredIssues(report.Issues); len(measured) > 0 {
+			sb.WriteString("<h3>Cycle Time per Issue</h3>\n")
+			sb.WriteString("<table class=\"stats\">\n")
+			sb.WriteString("<tr><th>Issue</th><th>Cycle Time</th><th>Lead Time</th></tr>\n")
+			for _, issue := range measured {
+				sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td></tr>\n", issue.Key,
+					formatDuration(issue.CycleTime), formatDuration(issue.LeadTime)))
+			}
+			sb.WriteString("</table>\n")
+		}
+	}
+
+	// Add the activity heatmap
+	if report.Heatmap != nil {
+		sb.WriteString("<h2>Activity by Hour</h2>\n")
+		sb.WriteString(report.Heatmap.HTML())
+		sb.WriteString(fmt.Sprintf("<p class=\"activity-summary\">%s</p>\n", report.Heatmap.SummaryLine()))
+	}
+
+	// List the problems that left data out of the report
+	if warnings := footerWarnings(report.Warnings); len(warnings) > 0 {
+		sb.WriteString("<h2>Warnings</h2>\n<ul class=\"warnings\">\n")
+		for _, warning := range warnings {
+			sb.WriteString(fmt.Sprintf("<li>%s</li>\n", html.EscapeString(warning.Message)))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	// Close with how the report was generated
+	if generation := report.Generation; generation != nil && report.Options.Verbosity.IncludeMetadata() {
+		sb.WriteString("<footer class=\"generation\">\n")
+		sb.WriteString(fmt.Sprintf("<p>%s</p>\n", html.EscapeString(generation.Line())))
+		if generation.Query != "" {
+			sb.WriteString(fmt.Sprintf("<p>Query:</p>\n<pre><code>%s</code></pre>\n", html.EscapeString(generation.Query)))
+		}
+		sb.WriteString("</footer>\n")
+	}
+	
 	// Close HTML document
 	sb.WriteString("</body>\n</html>")
 
@@ -395,18 +2319,367 @@ func (f *HTMLFormatter) Format(report *ActivityReport) (*FormattedContent, error
 	}, nil
 }
 
+// transitionLine renders a status journey with its transition count
+func transitionLine(transitions *TransitionSummary) string {
+	count := fmt.Sprintf("%d transitions", transitions.Count)
+	if transitions.Count == 1 {
+		count = "1 transition"
+	}
+
+	if len(transitions.Journey) == 0 {
+		return count
+	}
+	return fmt.Sprintf("%s (%s)", transitions.String(), count)
+}
+
+// changeAuthorLabel returns the change author annotated with their role, e.g.
+// "QA (reporter)", or as the user when other people's changes are shown too
+func changeAuthorLabel(options ReportOptions, change Change) string {
+	switch {
+	case change.IsCurrentUser && options.IncludeOthersChanges:
+		return fmt.Sprintf("%s (you)", change.Author)
+	case change.AuthorRole == "":
+		return change.Author
+	default:
+		return fmt.Sprintf("%s (%s)", change.Author, change.AuthorRole)
+	}
+}
+
+// actionItemTexts returns the text of each action item
+func actionItemTexts(items []ActionItem) []string {
+	texts := make([]string, 0, len(items))
+	for _, item := range items {
+		texts = append(texts, item.Text)
+	}
+	return texts
+}
+
+// htmlDiff renders a word-level diff with removed and added words marked up
+func htmlDiff(diff TextDiff) string {
+	parts := make([]string, 0, len(diff))
+	for _, segment := range diff {
+		switch segment.Op {
+		case DiffDelete:
+			parts = append(parts, "<del>"+segment.Text+"</del>")
+		case DiffInsert:
+			parts = append(parts, "<ins>"+segment.Text+"</ins>")
+		default:
+			parts = append(parts, segment.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// htmlAvatar renders an avatar image, or nothing when the URL is unknown
+func htmlAvatar(url string) string {
+	if url == "" {
+		return ""
+	}
+	return fmt.Sprintf("<img class=\"avatar\" src=\"%s\" alt=\"\">", html.EscapeString(url))
+}
+
 // XML structures for proper marshaling
+
+
 type jiraXMLReport struct {
-	XMLName xml.Name   `xml:"jira_report"`
-	Issues  []xmlIssue `xml:"issue"`
+	XMLName xml.Name `xml:"jira_report"`
+	xmlGeneration
+	Truncation  *xmlTruncation        `xml:"truncation,omitempty"`
+	AsOf        string                `xml:"as_of,omitempty"`
+	Sprint      *xmlSprint            `xml:"sprint,omitempty"`
+	Issues      []xmlIssue            `xml:"issue"`
+	Pinned      []xmlIssueRef         `xml:"pinned>issue,omitempty"`
+	Blockers    []xmlIssueRef         `xml:"blockers>issue,omitempty"`
+	CarryOver   []xmlIssueRef         `xml:"carry_over>issue,omitempty"`
+	Filed       []xmlIssueRef         `xml:"filed>issue,omitempty"`
+	DueSoon     []xmlDueIssue         `xml:"due_soon>issue,omitempty"`
+	Handoffs    *xmlHandoffs          `xml:"handoffs,omitempty"`
+	Epics       []xmlEpicRollup       `xml:"epics>epic,omitempty"`
+	Initiatives []xmlInitiativeRollup `xml:"initiatives>initiative,omitempty"`
+	Components  []xmlComponentDigest  `xml:"components>component,omitempty"`
+	Release     *xmlReleaseNotes      `xml:"release,omitempty"`
+	Comparison  *xmlReleaseComparison `xml:"comparison,omitempty"`
+	Triage      []xmlTriageIssue      `xml:"triage>issue,omitempty"`
+	Authors     []xmlAuthor           `xml:"authors>author,omitempty"`
+	Heatmap     *xmlHeatmap           `xml:"heatmap,omitempty"`
+	Stats       []xmlVelocity         `xml:"stats>window,omitempty"`
+	Warnings    []xmlWarning          `xml:"warnings>warning,omitempty"`
+}
+
+// xmlGeneration records how the report was generated as attributes of the root
+type xmlGeneration struct {
+	GeneratedAt   string `xml:"generated_at,attr,omitempty"`
+	PluginVersion string `xml:"plugin_version,attr,omitempty"`
+	InstanceURL   string `xml:"instance_url,attr,omitempty"`
+	Query         string `xml:"query,attr,omitempty"`
+	IssueCount    *int   `xml:"issue_count,attr"`
+}
+
+type xmlWarning struct {
+	Kind    string `xml:"kind,attr"`
+	Message string `xml:",chardata"`
+}
+
+type xmlVelocity struct {
+	Start                 string  `xml:"start,attr"`
+	End                   string  `xml:"end,attr"`
+	Current               bool    `xml:"current,attr,omitempty"`
+	IssuesCompleted       int     `xml:"issues_completed"`
+	PointsCompleted       float64 `xml:"points_completed"`
+	AverageCycleTimeHours float64 `xml:"average_cycle_time_hours,omitempty"`
+}
+
+type xmlHeatmap struct {
+	TimeZone   string        `xml:"time_zone,attr"`
+	Total      int           `xml:"total"`
+	AfterHours int           `xml:"after_hours"`
+	Hours      []xmlHourSlot `xml:"hour"`
+}
+
+type xmlHourSlot struct {
+	Hour  int `xml:"value,attr"`
+	Count int `xml:"count,attr"`
+}
+
+type xmlAuthor struct {
+	AccountID   string `xml:"account_id,attr"`
+	DisplayName string `xml:"display_name"`
+	Email       string `xml:"email,omitempty"`
+	TimeZone    string `xml:"time_zone,omitempty"`
+}
+
+type xmlComponentDigest struct {
+	Name           string        `xml:"name,attr"`
+	IssuesAdvanced int           `xml:"issues_advanced"`
+	Comments       int           `xml:"comments"`
+	Changes        int           `xml:"changes"`
+	Contributors   []string      `xml:"contributors>contributor"`
+	Issues         []xmlIssueRef `xml:"issue"`
+}
+
+type xmlReleaseNotes struct {
+	Version string            `xml:"version,attr"`
+	Groups  []xmlReleaseGroup `xml:"group"`
+}
+
+type xmlReleaseComparison struct {
+	From      string        `xml:"from,attr"`
+	To        string        `xml:"to,attr"`
+	Completed []xmlIssueRef `xml:"completed>issue"`
+	New       []xmlIssueRef `xml:"new>issue"`
+	Reopened  []xmlIssueRef `xml:"reopened>issue"`
+	Slipped   []xmlIssueRef `xml:"slipped>issue"`
+}
+
+type xmlReleaseGroup struct {
+	Type   string           `xml:"type,attr"`
+	Issues []xmlReleaseNote `xml:"issue"`
+}
+
+type xmlTriageIssue struct {
+	Key      string `xml:"key"`
+	Status   string `xml:"status"`
+	Summary  string `xml:"summary"`
+	Type     string `xml:"type,omitempty"`
+	Priority string `xml:"priority,omitempty"`
+	Reporter string `xml:"reporter,omitempty"`
+}
+
+type xmlReleaseNote struct {
+	Key        string `xml:"key"`
+	Status     string `xml:"status"`
+	Summary    string `xml:"summary"`
+	Resolution string `xml:"resolution"`
+}
+
+// newXMLEpicRollup converts an epic rollup to its XML structure
+func newXMLEpicRollup(rollup EpicRollup) xmlEpicRollup {
+	xmlRollup := xmlEpicRollup{
+		IssuesAdvanced: rollup.IssuesAdvanced,
+		Comments:       rollup.Comments,
+		Changes:        rollup.Changes,
+	}
+	if rollup.Epic != nil {
+		xmlRollup.Key = rollup.Epic.Key
+		xmlRollup.Summary = rollup.Epic.Summary
+	}
+	for _, issue := range rollup.Issues {
+		xmlRollup.Issues = append(xmlRollup.Issues, xmlIssueRef{
+			Key:     issue.Key,
+			Status:  issue.Status,
+			Summary: issue.Summary,
+		})
+	}
+	return xmlRollup
+}
+
+type xmlInitiativeRollup struct {
+	Key            string          `xml:"key,attr,omitempty"`
+	Summary        string          `xml:"summary,omitempty"`
+	IssuesAdvanced int             `xml:"issues_advanced"`
+	Comments       int             `xml:"comments"`
+	Changes        int             `xml:"changes"`
+	Epics          []xmlEpicRollup `xml:"epic"`
+}
+
+type xmlEpicRollup struct {
+	Key            string        `xml:"key,attr,omitempty"`
+	Summary        string        `xml:"summary,omitempty"`
+	IssuesAdvanced int           `xml:"issues_advanced"`
+	Comments       int           `xml:"comments"`
+	Changes        int           `xml:"changes"`
+	Issues         []xmlIssueRef `xml:"issue"`
+}
+
+type xmlIssueRef struct {
+	Key     string   `xml:"key"`
+	Status  string   `xml:"status"`
+	Summary string   `xml:"summary"`
+	Type    string   `xml:"type,omitempty"`
+	Notes   []string `xml:"note,omitempty"`
 }
 
 type xmlIssue struct {
 	Key      string      `xml:"key"`
 	Status   string      `xml:"status"`
 	Summary  string      `xml:"summary"`
+	ActivitySummary string `xml:"activity_summary,omitempty"`
+	Description string `xml:"description,omitempty"`
 	Comments xmlComments `xml:"comments"`
 	Changelog xmlChangelog `xml:"changelog"`
+	ActionItems *xmlActionItems `xml:"action_items,omitempty"`
+	Transitions *xmlTransitions `xml:"status_journey,omitempty"`
+	CollectionChanges []xmlCollectionChange `xml:"collection_changes>collection_change,omitempty"`
+	Hierarchy []xmlIssueRef `xml:"hierarchy>issue,omitempty"`
+	CycleTimeHours float64 `xml:"cycle_time_hours,omitempty"`
+	LeadTimeHours  float64 `xml:"lead_time_hours,omitempty"`
+	Attention      *xmlAttention `xml:"attention,omitempty"`
+	RemoteLinks    []xmlRemoteLink `xml:"remote_links>link,omitempty"`
+	Worklogs       []xmlWorklog    `xml:"worklogs>worklog,omitempty"`
+	Reopened       *xmlReopening   `xml:"reopened,omitempty"`
+	Escalation     *xmlEscalation  `xml:"priority_escalation,omitempty"`
+	Notes          []string        `xml:"note,omitempty"`
+	References     []xmlIssueRef   `xml:"referenced_issues>issue,omitempty"`
+}
+
+type xmlDueIssue struct {
+	Key     string `xml:"key"`
+	Status  string `xml:"status"`
+	Summary string `xml:"summary"`
+	DueDate string `xml:"due_date"`
+	Overdue bool   `xml:"overdue,attr,omitempty"`
+}
+
+type xmlTruncation struct {
+	Shown  int    `xml:"shown,attr"`
+	Total  int    `xml:"total,attr"`
+	Notice string `xml:",chardata"`
+}
+
+type xmlSprint struct {
+	ID            int           `xml:"id,attr"`
+	Name          string        `xml:"name,attr"`
+	PointsAdded   float64       `xml:"points_added"`
+	PointsRemoved float64       `xml:"points_removed"`
+	Added         []xmlIssueRef `xml:"added>issue"`
+	Removed       []xmlIssueRef `xml:"removed>issue"`
+}
+
+type xmlHandoffs struct {
+	Incoming []xmlHandoff `xml:"incoming>issue"`
+	Outgoing []xmlHandoff `xml:"outgoing>issue"`
+}
+
+type xmlHandoff struct {
+	Key      string `xml:"key"`
+	Status   string `xml:"status"`
+	Summary  string `xml:"summary"`
+	At       string `xml:"at,attr"`
+	AtMillis int64  `xml:"at_ms,attr"`
+	By       string `xml:"by,attr"`
+	From     string `xml:"from"`
+	To       string `xml:"to"`
+}
+
+// newXMLHandoff converts an issue that changed hands to its XML structure
+func newXMLHandoff(report *ActivityReport, issue Issue) xmlHandoff {
+	return xmlHandoff{
+		Key:      issue.Key,
+		Status:   issue.Status,
+		Summary:  issue.Summary,
+		At:       machineTime(report.Options, issue.Handoff.Timestamp, ""),
+		AtMillis: epochMillis(issue.Handoff.Timestamp),
+		By:       issue.Handoff.Author,
+		From:     assigneeName(issue.Handoff.FromAssignee),
+		To:       assigneeName(issue.Handoff.ToAssignee),
+	}
+}
+
+type xmlEscalation struct {
+	At       string `xml:"at,attr"`
+	AtMillis int64  `xml:"at_ms,attr"`
+	By       string `xml:"by,attr"`
+	Role     string `xml:"role,attr,omitempty"`
+	From     string `xml:"from"`
+	To       string `xml:"to"`
+}
+
+type xmlReopening struct {
+	At         string `xml:"at,attr"`
+	AtMillis   int64  `xml:"at_ms,attr"`
+	By         string `xml:"by,attr"`
+	Role       string `xml:"role,attr,omitempty"`
+	FromStatus string `xml:"from_status"`
+	ToStatus   string `xml:"to_status"`
+}
+
+type xmlRemoteLink struct {
+	URL          string  `xml:"url,attr"`
+	Application  string  `xml:"application,attr,omitempty"`
+	Relationship string  `xml:"relationship,attr,omitempty"`
+	Title        string  `xml:"title"`
+	AddedAt      xmlTime `xml:"added_at"`
+	AddedBy      string  `xml:"added_by"`
+}
+
+type xmlAttention struct {
+	Watchers       int    `xml:"watchers,attr"`
+	Votes          int    `xml:"votes,attr"`
+	WatchersGained int    `xml:"watchers_gained,attr"`
+	WatchersLost   int    `xml:"watchers_lost,attr"`
+	VotesChange    int    `xml:"votes_change,attr"`
+	Since          string `xml:"since,attr,omitempty"`
+}
+
+type xmlCollectionChange struct {
+	Field     string   `xml:"field,attr"`
+	Timestamp xmlTime  `xml:"timestamp"`
+	Author    string   `xml:"author"`
+	Added     []string `xml:"added"`
+	Removed   []string `xml:"removed"`
+}
+
+type xmlTransitions struct {
+	Count    int      `xml:"count,attr"`
+	Statuses []string `xml:"status"`
+}
+
+type xmlActionItems struct {
+	Completed []string `xml:"completed>item"`
+	Added     []string `xml:"added>item"`
+}
+
+// xmlTime is an event timestamp in RFC 3339, with its milliseconds since the
+// Unix epoch as an attribute for sorting
+type xmlTime struct {
+	Millis int64  `xml:"ms,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// newXMLTime formats an event timestamp, in author local time when the
+// report asks for it
+func newXMLTime(options ReportOptions, timestamp time.Time, timeZone string) xmlTime {
+	return xmlTime{Millis: epochMillis(timestamp), Value: machineTime(options, timestamp, timeZone)}
 }
 
 type xmlComments struct {
@@ -414,9 +2687,18 @@ type xmlComments struct {
 }
 
 type xmlComment struct {
-	Timestamp string `xml:"timestamp"`
-	Author    string `xml:"author"`
-	Content   string `xml:"content"`
+	Edited    bool   `xml:"edited,attr,omitempty"`
+	Mentions  bool    `xml:"mentions_user,attr,omitempty"`
+	Timestamp xmlTime `xml:"timestamp"`
+	Author    string  `xml:"author"`
+	Content   string  `xml:"content"`
+}
+
+type xmlWorklog struct {
+	TimeSpentSeconds int     `xml:"time_spent_seconds,attr"`
+	Started          xmlTime `xml:"started"`
+	Author           string  `xml:"author"`
+	Comment          string  `xml:"comment,omitempty"`
 }
 
 type xmlChangelog struct {
@@ -424,9 +2706,36 @@ type xmlChangelog struct {
 }
 
 type xmlChange struct {
-	Timestamp string `xml:"timestamp"`
-	Author    string `xml:"author"`
-	Field     string `xml:"field"`
-	From      string `xml:"from"`
-	To        string `xml:"to"`
+	Own       bool    `xml:"by_current_user,attr,omitempty"`
+	Timestamp xmlTime `xml:"timestamp"`
+	Author    string  `xml:"author"`
+	Role      string  `xml:"author_role,omitempty"`
+	Field     string  `xml:"field"`
+	From      string  `xml:"from"`
+	To        string  `xml:"to"`
+	Diff      string  `xml:"diff,omitempty"`
 } 
+
+// statusGroup is the issues of a report in one status
+type statusGroup struct {
+	Status   string
+	Category string // The status category, when known
+	Issues   []Issue
+}
+
+// groupByStatus groups issues by status. Groups are ordered by their first
+// issue and keep the issue order, so the output is the same on every run.
+func groupByStatus(issues []Issue) []statusGroup {
+	groups := make([]statusGroup, 0)
+	index := make(map[string]int)
+	for _, issue := range issues {
+		i, ok := index[issue.Status]
+		if !ok {
+			i = len(groups)
+			index[issue.Status] = i
+			groups = append(groups, statusGroup{Status: issue.Status, Category: issue.StatusCategory})
+		}
+		groups[i].Issues = append(groups[i].Issues, issue)
+	}
+	return groups
+}
@@ -110,7 +110,7 @@ func TestJSONFormatter_Format(t *testing.T) {
 				},
 				Issues: []Issue{},
 			},
-			expectedStr: "{}",
+			expectedStr: `"issues": []`,
 		},
 		{
 			name: "Report with issues",
@@ -337,3 +337,85 @@ func TestHTMLFormatter_Format(t *testing.T) {
 		})
 	}
 } 
+
+func TestFormatters_Avatars(t *testing.T) {
+	report := &ActivityReport{
+		TimeRange: TimeRange{
+			Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		User: User{DisplayName: "Test User", AvatarURL: "https://avatar/user?s=48&d=mm"},
+		Issues: []Issue{
+			{
+				Key:     "JIRA-123",
+				Summary: "Test Issue",
+				Status:  "In Progress",
+				Comments: []Comment{
+					{
+						Timestamp:       time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC),
+						Author:          "Other User",
+						Content:         "Looks good",
+						AuthorAvatarURL: "https://avatar/other",
+					},
+				},
+			},
+		},
+		Options: DefaultReportOptions(),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name      string
+		formatter ReportFormatter
+		expected  []string
+	}{
+		{
+			name:      "HTML",
+			formatter: NewHTMLFormatter(),
+			expected: []string{
+				`<img class="avatar" src="https://avatar/user?s=48&amp;d=mm" alt="">Test User`,
+				`<img class="avatar" src="https://avatar/other" alt=""><span class="author">Other User</span>`,
+			},
+		},
+		{
+			name:      "JSON",
+			formatter: NewJSONFormatter(),
+			expected: []string{
+				`"avatarUrl": "https://avatar/user?s=48\u0026d=mm"`,
+				`"authorAvatarUrl": "https://avatar/other"`,
+			},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected content to contain '%s', got '%s'", expected, result.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupByStatus(t *testing.T) {
+	issues := []Issue{
+		{Key: "JIRA-1", Status: "In Review"},
+		{Key: "JIRA-2", Status: "In Progress"},
+		{Key: "JIRA-3", Status: "In Review"},
+	}
+
+	groups := groupByStatus(issues)
+
+	if len(groups) != 2 || groups[0].Status != "In Review" || groups[1].Status != "In Progress" {
+		t.Fatalf("Expected groups in order of their first issue, got %+v", groups)
+	}
+	if len(groups[0].Issues) != 2 || groups[0].Issues[0].Key != "JIRA-1" || groups[0].Issues[1].Key != "JIRA-3" {
+		t.Errorf("Expected the issue order to be kept, got %+v", groups[0].Issues)
+	}
+}
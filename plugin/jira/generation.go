@@ -0,0 +1,71 @@
+package jira
+
+import (
+	"strings"
+	"time"
+)
+
+// GenerationInfo describes how a report was generated, so that an archived
+// report can be traced back to the plugin, Jira instance and query behind it
+type GenerationInfo struct {
+	GeneratedAt   time.Time
+	PluginVersion string
+	InstanceURL   string
+	Query         string // JQL of the activity search, one line per project searched
+	IssueCount    int
+}
+
+// Line renders the generation in one line, e.g. "Generated 2023-01-03T09:00:00Z
+// by daiv-jira v1.4.0 from https://example.atlassian.net, 3 issues"
+func (g *GenerationInfo) Line() string {
+	var sb strings.Builder
+	sb.WriteString("Generated " + g.GeneratedAt.UTC().Format(time.RFC3339) + " by daiv-jira")
+	if g.PluginVersion != "" {
+		sb.WriteString(" " + g.PluginVersion)
+	}
+	if g.InstanceURL != "" {
+		sb.WriteString(" from " + g.InstanceURL)
+	}
+	sb.WriteString(", " + pluralize(g.IssueCount, "issue", "issues"))
+	return sb.String()
+}
+
+// SetGenerationInfo sets the plugin version and Jira instance recorded on
+// every report; the time, query and issue count are filled in per report
+func (s *ActivityService) SetGenerationInfo(info GenerationInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.generation = info
+}
+
+// recordGeneration records how the report was generated, with the JQL of the
+// activity search when the report came from it
+func (s *ActivityService) recordGeneration(report *ActivityReport, activitySearch bool) {
+	s.mu.RLock()
+	info := s.generation
+	s.mu.RUnlock()
+
+	info.GeneratedAt = s.Now()
+	info.IssueCount = reportedIssueCount(report)
+	if activitySearch {
+		query, err := s.repository.ActivityQuery(report.TimeRange)
+		if err != nil {
+			// The report was built from the same query, so this does not happen in practice
+			s.logger.Printf("failed to record the report query: %v", err)
+		}
+		info.Query = query
+	}
+	report.Generation = &info
+}
+
+// reportedIssueCount counts the issues the report is about: the issues with
+// activity, or the triaged or released issues in those modes
+func reportedIssueCount(report *ActivityReport) int {
+	count := len(report.Issues) + len(report.Triage)
+	if report.Release != nil {
+		for _, group := range report.Release.Groups {
+			count += len(group.Issues)
+		}
+	}
+	return count
+}
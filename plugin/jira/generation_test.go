@@ -0,0 +1,136 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestGenerationInfo_Line(t *testing.T) {
+	generatedAt := time.Date(2023, 1, 3, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		info     GenerationInfo
+		expected string
+	}{
+		{
+			name:     "Complete",
+			info:     GenerationInfo{GeneratedAt: generatedAt, PluginVersion: "v1.4.0", InstanceURL: "https://example.atlassian.net", IssueCount: 3},
+			expected: "Generated 2023-01-03T09:30:00Z by daiv-jira v1.4.0 from https://example.atlassian.net, 3 issues",
+		},
+		{
+			name:     "Without version or instance",
+			info:     GenerationInfo{GeneratedAt: generatedAt, IssueCount: 1},
+			expected: "Generated 2023-01-03T09:30:00Z by daiv-jira, 1 issue",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if line := tc.info.Line(); line != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, line)
+			}
+		})
+	}
+}
+
+func TestActivityService_RecordsGeneration(t *testing.T) {
+	var queried TimeRange
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "JIRA-1", Summary: "First", Status: "Done"}, {Key: "JIRA-2", Summary: "Second", Status: "Done"}}, nil
+		},
+		MockActivityQuery: func(timeRange TimeRange) (string, error) {
+			queried = timeRange
+			return `project = "JIRA"`, nil
+		},
+	}
+
+	service := NewActivityService(mockRepo)
+	service.SetGenerationInfo(GenerationInfo{PluginVersion: "v1.4.0", InstanceURL: "https://example.atlassian.net"})
+
+	before := time.Now()
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	generation := report.Generation
+	if generation == nil {
+		t.Fatal("Expected the generation to be recorded")
+	}
+	if generation.PluginVersion != "v1.4.0" || generation.InstanceURL != "https://example.atlassian.net" {
+		t.Errorf("Expected the configured version and instance, got %+v", generation)
+	}
+	if generation.Query != `project = "JIRA"` || !queried.Start.Equal(report.TimeRange.Start) {
+		t.Errorf("Expected the query of the report range, got %q for %v", generation.Query, queried)
+	}
+	if generation.IssueCount != 2 {
+		t.Errorf("Expected 2 issues, got %d", generation.IssueCount)
+	}
+	if generation.GeneratedAt.Before(before) {
+		t.Errorf("Expected the time the report was generated, got %v", generation.GeneratedAt)
+	}
+}
+
+func TestJiraAPIRepository_ActivityQuery(t *testing.T) {
+	options := DefaultQueryOptions()
+	options.JQLTemplate = "project = %s AND updatedDate >= %s AND updatedDate < %s"
+	options.Projects = []string{"PAY", "OPS"}
+	options.AssigneeCurrentUser = false
+	options.ExcludeStatuses = nil
+	options.InOpenSprints = false
+
+	repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: options}}
+
+	query, err := repo.ActivityQuery(TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(query, "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], `project = "PAY"`) || !strings.HasPrefix(lines[1], `project = "OPS"`) {
+		t.Errorf("Expected a query per project, got %q", query)
+	}
+
+	// Dates are written in the time zone Jira reads them in: midnight in Berlin
+	// is 23:00 of the previous day in UTC
+	berlin := time.FixedZone("CET", 60*60)
+	repo.config.QueryOptions.TimeZone = time.UTC
+	query, err = repo.ActivityQuery(TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, berlin),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, berlin),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(query, `updatedDate >= "2023-01-01 23:00" AND updatedDate < "2023-01-02 23:00"`) {
+		t.Errorf("Expected the dates in UTC, got %q", query)
+	}
+}
+
+func TestXMLFormatter_EmptyReportGeneration(t *testing.T) {
+	report := &ActivityReport{Generation: &GenerationInfo{GeneratedAt: time.Date(2023, 1, 3, 9, 30, 0, 0, time.UTC), IssueCount: 0}}
+
+	content, err := NewXMLFormatter().Format(report)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `<jira_report generated_at="2023-01-03T09:30:00.000Z" issue_count="0"></jira_report>`
+	if content.Content != expected {
+		t.Errorf("Expected %q, got %q", expected, content.Content)
+	}
+}
@@ -0,0 +1,200 @@
+package jira
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Run `go test ./plugin/jira -run TestFormatters_Golden -update` after an
+// intended output change, and review the diff of the golden files.
+var updateGolden = flag.Bool("update", false, "rewrite the golden files with the current output")
+
+// goldenReport builds a report exercising every section the formatters render
+func goldenReport() *ActivityReport {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2023, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+	epic := &IssueRef{Key: "PAY-10", Summary: "Checkout", Status: "In Progress", Type: IssueTypeEpic}
+	initiative := &IssueRef{Key: "INIT-1", Summary: "Payments", Type: "Initiative"}
+
+	options := DefaultReportOptions()
+	options.Verbosity = VerbosityFull
+	options.IncludeOthersChanges = true
+	options.LinkBaseURL = "https://example.atlassian.net"
+
+	report := &ActivityReport{
+		TimeRange: TimeRange{Start: at(2, 0, 0), End: at(3, 0, 0)},
+		User:      User{AccountID: "user123", DisplayName: "Test User", Email: "test@example.com"},
+		Issues: []Issue{
+			{
+				Key:            "PAY-12",
+				Summary:        "Card form | validation",
+				Status:         "In Review",
+				StatusCategory: StatusCategoryInProgress,
+				Type:           "Story",
+				Description:    "Validate the card number.\n\n- [ ] Luhn check\n- [x] Expiry date",
+				Reporter:       User{AccountID: "qa1", DisplayName: "QA"},
+				Assignee:       User{AccountID: "user123", DisplayName: "Test User"},
+				Epic:           epic,
+				Hierarchy:      []IssueRef{*initiative, *epic},
+				Initiative:     initiative,
+				Components:     []string{"Web"},
+				StoryPoints:    3,
+				StatusSince:    at(2, 9, 0),
+				Comments: []Comment{
+					{ID: "10042", Timestamp: at(2, 9, 30), Author: "Test User", AuthorAccountID: "user123", Content: "Ready for **review**, refunds follow in PAY-14"},
+					{ID: "10043", Timestamp: at(2, 21, 5), Author: "QA", AuthorAccountID: "qa1", Content: "[~accountid:user123] found an edge case <script>", Edited: true, MentionsUser: true},
+				},
+				Changes: []Change{
+					{Timestamp: at(2, 9, 0), Author: "Test User", AuthorAccountID: "user123", IsCurrentUser: true, Field: "status", FromValue: "In Progress", ToValue: "In Review"},
+					{Timestamp: at(2, 10, 0), Author: "QA", AuthorAccountID: "qa1", AuthorRole: RoleReporter, Field: "priority", FromValue: "Medium", ToValue: "High"},
+					{Timestamp: at(2, 10, 30), Author: "Test User", AuthorAccountID: "user123", IsCurrentUser: true, Field: "description", FromValue: "Reject cards that fail the Luhn check", ToValue: "Reject cards that fail the Luhn or expiry check",
+						Diff: wordDiff("Reject cards that fail the Luhn check", "Reject cards that fail the Luhn or expiry check")},
+				},
+				CollectionChanges: []CollectionChange{
+					{Timestamp: at(2, 9, 15), Author: "Test User", Field: LabelsField, Added: []string{"frontend"}, Removed: []string{"triage"},
+						Source: Change{Timestamp: at(2, 9, 15), Author: "Test User", AuthorAccountID: "user123", IsCurrentUser: true, Field: "labels", FromValue: "triage", ToValue: "frontend"}},
+				},
+				ActionItems: ActionItemSummary{
+					Added:     []ActionItem{{Text: "Luhn check"}},
+					Completed: []ActionItem{{Text: "Expiry date", Done: true}},
+				},
+				ActivitySummary: "Moved to review and picked up an edge case",
+				Transitions:     &TransitionSummary{Journey: []string{"In Progress", "In Review"}, Count: 1},
+				CycleTime:       30 * time.Hour,
+				LeadTime:        72 * time.Hour,
+				Attention:       &AttentionChange{Since: at(1, 9, 0), Watchers: 4, Votes: 1, WatchersGained: 2, VotesChange: 1},
+				Reopened:        &Reopening{Timestamp: at(2, 8, 30), Author: "QA", AuthorAccountID: "qa1", AuthorRole: RoleReporter, FromStatus: "Done", ToStatus: "In Progress"},
+				Escalation:      &PriorityEscalation{Timestamp: at(2, 10, 0), Author: "QA", AuthorAccountID: "qa1", AuthorRole: RoleReporter, FromPriority: "Medium", ToPriority: "High"},
+				Notes:           []Note{{Text: "Waiting on the payments team", AddedAt: at(1, 17, 0)}},
+				RemoteLinks: []RemoteLink{
+					{ID: "10000", Title: "Design doc", URL: "https://wiki.example.com/display/PAY/Design (v2)", Application: "Confluence", AddedAt: at(2, 11, 0), AddedBy: "Test User"},
+				},
+				Worklogs: []Worklog{
+					{ID: "30000", Started: at(2, 13, 0), Author: "Test User", AuthorAccountID: "user123", TimeSpent: 2*time.Hour + 30*time.Minute, Comment: "Pairing on <validation> & tests"},
+				},
+				ReferencedIssues: []IssueRef{{Key: "PAY-14", Summary: "Refund API", Status: "In Progress", Type: "Task"}},
+			},
+			{
+				Key:            "PAY-14",
+				Summary:        "Refund API",
+				Status:         "In Progress",
+				StatusCategory: StatusCategoryInProgress,
+				Type:           "Task",
+				Epic:           epic,
+				Comments:       []Comment{{ID: "10050", Timestamp: at(2, 14, 0), Author: "Test User", AuthorAccountID: "user123", Content: "TODO: add idempotency keys"}},
+			},
+			{
+				Key:            "PAY-15",
+				Summary:        "Receipt emails",
+				Status:         "In Review",
+				StatusCategory: StatusCategoryInProgress,
+				Type:           "Story",
+				Changes: []Change{
+					{Timestamp: at(2, 16, 45), Author: "Test User", AuthorAccountID: "user123", IsCurrentUser: true, Field: "assignee", FromValue: "", ToValue: "Test User"},
+				},
+				Handoff: &Handoff{Direction: HandoffIncoming, Timestamp: at(2, 16, 45), Author: "Test User", ToAssignee: "Test User"},
+			},
+		},
+		Truncation: &SearchTruncation{Shown: 100, Total: 342},
+		Warnings:   []ReportWarning{
+			{Kind: WarningTruncation, Message: "Showing 100 of 342 issues; raise jira.query.max_results to include the rest"},
+			{Kind: WarningPermission, Message: "failed to get pinned issues: 403 Forbidden"},
+		},
+		Pinned:     []Issue{{Key: "OPS-7", Summary: "Checkout outage escalation", Status: "Escalated", Notes: []Note{{Text: "Check with support daily"}}}},
+		CarryOver:  []Issue{{Key: "PAY-7", Summary: "Finish migration", Status: "In Progress", Notes: []Note{{Text: "Needs a DBA"}}}},
+		Blockers:   []Issue{{Key: "PAY-9", Summary: "Gateway credentials", Status: "Blocked"}},
+		Filed:      []Issue{{Key: "PAY-20", Summary: "Apple Pay button misaligned", Status: "Open", Type: "Bug"}},
+		DueSoon: []Issue{
+			{Key: "PAY-7", Summary: "Finish migration", Status: "In Progress", DueDate: at(1, 0, 0)},
+			{Key: "PAY-14", Summary: "Refund API", Status: "In Progress", DueDate: at(5, 0, 0)},
+		},
+		Sprint: &SprintScope{
+			Sprint:        Sprint{ID: 7, Name: "Sprint 7"},
+			Added:         []Issue{{Key: "PAY-14", Summary: "Refund API", Status: "In Progress", Type: "Task"}},
+			Removed:       []Issue{{Key: "PAY-21", Summary: "Saved cards", Status: "To Do", Type: "Story"}},
+			PointsAdded:   3,
+			PointsRemoved: 5,
+		},
+		Authors: []User{
+			{AccountID: "qa1", DisplayName: "QA", TimeZone: "Europe/Berlin"},
+			{AccountID: "user123", DisplayName: "Test User", TimeZone: "UTC"},
+		},
+		Stats: &StatsBlock{
+			Current: VelocityStats{WindowStart: at(2, 0, 0), WindowEnd: at(3, 0, 0), IssuesCompleted: 2, PointsCompleted: 5, AverageCycleTime: 30 * time.Hour},
+			Trailing: []VelocityStats{
+				{WindowStart: at(1, 0, 0), WindowEnd: at(2, 0, 0), IssuesCompleted: 1, PointsCompleted: 2},
+			},
+		},
+		Generation: &GenerationInfo{
+			GeneratedAt:   at(3, 9, 30),
+			PluginVersion: "v1.4.0",
+			InstanceURL:   "https://example.atlassian.net",
+			Query:         "project = \"PAY\" AND updatedDate >= \"2023-01-02\" AND updatedDate < \"2023-01-03\" AND status != \"<Done>\"",
+			IssueCount:    2,
+		},
+		Options: options,
+	}
+	report.Heatmap = BuildHeatmap(report.Issues, "UTC")
+	report.Handoffs = BuildHandoffs(report.Issues)
+	return report
+}
+
+func TestFormatters_Golden(t *testing.T) {
+	report := goldenReport()
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		golden    string
+	}{
+		{formatter: NewMarkdownFormatter(), golden: "report.md"},
+		{formatter: NewHTMLFormatter(), golden: "report.html"},
+		{formatter: NewJSONFormatter(), golden: "report.json"},
+		{formatter: NewXMLFormatter(), golden: "report.xml"},
+		{formatter: NewSlackFormatter(), golden: "report.slack"},
+		{formatter: NewTeamsFormatter(), golden: "report.teams.json"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			path := filepath.Join("testdata", "golden", tc.golden)
+
+			// Every run over the same report must produce the same output
+			first, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for i := 0; i < 5; i++ {
+				again, err := tc.formatter.Format(report)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if again.Content != first.Content {
+					t.Fatalf("Expected identical output on every run, run %d differed", i+2)
+				}
+			}
+
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatalf("Failed to create the golden directory: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(first.Content), 0o644); err != nil {
+					t.Fatalf("Failed to update %s: %v", path, err)
+				}
+			}
+
+			expected, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read %s (run with -update to create it): %v", path, err)
+			}
+			if first.Content != string(expected) {
+				t.Errorf("Output differs from %s; if the change is intended, run with -update and review the diff.\nGot:\n%s", path, first.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// assigneeField is the changelog field of assignee changes
+const assigneeField = "assignee"
+
+// unassigned is shown for a handoff from or to nobody
+const unassigned = "Unassigned"
+
+// HandoffDirection tells whether work was handed to or away from the user
+type HandoffDirection string
+
+const (
+	// HandoffIncoming is work assigned to the user
+	HandoffIncoming HandoffDirection = "incoming"
+	// HandoffOutgoing is work the user was unassigned from
+	HandoffOutgoing HandoffDirection = "outgoing"
+)
+
+// Handoff is a change of assignee to or from the user
+type Handoff struct {
+	Direction    HandoffDirection
+	Timestamp    time.Time
+	Author       string // Who changed the assignee
+	FromAssignee string // Previous assignee; empty when the issue was unassigned
+	ToAssignee   string // New assignee; empty when the issue was unassigned
+
+	// Account IDs of the author and assignees, by which they are told apart
+	AuthorAccountID string
+	FromAccountID   string
+	ToAccountID     string
+}
+
+// Handoffs lists the issues handed to and away from the user within the range
+type Handoffs struct {
+	Incoming []Issue
+	Outgoing []Issue
+}
+
+// IsEmpty reports whether no work changed hands
+func (h *Handoffs) IsEmpty() bool {
+	return h == nil || (len(h.Incoming) == 0 && len(h.Outgoing) == 0)
+}
+
+// Sections returns the non-empty handoff lists in display order
+func (h *Handoffs) Sections() []supplementarySection {
+	sections := make([]supplementarySection, 0, 2)
+	if h == nil {
+		return sections
+	}
+	if len(h.Incoming) > 0 {
+		sections = append(sections, supplementarySection{Title: "Incoming", Issues: h.Incoming})
+	}
+	if len(h.Outgoing) > 0 {
+		sections = append(sections, supplementarySection{Title: "Outgoing", Issues: h.Outgoing})
+	}
+	return sections
+}
+
+// detectHandoff returns the last assignee change within the time range that
+// assigned the issue to the user or took it away, whoever made it, or nil when
+// the issue did not change hands. Jira records assignees by account ID, or by
+// username on Jira Server.
+func detectHandoff(histories []extJira.ChangelogHistory, timeRange TimeRange, userAccountID string) *Handoff {
+	if userAccountID == "" {
+		return nil
+	}
+
+	var handoff *Handoff
+	for _, history := range histories {
+		createdTime, err := parseJiraTime(history.Created)
+		if err != nil || !timeRange.IsInRange(createdTime) {
+			continue
+		}
+
+		for _, item := range history.Items {
+			if !strings.EqualFold(item.Field, assigneeField) {
+				continue
+			}
+			if handoff != nil && createdTime.Before(handoff.Timestamp) {
+				continue
+			}
+
+			var direction HandoffDirection
+			switch {
+			case item.To == userAccountID && item.From != userAccountID:
+				direction = HandoffIncoming
+			case item.From == userAccountID && item.To != userAccountID:
+				direction = HandoffOutgoing
+			default:
+				continue
+			}
+			handoff = &Handoff{
+				Direction:       direction,
+				Timestamp:       createdTime,
+				Author:          displayName(history.Author),
+				FromAssignee:    item.FromString,
+				ToAssignee:      item.ToString,
+				AuthorAccountID: history.Author.AccountID,
+				FromAccountID:   changelogValue(item.From),
+				ToAccountID:     changelogValue(item.To),
+			}
+		}
+	}
+	return handoff
+}
+
+// BuildHandoffs sorts the issues that changed hands into incoming and outgoing
+// work, listing each issue once in the order given
+func BuildHandoffs(issues []Issue) *Handoffs {
+	handoffs := &Handoffs{}
+	seen := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		if issue.Handoff == nil || seen[issue.Key] {
+			continue
+		}
+		seen[issue.Key] = true
+
+		switch issue.Handoff.Direction {
+		case HandoffIncoming:
+			handoffs.Incoming = append(handoffs.Incoming, issue)
+		case HandoffOutgoing:
+			handoffs.Outgoing = append(handoffs.Outgoing, issue)
+		}
+	}
+	return handoffs
+}
+
+// handoffLine renders who an issue came from or went to, e.g. "from Alice" or
+// "to Bob, by Carol" when someone else changed the assignee
+func handoffLine(handoff *Handoff, user User) string {
+	if handoff == nil {
+		return ""
+	}
+
+	line := ""
+	switch handoff.Direction {
+	case HandoffIncoming:
+		line = "from " + assigneeName(handoff.FromAssignee)
+	case HandoffOutgoing:
+		line = "to " + assigneeName(handoff.ToAssignee)
+	}
+
+	// Mention who made the change unless it was the user or the other party
+	author := func(accountID, name string) bool {
+		return sameUser(handoff.AuthorAccountID, handoff.Author, accountID, name)
+	}
+	if handoff.Author != "" && !author(user.AccountID, user.DisplayName) && !author(handoff.FromAccountID, handoff.FromAssignee) && !author(handoff.ToAccountID, handoff.ToAssignee) {
+		line = fmt.Sprintf("%s, by %s", line, handoff.Author)
+	}
+	return line
+}
+
+// assigneeName returns the name of an assignee, or Unassigned
+func assigneeName(name string) string {
+	if name == "" {
+		return unassigned
+	}
+	return name
+}
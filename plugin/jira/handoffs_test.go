@@ -0,0 +1,149 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestDetectHandoff(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	assign := func(created, from, fromName, to, toName string) extJira.ChangelogHistory {
+		return extJira.ChangelogHistory{
+			Created: created,
+			Author:  extJira.User{AccountID: "lead1", DisplayName: "Lead"},
+			Items:   []extJira.ChangelogItems{{Field: "assignee", From: from, FromString: fromName, To: to, ToString: toName}},
+		}
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name      string
+		histories []extJira.ChangelogHistory
+		expected  *Handoff
+	}{
+		{
+			name:      "Handed to the user",
+			histories: []extJira.ChangelogHistory{assign("2023-01-02T10:00:00.000+0000", "dev2", "Alice", "user123", "Test User")},
+			expected:  &Handoff{Direction: HandoffIncoming, Author: "Lead", FromAssignee: "Alice", ToAssignee: "Test User", AuthorAccountID: "lead1", FromAccountID: "dev2", ToAccountID: "user123"},
+		},
+		{
+			name:      "Handed off by the user",
+			histories: []extJira.ChangelogHistory{assign("2023-01-02T10:00:00.000+0000", "user123", "Test User", "", "")},
+			expected:  &Handoff{Direction: HandoffOutgoing, Author: "Lead", FromAssignee: "Test User", AuthorAccountID: "lead1", FromAccountID: "user123"},
+		},
+		{
+			name: "Last handoff wins",
+			histories: []extJira.ChangelogHistory{
+				assign("2023-01-02T15:00:00.000+0000", "user123", "Test User", "dev3", "Bob"),
+				assign("2023-01-02T09:00:00.000+0000", "dev2", "Alice", "user123", "Test User"),
+			},
+			expected: &Handoff{Direction: HandoffOutgoing, Author: "Lead", FromAssignee: "Test User", ToAssignee: "Bob", AuthorAccountID: "lead1", FromAccountID: "user123", ToAccountID: "dev3"},
+		},
+		{
+			name:      "Between other people",
+			histories: []extJira.ChangelogHistory{assign("2023-01-02T10:00:00.000+0000", "dev2", "Alice", "dev3", "Bob")},
+		},
+		{
+			name:      "Before the range",
+			histories: []extJira.ChangelogHistory{assign("2023-01-01T10:00:00.000+0000", "dev2", "Alice", "user123", "Test User")},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handoff := detectHandoff(tc.histories, timeRange, "user123")
+
+			if tc.expected == nil {
+				if handoff != nil {
+					t.Errorf("Expected no handoff, got %+v", handoff)
+				}
+				return
+			}
+			if handoff == nil {
+				t.Fatalf("Expected %+v, got nil", tc.expected)
+			}
+			handoff.Timestamp = time.Time{}
+			if *handoff != *tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, handoff)
+			}
+		})
+	}
+}
+
+func TestHandoffLine(t *testing.T) {
+	user := User{AccountID: "user123", DisplayName: "Test User"}
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		handoff  *Handoff
+		expected string
+	}{
+		{name: "No handoff", handoff: nil, expected: ""},
+		{name: "Taken over", handoff: &Handoff{Direction: HandoffIncoming, Author: "Test User", FromAssignee: "Alice", ToAssignee: "Test User"}, expected: "from Alice"},
+		{name: "Assigned by a lead", handoff: &Handoff{Direction: HandoffIncoming, Author: "Lead", ToAssignee: "Test User"}, expected: "from Unassigned, by Lead"},
+		{name: "Handed off", handoff: &Handoff{Direction: HandoffOutgoing, Author: "Test User", FromAssignee: "Test User", ToAssignee: "Bob"}, expected: "to Bob"},
+		{name: "Taken over under a former name", handoff: &Handoff{Direction: HandoffIncoming, Author: "Test U.", AuthorAccountID: "user123", FromAssignee: "Alice", FromAccountID: "dev2", ToAssignee: "Test U.", ToAccountID: "user123"}, expected: "from Alice"},
+		{name: "Assigned by a namesake", handoff: &Handoff{Direction: HandoffIncoming, Author: "Test User", AuthorAccountID: "dev9", FromAssignee: "Alice", FromAccountID: "dev2", ToAssignee: "Test User", ToAccountID: "user123"}, expected: "from Alice, by Test User"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if line := handoffLine(tc.handoff, user); line != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, line)
+			}
+		})
+	}
+}
+
+func TestActivityService_Handoffs(t *testing.T) {
+	incoming := &Handoff{Direction: HandoffIncoming, FromAssignee: "Alice", ToAssignee: "Test User"}
+	outgoing := &Handoff{Direction: HandoffOutgoing, FromAssignee: "Test User", ToAssignee: "Bob"}
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "JIRA-1", Status: "In Progress", Handoff: incoming}, {Key: "JIRA-2", Status: "In Progress"}}, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			if kind != SupplementaryHandoffs {
+				t.Errorf("Expected handoffs query, got %s", kind)
+			}
+			return []Issue{{Key: "JIRA-1", Status: "In Progress", Handoff: incoming}, {Key: "JIRA-3", Status: "To Do", Handoff: outgoing}}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.IncludeHandoffs = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Outgoing work is listed although it is no longer part of the activity
+	if report.Handoffs == nil || len(report.Handoffs.Incoming) != 1 || len(report.Handoffs.Outgoing) != 1 {
+		t.Fatalf("Expected one incoming and one outgoing issue, got %+v", report.Handoffs)
+	}
+	if report.Handoffs.Incoming[0].Key != "JIRA-1" || report.Handoffs.Outgoing[0].Key != "JIRA-3" {
+		t.Errorf("Expected JIRA-1 incoming and JIRA-3 outgoing, got %+v", report.Handoffs)
+	}
+	if len(report.Issues) != 2 {
+		t.Errorf("Expected the activity to be unchanged, got %d issues", len(report.Issues))
+	}
+}
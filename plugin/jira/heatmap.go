@@ -0,0 +1,108 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Working hours used to count after-hours activity, in the heatmap's time zone
+const (
+	workdayStartHour = 9
+	workdayEndHour   = 18
+)
+
+// heatmapShades renders increasing activity levels in the text heatmap
+var heatmapShades = []string{"·", "░", "▒", "▓", "█"}
+
+// ActivityHeatmap summarizes when activity happened, bucketed by hour of day
+type ActivityHeatmap struct {
+	TimeZone   string // Name of the zone the hours are expressed in
+	Hours      [24]int
+	Total      int
+	AfterHours int // Events before workdayStartHour or from workdayEndHour on, or on weekends
+}
+
+// BuildHeatmap buckets the activity events of the issues by hour of day in
+// the given IANA time zone, falling back to UTC when it is empty or unknown
+func BuildHeatmap(issues []Issue, timeZone string) *ActivityHeatmap {
+	loc := time.UTC
+	if timeZone != "" {
+		if zone, err := loadLocation(timeZone); err == nil {
+			loc = zone
+		}
+	}
+
+	heatmap := &ActivityHeatmap{TimeZone: loc.String()}
+	for _, issue := range issues {
+		for _, event := range issue.Events() {
+			heatmap.add(event.Timestamp.In(loc))
+		}
+	}
+	return heatmap
+}
+
+// add counts an event at the given local time
+func (h *ActivityHeatmap) add(local time.Time) {
+	h.Hours[local.Hour()]++
+	h.Total++
+
+	weekend := local.Weekday() == time.Saturday || local.Weekday() == time.Sunday
+	if weekend || local.Hour() < workdayStartHour || local.Hour() >= workdayEndHour {
+		h.AfterHours++
+	}
+}
+
+// peak returns the highest hourly count
+func (h *ActivityHeatmap) peak() int {
+	peak := 0
+	for _, count := range h.Hours {
+		if count > peak {
+			peak = count
+		}
+	}
+	return peak
+}
+
+// intensity scales an hourly count to 0 (no activity) through 4 (the peak hour)
+func (h *ActivityHeatmap) intensity(count int) int {
+	peak := h.peak()
+	if count == 0 || peak == 0 {
+		return 0
+	}
+	levels := len(heatmapShades) - 1
+	return (count*levels + peak - 1) / peak
+}
+
+// SummaryLine describes the totals, e.g. "12 events, 3 outside working hours (09:00–18:00 UTC)"
+func (h *ActivityHeatmap) SummaryLine() string {
+	return fmt.Sprintf("%s, %d outside working hours (%02d:00–%02d:00 %s)",
+		pluralize(h.Total, "event", "events"), h.AfterHours, workdayStartHour, workdayEndHour, h.TimeZone)
+}
+
+// Text renders the heatmap as two aligned rows: the hours and a shade per hour
+func (h *ActivityHeatmap) Text() string {
+	var hours, shades strings.Builder
+	for hour, count := range h.Hours {
+		hours.WriteString(fmt.Sprintf("%02d ", hour))
+		shades.WriteString(fmt.Sprintf("%s  ", heatmapShades[h.intensity(count)]))
+	}
+	return strings.TrimRight(hours.String(), " ") + "\n" + strings.TrimRight(shades.String(), " ")
+}
+
+// HTML renders the heatmap as a single-row table shaded by activity
+func (h *ActivityHeatmap) HTML() string {
+	var sb strings.Builder
+	sb.WriteString("<table class=\"heatmap\">\n<tr>")
+	for hour := range h.Hours {
+		sb.WriteString(fmt.Sprintf("<th>%02d</th>", hour))
+	}
+	sb.WriteString("</tr>\n<tr>")
+	for hour, count := range h.Hours {
+		alpha := float64(h.intensity(count)) / float64(len(heatmapShades)-1)
+		sb.WriteString(fmt.Sprintf("<td style=\"background-color: rgba(0, 82, 204, %.2f)\" title=\"%02d:00 – %s\">%d</td>",
+			alpha, hour, pluralize(count, "event", "events"), count))
+	}
+	sb.WriteString("</tr>\n</table>\n")
+	return sb.String()
+}
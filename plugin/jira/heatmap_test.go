@@ -0,0 +1,200 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestBuildHeatmap(t *testing.T) {
+	issues := []Issue{
+		{
+			Key: "JIRA-1",
+			Comments: []Comment{
+				{Timestamp: time.Date(2023, 1, 2, 10, 15, 0, 0, time.UTC)}, // Monday
+				{Timestamp: time.Date(2023, 1, 2, 10, 45, 0, 0, time.UTC)},
+			},
+			Changes: []Change{
+				{Timestamp: time.Date(2023, 1, 2, 22, 0, 0, 0, time.UTC)},
+				{Timestamp: time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC)}, // Sunday
+			},
+		},
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name          string
+		timeZone      string
+		expectedZone  string
+		expectedHours map[int]int
+		afterHours    int
+	}{
+		{
+			name:          "UTC",
+			timeZone:      "",
+			expectedZone:  "UTC",
+			expectedHours: map[int]int{10: 2, 22: 1, 11: 1},
+			afterHours:    2,
+		},
+		{
+			name:          "User time zone",
+			timeZone:      "Asia/Tokyo",
+			expectedZone:  "Asia/Tokyo",
+			expectedHours: map[int]int{19: 2, 7: 1, 20: 1},
+			afterHours:    4,
+		},
+		{
+			name:          "Unknown time zone falls back to UTC",
+			timeZone:      "Mars/Olympus",
+			expectedZone:  "UTC",
+			expectedHours: map[int]int{10: 2, 22: 1, 11: 1},
+			afterHours:    2,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			heatmap := BuildHeatmap(issues, tc.timeZone)
+
+			if heatmap.TimeZone != tc.expectedZone {
+				t.Errorf("Expected time zone %s, got %s", tc.expectedZone, heatmap.TimeZone)
+			}
+			for hour, count := range heatmap.Hours {
+				if count != tc.expectedHours[hour] {
+					t.Errorf("Expected %d events at %02d:00, got %d", tc.expectedHours[hour], hour, count)
+				}
+			}
+			if heatmap.Total != 4 {
+				t.Errorf("Expected 4 events, got %d", heatmap.Total)
+			}
+			if heatmap.AfterHours != tc.afterHours {
+				t.Errorf("Expected %d after-hours events, got %d", tc.afterHours, heatmap.AfterHours)
+			}
+		})
+	}
+}
+
+func TestActivityHeatmap_Text(t *testing.T) {
+	heatmap := &ActivityHeatmap{TimeZone: "UTC", Total: 5, AfterHours: 1}
+	heatmap.Hours[9] = 4
+	heatmap.Hours[10] = 1
+
+	lines := strings.Split(heatmap.Text(), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "00 01 02") || !strings.HasSuffix(lines[0], "23") {
+		t.Errorf("Expected an hour axis, got '%s'", lines[0])
+	}
+
+	shades := strings.Fields(lines[1])
+	if len(shades) != 24 || shades[9] != "█" || shades[10] != "░" || shades[0] != "·" {
+		t.Errorf("Expected peak and low shades at 09 and 10, got '%s'", lines[1])
+	}
+
+	expected := "5 events, 1 outside working hours (09:00–18:00 UTC)"
+	if heatmap.SummaryLine() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, heatmap.SummaryLine())
+	}
+}
+
+func TestFormatters_Heatmap(t *testing.T) {
+	options := DefaultReportOptions()
+	options.IncludeHeatmap = true
+
+	issues := []Issue{
+		{
+			Key:      "JIRA-123",
+			Summary:  "Test Issue",
+			Status:   "In Progress",
+			Comments: []Comment{{Timestamp: time.Date(2023, 1, 2, 23, 0, 0, 0, time.UTC), Author: "Test User"}},
+		},
+	}
+	report := &ActivityReport{
+		User:    User{DisplayName: "Test User"},
+		Issues:  issues,
+		Heatmap: BuildHeatmap(issues, ""),
+		Options: options,
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		expected  []string
+	}{
+		{formatter: NewMarkdownFormatter(), expected: []string{"## Activity by Hour", "1 event, 1 outside working hours"}},
+		{formatter: NewHTMLFormatter(), expected: []string{"<table class=\"heatmap\">", "title=\"23:00 – 1 event\""}},
+		{formatter: NewJSONFormatter(), expected: []string{`"heatmap": {`, `"afterHours": 1`}},
+		{formatter: NewXMLFormatter(), expected: []string{`<heatmap time_zone="UTC">`, `<hour value="23" count="1"></hour>`}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected content to contain '%s', got '%s'", expected, result.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestActivityService_HeatmapCountsEveryChange(t *testing.T) {
+	day := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{
+				Key:    "JIRA-1",
+				Status: "In Review",
+				Comments: []Comment{
+					{ID: "1", Timestamp: day.Add(10 * time.Hour), Author: "Test User", AuthorAccountID: "user123", Content: "Ready"},
+				},
+				Changes: []Change{
+					{Field: "labels", FromValue: "", ToValue: "backend", Timestamp: day.Add(11 * time.Hour), Author: "Test User", AuthorAccountID: "user123"},
+					{Field: "Component", ToValue: "API", Timestamp: day.Add(12 * time.Hour), Author: "Test User", AuthorAccountID: "user123"},
+					{Field: "status", FromValue: "In Progress", ToValue: "In Review", Timestamp: day.Add(20 * time.Hour), Author: "Test User", AuthorAccountID: "user123"},
+				},
+			}}, nil
+		},
+	}
+
+	// Label and component changes are tracked separately and status changes
+	// collapsed into a journey, yet each is still activity
+	options := DefaultReportOptions()
+	options.IncludeHeatmap = true
+	options.SummarizeTransitions = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{Start: day, End: day.AddDate(0, 0, 1)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if report.Heatmap == nil || report.Heatmap.Total != 4 {
+		t.Fatalf("Expected 4 events in the heatmap, got %+v", report.Heatmap)
+	}
+	for hour, expected := range map[int]int{10: 1, 11: 1, 12: 1, 20: 1} {
+		if report.Heatmap.Hours[hour] != expected {
+			t.Errorf("Expected %d event at %02d:00, got %d", expected, hour, report.Heatmap.Hours[hour])
+		}
+	}
+	if report.Heatmap.AfterHours != 1 {
+		t.Errorf("Expected the status change to count after hours, got %d", report.Heatmap.AfterHours)
+	}
+	if len(report.Issues) != 1 || len(report.Issues[0].CollectionChanges) != 2 || report.Issues[0].Transitions == nil {
+		t.Errorf("Expected the changes to be tracked separately, got %+v", report.Issues)
+	}
+}
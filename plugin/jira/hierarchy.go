@@ -0,0 +1,198 @@
+package jira
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxHierarchyDepth bounds how far up the parent chain the hierarchy is resolved
+// (sub-task → story → epic → initiative → theme needs four steps)
+const maxHierarchyDepth = 5
+
+// hierarchySeparator separates the levels of a rendered hierarchy path
+const hierarchySeparator = " › "
+
+// resolvesHierarchy reports whether the options need each issue's full parent hierarchy
+func (o ReportOptions) resolvesHierarchy() bool {
+	return o.IncludeHierarchy || o.Mode == ReportModeInitiative
+}
+
+// InitiativeRollup aggregates the activity of an initiative's epics
+type InitiativeRollup struct {
+	Initiative *IssueRef // Nil for issues without an initiative
+	Epics      []EpicRollup
+	RollupCounts
+}
+
+// Title returns the initiative's display title, or "No Initiative" for issues
+// outside any initiative
+func (r InitiativeRollup) Title() string {
+	if r.Initiative == nil {
+		return "No Initiative"
+	}
+	return fmt.Sprintf("[%s] %s", r.Initiative.Key, r.Initiative.Summary)
+}
+
+// RollupByInitiative groups issues under their initiative and, within each
+// initiative, under their epic. Initiatives are ordered by key with issues
+// without an initiative last.
+func RollupByInitiative(issues []Issue) []InitiativeRollup {
+	grouped := make(map[string][]Issue)
+	refs := make(map[string]*IssueRef)
+	keys := make([]string, 0)
+
+	for _, issue := range issues {
+		key := ""
+		if issue.Initiative != nil {
+			key = issue.Initiative.Key
+		}
+
+		if _, ok := grouped[key]; !ok {
+			refs[key] = issue.Initiative
+			keys = append(keys, key)
+		}
+		grouped[key] = append(grouped[key], issue)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i] == "" || keys[j] == "" {
+			return keys[j] == ""
+		}
+		return keys[i] < keys[j]
+	})
+
+	result := make([]InitiativeRollup, 0, len(keys))
+	for _, key := range keys {
+		rollup := InitiativeRollup{
+			Initiative: refs[key],
+			Epics:      RollupByEpic(grouped[key]),
+		}
+		for _, issue := range grouped[key] {
+			rollup.add(issue)
+		}
+		result = append(result, rollup)
+	}
+	return result
+}
+
+// HierarchyLine renders a hierarchy path, e.g. "[INIT-1] Payments › [PAY-10] Checkout"
+func HierarchyLine(path []IssueRef) string {
+	parts := make([]string, 0, len(path))
+	for _, ref := range path {
+		if ref.Summary == "" {
+			parts = append(parts, fmt.Sprintf("[%s]", ref.Key))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("[%s] %s", ref.Key, ref.Summary))
+	}
+	return strings.Join(parts, hierarchySeparator)
+}
+
+// resolveHierarchy sets the hierarchy path, epic and initiative of each issue by
+// walking up its parent chain past the epic level, fetching ancestors that are
+// not part of the report
+func resolveHierarchy(issues []Issue, fetch func(keys []string) ([]Issue, error)) error {
+	known, err := fetchAncestors(issues, fetch, maxHierarchyDepth, parentKey)
+	if err != nil {
+		return err
+	}
+
+	for i := range issues {
+		issues[i].Hierarchy = hierarchyPath(issues[i], known)
+
+		// The epic is the lowest epic-typed level, counting the issue itself
+		chain := append(append([]IssueRef{}, issues[i].Hierarchy...), issueRef(issues[i]))
+		epicIndex := -1
+		for j := len(chain) - 1; j >= 0; j-- {
+			if chain[j].Type == IssueTypeEpic {
+				epicIndex = j
+				break
+			}
+		}
+		if epicIndex < 0 {
+			continue
+		}
+
+		if issues[i].Epic == nil {
+			epic := chain[epicIndex]
+			issues[i].Epic = &epic
+		}
+		// The initiative is the level directly above the epic
+		if epicIndex > 0 {
+			initiative := chain[epicIndex-1]
+			issues[i].Initiative = &initiative
+		}
+	}
+	return nil
+}
+
+// hierarchyPath returns the ancestors of an issue ordered from the top level down
+func hierarchyPath(issue Issue, known map[string]Issue) []IssueRef {
+	path := make([]IssueRef, 0)
+	seen := map[string]bool{issue.Key: true}
+
+	current := issue
+	for depth := 0; depth < maxHierarchyDepth; depth++ {
+		key := parentKey(current)
+		if key == "" || seen[key] {
+			break
+		}
+		seen[key] = true
+
+		parent, ok := known[key]
+		if !ok {
+			parent = Issue{Key: key}
+		}
+		// Epics linked through the epic field carry their own details
+		if parent.Type == "" && current.Epic != nil && current.Epic.Key == key {
+			parent.Summary = current.Epic.Summary
+			parent.Type = current.Epic.Type
+		}
+
+		path = append(path, issueRef(parent))
+		current = parent
+	}
+
+	// Reverse so that the top level comes first
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// parentKey returns the key of an issue's parent, falling back to its epic
+// when the issue is linked to an epic without a parent
+func parentKey(issue Issue) string {
+	if issue.Parent != nil {
+		return issue.Parent.Key
+	}
+	if issue.Epic != nil && issue.Epic.Key != issue.Key {
+		return issue.Epic.Key
+	}
+	return ""
+}
+
+// issueRef returns a reference to an issue
+func issueRef(issue Issue) IssueRef {
+	return IssueRef{Key: issue.Key, Summary: issue.Summary, Status: issue.Status, Type: issue.Type}
+}
+
+// parentLinkKey extracts the parent key from an Advanced Roadmaps "Parent Link"
+// custom field value, which Jira returns either as a plain key or as an object
+func parentLinkKey(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]interface{}:
+		if key, ok := v["key"].(string); ok && key != "" {
+			return key
+		}
+		if data, ok := v["data"].(map[string]interface{}); ok {
+			if key, ok := data["key"].(string); ok {
+				return key
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,248 @@
+package jira
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+// hierarchyFetch returns a fetch function serving the given ancestors by key
+func hierarchyFetch(ancestors ...Issue) func(keys []string) ([]Issue, error) {
+	byKey := make(map[string]Issue, len(ancestors))
+	for _, ancestor := range ancestors {
+		byKey[ancestor.Key] = ancestor
+	}
+	return func(keys []string) ([]Issue, error) {
+		result := make([]Issue, 0, len(keys))
+		for _, key := range keys {
+			if ancestor, ok := byKey[key]; ok {
+				result = append(result, ancestor)
+			}
+		}
+		return result, nil
+	}
+}
+
+func TestResolveHierarchy(t *testing.T) {
+	issues := []Issue{
+		{Key: "JIRA-1", Type: "Sub-task", Parent: &IssueRef{Key: "JIRA-10"}},
+		{Key: "JIRA-2", Type: "Story", Epic: &IssueRef{Key: "EPIC-2", Summary: "Search", Type: IssueTypeEpic}},
+		{Key: "EPIC-1", Type: IssueTypeEpic, Summary: "Checkout", Parent: &IssueRef{Key: "INIT-1"}},
+		{Key: "JIRA-3", Type: "Story"},
+	}
+
+	fetch := hierarchyFetch(
+		Issue{Key: "JIRA-10", Type: "Story", Summary: "Pay by card", Parent: &IssueRef{Key: "EPIC-1"}},
+		Issue{Key: "EPIC-2", Type: IssueTypeEpic, Summary: "Search", Parent: &IssueRef{Key: "INIT-2"}},
+		Issue{Key: "INIT-1", Type: "Initiative", Summary: "Payments", Parent: &IssueRef{Key: "THEME-1"}},
+		Issue{Key: "INIT-2", Type: "Initiative", Summary: "Discovery"},
+		Issue{Key: "THEME-1", Type: "Theme", Summary: "Revenue"},
+	)
+
+	if err := resolveHierarchy(issues, fetch); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		key        string
+		path       string
+		epic       string
+		initiative string
+	}{
+		{
+			key:        "JIRA-1",
+			path:       "[THEME-1] Revenue › [INIT-1] Payments › [EPIC-1] Checkout › [JIRA-10] Pay by card",
+			epic:       "EPIC-1",
+			initiative: "INIT-1",
+		},
+		{
+			key:        "JIRA-2",
+			path:       "[INIT-2] Discovery › [EPIC-2] Search",
+			epic:       "EPIC-2",
+			initiative: "INIT-2",
+		},
+		{
+			key:        "EPIC-1",
+			path:       "[THEME-1] Revenue › [INIT-1] Payments",
+			epic:       "EPIC-1",
+			initiative: "INIT-1",
+		},
+		{
+			key: "JIRA-3",
+		},
+	}
+
+	// Run tests
+	for i, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			issue := issues[i]
+			if line := HierarchyLine(issue.Hierarchy); line != tc.path {
+				t.Errorf("Expected path '%s', got '%s'", tc.path, line)
+			}
+
+			epicKey := ""
+			if issue.Epic != nil {
+				epicKey = issue.Epic.Key
+			}
+			if epicKey != tc.epic {
+				t.Errorf("Expected epic '%s', got '%s'", tc.epic, epicKey)
+			}
+
+			initiativeKey := ""
+			if issue.Initiative != nil {
+				initiativeKey = issue.Initiative.Key
+			}
+			if initiativeKey != tc.initiative {
+				t.Errorf("Expected initiative '%s', got '%s'", tc.initiative, initiativeKey)
+			}
+		})
+	}
+}
+
+func TestRollupByInitiative(t *testing.T) {
+	payments := &IssueRef{Key: "INIT-1", Summary: "Payments"}
+	checkout := &IssueRef{Key: "EPIC-1", Summary: "Checkout"}
+
+	issues := []Issue{
+		{Key: "JIRA-1", Epic: checkout, Initiative: payments, Comments: []Comment{{}}},
+		{Key: "JIRA-2"},
+		{Key: "JIRA-3", Initiative: payments, Changes: []Change{{Field: "status"}}},
+	}
+
+	rollups := RollupByInitiative(issues)
+
+	titles := make([]string, 0, len(rollups))
+	for _, rollup := range rollups {
+		titles = append(titles, rollup.Title())
+	}
+	expectedTitles := []string{"[INIT-1] Payments", "No Initiative"}
+	if !reflect.DeepEqual(titles, expectedTitles) {
+		t.Fatalf("Expected titles %v, got %v", expectedTitles, titles)
+	}
+
+	if rollups[0].CountsLine() != "1 issue advanced, 1 comment, 1 change" {
+		t.Errorf("Expected counts line '1 issue advanced, 1 comment, 1 change', got '%s'", rollups[0].CountsLine())
+	}
+	if len(rollups[0].Epics) != 2 || rollups[0].Epics[0].Title() != "[EPIC-1] Checkout" || rollups[0].Epics[1].Title() != "No Epic" {
+		t.Errorf("Expected Checkout and No Epic rollups under Payments, got %+v", rollups[0].Epics)
+	}
+}
+
+func TestParentLinkKey(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{name: "Plain key", value: " INIT-1 ", expected: "INIT-1"},
+		{name: "Object with key", value: map[string]interface{}{"key": "INIT-2"}, expected: "INIT-2"},
+		{
+			name:     "Data Center object",
+			value:    map[string]interface{}{"showField": false, "data": map[string]interface{}{"id": 10001.0, "key": "INIT-3"}},
+			expected: "INIT-3",
+		},
+		{name: "Missing", value: nil, expected: ""},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := parentLinkKey(tc.value)
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_ParentLinkField(t *testing.T) {
+	options := DefaultQueryOptions()
+	options.ParentLinkField = "customfield_10500"
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: options})
+	server.Issues = []extJira.Issue{
+		{
+			Key: "EPIC-1",
+			Fields: &extJira.IssueFields{
+				Summary: "Checkout",
+				Type:    extJira.IssueType{Name: IssueTypeEpic},
+				Unknowns: map[string]interface{}{
+					"customfield_10500": map[string]interface{}{"data": map[string]interface{}{"key": "INIT-1"}},
+				},
+			},
+		},
+	}
+
+	issues, err := repo.GetIssuesByKey([]string{"EPIC-1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requestedFields := server.Requests("/rest/api/2/search")[0].Query.Get("fields"); !strings.Contains(requestedFields, "customfield_10500") {
+		t.Errorf("Expected the parent link field to be requested, got %v", requestedFields)
+	}
+	if len(issues) != 1 || issues[0].Parent == nil || issues[0].Parent.Key != "INIT-1" {
+		t.Errorf("Expected EPIC-1 with parent INIT-1, got %+v", issues)
+	}
+}
+
+func TestActivityService_InitiativeMode(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{Key: "JIRA-1", Summary: "Card form", Status: "In Progress", Type: "Story", Parent: &IssueRef{Key: "EPIC-1"},
+					Comments: []Comment{{Author: "Test User", Content: "Done"}}},
+			}, nil
+		},
+		MockGetIssuesByKey: hierarchyFetch(
+			Issue{Key: "EPIC-1", Type: IssueTypeEpic, Summary: "Checkout", Parent: &IssueRef{Key: "INIT-1"}},
+			Issue{Key: "INIT-1", Type: "Initiative", Summary: "Payments"},
+		),
+	}
+
+	options := DefaultReportOptions()
+	options.Mode = ReportModeInitiative
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(report.Initiatives) != 1 || report.Initiatives[0].Title() != "[INIT-1] Payments" {
+		t.Fatalf("Expected a Payments initiative rollup, got %+v", report.Initiatives)
+	}
+
+	formatters := []ReportFormatter{
+		NewXMLFormatter(),
+		NewJSONFormatter(),
+		NewMarkdownFormatter(),
+		NewHTMLFormatter(),
+	}
+	for _, formatter := range formatters {
+		t.Run(formatter.Name(), func(t *testing.T) {
+			result, err := formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range []string{"INIT-1", "Payments", "EPIC-1", "Card form"} {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected content to contain '%s', got '%s'", expected, result.Content)
+				}
+			}
+		})
+	}
+}
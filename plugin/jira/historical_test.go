@@ -0,0 +1,106 @@
+package jira
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestStateAsOf(t *testing.T) {
+	created := time.Date(2023, 3, 1, 9, 0, 0, 0, time.UTC)
+	history := []Change{
+		{Timestamp: time.Date(2023, 3, 2, 9, 0, 0, 0, time.UTC), Field: "assignee", FromValue: "", ToValue: "Alice", ToID: "alice"},
+		{Timestamp: time.Date(2023, 3, 3, 9, 0, 0, 0, time.UTC), Field: "status", FromValue: "Open", ToValue: "In Progress"},
+		{Timestamp: time.Date(2023, 4, 2, 9, 0, 0, 0, time.UTC), Field: "assignee", FromValue: "Alice", FromID: "alice", ToValue: "Bob", ToID: "bob"},
+		{Timestamp: time.Date(2023, 4, 3, 9, 0, 0, 0, time.UTC), Field: "status", FromValue: "In Progress", ToValue: "Done"},
+	}
+	current := Issue{Key: "TEST-1", Status: "Done", StatusCategory: StatusCategoryDone, Assignee: User{AccountID: "bob", DisplayName: "Bob"}, Created: created}
+
+	// Setup test cases
+	testCases := []struct {
+		name             string
+		at               time.Time
+		expectedStatus   string
+		expectedAssignee string
+		expectedSince    time.Time
+	}{
+		{name: "End of the quarter", at: time.Date(2023, 3, 31, 23, 59, 0, 0, time.UTC), expectedStatus: "In Progress", expectedAssignee: "alice", expectedSince: history[1].Timestamp},
+		{name: "Before any change", at: time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC), expectedStatus: "Open", expectedAssignee: "", expectedSince: created},
+		{name: "After every change", at: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), expectedStatus: "Done", expectedAssignee: "bob", expectedSince: history[3].Timestamp},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := stateAsOf(current, history, tc.at)
+			if state.Status != tc.expectedStatus {
+				t.Errorf("Expected status %q, got %q", tc.expectedStatus, state.Status)
+			}
+			if state.Assignee.AccountID != tc.expectedAssignee {
+				t.Errorf("Expected assignee %q, got %q", tc.expectedAssignee, state.Assignee.AccountID)
+			}
+			if !state.StatusSince.Equal(tc.expectedSince) {
+				t.Errorf("Expected status since %v, got %v", tc.expectedSince, state.StatusSince)
+			}
+			// Only the current status keeps its known category
+			if keeps := state.Status == current.Status; keeps != (state.StatusCategory == StatusCategoryDone) {
+				t.Errorf("Expected the category to be kept only for the current status, got %q for %q", state.StatusCategory, state.Status)
+			}
+		})
+	}
+}
+
+func TestActivityService_Historical(t *testing.T) {
+	end := time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "TEST-1", Status: "Done"}, {Key: "TEST-2", Status: "Done"}}, nil
+		},
+		MockGetFieldHistory: func(key string, fields ...string) ([]Change, error) {
+			if key == "TEST-2" {
+				return nil, errors.New("failed to fetch the changelog of TEST-2")
+			}
+			return []Change{
+				{Timestamp: end.Add(48 * time.Hour), Field: "status", FromValue: "In Review", ToValue: "Done"},
+			}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.Historical = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   end,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !report.AsOf.Equal(end) {
+		t.Errorf("Expected the report to be as of %v, got %v", end, report.AsOf)
+	}
+	if status := report.Issues[0].Status; status != "In Review" {
+		t.Errorf("Expected TEST-1 to be reported In Review as of the end of the range, got %s", status)
+	}
+	if status := report.Issues[1].Status; status != "Done" {
+		t.Errorf("Expected TEST-2 to keep its current status without a changelog, got %s", status)
+	}
+
+	output, err := (&MarkdownFormatter{}).Format(report)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(output.Content, "shown as of 2023-03-31 00:00") {
+		t.Errorf("Expected the report to disclose the reconstruction, got:\n%s", output.Content)
+	}
+}
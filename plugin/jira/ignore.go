@@ -0,0 +1,160 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// IgnoreList holds the issues and epics left out of reports, such as
+// long-running umbrella tickets with constant bot churn
+type IgnoreList struct {
+	Issues []string `json:"issues,omitempty"`
+	Epics  []string `json:"epics,omitempty"`
+}
+
+// withKey returns the keys with the given one added once, in upper case
+func withKey(keys []string, key string) []string {
+	key = strings.ToUpper(strings.TrimSpace(key))
+	for _, existing := range keys {
+		if strings.EqualFold(existing, key) {
+			return keys
+		}
+	}
+	return append(keys, key)
+}
+
+// withoutKey returns the keys without the given one
+func withoutKey(keys []string, key string) []string {
+	result := make([]string, 0, len(keys))
+	for _, existing := range keys {
+		if !strings.EqualFold(existing, strings.TrimSpace(key)) {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
+
+// IgnoreStore keeps the ignore list edited at runtime between reports
+type IgnoreStore interface {
+	Load() (IgnoreList, error)
+	Save(list IgnoreList) error
+}
+
+// FileIgnoreStore is an IgnoreStore persisted as a JSON file
+type FileIgnoreStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileIgnoreStore creates an ignore store stored at the given path
+func NewFileIgnoreStore(path string) *FileIgnoreStore {
+	return &FileIgnoreStore{path: path}
+}
+
+// DefaultIgnoreStorePath returns the default location of the ignore store
+func DefaultIgnoreStorePath() (string, error) {
+	return defaultStorePath(os.UserConfigDir, "ignore.json")
+}
+
+// Load reads the ignore list; a missing file is an empty list
+func (s *FileIgnoreStore) Load() (IgnoreList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return IgnoreList{}, nil
+	}
+	if err != nil {
+		return IgnoreList{}, fmt.Errorf("failed to read the ignore list: %w", err)
+	}
+
+	var list IgnoreList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return IgnoreList{}, fmt.Errorf("failed to decode the ignore list: %w", err)
+	}
+	return list, nil
+}
+
+// Save writes the ignore list
+func (s *FileIgnoreStore) Save(list IgnoreList) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the ignore list: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create ignore list directory: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to write the ignore list: %w", err)
+	}
+	return nil
+}
+
+// UpdateIgnoreList loads the stored ignore list, applies the update and saves it
+func UpdateIgnoreList(store IgnoreStore, update func(list *IgnoreList)) error {
+	if store == nil {
+		return fmt.Errorf("no ignore store is configured")
+	}
+	list, err := store.Load()
+	if err != nil {
+		return err
+	}
+	update(&list)
+	return store.Save(list)
+}
+
+// IgnoreIssue adds an issue to the list
+func (l *IgnoreList) IgnoreIssue(key string) {
+	l.Issues = withKey(l.Issues, key)
+}
+
+// IgnoreEpic adds an epic, and so every issue under it, to the list
+func (l *IgnoreList) IgnoreEpic(key string) {
+	l.Epics = withKey(l.Epics, key)
+}
+
+// Unignore removes an issue or epic from the list
+func (l *IgnoreList) Unignore(key string) {
+	l.Issues = withoutKey(l.Issues, key)
+	l.Epics = withoutKey(l.Epics, key)
+}
+
+// ignoresIssues reports whether the options leave any issues out of reports
+func (o ReportOptions) ignoresIssues() bool {
+	return len(o.IgnoreIssues) > 0 || len(o.IgnoreEpics) > 0
+}
+
+// isIgnored reports whether an issue is on the ignore list, or is or belongs
+// to an ignored epic
+func isIgnored(issue Issue, options ReportOptions) bool {
+	if containsFold(options.IgnoreIssues, issue.Key) || containsFold(options.IgnoreEpics, issue.Key) {
+		return true
+	}
+	if issue.Epic != nil && containsFold(options.IgnoreEpics, issue.Epic.Key) {
+		return true
+	}
+	return issue.Parent != nil && containsFold(options.IgnoreEpics, issue.Parent.Key)
+}
+
+// withoutIgnored returns the issues that are not ignored
+func withoutIgnored(issues []Issue, options ReportOptions) []Issue {
+	if !options.ignoresIssues() || issues == nil {
+		return issues
+	}
+	result := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !isIgnored(issue, options) {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package jira
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestIsIgnored(t *testing.T) {
+	options := DefaultReportOptions()
+	options.IgnoreIssues = []string{"TEST-1"}
+	options.IgnoreEpics = []string{"TEST-10"}
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		issue    Issue
+		expected bool
+	}{
+		{name: "Ignored issue", issue: Issue{Key: "test-1"}, expected: true},
+		{name: "Ignored epic", issue: Issue{Key: "TEST-10", Type: IssueTypeEpic}, expected: true},
+		{name: "Issue in an ignored epic", issue: Issue{Key: "TEST-2", Epic: &IssueRef{Key: "TEST-10"}}, expected: true},
+		{name: "Child of an ignored epic", issue: Issue{Key: "TEST-3", Parent: &IssueRef{Key: "TEST-10"}}, expected: true},
+		{name: "Other issue", issue: Issue{Key: "TEST-4", Parent: &IssueRef{Key: "TEST-11"}}, expected: false},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if ignored := isIgnored(tc.issue, options); ignored != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, ignored)
+			}
+		})
+	}
+}
+
+func TestFileIgnoreStore(t *testing.T) {
+	store := NewFileIgnoreStore(filepath.Join(t.TempDir(), "daiv-jira", "ignore.json"))
+
+	err := UpdateIgnoreList(store, func(list *IgnoreList) {
+		list.IgnoreIssue("test-1")
+		list.IgnoreIssue("TEST-1")
+		list.IgnoreEpic("TEST-10")
+		list.IgnoreIssue("TEST-2")
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := UpdateIgnoreList(store, func(list *IgnoreList) { list.Unignore("test-2") }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	list, err := store.Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(list.Issues, ",") != "TEST-1" || strings.Join(list.Epics, ",") != "TEST-10" {
+		t.Errorf("Expected TEST-1 and epic TEST-10 once each, got %+v", list)
+	}
+}
+
+func TestActivityService_IgnoreList(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{Key: "TEST-1", Status: "In Progress"},
+				{Key: "TEST-2", Status: "In Progress", Epic: &IssueRef{Key: "TEST-10"}},
+				{Key: "TEST-3", Status: "In Progress"},
+			}, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "TEST-4", Status: "In Progress", Parent: &IssueRef{Key: "TEST-10"}}, {Key: "TEST-5", Status: "In Progress"}}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.IncludeCarryOver = true
+	options.IgnoreIssues = []string{"TEST-1"}
+	options.IgnoreEpics = []string{"TEST-10"}
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if keys := strings.Join(issueKeys(report.Issues), ","); keys != "TEST-3" {
+		t.Errorf("Expected only TEST-3 in the activity, got %s", keys)
+	}
+	if keys := strings.Join(issueKeys(report.CarryOver), ","); keys != "TEST-5" {
+		t.Errorf("Expected only TEST-5 as carry-over, got %s", keys)
+	}
+}
+
+func TestJiraAPIRepository_IgnoredEpicFields(t *testing.T) {
+	reportOptions := DefaultReportOptions()
+	reportOptions.IgnoreEpics = []string{"TEST-10"}
+	repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: DefaultQueryOptions(), ReportOptions: reportOptions}}
+
+	if fields := strings.Join(repo.searchFields(), ","); !strings.Contains(fields, "parent") {
+		t.Errorf("Expected the parent to be requested, got %s", fields)
+	}
+}
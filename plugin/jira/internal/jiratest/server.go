@@ -0,0 +1,524 @@
+// Package jiratest provides an in-memory Jira server for tests. It serves
+// canned responses for the server info, search, JQL parse, user, changelog,
+// watcher, vote, remote link and agile endpoints over real HTTP, with Jira's
+// pagination and error bodies, so that the repository can be exercised end to
+// end through go-jira.
+package jiratest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// DefaultMaxPageSize is the largest page Jira returns, whatever maxResults asks for
+const DefaultMaxPageSize = 100
+
+// embeddedChangelogLimit is the number of histories Jira embeds in search results
+const embeddedChangelogLimit = 100
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+}
+
+// failure is a canned error response for a path
+type failure struct {
+	status  int
+	message string
+}
+
+// Server simulates the Jira REST API. Configure the exported fields before
+// issuing requests; the server only reads them.
+type Server struct {
+	URL string
+
+	Self         *extJira.User                         // Served by /myself; 401 when nil
+	Issues       []extJira.Issue                       // Served by every search, whatever the JQL
+	Changelogs   map[string][]extJira.ChangelogHistory // Full changelog per issue key
+	UserSearch   map[string][]extJira.User             // Canned user search results per query
+	Users        []extJira.User                        // Accounts served by the bulk user API
+	Watchers     map[string][]extJira.User             // Watchers per issue key
+	Votes        map[string]int                        // Votes per issue key
+	RemoteLinks  map[string][]extJira.RemoteLink       // Remote links per issue key
+	Sprints      map[int][]extJira.Sprint              // Sprints per board ID
+	SprintIssues map[int][]extJira.Issue               // Issues per sprint ID
+	JQLErrors    map[string][]string                   // Parse errors per query; other queries are valid
+	Projects     map[string][]extJira.Issue            // Issues per project key, served instead of Issues to searches naming the projects
+
+	// Version and DeploymentType are reported by /serverInfo, by default
+	// those of Jira Cloud: 1001.0.0 and Cloud
+	Version        string
+	DeploymentType string
+
+	// MaxPageSize caps the page size of paginated endpoints
+	MaxPageSize int
+	// HideChangelogTotals leaves total and isLast out of changelog pages, as
+	// some Jira versions do
+	HideChangelogTotals bool
+	// SearchGate holds searches, once recorded, until it is closed, so that
+	// tests can issue requests while a search is in flight
+	SearchGate chan struct{}
+
+	server    *httptest.Server
+	mu        sync.Mutex
+	requests  []Request
+	failures  map[string]failure
+	throttled int
+}
+
+// NewServer starts a server that is closed when the test finishes
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		MaxPageSize: DefaultMaxPageSize,
+		failures:    make(map[string]failure),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.server.URL
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// Client returns a go-jira client for the server
+func (s *Server) Client(t testing.TB) *extJira.Client {
+	client, err := extJira.NewClient(s.server.Client(), s.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+// HTTPClient returns an HTTP client for the server, for clients other than go-jira
+func (s *Server) HTTPClient() *http.Client {
+	return s.server.Client()
+}
+
+// Throttle answers the next n requests with 429 Too Many Requests
+func (s *Server) Throttle(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.throttled = n
+}
+
+// Fail answers every request to the path with the status and a Jira error body
+// carrying the message
+func (s *Server) Fail(path string, status int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[path] = failure{status: status, message: message}
+}
+
+// Requests returns the requests received so far, optionally only those to a path
+func (s *Server) Requests(path ...string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]Request, 0, len(s.requests))
+	for _, req := range s.requests {
+		if len(path) == 0 || req.Path == path[0] {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+// handle records the request, applies throttling and failures, then routes it
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query()})
+	throttled := s.throttled > 0
+	if throttled {
+		s.throttled--
+	}
+	fail, failed := s.failures[r.URL.Path]
+	s.mu.Unlock()
+
+	if throttled {
+		w.Header().Set("Retry-After", "1")
+		writeError(w, http.StatusTooManyRequests, "Rate limit exceeded.")
+		return
+	}
+	if failed {
+		writeError(w, fail.status, fail.message)
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.URL.Path == "/rest/api/2/serverInfo":
+		s.handleServerInfo(w)
+	case r.URL.Path == "/rest/api/2/myself":
+		s.handleSelf(w)
+	case r.URL.Path == "/rest/api/2/search":
+		s.handleSearch(w, r)
+	case r.URL.Path == "/rest/api/2/user/search":
+		writeJSON(w, s.UserSearch[r.URL.Query().Get("query")])
+	case r.URL.Path == "/rest/api/2/user/bulk":
+		s.handleBulkUsers(w, r)
+	case r.URL.Path == "/rest/api/2/jql/parse" && r.Method == http.MethodPost:
+		s.handleJQLParse(w, r)
+	case len(segments) == 6 && strings.Join(segments[:4], "/") == "rest/api/2/issue":
+		s.handleIssueResource(w, r, segments[4], segments[5])
+	case len(segments) == 6 && strings.Join(segments[:4], "/") == "rest/agile/1.0/board" && segments[5] == "sprint":
+		s.handleSprints(w, r, segments[4])
+	case len(segments) == 5 && strings.Join(segments[:4], "/") == "rest/agile/1.0/sprint":
+		s.handleSprint(w, r, segments[4])
+	case len(segments) == 6 && strings.Join(segments[:4], "/") == "rest/agile/1.0/sprint" && segments[5] == "issue":
+		s.handleSprintIssues(w, r, segments[4])
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("No resource at %s.", r.URL.Path))
+	}
+}
+
+// handleServerInfo describes the simulated Jira instance
+func (s *Server) handleServerInfo(w http.ResponseWriter) {
+	version, deploymentType := s.Version, s.DeploymentType
+	if version == "" {
+		version = "1001.0.0"
+	}
+	if deploymentType == "" {
+		deploymentType = "Cloud"
+	}
+
+	numbers := make([]int, 0, 3)
+	for _, part := range strings.Split(version, ".") {
+		if number, err := strconv.Atoi(part); err == nil {
+			numbers = append(numbers, number)
+		}
+	}
+	writeJSON(w, map[string]interface{}{"baseUrl": s.URL, "version": version, "versionNumbers": numbers, "deploymentType": deploymentType})
+}
+
+// handleSelf serves the current user
+func (s *Server) handleSelf(w http.ResponseWriter) {
+	if s.Self == nil {
+		writeError(w, http.StatusUnauthorized, "You are not authenticated.")
+		return
+	}
+	writeJSON(w, s.Self)
+}
+
+// handleSearch serves a page of issues, embedding at most the first 100
+// histories of each changelog when it is expanded
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	if s.SearchGate != nil {
+		<-s.SearchGate
+	}
+	startAt, maxResults, ok := s.page(w, r, 50)
+	if !ok {
+		return
+	}
+	expandChangelog := strings.Contains(r.URL.Query().Get("expand"), "changelog")
+
+	matching := s.Issues
+	if projects := searchedProjects(r.URL.Query().Get("jql")); len(projects) > 0 && s.Projects != nil {
+		matching = nil
+		for _, project := range projects {
+			if !s.hasProject(project) {
+				// Jira rejects the whole query for one project it refuses
+				writeError(w, http.StatusBadRequest, projectError(project))
+				return
+			}
+			matching = append(matching, s.Projects[project]...)
+		}
+	}
+
+	issues := make([]extJira.Issue, 0, maxResults)
+	for _, issue := range window(matching, startAt, maxResults) {
+		if !expandChangelog {
+			issue.Changelog = nil
+		} else if histories, ok := s.Changelogs[issue.Key]; ok {
+			issue.Changelog = &extJira.Changelog{Histories: window(histories, 0, embeddedChangelogLimit)}
+		}
+		issues = append(issues, issue)
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(matching),
+		"issues":     issues,
+	})
+}
+
+// handleJQLParse parses the posted queries, reporting the canned errors
+func (s *Server) handleJQLParse(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Queries []string `json:"queries"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request payload.")
+		return
+	}
+
+	queries := make([]map[string]interface{}, 0, len(body.Queries))
+	for _, query := range body.Queries {
+		parsed := map[string]interface{}{"query": query}
+		if errors := s.JQLErrors[query]; len(errors) > 0 {
+			parsed["errors"] = errors
+		} else if project, ok := s.refusedProject(query); ok {
+			parsed["errors"] = []string{projectError(project)}
+		} else {
+			parsed["structure"] = map[string]interface{}{}
+		}
+		queries = append(queries, parsed)
+	}
+	writeJSON(w, map[string]interface{}{"queries": queries})
+}
+
+// projectPattern matches the project a query searches, e.g. project = "PAY"
+var projectPattern = regexp.MustCompile(`project = "?([A-Za-z0-9_]+)"?`)
+
+// projectListPattern matches the projects a query searches together, e.g.
+// project IN ("PAY", "WEB")
+var projectListPattern = regexp.MustCompile(`project IN \(([^)]*)\)`)
+
+// searchedProjects returns the projects a query searches, if it names any
+func searchedProjects(jql string) []string {
+	if match := projectPattern.FindStringSubmatch(jql); match != nil {
+		return []string{match[1]}
+	}
+	match := projectListPattern.FindStringSubmatch(jql)
+	if match == nil {
+		return nil
+	}
+	var projects []string
+	for _, project := range strings.Split(match[1], ",") {
+		projects = append(projects, strings.Trim(strings.TrimSpace(project), `"`))
+	}
+	return projects
+}
+
+// refusedProject returns the first project the query names that the user may
+// not browse, if any
+func (s *Server) refusedProject(jql string) (string, bool) {
+	if s.Projects == nil {
+		return "", false
+	}
+	for _, project := range searchedProjects(jql) {
+		if !s.hasProject(project) {
+			return project, true
+		}
+	}
+	return "", false
+}
+
+// hasProject reports whether the user may browse the project
+func (s *Server) hasProject(project string) bool {
+	_, ok := s.Projects[project]
+	return ok
+}
+
+// projectError is Jira's message for a project the user cannot browse
+func projectError(project string) string {
+	return fmt.Sprintf("The value '%s' does not exist for the field 'project'.", project)
+}
+
+// handleBulkUsers serves the known accounts among the requested ones
+func (s *Server) handleBulkUsers(w http.ResponseWriter, r *http.Request) {
+	requested := make(map[string]bool)
+	for _, accountID := range r.URL.Query()["accountId"] {
+		requested[accountID] = true
+	}
+
+	users := []extJira.User{}
+	for _, user := range s.Users {
+		if requested[user.AccountID] {
+			users = append(users, user)
+		}
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"startAt":    0,
+		"maxResults": len(users),
+		"total":      len(users),
+		"isLast":     true,
+		"values":     users,
+	})
+}
+
+// handleIssueResource serves the changelog, watchers, votes or remote links of an issue
+func (s *Server) handleIssueResource(w http.ResponseWriter, r *http.Request, key, resource string) {
+	switch resource {
+	case "changelog":
+		startAt, maxResults, ok := s.page(w, r, DefaultMaxPageSize)
+		if !ok {
+			return
+		}
+		histories := s.Changelogs[key]
+		page := map[string]interface{}{
+			"startAt":    startAt,
+			"maxResults": maxResults,
+			"values":     window(histories, startAt, maxResults),
+		}
+		if !s.HideChangelogTotals {
+			page["total"] = len(histories)
+			page["isLast"] = startAt+maxResults >= len(histories)
+		}
+		writeJSON(w, page)
+	case "watchers":
+		watchers := s.Watchers[key]
+		if watchers == nil {
+			watchers = []extJira.User{}
+		}
+		writeJSON(w, map[string]interface{}{
+			"isWatching": false,
+			"watchCount": len(watchers),
+			"watchers":   watchers,
+		})
+	case "votes":
+		writeJSON(w, map[string]interface{}{
+			"votes":    s.Votes[key],
+			"hasVoted": false,
+		})
+	case "remotelink":
+		links := s.RemoteLinks[key]
+		if links == nil {
+			links = []extJira.RemoteLink{}
+		}
+		writeJSON(w, links)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("No resource at %s.", r.URL.Path))
+	}
+}
+
+// handleSprints serves a page of the sprints of a board, optionally filtered by state
+func (s *Server) handleSprints(w http.ResponseWriter, r *http.Request, boardID string) {
+	id, err := strconv.Atoi(boardID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid board ID %q.", boardID))
+		return
+	}
+	startAt, maxResults, ok := s.page(w, r, 50)
+	if !ok {
+		return
+	}
+
+	states := make(map[string]bool)
+	for _, state := range strings.Split(r.URL.Query().Get("state"), ",") {
+		if state != "" {
+			states[strings.ToLower(state)] = true
+		}
+	}
+	sprints := []extJira.Sprint{}
+	for _, sprint := range s.Sprints[id] {
+		if len(states) == 0 || states[strings.ToLower(sprint.State)] {
+			sprints = append(sprints, sprint)
+		}
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"isLast":     startAt+maxResults >= len(sprints),
+		"values":     window(sprints, startAt, maxResults),
+	})
+}
+
+// handleSprint serves a sprint of any board by ID
+func (s *Server) handleSprint(w http.ResponseWriter, r *http.Request, sprintID string) {
+	id, err := strconv.Atoi(sprintID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid sprint ID %q.", sprintID))
+		return
+	}
+	for _, sprints := range s.Sprints {
+		for _, sprint := range sprints {
+			if sprint.ID == id {
+				writeJSON(w, sprint)
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("Sprint with id %d does not exist.", id))
+}
+
+// handleSprintIssues serves a page of the issues in a sprint
+func (s *Server) handleSprintIssues(w http.ResponseWriter, r *http.Request, sprintID string) {
+	id, err := strconv.Atoi(sprintID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid sprint ID %q.", sprintID))
+		return
+	}
+	startAt, maxResults, ok := s.page(w, r, 50)
+	if !ok {
+		return
+	}
+
+	issues := s.SprintIssues[id]
+	writeJSON(w, map[string]interface{}{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(issues),
+		"issues":     window(issues, startAt, maxResults),
+	})
+}
+
+// page parses startAt and maxResults, capping the page size like Jira does
+func (s *Server) page(w http.ResponseWriter, r *http.Request, defaultSize int) (int, int, bool) {
+	startAt, maxResults := 0, defaultSize
+
+	query := r.URL.Query()
+	if value := query.Get("startAt"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid startAt %q.", value))
+			return 0, 0, false
+		}
+		startAt = parsed
+	}
+	if value := query.Get("maxResults"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid maxResults %q.", value))
+			return 0, 0, false
+		}
+		maxResults = parsed
+	}
+	if s.MaxPageSize > 0 && maxResults > s.MaxPageSize {
+		maxResults = s.MaxPageSize
+	}
+
+	return startAt, maxResults, true
+}
+
+// window returns the items of a page
+func window[T any](items []T, startAt, maxResults int) []T {
+	if startAt >= len(items) {
+		return []T{}
+	}
+	end := startAt + maxResults
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[startAt:end]
+}
+
+// writeJSON writes a 200 response with the value as JSON
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// writeError writes an error response with a Jira error body
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errorMessages": []string{message},
+		"errors":        map[string]string{},
+	})
+}
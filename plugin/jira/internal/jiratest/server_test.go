@@ -0,0 +1,152 @@
+package jiratest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestServer_SearchPagination(t *testing.T) {
+	server := NewServer(t)
+	for i := 1; i <= 120; i++ {
+		server.Issues = append(server.Issues, extJira.Issue{Key: fmt.Sprintf("JIRA-%d", i), Fields: &extJira.IssueFields{Summary: "Issue"}})
+	}
+	client := server.Client(t)
+
+	// Setup test cases
+	testCases := []struct {
+		name          string
+		options       *extJira.SearchOptions
+		expectedCount int
+		expectedFirst string
+	}{
+		{name: "Default page size", options: &extJira.SearchOptions{}, expectedCount: 50, expectedFirst: "JIRA-1"},
+		{name: "Page size capped", options: &extJira.SearchOptions{MaxResults: 500}, expectedCount: 100, expectedFirst: "JIRA-1"},
+		{name: "Last page", options: &extJira.SearchOptions{StartAt: 100, MaxResults: 100}, expectedCount: 20, expectedFirst: "JIRA-101"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, resp, err := client.Issue.Search("project = TEST", tc.options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(issues) != tc.expectedCount || issues[0].Key != tc.expectedFirst {
+				t.Errorf("Expected %d issues from %s, got %d", tc.expectedCount, tc.expectedFirst, len(issues))
+			}
+			if resp.Total != 120 {
+				t.Errorf("Expected a total of 120, got %d", resp.Total)
+			}
+		})
+	}
+
+	requests := server.Requests("/rest/api/2/search")
+	if len(requests) != len(testCases) || requests[0].Query.Get("jql") != "project = TEST" {
+		t.Errorf("Expected the searches to be recorded, got %+v", requests)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	server := NewServer(t)
+	server.Self = &extJira.User{AccountID: "user123"}
+	client := server.Client(t)
+
+	// Throttled requests are answered with 429 and a retry hint
+	server.Throttle(1)
+	_, resp, err := client.User.GetSelf()
+	if err == nil || resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "1" {
+		t.Errorf("Expected a throttled request, got %v", err)
+	}
+	if _, _, err := client.User.GetSelf(); err != nil {
+		t.Errorf("Expected the next request to succeed, got %v", err)
+	}
+
+	// Failures carry Jira's error body
+	server.Fail("/rest/api/2/search", http.StatusBadRequest, "Field 'sprint' does not exist.")
+	if _, _, err := client.Issue.Search("sprint IN openSprints()", nil); err == nil || !strings.Contains(err.Error(), "Field 'sprint' does not exist.") {
+		t.Errorf("Expected the Jira error message, got %v", err)
+	}
+}
+
+func TestServer_Projects(t *testing.T) {
+	server := NewServer(t)
+	server.Projects = map[string][]extJira.Issue{
+		"PAY": {{Key: "PAY-1", Fields: &extJira.IssueFields{Summary: "Issue"}}},
+		"WEB": {{Key: "WEB-1", Fields: &extJira.IssueFields{Summary: "Issue"}}},
+	}
+	client := server.Client(t)
+
+	issues, _, err := client.Issue.Search(`project IN ("PAY", "WEB") AND assignee = currentUser()`, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Key != "PAY-1" || issues[1].Key != "WEB-1" {
+		t.Errorf("Expected the issues of both projects, got %+v", issues)
+	}
+
+	// One project the user cannot browse fails the whole query
+	if _, _, err := client.Issue.Search(`project IN ("PAY", "OPS")`, nil); err == nil || !strings.Contains(err.Error(), "The value 'OPS' does not exist for the field 'project'.") {
+		t.Errorf("Expected OPS to be refused, got %v", err)
+	}
+}
+
+func TestServer_Changelog(t *testing.T) {
+	server := NewServer(t)
+	histories := make([]extJira.ChangelogHistory, 150)
+	for i := range histories {
+		histories[i] = extJira.ChangelogHistory{Id: fmt.Sprint(i)}
+	}
+	server.Issues = []extJira.Issue{{Key: "JIRA-1", Fields: &extJira.IssueFields{}}}
+	server.Changelogs = map[string][]extJira.ChangelogHistory{"JIRA-1": histories}
+	client := server.Client(t)
+
+	issues, _, err := client.Issue.Search("key = JIRA-1", &extJira.SearchOptions{Expand: "changelog"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues[0].Changelog.Histories) != embeddedChangelogLimit {
+		t.Errorf("Expected the embedded changelog to be truncated, got %d histories", len(issues[0].Changelog.Histories))
+	}
+
+	req, _ := client.NewRequest("GET", "rest/api/2/issue/JIRA-1/changelog?startAt=100&maxResults=100", nil)
+	var page struct {
+		Total  int                        `json:"total"`
+		IsLast bool                       `json:"isLast"`
+		Values []extJira.ChangelogHistory `json:"values"`
+	}
+	if _, err := client.Do(req, &page); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if page.Total != 150 || !page.IsLast || len(page.Values) != 50 || page.Values[0].Id != "100" {
+		t.Errorf("Expected the last changelog page, got total %d, %d values", page.Total, len(page.Values))
+	}
+}
+
+func TestServer_Sprints(t *testing.T) {
+	server := NewServer(t)
+	server.Sprints = map[int][]extJira.Sprint{
+		7: {{ID: 1, Name: "Sprint 1", State: "closed"}, {ID: 2, Name: "Sprint 2", State: "active"}},
+	}
+	server.SprintIssues = map[int][]extJira.Issue{2: {{Key: "JIRA-1"}}}
+	client := server.Client(t)
+
+	sprints, _, err := client.Board.GetAllSprintsWithOptions(7, &extJira.GetAllSprintsOptions{State: "active"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sprints.Values) != 1 || sprints.Values[0].Name != "Sprint 2" || !sprints.IsLast {
+		t.Errorf("Expected the active sprint, got %+v", sprints)
+	}
+
+	issues, _, err := client.Sprint.GetIssuesForSprint(2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "JIRA-1" {
+		t.Errorf("Expected the sprint issues, got %+v", issues)
+	}
+}
@@ -0,0 +1,242 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// jqlOperators lists the JQL operators accepted in user-provided conditions,
+// longest first so that "NOT IN" is matched before "IN"
+var jqlOperators = []string{
+	"WAS NOT IN", "WAS NOT", "WAS IN", "NOT IN", "IS NOT",
+	"WAS", "IN", "IS",
+	"!=", "!~", ">=", "<=", "=", "~", ">", "<",
+}
+
+// jqlFunctionPattern matches JQL function calls such as currentUser() or openSprints()
+var jqlFunctionPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*\([^()]*\)$`)
+
+// jqlOrPattern detects top-level disjunctions in a JQL fragment
+var jqlOrPattern = regexp.MustCompile(`(?i)\bOR\b`)
+
+// QuoteJQL quotes a value as a JQL string literal. Values are always quoted,
+// which is valid for keys, names and dates on every Jira Cloud and Server
+// version and avoids clashes with reserved words.
+func QuoteJQL(value string) string {
+	escaped := strings.ReplaceAll(value, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `"`, `\"`)
+	return `"` + escaped + `"`
+}
+
+// quoteJQLValues quotes each value as a JQL string literal and joins them with commas
+func quoteJQLValues(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, QuoteJQL(value))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// jqlBuilder assembles a JQL query from clauses joined with AND
+type jqlBuilder struct {
+	clauses []string
+}
+
+// Raw adds a trusted clause, wrapping it in parentheses if it contains OR
+func (b *jqlBuilder) Raw(clause string) {
+	clause = strings.TrimSpace(clause)
+	if clause == "" {
+		return
+	}
+	if jqlOrPattern.MatchString(clause) {
+		clause = "(" + clause + ")"
+	}
+	b.clauses = append(b.clauses, clause)
+}
+
+// In adds a "field IN (...)" clause with quoted values
+func (b *jqlBuilder) In(field string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	b.clauses = append(b.clauses, fmt.Sprintf("%s IN (%s)", field, quoteJQLValues(values)))
+}
+
+// NotIn adds a "field NOT IN (...)" clause with quoted values
+func (b *jqlBuilder) NotIn(field string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	b.clauses = append(b.clauses, fmt.Sprintf("%s NOT IN (%s)", field, quoteJQLValues(values)))
+}
+
+// Condition adds a clause from a user-provided "<operator> <operand>" expression,
+// validating the operator and quoting the operand
+func (b *jqlBuilder) Condition(field, expression string) error {
+	clause, err := buildJQLCondition(field, expression)
+	if err != nil {
+		return err
+	}
+	b.clauses = append(b.clauses, clause)
+	return nil
+}
+
+// String returns the assembled query
+func (b *jqlBuilder) String() string {
+	return strings.Join(b.clauses, " AND ")
+}
+
+// buildJQLCondition validates an "<operator> <operand>" expression for the field
+// and returns the safe JQL clause
+func buildJQLCondition(field, expression string) (string, error) {
+	operator, operand, err := splitJQLCondition(expression)
+	if err != nil {
+		return "", err
+	}
+
+	switch operator {
+	case "IN", "NOT IN", "WAS IN", "WAS NOT IN":
+		if jqlFunctionPattern.MatchString(operand) {
+			return fmt.Sprintf("%s %s %s", field, operator, operand), nil
+		}
+		if !strings.HasPrefix(operand, "(") || !strings.HasSuffix(operand, ")") {
+			return "", fmt.Errorf("operator %s requires a parenthesised list, got %q", operator, operand)
+		}
+		values, err := splitJQLList(operand[1 : len(operand)-1])
+		if err != nil {
+			return "", err
+		}
+		quoted := make([]string, 0, len(values))
+		for _, value := range values {
+			quoted = append(quoted, quoteJQLOperand(value))
+		}
+		return fmt.Sprintf("%s %s (%s)", field, operator, strings.Join(quoted, ", ")), nil
+	case "IS", "IS NOT":
+		if !isJQLEmptyKeyword(operand) {
+			return "", fmt.Errorf("operator %s only accepts EMPTY or NULL, got %q", operator, operand)
+		}
+		return fmt.Sprintf("%s %s %s", field, operator, strings.ToUpper(operand)), nil
+	default:
+		return fmt.Sprintf("%s %s %s", field, operator, quoteJQLOperand(operand)), nil
+	}
+}
+
+// splitJQLCondition splits an expression into a validated operator and its operand
+func splitJQLCondition(expression string) (string, string, error) {
+	expression = strings.TrimSpace(expression)
+	upper := strings.ToUpper(expression)
+
+	for _, operator := range jqlOperators {
+		if !strings.HasPrefix(upper, operator) {
+			continue
+		}
+
+		rest := expression[len(operator):]
+
+		// Word operators must be followed by whitespace or a parenthesis
+		if isWordOperator(operator) && rest != "" && !strings.HasPrefix(rest, " ") && !strings.HasPrefix(rest, "(") {
+			continue
+		}
+
+		operand := strings.TrimSpace(rest)
+		if operand == "" {
+			return "", "", fmt.Errorf("missing operand after operator %s", operator)
+		}
+		return operator, operand, nil
+	}
+
+	return "", "", fmt.Errorf("invalid JQL condition %q: must start with one of %s", expression, strings.Join(jqlOperators, ", "))
+}
+
+// splitJQLList splits a comma-separated list, respecting quoted values
+func splitJQLList(list string) ([]string, error) {
+	values := make([]string, 0)
+	var current strings.Builder
+	var quote rune
+	escaped := false
+
+	for _, r := range list {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			current.WriteRune(r)
+			escaped = true
+		case quote != 0:
+			current.WriteRune(r)
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			current.WriteRune(r)
+			quote = r
+		case r == ',':
+			values = append(values, strings.TrimSpace(current.String()))
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in list %q", list)
+	}
+	values = append(values, strings.TrimSpace(current.String()))
+
+	for _, value := range values {
+		if value == "" {
+			return nil, fmt.Errorf("empty value in list %q", list)
+		}
+	}
+	return values, nil
+}
+
+// quoteJQLOperand quotes a single operand, keeping functions and EMPTY/NULL as-is
+func quoteJQLOperand(operand string) string {
+	operand = strings.TrimSpace(operand)
+
+	if isJQLEmptyKeyword(operand) {
+		return strings.ToUpper(operand)
+	}
+	if jqlFunctionPattern.MatchString(operand) {
+		return operand
+	}
+
+	// Re-quote already quoted values so embedded quotes are escaped consistently
+	if len(operand) >= 2 {
+		first, last := operand[0], operand[len(operand)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return QuoteJQL(unescapeJQL(operand[1 : len(operand)-1]))
+		}
+	}
+
+	return QuoteJQL(operand)
+}
+
+// unescapeJQL removes backslash escapes from the contents of a quoted literal
+func unescapeJQL(value string) string {
+	var sb strings.Builder
+	escaped := false
+	for _, r := range value {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// isJQLEmptyKeyword reports whether the operand is the EMPTY or NULL keyword
+func isJQLEmptyKeyword(operand string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(operand))
+	return upper == "EMPTY" || upper == "NULL"
+}
+
+// isWordOperator reports whether the operator is made of letters (IN, IS, WAS...)
+func isWordOperator(operator string) bool {
+	return operator[0] >= 'A' && operator[0] <= 'Z'
+}
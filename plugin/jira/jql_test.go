@@ -0,0 +1,166 @@
+package jira
+
+import (
+	"testing"
+)
+
+func TestQuoteJQL(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "Plain value",
+			value:    "TEST",
+			expected: `"TEST"`,
+		},
+		{
+			name:     "Value with spaces",
+			value:    "In Progress",
+			expected: `"In Progress"`,
+		},
+		{
+			name:     "Embedded quotes are escaped",
+			value:    `say "hi"`,
+			expected: `"say \"hi\""`,
+		},
+		{
+			name:     "Backslashes are escaped",
+			value:    `a\b`,
+			expected: `"a\\b"`,
+		},
+		{
+			name:     "Injection attempt stays a literal",
+			value:    `TEST" OR project = "SECRET`,
+			expected: `"TEST\" OR project = \"SECRET"`,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := QuoteJQL(tc.value)
+			if result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestBuildJQLCondition(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		expression  string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:       "Equality",
+			expression: "= Done",
+			expected:   `status = "Done"`,
+		},
+		{
+			name:       "Inequality with quoted value",
+			expression: `!= "In Progress"`,
+			expected:   `status != "In Progress"`,
+		},
+		{
+			name:       "Single-quoted value is re-quoted",
+			expression: "= 'To Do'",
+			expected:   `status = "To Do"`,
+		},
+		{
+			name:       "Injection attempt is quoted",
+			expression: `= Done OR project = SECRET`,
+			expected:   `status = "Done OR project = SECRET"`,
+		},
+		{
+			name:       "IN list",
+			expression: `IN (Done, "In Review")`,
+			expected:   `status IN ("Done", "In Review")`,
+		},
+		{
+			name:       "NOT IN list with lowercase operator",
+			expression: "not in (Done,Closed)",
+			expected:   `status NOT IN ("Done", "Closed")`,
+		},
+		{
+			name:       "IN with function",
+			expression: "IN openSprints()",
+			expected:   "status IN openSprints()",
+		},
+		{
+			name:       "IS EMPTY",
+			expression: "is empty",
+			expected:   "status IS EMPTY",
+		},
+		{
+			name:       "Function operand is kept",
+			expression: "= currentUser()",
+			expected:   "status = currentUser()",
+		},
+		{
+			name:        "Unknown operator",
+			expression:  "LIKE Done",
+			expectError: true,
+		},
+		{
+			name:        "Missing operand",
+			expression:  "=",
+			expectError: true,
+		},
+		{
+			name:        "IN without list",
+			expression:  "IN Done",
+			expectError: true,
+		},
+		{
+			name:        "IS with value",
+			expression:  "IS Done",
+			expectError: true,
+		},
+		{
+			name:        "Unterminated quote",
+			expression:  `IN ("Done, Closed)`,
+			expectError: true,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := buildJQLCondition("status", tc.expression)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got %s", result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestJQLBuilder(t *testing.T) {
+	var query jqlBuilder
+	query.Raw(`project = "TEST" OR project = "OTHER"`)
+	query.Raw("")
+	query.In("labels", []string{"backend"})
+	query.In("component", nil)
+	query.NotIn("status", []string{"Closed"})
+
+	expected := `(project = "TEST" OR project = "OTHER") AND labels IN ("backend") AND status NOT IN ("Closed")`
+	if query.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, query.String())
+	}
+}
@@ -0,0 +1,98 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings")
+
+// jqlParseEndpoint is Jira's JQL parse API, validating queries strictly
+const jqlParseEndpoint = "rest/api/2/jql/parse?validation=strict"
+
+// jqlPositionPattern matches the position Jira appends to JQL syntax errors,
+// e.g. "(line 1, character 15)"
+var jqlPositionPattern = regexp.MustCompile(`\s*\(line (\d+), character (\d+)\)\.?\s*$`)
+
+// JQLDiagnostic is a problem Jira found in a query, with its position when
+// Jira reports one
+type JQLDiagnostic struct {
+	Message string
+	Line    int // 0 when Jira reports no position
+	Column  int
+}
+
+// String renders the diagnostic, e.g. "line 1, character 15: Expecting ..."
+func (d JQLDiagnostic) String() string {
+	if d.Line == 0 {
+		return d.Message
+	}
+	return fmt.Sprintf("line %d, character %d: %s", d.Line, d.Column, d.Message)
+}
+
+// InvalidJQLError reports a query that Jira rejected before it was searched
+type InvalidJQLError struct {
+	Query       string
+	Diagnostics []JQLDiagnostic
+}
+
+// Error lists every diagnostic along with the query
+func (e *InvalidJQLError) Error() string {
+	lines := make([]string, 0, len(e.Diagnostics))
+	for _, diagnostic := range e.Diagnostics {
+		lines = append(lines, diagnostic.String())
+	}
+	return fmt.Sprintf("invalid JQL %q: %s", e.Query, strings.Join(lines, "; "))
+}
+
+// parseJQLDiagnostic splits the position off a JQL error message
+func parseJQLDiagnostic(message string) JQLDiagnostic {
+	message = strings.TrimSpace(message)
+	match := jqlPositionPattern.FindStringSubmatchIndex(message)
+	if match == nil {
+		return JQLDiagnostic{Message: message}
+	}
+
+	line, _ := strconv.Atoi(message[match[2]:match[3]])
+	column, _ := strconv.Atoi(message[match[4]:match[5]])
+	return JQLDiagnostic{
+		Message: strings.TrimPrefix(message[:match[0]], "Error in the JQL Query: "),
+		Line:    line,
+		Column:  column,
+	}
+}
+
+// validateJQL checks the query with Jira's JQL parse API, so that subtly
+// invalid JQL fails with precise diagnostics instead of silently returning no
+// issues. Servers without the parse API, such as Jira Data Center, skip the
+// check.
+func (r *JiraAPIRepository) validateJQL(jql string) error {
+	body := struct {
+		Queries []string `json:"queries"`
+	}{Queries: []string{jql}}
+
+	var parsed struct {
+		Queries []struct {
+			Query  string   `json:"query"`
+			Errors []string `json:"errors"`
+		} `json:"queries"`
+	}
+	if err := r.api.Post(jqlParseEndpoint, body, &parsed); err != nil {
+		if apiStatus(err) == http.StatusNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to validate the JQL query: %w", err)
+	}
+
+	for _, query := range parsed.Queries {
+		if len(query.Errors) == 0 {
+			continue
+		}
+		invalid := &InvalidJQLError{Query: jql}
+		for _, message := range query.Errors {
+			invalid.Diagnostics = append(invalid.Diagnostics, parseJQLDiagnostic(message))
+		}
+		return invalid
+	}
+	return nil
+}
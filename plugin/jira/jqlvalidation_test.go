@@ -0,0 +1,109 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseJQLDiagnostic(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		message  string
+		expected JQLDiagnostic
+	}{
+		{
+			name:    "Syntax error with a position",
+			message: "Error in the JQL Query: Expecting operator but got 'Progress'. (line 1, character 15)",
+			expected: JQLDiagnostic{
+				Message: "Expecting operator but got 'Progress'.",
+				Line:    1,
+				Column:  15,
+			},
+		},
+		{
+			name:     "Validation error without a position",
+			message:  "Field 'storypoints' does not exist or you do not have permission to view it.",
+			expected: JQLDiagnostic{Message: "Field 'storypoints' does not exist or you do not have permission to view it."},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diagnostic := parseJQLDiagnostic(tc.message); diagnostic != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, diagnostic)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_GetIssues_ValidateJQL(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	options := DefaultQueryOptions()
+	options.JQLTemplate = ""
+	options.RawJQL = "status = In Progress"
+	options.AssigneeCurrentUser = false
+	options.ExcludeStatuses = nil
+	options.InOpenSprints = false
+
+	// Setup test cases
+	testCases := []struct {
+		name             string
+		validate         bool
+		parseUnavailable bool
+		expectedError    string
+		expectedSearches int
+	}{
+		{
+			name:          "Invalid JQL is reported before searching",
+			validate:      true,
+			expectedError: `invalid JQL "status = In Progress": line 1, character 15: Expecting either 'OR' or 'AND' but got 'Progress'.`,
+		},
+		{
+			name:             "Servers without the parse API skip the check",
+			validate:         true,
+			parseUnavailable: true,
+			expectedSearches: 1,
+		},
+		{
+			name:             "Validation disabled",
+			validate:         false,
+			expectedSearches: 1,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queryOptions := options
+			queryOptions.ValidateJQL = tc.validate
+			repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: queryOptions})
+			server.JQLErrors = map[string][]string{
+				"status = In Progress": {"Error in the JQL Query: Expecting either 'OR' or 'AND' but got 'Progress'. (line 1, character 15)"},
+			}
+			if tc.parseUnavailable {
+				server.Fail("/rest/api/2/jql/parse", http.StatusNotFound, "null for uri: /rest/api/2/jql/parse")
+			}
+
+			_, _, err := repo.GetIssues(timeRange, "user123")
+			if tc.expectedError != "" {
+				var invalid *InvalidJQLError
+				if !errors.As(err, &invalid) || err.Error() != tc.expectedError {
+					t.Errorf("Expected the error '%s', got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if searches := len(server.Requests("/rest/api/2/search")); searches != tc.expectedSearches {
+				t.Errorf("Expected %d searches, got %d", tc.expectedSearches, searches)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger receives diagnostic messages such as performance warnings.
+// The daiv host can wire its own logger; by default messages go to stderr.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// LogMode selects how the plugin's own diagnostics are written
+type LogMode string
+
+const (
+	// LogModeText writes prefixed lines, the default
+	LogModeText LogMode = "text"
+	// LogModeQuiet writes nothing; problems still reach the Warnings of the report
+	LogModeQuiet LogMode = "quiet"
+	// LogModeMachine writes one JSON object per line, for hosts piping the
+	// report from stdout into other programs
+	LogModeMachine LogMode = "machine"
+)
+
+// ParseLogMode parses a log mode name, defaulting to text when empty
+func ParseLogMode(value string) (LogMode, error) {
+	switch LogMode(strings.ToLower(strings.TrimSpace(value))) {
+	case LogModeText, "":
+		return LogModeText, nil
+	case LogModeQuiet:
+		return LogModeQuiet, nil
+	case LogModeMachine:
+		return LogModeMachine, nil
+	default:
+		return "", fmt.Errorf("unknown log mode %q (expected text, quiet or machine)", value)
+	}
+}
+
+// NewLogger creates the logger for a mode, writing to the file at path or to
+// stderr when path is empty. Every message names the plugin version, when
+// given, so that pasted diagnostics say which build produced them. Whatever
+// the mode, nothing is written to stdout, which carries the report. A logger
+// writing to a file holds it open until closed; the service closes its logger
+// with the stores.
+func NewLogger(mode LogMode, path, version string) (Logger, error) {
+	if mode == LogModeQuiet {
+		return NewNoopLogger(), nil
+	}
+
+	var writer io.Writer = os.Stderr
+	var file *os.File
+	flags := 0
+	if path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create log directory: %w", err)
+		}
+		var err error
+		if file, err = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		// Lines in a file are read later, so they carry the time
+		writer, flags = file, log.LstdFlags
+	}
+
+	var logger Logger
+	if mode == LogModeMachine {
+		jsonLogger := NewJSONLogger(writer)
+		jsonLogger.version = version
+		logger = jsonLogger
+	} else {
+		prefix := "daiv-jira: "
+		if version != "" {
+			prefix = "daiv-jira " + version + ": "
+		}
+		logger = log.New(writer, prefix, flags)
+	}
+	if file != nil {
+		return &fileLogger{Logger: logger, file: file}, nil
+	}
+	return logger, nil
+}
+
+// NewStderrLogger creates a logger that writes prefixed messages to stderr
+func NewStderrLogger() Logger {
+	return log.New(os.Stderr, "daiv-jira: ", 0)
+}
+
+// fileLogger is a logger writing to a file it holds open
+type fileLogger struct {
+	Logger
+	file *os.File
+}
+
+// Close closes the log file
+func (l *fileLogger) Close() error {
+	return l.file.Close()
+}
+
+// JSONLogger writes each message as a JSON object on its own line, with the
+// time, level and source of the message, for log collectors to parse
+type JSONLogger struct {
+	mu      sync.Mutex
+	writer  io.Writer
+	now     func() time.Time
+	version string // The plugin version, added to every line when set
+}
+
+// NewJSONLogger creates a logger writing JSON lines to the writer
+func NewJSONLogger(writer io.Writer) *JSONLogger {
+	return &JSONLogger{writer: writer, now: time.Now}
+}
+
+// jsonLogLine is a message as written by JSONLogger
+type jsonLogLine struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Source  string `json:"source"`
+	Version string `json:"version,omitempty"`
+	Message string `json:"message"`
+}
+
+// Printf writes the message. The plugin logs only problems it works around,
+// so every message has the warn level.
+func (l *JSONLogger) Printf(format string, args ...interface{}) {
+	line, err := json.Marshal(jsonLogLine{
+		Time:    l.now().UTC().Format(time.RFC3339),
+		Level:   "warn",
+		Source:  "daiv-jira",
+		Version: l.version,
+		Message: fmt.Sprintf(format, args...),
+	})
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.writer.Write(append(line, '\n'))
+}
+
+// NoopLogger discards all messages
+type NoopLogger struct{}
+
+// NewNoopLogger creates a new no-op logger
+func NewNoopLogger() *NoopLogger {
+	return &NoopLogger{}
+}
+
+// Printf discards the message
+func (l *NoopLogger) Printf(format string, args ...interface{}) {}
+
+// LoggerFunc adapts an ordinary function to the Logger interface
+type LoggerFunc func(format string, args ...interface{})
+
+// Printf calls f(format, args...)
+func (f LoggerFunc) Printf(format string, args ...interface{}) {
+	f(format, args...)
+}
@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoggerFunc(t *testing.T) {
+	var message string
+	logger := LoggerFunc(func(format string, args ...interface{}) {
+		message = fmt.Sprintf(format, args...)
+	})
+
+	logger.Printf("fetched %d issues", 3)
+
+	if message != "fetched 3 issues" {
+		t.Errorf("Expected message 'fetched 3 issues', got '%s'", message)
+	}
+}
+
+func TestActivityService_SetLogger(t *testing.T) {
+	service := NewActivityService(&MockJiraRepository{})
+
+	service.SetLogger(nil)
+	if _, ok := service.logger.(*NoopLogger); !ok {
+		t.Errorf("Expected a nil logger to be replaced by a NoopLogger, got %T", service.logger)
+	}
+}
+
+func TestParseLogMode(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		value    string
+		expected LogMode
+		wantErr  bool
+	}{
+		{value: "", expected: LogModeText},
+		{value: "Machine", expected: LogModeMachine},
+		{value: " quiet ", expected: LogModeQuiet},
+		{value: "verbose", wantErr: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			mode, err := ParseLogMode(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if mode != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, mode)
+			}
+		})
+	}
+}
+
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf)
+	logger.now = func() time.Time { return time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC) }
+
+	logger.Printf("failed to export %d events: %q", 3, "pipeline down")
+	logger.Printf("second")
+
+	expected := `{"time":"2023-01-02T09:00:00Z","level":"warn","source":"daiv-jira","message":"failed to export 3 events: \"pipeline down\""}` + "\n" +
+		`{"time":"2023-01-02T09:00:00Z","level":"warn","source":"daiv-jira","message":"second"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestNewLogger_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "daiv-jira.log")
+
+	logger, err := NewLogger(LogModeMachine, path, "v1.4.0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	logger.Printf("could not detect the Jira instance")
+
+	// The service closes the log file with its stores
+	service := NewActivityService(&MockJiraRepository{})
+	service.SetLogger(logger)
+	if err := service.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the log file, got %v", err)
+	}
+	var line jsonLogLine
+	if err := json.Unmarshal(data, &line); err != nil || line.Message != "could not detect the Jira instance" || line.Version != "v1.4.0" {
+		t.Errorf("Expected a JSON line with the message and version, got %q", data)
+	}
+	if _, ok := logger.(io.Closer); !ok {
+		t.Errorf("Expected a file logger to be closable, got %T", logger)
+	}
+
+	if quiet, _ := NewLogger(LogModeQuiet, path, "v1.4.0"); quiet == nil {
+		t.Errorf("Expected a logger in quiet mode")
+	} else if _, ok := quiet.(*NoopLogger); !ok {
+		t.Errorf("Expected quiet mode to discard messages, got %T", quiet)
+	}
+}
@@ -0,0 +1,66 @@
+package jira
+
+import (
+	"regexp"
+	"strings"
+)
+
+// markdownInlineReplacer escapes characters with special meaning in inline Markdown
+// (headings, table cells, emphasis and list items) as well as raw HTML
+var markdownInlineReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`#`, `\#`,
+	`|`, `\|`,
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+)
+
+// markdownBlockReplacer escapes raw HTML and table delimiters in multi-line content
+// while leaving inline formatting such as emphasis and links intact
+var markdownBlockReplacer = strings.NewReplacer(
+	`|`, `\|`,
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+)
+
+// markdownBreakLinePattern matches lines that Markdown renders as a thematic break
+// or as the underline of a setext heading
+var markdownBreakLinePattern = regexp.MustCompile(`^\s*([-=*_]\s*){3,}$|^\s*(=+|-+)\s*$`)
+
+// escapeMarkdownInline escapes a single-line value such as a summary, author or
+// table cell. Line breaks are collapsed into spaces so tables stay intact.
+func escapeMarkdownInline(value string) string {
+	value = strings.ReplaceAll(normalizeLineEndings(value), "\n", " ")
+	return markdownInlineReplacer.Replace(value)
+}
+
+// escapeMarkdownBlock escapes multi-line content such as comments and descriptions
+// so that it cannot open headings, close tables or inject HTML into the report
+func escapeMarkdownBlock(value string) string {
+	lines := strings.Split(normalizeLineEndings(value), "\n")
+	for i, line := range lines {
+		line = markdownBlockReplacer.Replace(line)
+
+		trimmed := strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(trimmed, "#") || markdownBreakLinePattern.MatchString(line) {
+			line = line[:len(line)-len(trimmed)] + `\` + trimmed
+		}
+
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// normalizeLineEndings converts CRLF and lone CR line endings, both of which
+// Markdown treats as line breaks, to LF
+func normalizeLineEndings(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	return strings.ReplaceAll(value, "\r", "\n")
+}
@@ -0,0 +1,390 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEscapeMarkdownInline(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "Plain text",
+			value:    "Fix login bug",
+			expected: "Fix login bug",
+		},
+		{
+			name:     "Table delimiter",
+			value:    "a | b",
+			expected: `a \| b`,
+		},
+		{
+			name:     "Heading marker",
+			value:    "# Not a heading",
+			expected: `\# Not a heading`,
+		},
+		{
+			name:     "Raw HTML",
+			value:    "<script>alert(1)</script>",
+			expected: "&lt;script&gt;alert(1)&lt;/script&gt;",
+		},
+		{
+			name:     "Emphasis and links",
+			value:    "*bold* _it_ [link]",
+			expected: `\*bold\* \_it\_ \[link\]`,
+		},
+		{
+			name:     "Line breaks are collapsed",
+			value:    "first\r\nsecond\nthird",
+			expected: "first second third",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := escapeMarkdownInline(tc.value)
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestEscapeMarkdownBlock(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "Formatting is kept",
+			value:    "Looks *good* to me",
+			expected: "Looks *good* to me",
+		},
+		{
+			name:     "Heading lines are escaped",
+			value:    "Notes\n  ## Section",
+			expected: "Notes\n  \\## Section",
+		},
+		{
+			name:     "Setext underline and thematic break are escaped",
+			value:    "Title\n---\ntext\n===",
+			expected: "Title\n\\---\ntext\n\\===",
+		},
+		{
+			name:     "Table delimiters and HTML are escaped",
+			value:    "| a | b |\n<div>x</div>",
+			expected: "\\| a \\| b \\|\n&lt;div&gt;x&lt;/div&gt;",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := escapeMarkdownBlock(tc.value)
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestMarkdownFormatter_Escaping(t *testing.T) {
+	report := &ActivityReport{
+		TimeRange: TimeRange{
+			Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		User: User{DisplayName: "Test User", Email: "test@example.com"},
+		Issues: []Issue{
+			{
+				Key:     "JIRA-123",
+				Summary: "Pipe | in <b>summary</b>",
+				Status:  "In Progress",
+				Changes: []Change{
+					{
+						Timestamp: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						Author:    "Test User",
+						Field:     "summary",
+						FromValue: "old | value",
+						ToValue:   "new value",
+					},
+				},
+				Comments: []Comment{
+					{
+						Timestamp: time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC),
+						Author:    "Test User",
+						Content:   "# Heading in comment",
+					},
+				},
+			},
+		},
+		Options: DefaultReportOptions(),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name       string
+		allowRaw   bool
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:     "Escaped by default",
+			allowRaw: false,
+			expected: []string{
+				`### [JIRA-123] Pipe \| in &lt;b&gt;summary&lt;/b&gt;`,
+				`| old \| value | new value |`,
+				`\# Heading in comment`,
+			},
+			unexpected: []string{"<b>", "\n# Heading in comment"},
+		},
+		{
+			name:     "Raw passthrough",
+			allowRaw: true,
+			expected: []string{
+				"### [JIRA-123] Pipe | in <b>summary</b>",
+				"\n# Heading in comment",
+			},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter := NewMarkdownFormatter()
+			formatter.SetAllowRaw(tc.allowRaw)
+
+			result, err := formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			for _, expected := range tc.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected content to contain '%s', got '%s'", expected, result.Content)
+				}
+			}
+			for _, unexpected := range tc.unexpected {
+				if strings.Contains(result.Content, unexpected) {
+					t.Errorf("Expected content not to contain '%s', got '%s'", unexpected, result.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownFormatter_Layout(t *testing.T) {
+	report := &ActivityReport{
+		TimeRange: TimeRange{
+			Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		User: User{DisplayName: "Test User"},
+		Issues: []Issue{
+			{
+				Key:     "JIRA-1",
+				Summary: "Fix login",
+				Status:  "In Progress",
+				Comments: []Comment{
+					{
+						Timestamp: time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC),
+						Author:    "Test User",
+						Content:   "First line\nSecond line",
+					},
+				},
+			},
+			{Key: "JIRA-2", Summary: "Quiet issue", Status: "In Progress"},
+		},
+		Options: DefaultReportOptions(),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name       string
+		options    FormatterOptions
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:     "Default",
+			expected: []string{"# Jira Activity Report\n", "\n## In Progress Issues\n", "\n### [JIRA-1] Fix login\n", "\n#### Comments\n", "---\n"},
+		},
+		{
+			name:       "Nested under a level 2 heading",
+			options:    FormatterOptions{"heading_level": "3"},
+			expected:   []string{"### Jira Activity Report\n", "\n#### In Progress Issues\n", "\n##### [JIRA-1] Fix login\n", "\n###### Comments\n"},
+			unexpected: []string{"\n# ", "\n## "},
+		},
+		{
+			name:     "Beyond six levels",
+			options:  FormatterOptions{"heading_level": "4"},
+			expected: []string{"\n###### [JIRA-1] Fix login\n", "\n**Comments**\n"},
+		},
+		{
+			name:    "Issues as list items",
+			options: FormatterOptions{"issues": "list"},
+			expected: []string{
+				"## In Progress Issues\n\n- [JIRA-1] Fix login\n\n  **Comments**\n\n  **Test User** - 2023-01-01 14:00\n\n  First line\n  Second line\n\n- [JIRA-2] Quiet issue\n\n",
+			},
+			unexpected: []string{"### [JIRA-1]", "---\n"},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter := NewMarkdownFormatter()
+			if err := formatter.SetOptions(tc.options); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			result, err := formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			for _, expected := range tc.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected content to contain %q, got:\n%s", expected, result.Content)
+				}
+			}
+			for _, unexpected := range tc.unexpected {
+				if strings.Contains(result.Content, unexpected) {
+					t.Errorf("Expected content not to contain %q, got:\n%s", unexpected, result.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownFormatter_Compact(t *testing.T) {
+	at := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	report := &ActivityReport{
+		TimeRange: TimeRange{
+			Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		User: User{DisplayName: "Test User"},
+		Issues: []Issue{
+			{
+				Key:    "PROJ-12",
+				Status: "In Review",
+				Changes: []Change{
+					{Timestamp: at, Field: "status", FromValue: "In Progress", ToValue: "In Review"},
+					{Timestamp: at, Field: "priority", FromValue: "Low", ToValue: "High"},
+				},
+				Comments: []Comment{{Timestamp: at, Author: "Test User"}, {Timestamp: at, Author: "Test User"}},
+			},
+			{
+				Key:      "PROJ-7",
+				Status:   "In Progress",
+				Worklogs: []Worklog{{Started: at, TimeSpent: time.Hour}, {Started: at, TimeSpent: 30 * time.Minute}},
+			},
+			{Key: "PROJ-3", Status: "In Review"},
+		},
+		Options: DefaultReportOptions(),
+	}
+
+	formatter := NewMarkdownFormatter()
+	if err := formatter.SetOptions(FormatterOptions{"style": "compact"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result, err := formatter.Format(report)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "## Issues\n\n" +
+		"- PROJ-12 (In Review): moved from In Progress; 2 comments; 1 change\n" +
+		"- PROJ-3 (In Review)\n" +
+		"- PROJ-7 (In Progress): logged 1h 30m\n\n"
+	if !strings.Contains(result.Content, expected) {
+		t.Errorf("Expected content to contain %q, got:\n%s", expected, result.Content)
+	}
+	if strings.Contains(result.Content, "### ") {
+		t.Errorf("Expected no per-issue sections, got:\n%s", result.Content)
+	}
+}
+
+// hasUnescapedPipe reports whether a pipe in the value is not escaped by an odd
+// number of backslashes, which would split a Markdown table cell
+func hasUnescapedPipe(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] != '|' {
+			continue
+		}
+		backslashes := 0
+		for j := i - 1; j >= 0 && value[j] == '\\'; j-- {
+			backslashes++
+		}
+		if backslashes%2 == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func FuzzEscapeMarkdownInline(f *testing.F) {
+	for _, seed := range []string{
+		"Fix | parser",
+		"# heading\r\n<script>alert(1)</script>",
+		"line\rbreak",
+		`\|`,
+		"*bold* _em_ [link](url) `code`",
+		"{code:java}int x = 1;{code}",
+		"h1. Jira heading\n||a||b||",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		result := escapeMarkdownInline(value)
+
+		if strings.ContainsAny(result, "\r\n") {
+			t.Errorf("Expected a single line, got %q", result)
+		}
+		if strings.ContainsAny(result, "<>") {
+			t.Errorf("Expected raw HTML to be escaped, got %q", result)
+		}
+		if hasUnescapedPipe(result) {
+			t.Errorf("Expected pipes to be escaped, got %q", result)
+		}
+	})
+}
+
+func FuzzEscapeMarkdownBlock(f *testing.F) {
+	for _, seed := range []string{
+		"First line\n# Not a heading\n---\n| cell |",
+		"text\r# heading after a carriage return",
+		"  ### indented\r\n===",
+		"<img src=x onerror=alert(1)>",
+		"{quote}\nquoted\n{quote}\nbq. block quote",
+		"- [ ] todo\n* [x] done",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		result := escapeMarkdownBlock(value)
+
+		if strings.ContainsAny(result, "<>") {
+			t.Errorf("Expected raw HTML to be escaped, got %q", result)
+		}
+		if hasUnescapedPipe(result) {
+			t.Errorf("Expected pipes to be escaped, got %q", result)
+		}
+		for _, line := range strings.FieldsFunc(result, func(r rune) bool { return r == '\n' || r == '\r' }) {
+			if strings.HasPrefix(strings.TrimLeft(line, " \t"), "#") {
+				t.Errorf("Expected no line to open a heading, got %q", result)
+			}
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package jira
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern matches a mention in Jira wiki markup, capturing the account:
+// [~accountid:5b10ac8d82e05b22cc7d4ef5] on Jira Cloud, [~jdoe] on Jira Server
+var mentionPattern = regexp.MustCompile(`\[~(?:accountid:)?([^\]\s]+)\]`)
+
+// mentionsAccount reports whether the text mentions the account. Mentions
+// are matched by account ID, or by username on Jira Server, never by the
+// display name they render as.
+func mentionsAccount(text, accountID string) bool {
+	if accountID == "" || !strings.Contains(text, "[~") {
+		return false
+	}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		if match[1] == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeComments flags the comments mentioning the user and, for reports
+// of the user's own comments only, leaves out the comments of other people.
+// Both go by account ID, or by username on Jira Server, so that a renamed
+// user keeps their comments.
+func attributeComments(issues []Issue, accountID string, ownOnly bool) {
+	for i := range issues {
+		comments := issues[i].Comments[:0]
+		for _, comment := range issues[i].Comments {
+			own := isAccount(comment.authorID(), accountID)
+			if ownOnly && !own {
+				continue
+			}
+			comment.MentionsUser = !own && mentionsAccount(comment.Content, accountID)
+			comments = append(comments, comment)
+		}
+		issues[i].Comments = comments
+	}
+}
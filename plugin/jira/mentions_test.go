@@ -0,0 +1,118 @@
+package jira
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMentionsAccount(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		text     string
+		expected bool
+	}{
+		{name: "Cloud mention", text: "[~accountid:user123] can you take a look?", expected: true},
+		{name: "Server mention", text: "Thanks [~user123]", expected: true},
+		{name: "Someone else", text: "[~accountid:qa1] can you take a look?", expected: false},
+		{name: "Account ID prefix", text: "[~accountid:user1234]", expected: false},
+		{name: "Display name", text: "Test User, can you take a look?", expected: false},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if mentioned := mentionsAccount(tc.text, "user123"); mentioned != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, mentioned)
+			}
+		})
+	}
+}
+
+func FuzzMentionsAccount(f *testing.F) {
+	for _, seed := range []struct{ text, accountID string }{
+		{"[~accountid:user123] can you take a look?", "user123"},
+		{"Thanks [~jdoe]", "jdoe"},
+		{"[~accountid:user1234]", "user123"},
+		{"[~accountid:]] [~ [~jdoe", "jdoe"},
+		{"{quote}[~jdoe]{quote} h1. [~jdoe]", "jdoe"},
+		{"[~accountid:accountid:x]", "accountid:x"},
+		{"[~accountid:x]", "accountid:x"},
+	} {
+		f.Add(seed.text, seed.accountID)
+	}
+
+	f.Fuzz(func(t *testing.T, text, accountID string) {
+		mentioned := mentionsAccount(text, accountID)
+
+		if accountID == "" && mentioned {
+			t.Errorf("Expected no mention of an empty account in %q", text)
+		}
+		if mentioned && !strings.Contains(text, accountID+"]") {
+			t.Errorf("Expected a mention of %q to name the account, got %q", accountID, text)
+		}
+
+		// An account that can be mentioned is found after any text
+		if accountID == "" || strings.ContainsAny(accountID, "] \t\n\f\r") {
+			return
+		}
+		mentions := []string{"[~accountid:" + accountID + "]"}
+		// A Server username starting with the Cloud prefix reads as an account ID
+		if !strings.HasPrefix(accountID, "accountid:") {
+			mentions = append(mentions, "[~"+accountID+"]")
+		}
+		for _, mention := range mentions {
+			if !mentionsAccount(text+" "+mention, accountID) {
+				t.Errorf("Expected %q to mention %q", text+" "+mention, accountID)
+			}
+		}
+	})
+}
+
+func TestAttributeComments(t *testing.T) {
+	newIssues := func() []Issue {
+		return []Issue{{
+			Key: "JIRA-1",
+			Comments: []Comment{
+				{ID: "1", Author: "Test U.", AuthorAccountID: "user123", Content: "Renamed, still mine"},
+				{ID: "2", Author: "Test User", AuthorAccountID: "dev9", Content: "A namesake"},
+				{ID: "3", Author: "QA", AuthorAccountID: "qa1", Content: "[~accountid:user123] please review"},
+			},
+		}}
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		ownOnly  bool
+		expected []Comment
+	}{
+		{
+			name: "Everyone's comments",
+			expected: []Comment{
+				{ID: "1", Author: "Test U.", AuthorAccountID: "user123", Content: "Renamed, still mine"},
+				{ID: "2", Author: "Test User", AuthorAccountID: "dev9", Content: "A namesake"},
+				{ID: "3", Author: "QA", AuthorAccountID: "qa1", Content: "[~accountid:user123] please review", MentionsUser: true},
+			},
+		},
+		{
+			name:    "Own comments only",
+			ownOnly: true,
+			expected: []Comment{
+				{ID: "1", Author: "Test U.", AuthorAccountID: "user123", Content: "Renamed, still mine"},
+			},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			issues := newIssues()
+			attributeComments(issues, "user123", tc.ownOnly)
+			if !reflect.DeepEqual(issues[0].Comments, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, issues[0].Comments)
+			}
+		})
+	}
+}
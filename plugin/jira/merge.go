@@ -0,0 +1,116 @@
+package jira
+
+import (
+	"sort"
+	"strings"
+)
+
+// mergeIssue combines two results for the same issue, such as the issue
+// returned by both the activity search and a supplementary query. The
+// comments, changes, remote links and worklogs are the union of both, without
+// duplicates and in time order; anything else the first result lacks is
+// taken from the second.
+func mergeIssue(issue, other Issue) Issue {
+	comments := make(map[string]bool, len(issue.Comments))
+	for _, comment := range issue.Comments {
+		comments[commentEventID(issue.Key, comment)] = true
+	}
+	for _, comment := range other.Comments {
+		if !comments[commentEventID(issue.Key, comment)] {
+			issue.Comments = append(issue.Comments, comment)
+		}
+	}
+	sort.SliceStable(issue.Comments, func(i, j int) bool {
+		return issue.Comments[i].Timestamp.Before(issue.Comments[j].Timestamp)
+	})
+
+	changes := make(map[string]bool, len(issue.Changes))
+	for _, change := range issue.Changes {
+		changes[changeEventID(issue.Key, change)] = true
+	}
+	for _, change := range other.Changes {
+		if !changes[changeEventID(issue.Key, change)] {
+			issue.Changes = append(issue.Changes, change)
+		}
+	}
+	sort.SliceStable(issue.Changes, func(i, j int) bool {
+		return issue.Changes[i].Timestamp.Before(issue.Changes[j].Timestamp)
+	})
+
+	links := make(map[string]bool, len(issue.RemoteLinks))
+	for _, link := range issue.RemoteLinks {
+		links[remoteLinkEventID(issue.Key, link)] = true
+	}
+	for _, link := range other.RemoteLinks {
+		if !links[remoteLinkEventID(issue.Key, link)] {
+			issue.RemoteLinks = append(issue.RemoteLinks, link)
+		}
+	}
+
+	worklogs := make(map[string]bool, len(issue.Worklogs))
+	for _, worklog := range issue.Worklogs {
+		worklogs[worklogEventID(issue.Key, worklog)] = true
+	}
+	for _, worklog := range other.Worklogs {
+		if !worklogs[worklogEventID(issue.Key, worklog)] {
+			issue.Worklogs = append(issue.Worklogs, worklog)
+		}
+	}
+	sort.SliceStable(issue.Worklogs, func(i, j int) bool {
+		return issue.Worklogs[i].Started.Before(issue.Worklogs[j].Started)
+	})
+
+	if issue.Reopened == nil {
+		issue.Reopened = other.Reopened
+	}
+	if issue.Escalation == nil {
+		issue.Escalation = other.Escalation
+	}
+	if issue.Handoff == nil {
+		issue.Handoff = other.Handoff
+	}
+	if issue.DueDate.IsZero() {
+		issue.DueDate = other.DueDate
+	}
+	if issue.StatusSince.IsZero() {
+		issue.StatusSince = other.StatusSince
+	}
+	return issue
+}
+
+// mergeIssues lists each issue once, merging the results that share a key
+// into the first of them and keeping the order in which the keys first appear
+func mergeIssues(issues []Issue) []Issue {
+	result := make([]Issue, 0, len(issues))
+	index := make(map[string]int, len(issues))
+	for _, issue := range issues {
+		key := strings.ToUpper(issue.Key)
+		if i, ok := index[key]; ok {
+			result[i] = mergeIssue(result[i], issue)
+			continue
+		}
+		index[key] = len(result)
+		result = append(result, issue)
+	}
+	return result
+}
+
+// mergeReported merges the issues that were already reported into the
+// reported list, in place, and returns the others. An issue that appears in
+// more than one query is then listed once, with the activity of all of them.
+func mergeReported(issues []Issue, reported []Issue) []Issue {
+	index := make(map[string]int, len(reported))
+	for i, issue := range reported {
+		index[strings.ToUpper(issue.Key)] = i
+	}
+
+	result := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if i, ok := index[strings.ToUpper(issue.Key)]; ok {
+			reported[i] = mergeIssue(reported[i], issue)
+			continue
+		}
+		result = append(result, issue)
+	}
+	return mergeIssues(result)
+}
@@ -0,0 +1,98 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestMergeIssue(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2023, 1, 1, hour, 0, 0, 0, time.UTC) }
+	escalation := &PriorityEscalation{FromPriority: "Medium", ToPriority: "Blocker"}
+
+	issue := Issue{
+		Key:      "JIRA-1",
+		Comments: []Comment{{ID: "2", Timestamp: at(11), Content: "Second"}},
+		Changes:  []Change{{Field: "status", FromValue: "Open", ToValue: "In Progress", Timestamp: at(9)}},
+	}
+	other := Issue{
+		Key:      "JIRA-1",
+		Comments: []Comment{{ID: "1", Timestamp: at(10), Content: "First"}, {ID: "2", Timestamp: at(11), Content: "Second"}},
+		Changes: []Change{
+			{Field: "status", FromValue: "Open", ToValue: "In Progress", Timestamp: at(9)},
+			{Field: "priority", FromValue: "Medium", ToValue: "Blocker", Timestamp: at(12)},
+		},
+		Escalation: escalation,
+	}
+
+	merged := mergeIssue(issue, other)
+	if len(merged.Comments) != 2 || merged.Comments[0].ID != "1" || merged.Comments[1].ID != "2" {
+		t.Errorf("Expected comments 1 and 2 once each, in time order, got %+v", merged.Comments)
+	}
+	if len(merged.Changes) != 2 || merged.Changes[0].Field != "status" || merged.Changes[1].Field != "priority" {
+		t.Errorf("Expected the status and priority changes once each, got %+v", merged.Changes)
+	}
+	if merged.Escalation != escalation {
+		t.Errorf("Expected the escalation to be taken from the other result, got %+v", merged.Escalation)
+	}
+}
+
+func TestMergeReported(t *testing.T) {
+	reported := []Issue{{Key: "JIRA-1", Comments: []Comment{{ID: "1"}}}}
+	issues := []Issue{
+		{Key: "jira-1", Comments: []Comment{{ID: "2"}}},
+		{Key: "JIRA-2"},
+		{Key: "JIRA-2", Comments: []Comment{{ID: "3"}}},
+	}
+
+	rest := mergeReported(issues, reported)
+	if len(rest) != 1 || rest[0].Key != "JIRA-2" || len(rest[0].Comments) != 1 {
+		t.Errorf("Expected JIRA-2 once with its comment, got %+v", rest)
+	}
+	if len(reported[0].Comments) != 2 {
+		t.Errorf("Expected the comment of the duplicate merged into JIRA-1, got %+v", reported[0].Comments)
+	}
+}
+
+func TestActivityService_MergesDuplicateIssues(t *testing.T) {
+	posted := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "JIRA-1", Comments: []Comment{{ID: "1", Timestamp: posted, Content: "Looking into it"}}}}, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{
+				Key:        "JIRA-1",
+				Comments:   []Comment{{ID: "1", Timestamp: posted, Content: "Looking into it"}, {ID: "2", Timestamp: posted.Add(time.Hour), Content: "Raised to Blocker"}},
+				Escalation: &PriorityEscalation{FromPriority: "Medium", ToPriority: "Blocker", Author: "QA"},
+			}}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.IncludeEscalations = true
+	options.IncludeFiled = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(report.Issues) != 1 || len(report.Filed) != 0 {
+		t.Fatalf("Expected JIRA-1 listed once, got %+v and filed %+v", report.Issues, report.Filed)
+	}
+	issue := report.Issues[0]
+	if len(issue.Comments) != 2 || issue.Escalation == nil {
+		t.Errorf("Expected both comments and the escalation, got %+v", issue)
+	}
+}
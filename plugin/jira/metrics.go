@@ -0,0 +1,76 @@
+package jira
+
+import (
+	"sync/atomic"
+)
+
+// TransferMetrics describes the HTTP traffic exchanged with Jira
+type TransferMetrics struct {
+	// Number of requests sent to Jira
+	Requests int64
+
+	// Response bytes received over the wire, compressed when the server used gzip
+	BytesTransferred int64
+
+	// Response bytes after decompression
+	BytesDecoded int64
+}
+
+// Sub returns the traffic recorded since the earlier snapshot
+func (m TransferMetrics) Sub(earlier TransferMetrics) TransferMetrics {
+	return TransferMetrics{
+		Requests:         m.Requests - earlier.Requests,
+		BytesTransferred: m.BytesTransferred - earlier.BytesTransferred,
+		BytesDecoded:     m.BytesDecoded - earlier.BytesDecoded,
+	}
+}
+
+// CompressionRatio returns the decoded size divided by the transferred size,
+// or 0 if nothing was transferred
+func (m TransferMetrics) CompressionRatio() float64 {
+	if m.BytesTransferred == 0 {
+		return 0
+	}
+	return float64(m.BytesDecoded) / float64(m.BytesTransferred)
+}
+
+// ReportMetrics holds the metrics collected while building a report
+type ReportMetrics struct {
+	Transfer TransferMetrics
+}
+
+// MetricsRecorder accumulates transfer metrics; it is safe for concurrent use
+type MetricsRecorder struct {
+	requests         int64
+	bytesTransferred int64
+	bytesDecoded     int64
+}
+
+// NewMetricsRecorder creates a new metrics recorder
+func NewMetricsRecorder() *MetricsRecorder {
+	return &MetricsRecorder{}
+}
+
+// Snapshot returns the metrics recorded so far
+func (r *MetricsRecorder) Snapshot() TransferMetrics {
+	return TransferMetrics{
+		Requests:         atomic.LoadInt64(&r.requests),
+		BytesTransferred: atomic.LoadInt64(&r.bytesTransferred),
+		BytesDecoded:     atomic.LoadInt64(&r.bytesDecoded),
+	}
+}
+
+// addRequest records a request sent to Jira
+func (r *MetricsRecorder) addRequest() {
+	atomic.AddInt64(&r.requests, 1)
+}
+
+// addTransferred records response bytes received over the wire
+func (r *MetricsRecorder) addTransferred(n int) {
+	atomic.AddInt64(&r.bytesTransferred, int64(n))
+}
+
+// addDecoded records response bytes after decompression
+func (r *MetricsRecorder) addDecoded(n int) {
+	atomic.AddInt64(&r.bytesDecoded, int64(n))
+}
@@ -0,0 +1,87 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestTransferMetrics_Sub(t *testing.T) {
+	later := TransferMetrics{Requests: 5, BytesTransferred: 300, BytesDecoded: 1200}
+	earlier := TransferMetrics{Requests: 2, BytesTransferred: 100, BytesDecoded: 400}
+
+	result := later.Sub(earlier)
+	expected := TransferMetrics{Requests: 3, BytesTransferred: 200, BytesDecoded: 800}
+	if result != expected {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+}
+
+func TestTransferMetrics_CompressionRatio(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		metrics  TransferMetrics
+		expected float64
+	}{
+		{
+			name:     "No traffic",
+			metrics:  TransferMetrics{},
+			expected: 0,
+		},
+		{
+			name:     "Compressed traffic",
+			metrics:  TransferMetrics{BytesTransferred: 100, BytesDecoded: 400},
+			expected: 4,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if ratio := tc.metrics.CompressionRatio(); ratio != tc.expected {
+				t.Errorf("Expected ratio %v, got %v", tc.expected, ratio)
+			}
+		})
+	}
+}
+
+func TestActivityService_TransferMetrics(t *testing.T) {
+	metrics := NewMetricsRecorder()
+
+	// Traffic recorded before the report must not be attributed to it
+	metrics.addRequest()
+	metrics.addTransferred(50)
+
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			metrics.addRequest()
+			metrics.addTransferred(10)
+			metrics.addDecoded(40)
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			metrics.addRequest()
+			metrics.addTransferred(100)
+			metrics.addDecoded(600)
+			return []Issue{}, nil
+		},
+	}
+
+	service := NewActivityService(mockRepo)
+	service.SetMetricsRecorder(metrics)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := TransferMetrics{Requests: 2, BytesTransferred: 110, BytesDecoded: 640}
+	if report.Metrics.Transfer != expected {
+		t.Errorf("Expected %+v, got %+v", expected, report.Metrics.Transfer)
+	}
+}
@@ -9,27 +9,90 @@ import (
 
 // ActivityReport represents processed activity data for a specific time range
 type ActivityReport struct {
-	TimeRange TimeRange
-	User      User
-	Issues    []Issue
+	TimeRange   TimeRange
+	User        User
+	Issues      []Issue
+	CarryOver   []Issue
+	Blockers    []Issue
+	Filed       []Issue // Issues the user created in the range, without activity of their own
+	Handoffs    *Handoffs // Set when handoffs are included
+	Sprint      *SprintScope // Set when a board is configured and has an active sprint
+	DueSoon     []Issue // Open issues assigned to the user due within the horizon, earliest first
+	Pinned      []Issue // Pinned issues without activity in the range, in the order they were pinned
+	Truncation  *SearchTruncation // Set when the activity search matched more issues than it returned
+	AsOf        time.Time          // Set when statuses and assignees were reconstructed as of the end of the range
+	Warnings    []ReportWarning    // Problems that left data out of the report without preventing it
+	Epics       []EpicRollup       // Set in epic mode
+	Initiatives []InitiativeRollup // Set in initiative mode
+	Components  []ComponentDigest  // Set in component mode
+	Release     *ReleaseNotes      // Set in release mode
+	Comparison  *ReleaseComparison // Set in release mode when compared with an earlier release
+	Triage      []Issue            // Set in triage mode, highest priority first
+	Authors     []User             // Set when authors are resolved through a UserDirectory
+	Heatmap     *ActivityHeatmap   // Set when the activity heatmap is included
+	Stats       *StatsBlock        // Set when velocity statistics are included
+	Options     ReportOptions
+	Metrics     ReportMetrics
+	Generation  *GenerationInfo // Set when the report is generated by the service
 }
 
 // TimeRange represents a time period for the report
 type TimeRange struct {
 	Start time.Time
 	End   time.Time
+
+	// Whether events exactly at End are in the range. Ranges are half-open by
+	// default, so that consecutive ranges sharing a boundary, such as days
+	// from midnight to midnight, count an event at the boundary once; a range
+	// ending at 23:59:59 includes its end so as not to drop that second.
+	EndInclusive bool
 }
 
-// IsInRange checks if a given time is within the time range
+// IsInRange checks if a given time is within the time range: at or after its
+// start, and before its end, or at it when the end is inclusive
 func (tr TimeRange) IsInRange(t time.Time) bool {
-	return (t.Equal(tr.Start) || t.After(tr.Start)) && t.Before(tr.End)
+	if t.Before(tr.Start) {
+		return false
+	}
+	if tr.EndInclusive {
+		return !t.After(tr.End)
+	}
+	return t.Before(tr.End)
+}
+
+// queryBounds returns the bounds of a search covering the range to the
+// minute, the precision of JQL dates: the start rounded down and the end
+// rounded up, or past the end's minute when the end is inclusive. The search
+// then returns every issue with activity in the range, and possibly some with
+// activity only in the boundary minutes outside it, which IsInRange leaves
+// out; events within the boundary minutes are decided by IsInRange alone.
+func (tr TimeRange) queryBounds() (from, to time.Time) {
+	from = tr.Start.Truncate(time.Minute)
+	to = tr.End.Truncate(time.Minute)
+	if tr.EndInclusive || to.Before(tr.End) {
+		to = to.Add(time.Minute)
+	}
+	return from, to
 }
 
 // User represents a Jira user
 type User struct {
 	AccountID   string
+	Name        string // Username, which identifies the user on Jira Server
 	DisplayName string
 	Email       string
+	AvatarURL   string
+	TimeZone    string
+}
+
+// ID identifies the user in comparisons with the authors of comments and
+// changes: the account ID, or the username on Jira Server, which has no
+// account IDs
+func (u User) ID() string {
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+	return u.Name
 }
 
 // Issue represents a Jira issue with relevant activity data
@@ -37,51 +100,240 @@ type Issue struct {
 	Key     string
 	Summary string
 	Status  string
+	StatusCategory string // Key of the status category: new, indeterminate or done; empty when unknown
+	Description string
+	Reporter User
+	Assignee User
 	Comments []Comment
 	Changes  []Change
+	ActionItems ActionItemSummary
+	ActivitySummary string
+	Transitions *TransitionSummary // Set when status transitions are summarized
+	CollectionChanges []CollectionChange
+	Type     string    // Issue type name, e.g. Story or Epic
+	Resolution string  // Resolution name, e.g. Done or Won't Fix; empty while unresolved
+	Priority   string  // Priority name, e.g. High
+	Parent   *IssueRef // Direct parent, if any
+	Epic     *IssueRef // Set when the epic is known or resolved
+	Components []string
+	Hierarchy  []IssueRef // Ancestors from the top level down, set when the hierarchy is resolved
+	Initiative *IssueRef  // Level directly above the epic, set when the hierarchy is resolved
+	StoryPoints float64   // Set when a story points field is configured
+	Created     time.Time // Set when statistics are included
+	DueDate     time.Time // Set when the issue has a due date and due issues are included
+	StatusSince   time.Time     // When the issue entered its current status; zero when unknown
+	StatusHistory []Change      // Every status change regardless of range or author, set when statistics are included
+	CycleTime     time.Duration // First in-progress status to done; zero when not measured
+	LeadTime      time.Duration // Creation to done; zero when not measured
+	Attention     *AttentionChange // Set when watcher and vote activity is included
+	RemoteLinks   []RemoteLink     // Remote links added within the time range, set when remote links are included
+	Worklogs      []Worklog        // Work started within the time range, set when the worklogs section is included
+	Reopened      *Reopening       // Set when the issue moved from a done status back to an open one within the range
+	Escalation    *PriorityEscalation // Set when the priority was raised within the range
+	Handoff       *Handoff         // Set when the issue was assigned to or away from the user within the range
+	Notes         []Note           // Side notes the user pinned to the issue
+	ReferencedIssues []IssueRef    // Other issues mentioned in the comments and changes within the range
+
+	historyTruncated bool // Set when the embedded changelog may be missing histories
+	unparsedEvents   int  // Comments and histories left out because their timestamps could not be read
+}
+
+// IssueTypeEpic is the issue type name of epics
+const IssueTypeEpic = "Epic"
+
+// IssueRef identifies a related issue such as a parent or epic
+type IssueRef struct {
+	Key     string
+	Summary string
+	Status  string
+	Type    string
+}
+
+// Roles an author can have on an issue, used to annotate other people's changes
+const (
+	RoleReporter   = "reporter"
+	RoleAssignee   = "assignee"
+	RoleThirdParty = "third party"
+)
+
+// RoleOf returns the role the given account has on the issue
+func (i Issue) RoleOf(accountID string) string {
+	switch {
+	case accountID != "" && accountID == i.Reporter.ID():
+		return RoleReporter
+	case accountID != "" && accountID == i.Assignee.ID():
+		return RoleAssignee
+	default:
+		return RoleThirdParty
+	}
 }
 
 // Comment represents a comment on a Jira issue
 type Comment struct {
+	ID        string
 	Timestamp time.Time
 	Author    string
 	Content   string
+	AuthorAvatarURL string
+	AuthorAccountID string
+	AuthorName      string // Username of the author, which identifies them on Jira Server
+	AuthorTimeZone  string // IANA time zone of the author, set when authors are resolved
+	Edited          bool   // Set when the comment was edited after it was posted
+	MentionsUser    bool   // Set when someone else's comment mentions the user
+}
+
+// authorID identifies the author of the comment like User.ID
+func (c Comment) authorID() string {
+	if c.AuthorAccountID != "" {
+		return c.AuthorAccountID
+	}
+	return c.AuthorName
 }
 
 // Change represents a change to a Jira issue
 type Change struct {
 	Timestamp time.Time
 	Author    string
+	AuthorRole string // Role of the author on the issue; empty for the current user's own changes
+	AuthorAccountID string
+	AuthorName      string // Username of the author, which identifies them on Jira Server
+	AuthorTimeZone  string // IANA time zone of the author, set when authors are resolved
+	IsCurrentUser   bool   // Set when the current user made the change, recognized by account ID
 	Field     string
 	FromValue string
 	ToValue   string
+	FromID    string // Raw value replaced by the change, such as the previous assignee's account ID
+	ToID      string // Raw value set by the change, such as the ID of an added link
+	Diff      TextDiff // Word-level diff of an edited long text such as the description
+}
+
+// CollectionChange represents labels or components added to or removed from an issue
+type CollectionChange struct {
+	Timestamp time.Time
+	Author    string
+	AuthorTimeZone string
+	Field     string // LabelsField or ComponentsField
+	Added     []string
+	Removed   []string
+	Source    Change // Changelog entry the additions and removals were read from
+}
+
+// TransitionSummary represents the status journey of an issue within the time range
+type TransitionSummary struct {
+	Journey []string // Statuses visited in order, starting with the initial status
+	Count   int      // Number of status transitions
+	Changes []Change // Status changes the journey was built from, in chronological order
+}
+
+// ActionItem represents a checklist entry or TODO marker found in issue text
+type ActionItem struct {
+	Text string
+	Done bool
+}
+
+// ActionItemSummary groups the action items added or completed within the time range
+type ActionItemSummary struct {
+	Added     []ActionItem
+	Completed []ActionItem
+}
+
+// IsEmpty reports whether the summary contains no action items
+func (s ActionItemSummary) IsEmpty() bool {
+	return len(s.Added) == 0 && len(s.Completed) == 0
 }
 
 // QueryOptions represents configurable options for Jira queries
 type QueryOptions struct {
 	// JQL template with placeholders for dynamic values
 	JQLTemplate string
+
+	// Raw JQL used instead of the template (mutually exclusive with JQLTemplate)
+	RawJQL string
+
+	// ID of a saved Jira filter the query is based on instead of the project
+	// (mutually exclusive with JQLTemplate and RawJQL)
+	FilterID string
 	
 	// Whether to include only issues assigned to the current user
 	AssigneeCurrentUser bool
 	
 	// Project key to filter issues by
 	Project string
+
+	// Project keys searched one by one in multi-project mode, set when
+	// several are configured; Project is the first of them
+	Projects []string
 	
-	// Status filter (e.g., "!= Closed" to exclude closed issues)
+	// Statuses to include (compiled to "status IN (...)")
+	IncludeStatuses []string
+
+	// Statuses to exclude (compiled to "status NOT IN (...)")
+	ExcludeStatuses []string
+
+	// Legacy free-form status filter (e.g., "!= Closed"), used when no status lists are set
 	StatusFilter string
 	
 	// Whether to include only issues in open sprints
 	InOpenSprints bool
+
+	// Statuses of the user's assigned issues reported as carry-over work
+	CarryOverStatuses []string
+
+	// Issue types to include (compiled to "issuetype IN (...)")
+	IncludeIssueTypes []string
+
+	// Issue types to exclude (compiled to "issuetype NOT IN (...)")
+	ExcludeIssueTypes []string
+
+	// Labels to filter issues by (any of)
+	Labels []string
+
+	// Components to filter issues by (any of)
+	Components []string
+
+	// Custom field holding story points (e.g. customfield_10016), used for velocity statistics
+	StoryPointsField string
+
+	// Custom field holding the Advanced Roadmaps parent link on Jira Data Center
+	// (e.g. customfield_10500); Jira Cloud reports all levels through the parent field
+	ParentLinkField string
 	
 	// Maximum number of results to return
 	MaxResults int
 	
-	// Fields to include in the response
+	// Extra fields to fetch on top of those the report renders, which are
+	// derived from its sections and settings
 	Fields []string
 	
 	// Whether to expand changelog in the response
 	ExpandChangelog bool
+
+	// Whether a hidden email address is looked up through the user search API
+	ResolveEmail bool
+
+	// Whether the query is checked with Jira's JQL parse API before searching
+	ValidateJQL bool
+
+	// Time zone Jira reads the dates of JQL queries in; nil writes them in the
+	// time zone of the report's range
+	TimeZone *time.Location
+}
+
+// jqlTime formats a time for a JQL query in the time zone Jira reads it in,
+// so that a range starting at midnight in Sydney does not become the wrong day
+// on a server in New York
+func (o QueryOptions) jqlTime(t time.Time, layout string) string {
+	if o.TimeZone != nil {
+		t = t.In(o.TimeZone)
+	}
+	return t.Format(layout)
+}
+
+// jqlRange formats the bounds of a search covering the time range to the
+// minute for a JQL query
+func (o QueryOptions) jqlRange(timeRange TimeRange) (from, to string) {
+	start, end := timeRange.queryBounds()
+	return o.jqlTime(start, jqlMinuteLayout), o.jqlTime(end, jqlMinuteLayout)
 }
 
 // DefaultQueryOptions returns the default query options
@@ -89,10 +341,196 @@ func DefaultQueryOptions() QueryOptions {
 	return QueryOptions{
 		JQLTemplate:       "project = %s AND updatedDate >= %s AND updatedDate < %s",
 		AssigneeCurrentUser: true,
-		StatusFilter:      "!= Closed",
+		ExcludeStatuses:   []string{"Closed"},
 		InOpenSprints:     true,
+		CarryOverStatuses: []string{"In Progress"},
 		MaxResults:        100,
-		Fields:            []string{"summary", "description", "status", "changelog", "comment"},
 		ExpandChangelog:   true,
+		ResolveEmail:      true,
+		ValidateJQL:       true,
 	}
 } 
+
+// ReportOptions represents configurable options for the report content
+type ReportOptions struct {
+	// Whether formatters render only the per-issue activity summary
+	SummaryOnly bool
+
+	// Amount of detail included in the report
+	Verbosity Verbosity
+
+	// Kinds of activity included; empty for comments and changes
+	Sections ReportSections
+
+	// Whether changes made by other people are included alongside the user's own
+	IncludeOthersChanges bool
+
+	// Whether only the user's own comments are included, recognized by account ID
+	OwnCommentsOnly bool
+
+	// Whether status transitions are collapsed into a single journey line
+	SummarizeTransitions bool
+
+	// Whether open work assigned to the user is listed even without activity
+	IncludeCarryOver bool
+
+	// Whether flagged issues are always listed as blockers, regardless of query filters
+	IncludeFlagged bool
+
+	// Whether issues the user created within the range are listed, whoever they are assigned to
+	IncludeFiled bool
+
+	// Whether work assigned to or away from the user within the range is listed
+	IncludeHandoffs bool
+
+	// Issues left out of reports, such as umbrella tickets with constant bot churn
+	IgnoreIssues []string
+
+	// Epics whose issues, and the epics themselves, are left out of reports
+	IgnoreEpics []string
+
+	// Issues always listed with their latest status, whatever their activity
+	// and the query filters, such as a critical escalation being tracked
+	PinnedIssues []string
+
+	// Whether comments, changes and remote links already included in a previous
+	// report are left out, so that overlapping ranges do not repeat them
+	Dedupe bool
+
+	// Number of days after the end of the range within which open issues
+	// assigned to the user are listed as due soon; zero leaves them out
+	DueWithinDays int
+
+	// Agile board whose active sprint heads the report with its scope change;
+	// zero leaves the sprint out
+	SprintBoardID int
+
+	// Whether issues in the project whose priority was raised within the range
+	// are reported, whoever they are assigned to
+	IncludeEscalations bool
+
+	// How issues are grouped in the report
+	Mode ReportMode
+
+	// Whether each issue's parent chain is resolved into a hierarchy path,
+	// including levels above epics such as initiatives
+	IncludeHierarchy bool
+
+	// Whether the user's email address is left out of the report header
+	HideEmail bool
+
+	// Whether event times are shown in the author's local time, for authors
+	// whose time zone was resolved through a UserDirectory
+	AuthorLocalTime bool
+
+	// Whether an hour-of-day heatmap of the report's activity is included
+	IncludeHeatmap bool
+
+	// Whether velocity and throughput statistics are included
+	IncludeStats bool
+
+	// Statuses that count as completing an issue
+	DoneStatuses []string
+
+	// Statuses that start an issue's cycle time
+	InProgressStatuses []string
+
+	// Number of past windows shown alongside the current statistics
+	TrailingWindows int
+
+	// Whether changes in watchers and votes since the previous report are included
+	IncludeAttention bool
+
+	// Whether remote links added within the time range are resolved to their titles and URLs
+	IncludeRemoteLinks bool
+
+	// Approximate number of tokens the activity of a report may take up; the
+	// activity search stops paging once it is reached (0 means unlimited)
+	MaxTokens int
+
+	// Whether statuses and assignees are reconstructed from the changelog as
+	// they were at the end of the time range, rather than reported as they are now
+	Historical bool
+
+	// Jira site URL that issue, comment and history deep links are built from;
+	// empty leaves the Markdown and HTML reports without links
+	LinkBaseURL string
+
+	// Number of issues mentioned in comments and changes, outside the report,
+	// that are looked up for their summary and status; zero leaves referenced
+	// issues unresolved
+	ReferenceLookups int
+
+	// File the analytics are exported to, independent of the report format;
+	// empty disables the export
+	AnalyticsPath string
+
+	// Fix version whose issues are listed in release mode
+	ReleaseVersion string
+
+	// Earlier fix version the release is compared with; empty skips the comparison
+	ReleaseCompareTo string
+
+	// Issue types listed in triage mode when created within the range
+	TriageIssueTypes []string
+
+	// Whether the statistics and attention snapshots of the report are left
+	// out of the stores, so that a report over another kind of range, such as
+	// a weekly summary, does not become a trailing window or baseline of the
+	// regular reports
+	SkipHistory bool
+
+	// Whether the report is posted to the configured chat services. Only the
+	// standup report sets it; reports that skip history are never posted.
+	Publish bool
+
+	// What each format produces for a report without activity
+	EmptyReports EmptyReportPolicy
+
+	// Largest part, in bytes, that reports split into parts are cut into;
+	// 0 keeps them whole
+	PartSize int
+
+	// Longest time range, in days, a report may cover; longer ranges are
+	// refused before anything is queried (0 means unlimited)
+	MaxRangeDays int
+
+	// Whether events exactly at the end of a report's range are included, for
+	// hosts whose ranges end at the last second of a day rather than midnight
+	EndInclusive bool
+}
+
+// DefaultReportOptions returns the default report options
+func DefaultReportOptions() ReportOptions {
+	return ReportOptions{
+		SummaryOnly: false,
+		Verbosity:   VerbosityNormal,
+		Mode:        ReportModeStandard,
+		DoneStatuses:       []string{"Done", "Resolved", "Closed"},
+		InProgressStatuses: []string{"In Progress"},
+		TrailingWindows:    4,
+		TriageIssueTypes:   []string{"Bug", "Incident"},
+		MaxRangeDays:       90,
+	}
+}
+
+// HTTPOptions represents configurable options for the HTTP traffic to Jira
+type HTTPOptions struct {
+	// Maximum number of concurrent requests to Jira (0 means unlimited)
+	MaxConcurrent int
+
+	// Decoded response bytes per report above which a warning is logged (0 disables it)
+	MaxReportBytes int64
+
+	// HTTP client the repository reaches Jira through
+	Client ClientBackend
+}
+
+// DefaultHTTPOptions returns the default HTTP options
+func DefaultHTTPOptions() HTTPOptions {
+	return HTTPOptions{
+		MaxConcurrent:  4,
+		MaxReportBytes: 5 << 20,
+		Client:         ClientGoJira,
+	}
+}
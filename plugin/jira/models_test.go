@@ -1,7 +1,6 @@
 package jira
 
 import (
-	"reflect"
 	"testing"
 	"time"
 )
@@ -59,6 +58,26 @@ func TestTimeRange_IsInRange(t *testing.T) {
 			testTime: time.Date(2023, 1, 4, 0, 0, 0, 0, time.UTC),
 			expected: false,
 		},
+		{
+			name: "Time is equal to end (inclusive end)",
+			timeRange: TimeRange{
+				Start:        time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:          time.Date(2023, 1, 2, 23, 59, 59, 0, time.UTC),
+				EndInclusive: true,
+			},
+			testTime: time.Date(2023, 1, 2, 23, 59, 59, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name: "Time is after end (inclusive end)",
+			timeRange: TimeRange{
+				Start:        time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:          time.Date(2023, 1, 2, 23, 59, 59, 0, time.UTC),
+				EndInclusive: true,
+			},
+			testTime: time.Date(2023, 1, 2, 23, 59, 59, 500, time.UTC),
+			expected: false,
+		},
 	}
 
 	// Run tests
@@ -72,6 +91,63 @@ func TestTimeRange_IsInRange(t *testing.T) {
 	}
 }
 
+func TestTimeRange_QueryBounds(t *testing.T) {
+	at := func(hour, minute, second int) time.Time {
+		return time.Date(2023, 1, 2, hour, minute, second, 0, time.UTC)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name         string
+		timeRange    TimeRange
+		expectedFrom string
+		expectedTo   string
+	}{
+		{
+			name:         "Whole minutes",
+			timeRange:    TimeRange{Start: at(0, 0, 0), End: at(9, 30, 0)},
+			expectedFrom: "2023-01-02 00:00",
+			expectedTo:   "2023-01-02 09:30",
+		},
+		{
+			name:         "Within the boundary minutes",
+			timeRange:    TimeRange{Start: at(0, 0, 30), End: at(9, 30, 45)},
+			expectedFrom: "2023-01-02 00:00",
+			expectedTo:   "2023-01-02 09:31",
+		},
+		{
+			name:         "Inclusive end",
+			timeRange:    TimeRange{Start: at(0, 0, 0), End: at(23, 59, 59), EndInclusive: true},
+			expectedFrom: "2023-01-02 00:00",
+			expectedTo:   "2023-01-03 00:00",
+		},
+		{
+			name:         "Inclusive end on a whole minute",
+			timeRange:    TimeRange{Start: at(0, 0, 0), End: at(9, 30, 0), EndInclusive: true},
+			expectedFrom: "2023-01-02 00:00",
+			expectedTo:   "2023-01-02 09:31",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to := DefaultQueryOptions().jqlRange(tc.timeRange)
+			if from != tc.expectedFrom || to != tc.expectedTo {
+				t.Errorf("Expected %s to %s, got %s to %s", tc.expectedFrom, tc.expectedTo, from, to)
+			}
+
+			// Every event the range holds, to the second, falls within the bounds
+			start, end := tc.timeRange.queryBounds()
+			for event := tc.timeRange.Start.Add(-time.Minute); !event.After(tc.timeRange.End.Add(time.Minute)); event = event.Add(time.Second) {
+				if tc.timeRange.IsInRange(event) && (event.Before(start) || !event.Before(end)) {
+					t.Fatalf("Expected the bounds %v to %v to cover the event at %v", start, end, event)
+				}
+			}
+		})
+	}
+}
+
 func TestDefaultQueryOptions(t *testing.T) {
 	// Get default options
 	options := DefaultQueryOptions()
@@ -85,8 +161,16 @@ func TestDefaultQueryOptions(t *testing.T) {
 		t.Errorf("Expected default AssigneeCurrentUser to be true, got false")
 	}
 
-	if options.StatusFilter != "!= Closed" {
-		t.Errorf("Expected default StatusFilter to be '!= Closed', got '%s'", options.StatusFilter)
+	if options.StatusFilter != "" {
+		t.Errorf("Expected default StatusFilter to be empty, got '%s'", options.StatusFilter)
+	}
+
+	if len(options.IncludeStatuses) != 0 {
+		t.Errorf("Expected default IncludeStatuses to be empty, got %v", options.IncludeStatuses)
+	}
+
+	if len(options.ExcludeStatuses) != 1 || options.ExcludeStatuses[0] != "Closed" {
+		t.Errorf("Expected default ExcludeStatuses to be [Closed], got %v", options.ExcludeStatuses)
 	}
 
 	if !options.InOpenSprints {
@@ -97,12 +181,23 @@ func TestDefaultQueryOptions(t *testing.T) {
 		t.Errorf("Expected default MaxResults to be 100, got %d", options.MaxResults)
 	}
 
-	expectedFields := []string{"summary", "description", "status", "changelog", "comment"}
-	if !reflect.DeepEqual(options.Fields, expectedFields) {
-		t.Errorf("Expected default Fields to be %v, got %v", expectedFields, options.Fields)
+	if len(options.Fields) != 0 {
+		t.Errorf("Expected no extra Fields by default, got %v", options.Fields)
 	}
 
 	if !options.ExpandChangelog {
 		t.Errorf("Expected default ExpandChangelog to be true, got false")
 	}
 } 
+
+func TestDefaultHTTPOptions(t *testing.T) {
+	options := DefaultHTTPOptions()
+
+	if options.MaxConcurrent != 4 {
+		t.Errorf("Expected default MaxConcurrent to be 4, got %d", options.MaxConcurrent)
+	}
+
+	if options.MaxReportBytes != 5<<20 {
+		t.Errorf("Expected default MaxReportBytes to be 5 MiB, got %d", options.MaxReportBytes)
+	}
+}
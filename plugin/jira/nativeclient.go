@@ -0,0 +1,184 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// maxErrorBody is the number of bytes of an error response read for its messages
+const maxErrorBody = 64 << 10
+
+// nativeAPI is a minimal Jira REST client covering the endpoints the
+// repository uses, an alternative to go-jira, which is in maintenance mode
+type nativeAPI struct {
+	httpClient *http.Client
+	baseURL    *url.URL
+}
+
+// newNativeAPI creates a native client for the Jira site at the base URL
+func newNativeAPI(httpClient *http.Client, baseURL string) (*nativeAPI, error) {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &nativeAPI{httpClient: httpClient, baseURL: parsed}, nil
+}
+
+// Self implements jiraAPI
+func (c *nativeAPI) Self() (*extJira.User, error) {
+	var user extJira.User
+	if err := c.Get("rest/api/2/myself", &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Search implements jiraAPI
+func (c *nativeAPI) Search(jql string, options *extJira.SearchOptions) ([]extJira.Issue, int, error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	if options != nil {
+		if options.StartAt > 0 {
+			params.Set("startAt", strconv.Itoa(options.StartAt))
+		}
+		if options.MaxResults > 0 {
+			params.Set("maxResults", strconv.Itoa(options.MaxResults))
+		}
+		if options.Expand != "" {
+			params.Set("expand", options.Expand)
+		}
+		if len(options.Fields) > 0 {
+			params.Set("fields", strings.Join(options.Fields, ","))
+		}
+	}
+
+	var result struct {
+		Total  int             `json:"total"`
+		Issues []extJira.Issue `json:"issues"`
+	}
+	if err := c.Get("rest/api/2/search?"+params.Encode(), &result); err != nil {
+		return nil, 0, err
+	}
+	return result.Issues, result.Total, nil
+}
+
+// FindUsers implements jiraAPI
+func (c *nativeAPI) FindUsers(query string) ([]extJira.User, error) {
+	users := make([]extJira.User, 0)
+	if err := c.Get("rest/api/2/user/search?query="+url.QueryEscape(query), &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// RemoteLinks implements jiraAPI
+func (c *nativeAPI) RemoteLinks(key string) ([]extJira.RemoteLink, error) {
+	links := make([]extJira.RemoteLink, 0)
+	if err := c.Get(fmt.Sprintf("rest/api/2/issue/%s/remotelink", url.PathEscape(key)), &links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// ActiveSprints implements jiraAPI
+func (c *nativeAPI) ActiveSprints(boardID int) ([]extJira.Sprint, error) {
+	var page struct {
+		Values []extJira.Sprint `json:"values"`
+	}
+	if err := c.Get(fmt.Sprintf("rest/agile/1.0/board/%d/sprint?state=active", boardID), &page); err != nil {
+		return nil, err
+	}
+	return page.Values, nil
+}
+
+// Get implements jiraAPI
+func (c *nativeAPI) Get(endpoint string, v interface{}) error {
+	return c.send(http.MethodGet, endpoint, nil, v)
+}
+
+// Post implements jiraAPI
+func (c *nativeAPI) Post(endpoint string, body, v interface{}) error {
+	return c.send(http.MethodPost, endpoint, body, v)
+}
+
+// send runs a request against the site, decoding a successful response into
+// v and an error response into an apiError with Jira's messages
+func (c *nativeAPI) send(method, endpoint string, body, v interface{}) error {
+	target, err := c.baseURL.Parse(strings.TrimPrefix(endpoint, "/"))
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, target.String(), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &apiError{StatusCode: resp.StatusCode, err: responseError(resp)}
+	}
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to decode the response of %s: %w", target.Path, err)
+	}
+	return nil
+}
+
+// responseError builds an error from the messages of a Jira error response,
+// e.g. "Field 'sprint' does not exist: request failed with status 400"
+func responseError(resp *http.Response) error {
+	var body struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	_ = json.Unmarshal(raw, &body)
+
+	messages := append([]string{}, body.ErrorMessages...)
+	fields := make([]string, 0, len(body.Errors))
+	for field := range body.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%s - %s", field, body.Errors[field]))
+	}
+
+	if len(messages) == 0 {
+		return fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+	return fmt.Errorf("%s: request failed with status %d", strings.Join(messages, "; "), resp.StatusCode)
+}
@@ -0,0 +1,119 @@
+package jira
+
+import (
+	"daiv-jira/plugin/jira/internal/jiratest"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// newNativeServerRepository creates a repository talking to a simulated Jira
+// server through the native client
+func newNativeServerRepository(t *testing.T, server *jiratest.Server, config *JiraConfig) *JiraAPIRepository {
+	repo, err := NewNativeJiraAPIRepository(server.HTTPClient(), server.URL, config)
+	if err != nil {
+		t.Fatalf("Failed to create the native repository: %v", err)
+	}
+	return repo
+}
+
+func TestNativeJiraAPIRepository_MatchesGoJira(t *testing.T) {
+	queryOptions := DefaultQueryOptions()
+	queryOptions.Project = "TEST"
+	config := &JiraConfig{QueryOptions: queryOptions, ReportOptions: DefaultReportOptions()}
+
+	goJira, server := newServerRepository(t, config)
+	native := newNativeServerRepository(t, server, config)
+
+	server.Self = &extJira.User{AccountID: "user123", DisplayName: "Test User", EmailAddress: "test@example.com"}
+	server.Issues = []extJira.Issue{
+		{
+			Key: "TEST-1",
+			Fields: &extJira.IssueFields{
+				Summary: "Checkout",
+				Status:  &extJira.Status{Name: "In Progress"},
+				Comments: &extJira.Comments{Comments: []*extJira.Comment{
+					{ID: "1", Created: "2023-01-01T12:00:00.000+0000", Author: extJira.User{AccountID: "user123", DisplayName: "Test User"}, Body: "Started"},
+				}},
+			},
+			Changelog: &extJira.Changelog{Histories: []extJira.ChangelogHistory{
+				statusHistoryEntry(time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), "Open", "In Progress"),
+			}},
+		},
+	}
+	server.RemoteLinks = map[string][]extJira.RemoteLink{
+		"TEST-1": {{ID: 10000, Object: &extJira.RemoteLinkObject{URL: "https://wiki.example.com/pages/123", Title: "Design doc"}}},
+	}
+	server.Sprints = map[int][]extJira.Sprint{12: {{ID: 7, Name: "Sprint 7", State: "active"}}}
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name string
+		call func(repo *JiraAPIRepository) (interface{}, error)
+	}{
+		{name: "User", call: func(repo *JiraAPIRepository) (interface{}, error) { return repo.GetUser() }},
+		{name: "Issues", call: func(repo *JiraAPIRepository) (interface{}, error) {
+			issues, truncation, err := repo.GetIssues(timeRange, "user123")
+			return []interface{}{issues, truncation}, err
+		}},
+		{name: "Remote links", call: func(repo *JiraAPIRepository) (interface{}, error) { return repo.GetRemoteLinks("TEST-1") }},
+		{name: "Sprint scope", call: func(repo *JiraAPIRepository) (interface{}, error) { return repo.GetSprintScope(12, timeRange) }},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected, err := tc.call(goJira)
+			if err != nil {
+				t.Fatalf("Unexpected error from go-jira: %v", err)
+			}
+			got, err := tc.call(native)
+			if err != nil {
+				t.Fatalf("Unexpected error from the native client: %v", err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected the native client to match go-jira:\n%+v\ngot:\n%+v", expected, got)
+			}
+		})
+	}
+}
+
+func TestNativeJiraAPIRepository_Errors(t *testing.T) {
+	server := jiratest.NewServer(t)
+	options := DefaultQueryOptions()
+	options.JQLTemplate = ""
+	options.RawJQL = "status = In Progress"
+	repo := newNativeServerRepository(t, server, &JiraConfig{QueryOptions: options})
+
+	// Jira's error messages are kept, along with the status
+	server.Fail("/rest/api/2/search", http.StatusBadRequest, "Field 'sprint' does not exist or you do not have permission to view it.")
+	_, _, err := repo.GetIssues(TimeRange{}, "user123")
+	if err == nil || !strings.Contains(err.Error(), "Field 'sprint' does not exist") || apiStatus(err) != http.StatusBadRequest {
+		t.Errorf("Expected the search error with status 400, got %v", err)
+	}
+
+	// Servers without the JQL parse API skip the check
+	server.Fail("/rest/api/2/jql/parse", http.StatusNotFound, "Not found.")
+	if err := repo.validateJQL("status = Done"); err != nil {
+		t.Errorf("Expected validation to be skipped, got %v", err)
+	}
+}
+
+func TestParseClientBackend(t *testing.T) {
+	for value, expected := range map[string]ClientBackend{"": ClientGoJira, "go-jira": ClientGoJira, " Native ": ClientNative} {
+		if backend, err := ParseClientBackend(value); err != nil || backend != expected {
+			t.Errorf("Expected %q to parse as %s, got %s (%v)", value, expected, backend, err)
+		}
+	}
+	if _, err := ParseClientBackend("resty"); err == nil {
+		t.Error("Expected an unknown client to be rejected")
+	}
+}
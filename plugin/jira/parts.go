@@ -0,0 +1,90 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SplitContent splits formatted content longer than maxBytes into parts of
+// at most maxBytes each, so that hosts with message size limits, such as chat
+// or a language model context, can take a large report in turn. Parts are cut
+// between lines, preferably before a heading or after a blank line. Markdown,
+// Slack and HTML parts start with a "Part 1/3" marker; JSON, XML and Teams
+// parts are fragments of one document, to be joined in order before parsing.
+// Content within the limit, or a limit of 0, gives a single whole part.
+func SplitContent(content *FormattedContent, maxBytes int) []FormattedContent {
+	if maxBytes <= 0 || len(content.Content) <= maxBytes {
+		return []FormattedContent{*content}
+	}
+
+	// Leave room for the marker of the widest part number
+	limit := maxBytes - len(partMarker(content.ContentType, 999, 999))
+	chunks := splitLines(content.Content, max(limit, 1))
+
+	parts := make([]FormattedContent, len(chunks))
+	for i, chunk := range chunks {
+		parts[i] = FormattedContent{
+			ContentType: content.ContentType,
+			Content:     partMarker(content.ContentType, i+1, len(chunks)) + chunk,
+			Part:        i + 1,
+			Parts:       len(chunks),
+		}
+	}
+	return parts
+}
+
+// partMarker returns the line that opens a part in the content type, or
+// nothing for structured content, which a marker would corrupt
+func partMarker(contentType string, part, parts int) string {
+	switch contentType {
+	case "text/markdown", "text/plain":
+		return fmt.Sprintf("_Part %d/%d_\n\n", part, parts)
+	case "text/html":
+		return fmt.Sprintf("<p><em>Part %d/%d</em></p>\n", part, parts)
+	default:
+		return ""
+	}
+}
+
+// splitLines cuts text into chunks of at most limit bytes. Each chunk ends
+// before a heading in its second half when there is one, then after a blank
+// line, otherwise after its last full line; a line longer than the limit is
+// cut on a character boundary.
+func splitLines(text string, limit int) []string {
+	chunks := make([]string, 0, len(text)/limit+1)
+	for len(text) > limit {
+		window := text[:limit]
+
+		cut := 0
+		for _, heading := range []string{"\n#", "\n<h", "\n*"} {
+			if i := strings.LastIndex(window, heading); i+1 > cut {
+				cut = i + 1
+			}
+		}
+		if i := strings.LastIndex(window, "\n\n"); cut < limit/2 && i >= 0 {
+			cut = i + 2
+		}
+		if cut < limit/2 {
+			cut = strings.LastIndex(window, "\n") + 1
+		}
+		if cut == 0 {
+			cut = limit
+			for cut > 0 && !utf8.RuneStart(text[cut]) {
+				cut--
+			}
+			if cut == 0 {
+				// A character wider than the limit still makes progress
+				_, size := utf8.DecodeRuneInString(text)
+				cut = size
+			}
+		}
+
+		chunks = append(chunks, text[:cut])
+		text = text[cut:]
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
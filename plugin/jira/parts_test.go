@@ -0,0 +1,82 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitContent(t *testing.T) {
+	markdown := "# Report\n\nIntro line\n\n## PAY-1\n\nFirst issue activity\n\n## PAY-2\n\nSecond issue activity\n"
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		content  FormattedContent
+		maxBytes int
+		expected []string
+	}{
+		{
+			name:     "Within the limit",
+			content:  FormattedContent{ContentType: "text/markdown", Content: markdown},
+			maxBytes: len(markdown),
+			expected: []string{markdown},
+		},
+		{
+			name:     "No limit",
+			content:  FormattedContent{ContentType: "text/markdown", Content: markdown},
+			expected: []string{markdown},
+		},
+		{
+			name:     "Markdown cut before headings",
+			content:  FormattedContent{ContentType: "text/markdown", Content: markdown},
+			maxBytes: 60,
+			expected: []string{
+				"_Part 1/3_\n\n# Report\n\nIntro line\n\n",
+				"_Part 2/3_\n\n## PAY-1\n\nFirst issue activity\n\n",
+				"_Part 3/3_\n\n## PAY-2\n\nSecond issue activity\n",
+			},
+		},
+		{
+			name:     "JSON fragments without markers",
+			content:  FormattedContent{ContentType: "application/json", Content: "{\n  \"a\": 1,\n  \"b\": 2\n}"},
+			maxBytes: 12,
+			expected: []string{"{\n  \"a\": 1,\n", "  \"b\": 2\n}"},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parts := SplitContent(&tc.content, tc.maxBytes)
+			if len(parts) != len(tc.expected) {
+				t.Fatalf("Expected %d parts, got %d: %+v", len(tc.expected), len(parts), parts)
+			}
+			for i, part := range parts {
+				if part.Content != tc.expected[i] {
+					t.Errorf("Expected part %d to be %q, got %q", i+1, tc.expected[i], part.Content)
+				}
+				if part.ContentType != tc.content.ContentType {
+					t.Errorf("Expected content type %s, got %s", tc.content.ContentType, part.ContentType)
+				}
+				if len(parts) > 1 && (part.Part != i+1 || part.Parts != len(parts)) {
+					t.Errorf("Expected part %d/%d, got %d/%d", i+1, len(parts), part.Part, part.Parts)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitLines_LongLine(t *testing.T) {
+	text := strings.Repeat("é", 10)
+
+	chunks := splitLines(text, 5)
+	if strings.Join(chunks, "") != text {
+		t.Fatalf("Expected the chunks to join back into the text, got %q", chunks)
+	}
+	for _, chunk := range chunks {
+		if len(chunk) > 5 || !utf8.ValidString(chunk) {
+			t.Errorf("Expected valid chunks of at most 5 bytes, got %q", chunk)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// PinStore keeps the issues pinned at runtime between reports
+type PinStore interface {
+	Load() ([]string, error)
+	Save(keys []string) error
+}
+
+// FilePinStore is a PinStore persisted as a JSON file
+type FilePinStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFilePinStore creates a pin store stored at the given path
+func NewFilePinStore(path string) *FilePinStore {
+	return &FilePinStore{path: path}
+}
+
+// DefaultPinStorePath returns the default location of the pin store
+func DefaultPinStorePath() (string, error) {
+	return defaultStorePath(os.UserConfigDir, "pins.json")
+}
+
+// Load reads the pinned issue keys; a missing file is an empty list
+func (s *FilePinStore) Load() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the pinned issues: %w", err)
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode the pinned issues: %w", err)
+	}
+	return keys, nil
+}
+
+// Save writes the pinned issue keys
+func (s *FilePinStore) Save(keys []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the pinned issues: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create pinned issues directory: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to write the pinned issues: %w", err)
+	}
+	return nil
+}
+
+// Pin adds an issue to the stored pins
+func Pin(store PinStore, key string) error {
+	if store == nil {
+		return fmt.Errorf("no pin store is configured")
+	}
+	keys, err := store.Load()
+	if err != nil {
+		return err
+	}
+	return store.Save(withKey(keys, key))
+}
+
+// Unpin removes an issue from the stored pins
+func Unpin(store PinStore, key string) error {
+	if store == nil {
+		return fmt.Errorf("no pin store is configured")
+	}
+	keys, err := store.Load()
+	if err != nil {
+		return err
+	}
+	return store.Save(withoutKey(keys, key))
+}
+
+// getPinnedIssues fetches the latest state of the pinned issues, whatever the
+// query filters, merging those already reported into their activity. The
+// issues keep the order they were pinned in. Failures are warned about, since
+// the report is still useful without them.
+func (s *ActivityService) getPinnedIssues(options ReportOptions, reported []Issue, warnings *reportWarnings) []Issue {
+	fetched, err := s.repository.GetIssuesByKey(options.PinnedIssues)
+	if err != nil {
+		warnings.add(warningKind(err), "failed to get pinned issues: %v", err)
+		return nil
+	}
+
+	byKey := make(map[string]Issue, len(fetched))
+	for _, issue := range fetched {
+		byKey[strings.ToUpper(issue.Key)] = issue
+	}
+	pinned := make([]Issue, 0, len(fetched))
+	for _, key := range options.PinnedIssues {
+		if issue, ok := byKey[strings.ToUpper(key)]; ok {
+			pinned = append(pinned, issue)
+			delete(byKey, strings.ToUpper(key))
+		}
+	}
+	return mergeReported(pinned, reported)
+}
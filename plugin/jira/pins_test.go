@@ -0,0 +1,72 @@
+package jira
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestFilePinStore(t *testing.T) {
+	store := NewFilePinStore(filepath.Join(t.TempDir(), "daiv-jira", "pins.json"))
+
+	for _, key := range []string{"test-1", "TEST-1", "TEST-2", "TEST-3"} {
+		if err := Pin(store, key); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := Unpin(store, "test-2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	keys, err := store.Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if joined := strings.Join(keys, ","); joined != "TEST-1,TEST-3" {
+		t.Errorf("Expected TEST-1 and TEST-3 once each, got %s", joined)
+	}
+}
+
+func TestActivityService_PinnedIssues(t *testing.T) {
+	var requested []string
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "TEST-1", Status: "In Progress"}}, nil
+		},
+		MockGetIssuesByKey: func(keys []string) ([]Issue, error) {
+			requested = keys
+			return []Issue{
+				{Key: "TEST-1", Status: "In Progress"},
+				{Key: "OPS-7", Status: "Escalated"},
+				{Key: "TEST-9", Status: "Done"},
+			}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.PinnedIssues = []string{"TEST-9", "TEST-1", "ops-7"}
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if keys := strings.Join(requested, ","); keys != "TEST-9,TEST-1,ops-7" {
+		t.Errorf("Expected every pinned issue to be fetched, got %s", keys)
+	}
+	if keys := strings.Join(issueKeys(report.Pinned), ","); keys != "TEST-9,OPS-7" {
+		t.Errorf("Expected TEST-9 and OPS-7 in pin order without the reported TEST-1, got %s", keys)
+	}
+}
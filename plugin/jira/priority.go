@@ -0,0 +1,105 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// priorityField is the changelog field of priority changes
+const priorityField = "priority"
+
+// priorityRanks orders the priorities of Jira's current and legacy default
+// schemes from lowest to highest. Custom priorities are not ranked, so
+// changes to or from them are never reported as escalations.
+var priorityRanks = map[string]int{
+	"lowest":   1,
+	"trivial":  1,
+	"low":      2,
+	"minor":    2,
+	"medium":   3,
+	"major":    3,
+	"high":     4,
+	"critical": 4,
+	"highest":  5,
+	"blocker":  5,
+}
+
+// PriorityEscalation is a raise of an issue's priority
+type PriorityEscalation struct {
+	Timestamp       time.Time
+	Author          string
+	AuthorAccountID string
+	AuthorRole      string // Role of the author on the issue; empty when the user raised it
+	FromPriority    string
+	ToPriority      string
+}
+
+// isEscalation reports whether a priority change raises the priority
+func isEscalation(from, to string) bool {
+	fromRank, fromOK := priorityRanks[strings.ToLower(strings.TrimSpace(from))]
+	toRank, toOK := priorityRanks[strings.ToLower(strings.TrimSpace(to))]
+	return fromOK && toOK && toRank > fromRank
+}
+
+// detectEscalation returns the last priority escalation within the time
+// range, whoever made it, or nil when the priority was not raised
+func detectEscalation(histories []extJira.ChangelogHistory, timeRange TimeRange, userAccountID string, issue Issue) *PriorityEscalation {
+	var escalation *PriorityEscalation
+	for _, history := range histories {
+		createdTime, err := parseJiraTime(history.Created)
+		if err != nil || !timeRange.IsInRange(createdTime) {
+			continue
+		}
+
+		for _, item := range history.Items {
+			if !strings.EqualFold(item.Field, priorityField) || !isEscalation(item.FromString, item.ToString) {
+				continue
+			}
+			if escalation != nil && createdTime.Before(escalation.Timestamp) {
+				continue
+			}
+
+			role := ""
+			if !isAccount(userID(history.Author), userAccountID) {
+				role = issue.RoleOf(userID(history.Author))
+			}
+			escalation = &PriorityEscalation{
+				Timestamp:       createdTime,
+				Author:          displayName(history.Author),
+				AuthorAccountID: history.Author.AccountID,
+				AuthorRole:      role,
+				FromPriority:    item.FromString,
+				ToPriority:      item.ToString,
+			}
+		}
+	}
+	return escalation
+}
+
+// escalatedIssues returns the issues whose priority was raised
+func escalatedIssues(issues []Issue) []Issue {
+	result := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Escalation != nil {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// escalationLine renders a priority escalation and who made it, e.g.
+// "Medium → Blocker by QA (reporter)"; it is empty when the priority was not raised
+func escalationLine(escalation *PriorityEscalation) string {
+	if escalation == nil {
+		return ""
+	}
+
+	author := escalation.Author
+	if escalation.AuthorRole != "" {
+		author = fmt.Sprintf("%s (%s)", author, escalation.AuthorRole)
+	}
+	return fmt.Sprintf("%s → %s by %s", escalation.FromPriority, escalation.ToPriority, author)
+}
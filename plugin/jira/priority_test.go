@@ -0,0 +1,184 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestIsEscalation(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		from     string
+		to       string
+		expected bool
+	}{
+		{name: "Raised", from: "Medium", to: "Highest", expected: true},
+		{name: "Legacy scheme", from: "Major", to: "Blocker", expected: true},
+		{name: "Across schemes", from: "Low", to: "critical", expected: true},
+		{name: "Lowered", from: "High", to: "Low", expected: false},
+		{name: "Same rank", from: "Highest", to: "Blocker", expected: false},
+		{name: "Custom priority", from: "P3", to: "P1", expected: false},
+		{name: "Newly set", from: "", to: "High", expected: false},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if escalation := isEscalation(tc.from, tc.to); escalation != tc.expected {
+				t.Errorf("Expected %v for %s → %s, got %v", tc.expected, tc.from, tc.to, escalation)
+			}
+		})
+	}
+}
+
+func TestDetectEscalation(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	issue := Issue{Reporter: User{AccountID: "qa1"}, Assignee: User{AccountID: "user123"}}
+	histories := []extJira.ChangelogHistory{
+		{
+			Created: "2023-01-01T10:00:00.000+0000",
+			Author:  extJira.User{AccountID: "qa1", DisplayName: "QA"},
+			Items:   []extJira.ChangelogItems{{Field: "priority", FromString: "Low", ToString: "Medium"}},
+		},
+		{
+			Created: "2023-01-02T10:00:00.000+0000",
+			Author:  extJira.User{AccountID: "qa1", DisplayName: "QA"},
+			Items:   []extJira.ChangelogItems{{Field: "priority", FromString: "Medium", ToString: "Blocker"}},
+		},
+		{
+			Created: "2023-01-02T12:00:00.000+0000",
+			Author:  extJira.User{AccountID: "user123", DisplayName: "Test User"},
+			Items:   []extJira.ChangelogItems{{Field: "priority", FromString: "Blocker", ToString: "High"}},
+		},
+	}
+
+	escalation := detectEscalation(histories, timeRange, "user123", issue)
+	if escalation == nil {
+		t.Fatalf("Expected an escalation, got nil")
+	}
+	if escalation.FromPriority != "Medium" || escalation.ToPriority != "Blocker" || escalation.AuthorRole != RoleReporter {
+		t.Errorf("Expected Medium → Blocker by the reporter, got %+v", escalation)
+	}
+	if line := escalationLine(escalation); line != "Medium → Blocker by QA (reporter)" {
+		t.Errorf("Expected 'Medium → Blocker by QA (reporter)', got '%s'", line)
+	}
+
+	// Lowering the priority is not an escalation
+	if escalation := detectEscalation(histories[2:], timeRange, "user123", issue); escalation != nil {
+		t.Errorf("Expected no escalation, got %+v", escalation)
+	}
+}
+
+func TestJiraAPIRepository_GetIssues_EscalatedByOthers(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions(), ReportOptions: DefaultReportOptions()})
+	server.Issues = []extJira.Issue{
+		{
+			Key:    "JIRA-1",
+			Fields: &extJira.IssueFields{Summary: "Checkout", Status: &extJira.Status{Name: "In Progress"}},
+			Changelog: &extJira.Changelog{Histories: []extJira.ChangelogHistory{{
+				Created: "2023-01-02T10:00:00.000+0000",
+				Author:  extJira.User{AccountID: "qa1", DisplayName: "QA"},
+				Items:   []extJira.ChangelogItems{{Field: "priority", FromString: "Medium", ToString: "Highest"}},
+			}}},
+		},
+		{
+			Key:    "JIRA-2",
+			Fields: &extJira.IssueFields{Summary: "Refunds", Status: &extJira.Status{Name: "In Progress"}},
+		},
+	}
+
+	issues, _, err := repo.GetIssues(TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The escalation is reported although the user's own activity is filtered out
+	if len(issues) != 1 || issues[0].Key != "JIRA-1" || issues[0].Escalation == nil || len(issues[0].Changes) != 0 {
+		t.Errorf("Expected only the escalated JIRA-1, got %+v", issues)
+	}
+}
+
+func TestJiraAPIRepository_GetSupplementaryIssues_EscalatedWithoutChanges(t *testing.T) {
+	// Escalations are read from the changelog, whichever sections are reported
+	reportOptions := DefaultReportOptions()
+	reportOptions.Sections = ReportSections{SectionComments: true}
+	reportOptions.IncludeEscalations = true
+	repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: DefaultQueryOptions(), ReportOptions: reportOptions})
+	server.Issues = []extJira.Issue{
+		{Key: "JIRA-1", Fields: &extJira.IssueFields{Summary: "Checkout", Status: &extJira.Status{Name: "In Progress"}}},
+	}
+	server.Changelogs = map[string][]extJira.ChangelogHistory{
+		"JIRA-1": {{
+			Created: "2023-01-02T10:00:00.000+0000",
+			Author:  extJira.User{AccountID: "qa1", DisplayName: "QA"},
+			Items:   []extJira.ChangelogItems{{Field: "priority", FromString: "Medium", ToString: "Highest"}},
+		}},
+	}
+
+	issues, err := repo.GetSupplementaryIssues(SupplementaryEscalated, TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requests := server.Requests("/rest/api/2/search"); len(requests) != 1 || requests[0].Query.Get("expand") != "changelog" {
+		t.Fatalf("Expected one search expanding the changelog, got %+v", requests)
+	}
+	if len(issues) != 1 || issues[0].Escalation == nil || issues[0].Escalation.ToPriority != "Highest" || len(issues[0].Changes) != 0 {
+		t.Errorf("Expected JIRA-1 escalated to Highest without its changes, got %+v", issues)
+	}
+}
+
+func TestActivityService_IncludeEscalations(t *testing.T) {
+	escalation := &PriorityEscalation{Author: "QA", FromPriority: "Medium", ToPriority: "Blocker"}
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "JIRA-1", Status: "In Progress", Escalation: escalation}}, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			if kind != SupplementaryEscalated {
+				t.Errorf("Expected escalated query, got %s", kind)
+			}
+			return []Issue{
+				{Key: "JIRA-1", Status: "In Progress", Escalation: escalation},
+				{Key: "JIRA-4", Status: "To Do", Escalation: escalation},
+				{Key: "JIRA-5", Status: "To Do"},
+			}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.IncludeEscalations = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Lowered priorities and issues already reported are left out
+	keys := issueKeys(report.Issues)
+	if len(keys) != 2 || keys[0] != "JIRA-1" || keys[1] != "JIRA-4" {
+		t.Errorf("Expected JIRA-1 and JIRA-4, got %v", keys)
+	}
+}
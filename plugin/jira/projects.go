@@ -0,0 +1,125 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// projectFieldError is how Jira words a query on a project the user cannot
+// browse, which it cannot tell from a project that does not exist
+const projectFieldError = "for the field 'project'"
+
+// SkippedProject is a project left out of the activity search because Jira
+// refused access to it
+type SkippedProject struct {
+	Key string
+	Err error
+}
+
+// PartialSearchError reports an activity search over several projects that
+// left out the projects Jira refused access to. It is returned along with the
+// issues of the other projects.
+type PartialSearchError struct {
+	Skipped []SkippedProject
+}
+
+// Error lists the projects left out, e.g. "skipped project OPS: ..."
+func (e *PartialSearchError) Error() string {
+	parts := make([]string, 0, len(e.Skipped))
+	for _, skipped := range e.Skipped {
+		parts = append(parts, fmt.Sprintf("skipped project %s: %v", skipped.Key, skipped.Err))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// refusedProjects remembers the projects the last activity search skipped
+// because Jira refused access to them. Jira rejects a query naming any of them
+// as a whole, so the queries over every project leave them out. The zero
+// value is ready to use.
+type refusedProjects struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+// set replaces the refused projects with those skipped by an activity search
+func (p *refusedProjects) set(skipped []SkippedProject) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.keys = make(map[string]bool, len(skipped))
+	for _, project := range skipped {
+		p.keys[project.Key] = true
+	}
+}
+
+// without returns the projects less the refused ones, or all of them when
+// every one was refused, so that the query still reports Jira's error
+func (p *refusedProjects) without(projects []string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	allowed := make([]string, 0, len(projects))
+	for _, project := range projects {
+		if !p.keys[project] {
+			allowed = append(allowed, project)
+		}
+	}
+	if len(allowed) == 0 {
+		return projects
+	}
+	return allowed
+}
+
+// searchedProjects returns the projects the activity search covers, each
+// searched on its own: every configured project in multi-project mode,
+// otherwise the one project. Saved filters and raw JQL name their own projects.
+func (r *JiraAPIRepository) searchedProjects() []string {
+	opts := r.config.QueryOptions
+	if len(opts.Projects) > 1 && opts.FilterID == "" && opts.RawJQL == "" {
+		return opts.Projects
+	}
+	return []string{opts.Project}
+}
+
+// ActivityQuery returns the JQL of the activity search over the time range,
+// one line per project searched
+func (r *JiraAPIRepository) ActivityQuery(timeRange TimeRange) (string, error) {
+	fromTime, toTime := r.config.QueryOptions.jqlRange(timeRange)
+
+	projects := r.searchedProjects()
+	queries := make([]string, 0, len(projects))
+	for _, project := range projects {
+		jql, err := r.buildProjectJQLQuery(project, fromTime, toTime)
+		if err != nil {
+			return "", err
+		}
+		queries = append(queries, jql)
+	}
+	return strings.Join(queries, "\n"), nil
+}
+
+// projectDenied reports whether a search failed because the user may not
+// browse its project, whether Jira answered 403 or 404, or rejected the
+// project in the query
+func projectDenied(err error) bool {
+	switch apiStatus(err) {
+	case http.StatusForbidden, http.StatusNotFound:
+		return true
+	case http.StatusBadRequest:
+		return strings.Contains(err.Error(), projectFieldError)
+	}
+
+	var invalid *InvalidJQLError
+	if errors.As(err, &invalid) {
+		for _, diagnostic := range invalid.Diagnostics {
+			if !strings.Contains(diagnostic.Message, projectFieldError) {
+				return false
+			}
+		}
+		return len(invalid.Diagnostics) > 0
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestProjectDenied(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "Forbidden", err: &apiError{StatusCode: http.StatusForbidden, err: errors.New("forbidden")}, expected: true},
+		{name: "Not found", err: &apiError{StatusCode: http.StatusNotFound, err: errors.New("not found")}, expected: true},
+		{name: "Rejected project", err: &apiError{StatusCode: http.StatusBadRequest, err: errors.New("The value 'OPS' does not exist for the field 'project'.")}, expected: true},
+		{name: "Other bad request", err: &apiError{StatusCode: http.StatusBadRequest, err: errors.New("Field 'sprint' does not exist")}, expected: false},
+		{name: "Rejected project in validation", err: &InvalidJQLError{Diagnostics: []JQLDiagnostic{{Message: "The value 'OPS' does not exist for the field 'project'."}}}, expected: true},
+		{name: "Syntax error in validation", err: &InvalidJQLError{Diagnostics: []JQLDiagnostic{{Message: "Expecting operator"}}}, expected: false},
+		{name: "Server error", err: &apiError{StatusCode: http.StatusInternalServerError, err: errors.New("boom")}, expected: false},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if denied := projectDenied(tc.err); denied != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, denied)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_GetIssues_MultiProject(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	issue := func(key string) extJira.Issue {
+		return extJira.Issue{Key: key, Fields: &extJira.IssueFields{
+			Summary: "Issue",
+			Comments: &extJira.Comments{Comments: []*extJira.Comment{
+				{Created: "2023-01-01T12:00:00.000+0000", Author: extJira.User{AccountID: "user123"}, Body: "Done"},
+			}},
+		}}
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name            string
+		projects        []string
+		validate        bool
+		expectedKeys    []string
+		expectedSkipped []string
+		expectError     bool
+	}{
+		{
+			name:         "Every project is searched",
+			projects:     []string{"PAY", "WEB"},
+			expectedKeys: []string{"PAY-1", "WEB-1"},
+		},
+		{
+			name:            "A project the user cannot browse is skipped",
+			projects:        []string{"PAY", "OPS", "WEB"},
+			expectedKeys:    []string{"PAY-1", "WEB-1"},
+			expectedSkipped: []string{"OPS"},
+		},
+		{
+			name:            "A project rejected by JQL validation is skipped",
+			projects:        []string{"OPS", "PAY"},
+			validate:        true,
+			expectedKeys:    []string{"PAY-1"},
+			expectedSkipped: []string{"OPS"},
+		},
+		{
+			name:        "No project can be browsed",
+			projects:    []string{"OPS", "SEC"},
+			expectError: true,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := DefaultQueryOptions()
+			options.Project = tc.projects[0]
+			options.Projects = tc.projects
+			options.ValidateJQL = tc.validate
+			repo, server := newServerRepository(t, &JiraConfig{QueryOptions: options})
+			server.Projects = map[string][]extJira.Issue{
+				"PAY": {issue("PAY-1")},
+				"WEB": {issue("WEB-1")},
+			}
+
+			issues, _, err := repo.GetIssues(timeRange, "user123")
+			var partial *PartialSearchError
+			if tc.expectError {
+				if err == nil || issues != nil {
+					t.Fatalf("Expected an error without issues, got %v and %+v", err, issues)
+				}
+				return
+			}
+			if len(tc.expectedSkipped) == 0 && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(tc.expectedSkipped) > 0 {
+				if !errors.As(err, &partial) || len(partial.Skipped) != len(tc.expectedSkipped) || partial.Skipped[0].Key != tc.expectedSkipped[0] {
+					t.Fatalf("Expected %v to be skipped, got %v", tc.expectedSkipped, err)
+				}
+				if !strings.Contains(err.Error(), "does not exist for the field 'project'") {
+					t.Errorf("Expected Jira's message in the error, got %v", err)
+				}
+			}
+
+			keys := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				keys = append(keys, issue.Key)
+			}
+			if strings.Join(keys, ",") != strings.Join(tc.expectedKeys, ",") {
+				t.Errorf("Expected issues %v, got %v", tc.expectedKeys, keys)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_GetSupplementaryIssues_SkippedProject(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	options := DefaultQueryOptions()
+	options.Project = "PAY"
+	options.Projects = []string{"PAY", "OPS", "WEB"}
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: options})
+	server.Projects = map[string][]extJira.Issue{
+		"PAY": {{Key: "PAY-7", Fields: &extJira.IssueFields{Summary: "Open work", Status: &extJira.Status{Name: "In Progress"}}}},
+		"WEB": {{Key: "WEB-7", Fields: &extJira.IssueFields{Summary: "Open work", Status: &extJira.Status{Name: "In Progress"}}}},
+	}
+
+	// Jira rejects a query over every project for the one it refuses
+	if _, err := repo.GetSupplementaryIssues(SupplementaryCarryOver, timeRange, "user123"); err == nil || !strings.Contains(err.Error(), "'OPS'") {
+		t.Fatalf("Expected Jira to refuse OPS, got %v", err)
+	}
+
+	// Once the activity search skipped it, the project is left out
+	var partial *PartialSearchError
+	if _, _, err := repo.GetIssues(timeRange, "user123"); !errors.As(err, &partial) {
+		t.Fatalf("Expected OPS to be skipped, got %v", err)
+	}
+	issues, err := repo.GetSupplementaryIssues(SupplementaryCarryOver, timeRange, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Key != "PAY-7" || issues[1].Key != "WEB-7" {
+		t.Errorf("Expected the carry-over of PAY and WEB, got %+v", issues)
+	}
+	requests := server.Requests("/rest/api/2/search")
+	if jql := requests[len(requests)-1].Query.Get("jql"); strings.Contains(jql, "OPS") || !strings.Contains(jql, `project IN ("PAY", "WEB")`) {
+		t.Errorf("Expected OPS to be left out of the query, got '%s'", jql)
+	}
+}
+
+func TestActivityService_SkippedProjects(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "PAY-1", Comments: []Comment{{ID: "1"}}}}, &PartialSearchError{Skipped: []SkippedProject{
+				{Key: "OPS", Err: errors.New("The value 'OPS' does not exist for the field 'project'.")},
+			}}
+		},
+	}
+
+	service := NewActivityService(mockRepo)
+	service.SetLogger(NewNoopLogger())
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Issues) != 1 {
+		t.Errorf("Expected the issues of the other projects, got %+v", report.Issues)
+	}
+	expected := ReportWarning{Kind: WarningPermission, Message: "project OPS was left out: The value 'OPS' does not exist for the field 'project'."}
+	if len(report.Warnings) != 1 || report.Warnings[0] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, report.Warnings)
+	}
+}
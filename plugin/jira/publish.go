@@ -0,0 +1,68 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// publishTimeout bounds a post to a chat service, so that a slow service does
+// not hold up the report
+const publishTimeout = 10 * time.Second
+
+// ReportPublisher posts every generated report to an external service, such
+// as a team chat channel
+type ReportPublisher interface {
+	// Publish posts the report
+	Publish(report *ActivityReport) error
+	Name() string // Returns the name of the service published to
+}
+
+// newPublishClient returns the client, or one with the publish timeout when nil
+func newPublishClient(client *http.Client) *http.Client {
+	if client == nil {
+		return &http.Client{Timeout: publishTimeout}
+	}
+	return client
+}
+
+// postJSON posts the payload as JSON, failing on any status other than 2xx.
+// The response body is returned for services that report errors in it.
+func postJSON(client *http.Client, target string, header http.Header, payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the message: %w", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the request: %w", err)
+	}
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	response, err := client.Do(request)
+	if err != nil {
+		// Webhook URLs embed their secret, so the URL is left out of the error
+		if urlErr, ok := err.(*url.Error); ok {
+			return nil, urlErr.Err
+		}
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response: %w", err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("status %d: %s", response.StatusCode, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
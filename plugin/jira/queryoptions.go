@@ -0,0 +1,188 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// minMaxResults and maxMaxResults bound the page size accepted by the search API
+	minMaxResults = 1
+	maxMaxResults = 1000
+)
+
+// reportFields lists the system fields searchFields requests for the report
+// sections and settings, which are always valid as extra fields
+var reportFields = []string{
+	"summary", "status", "description", "comment", "worklog", "created",
+	"reporter", "assignee", "issuetype", "parent", "components", "resolution",
+	"duedate", "priority",
+}
+
+// systemFields lists the other Jira system fields that can be requested in a search
+var systemFields = []string{
+	"key", "id", "changelog", "creator", "labels", "project", "subtasks",
+	"issuelinks", "updated", "resolutiondate", "fixVersions", "versions",
+	"environment", "watches", "votes", "timetracking", "attachment", "security",
+	"lastViewed", "statuscategorychangedate", "timespent", "timeestimate",
+	"timeoriginalestimate", "aggregatetimespent", "aggregatetimeestimate",
+	"aggregatetimeoriginalestimate", "progress", "aggregateprogress", "workratio",
+}
+
+// knownFields indexes the fields that can be requested in a search
+var knownFields = func() map[string]bool {
+	known := make(map[string]bool, len(reportFields)+len(systemFields))
+	for _, field := range append(append([]string{}, reportFields...), systemFields...) {
+		known[field] = true
+	}
+	return known
+}()
+
+// customFieldPattern matches custom field identifiers such as customfield_10020
+var customFieldPattern = regexp.MustCompile(`^customfield_[0-9]+$`)
+
+// filterIDPattern matches the ID of a saved filter such as 12345
+var filterIDPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// Validate normalizes legacy option forms and checks the options for
+// consistency, returning every problem found
+func (o *QueryOptions) Validate() error {
+	o.normalize()
+
+	errs := o.sourceErrors()
+
+	if o.MaxResults < minMaxResults || o.MaxResults > maxMaxResults {
+		errs = append(errs, fmt.Errorf("max results must be between %d and %d, got %d", minMaxResults, maxMaxResults, o.MaxResults))
+	}
+
+	for _, field := range o.Fields {
+		if !isKnownField(field) {
+			errs = append(errs, fmt.Errorf("unknown field %q", field))
+		}
+	}
+
+	if o.StoryPointsField != "" && !customFieldPattern.MatchString(o.StoryPointsField) {
+		errs = append(errs, fmt.Errorf("story points field must be a custom field such as customfield_10016, got %q", o.StoryPointsField))
+	}
+
+	if o.ParentLinkField != "" && !customFieldPattern.MatchString(o.ParentLinkField) {
+		errs = append(errs, fmt.Errorf("parent link field must be a custom field such as customfield_10500, got %q", o.ParentLinkField))
+	}
+
+	if o.StatusFilter != "" && (len(o.IncludeStatuses) > 0 || len(o.ExcludeStatuses) > 0) {
+		errs = append(errs, errors.New("legacy status filter cannot be combined with status include/exclude lists"))
+	}
+
+	for _, status := range o.IncludeStatuses {
+		if containsFold(o.ExcludeStatuses, status) {
+			errs = append(errs, fmt.Errorf("status %q is both included and excluded", status))
+		}
+	}
+
+	for _, issueType := range o.IncludeIssueTypes {
+		if containsFold(o.ExcludeIssueTypes, issueType) {
+			errs = append(errs, fmt.Errorf("issue type %q is both included and excluded", issueType))
+		}
+	}
+
+	if o.StatusFilter != "" {
+		if _, err := buildJQLCondition("status", o.StatusFilter); err != nil {
+			errs = append(errs, fmt.Errorf("invalid status filter: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sourceErrors checks the saved filter, raw JQL and JQL template the query
+// starts from, which are written into it as they are
+func (o QueryOptions) sourceErrors() []error {
+	var errs []error
+
+	// A saved filter and raw JQL replace the template entirely, so only one of
+	// them may be set
+	switch {
+	case o.FilterID != "":
+		if !filterIDPattern.MatchString(o.FilterID) {
+			errs = append(errs, fmt.Errorf("filter ID must be the numeric ID of a saved filter, got %q", o.FilterID))
+		}
+		if o.RawJQL != "" || o.JQLTemplate != "" {
+			errs = append(errs, errors.New("saved filter, raw JQL and JQL template are mutually exclusive"))
+		}
+	case o.RawJQL != "" && o.JQLTemplate != "":
+		errs = append(errs, errors.New("raw JQL and JQL template are mutually exclusive"))
+	case o.RawJQL == "" && o.JQLTemplate == "":
+		errs = append(errs, errors.New("either a JQL template, raw JQL or a saved filter is required"))
+	case o.JQLTemplate != "" && strings.Count(o.JQLTemplate, "%s") != 3:
+		errs = append(errs, fmt.Errorf("JQL template must contain 3 %%s placeholders (project, start date, end date), got %d", strings.Count(o.JQLTemplate, "%s")))
+	}
+	return errs
+}
+
+// normalize rewrites legacy forms into their canonical representation
+func (o *QueryOptions) normalize() {
+	o.RawJQL = strings.TrimSpace(o.RawJQL)
+	o.FilterID = strings.TrimSpace(o.FilterID)
+	o.ParentLinkField = strings.TrimSpace(o.ParentLinkField)
+	o.StoryPointsField = strings.TrimSpace(o.StoryPointsField)
+	o.StatusFilter = normalizeStatusFilter(o.StatusFilter)
+	o.IncludeStatuses = normalizeList(o.IncludeStatuses)
+	o.ExcludeStatuses = normalizeList(o.ExcludeStatuses)
+	o.IncludeIssueTypes = normalizeList(o.IncludeIssueTypes)
+	o.ExcludeIssueTypes = normalizeList(o.ExcludeIssueTypes)
+	o.CarryOverStatuses = normalizeList(o.CarryOverStatuses)
+
+	o.Fields = normalizeList(o.Fields)
+}
+
+// normalizeList trims the values and drops empty and duplicate entries
+func normalizeList(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = appendMissing(result, value)
+		}
+	}
+	return result
+}
+
+// containsFold reports whether the values contain the value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeStatusFilter converts the legacy "!Closed" and "!=Closed" forms to "!= Closed"
+func normalizeStatusFilter(filter string) string {
+	filter = strings.TrimSpace(filter)
+	if !strings.HasPrefix(filter, "!") || strings.HasPrefix(filter, "!~") {
+		return filter
+	}
+
+	operand := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(filter, "!"), "="))
+	if operand == "" {
+		return filter
+	}
+	return "!= " + operand
+}
+
+// isKnownField reports whether a field can be requested from the search API
+func isKnownField(field string) bool {
+	// Navigable/all selectors and exclusions such as "-comment"
+	if field == "*all" || field == "*navigable" {
+		return true
+	}
+	field = strings.TrimPrefix(field, "-")
+
+	return knownFields[field] || customFieldPattern.MatchString(field)
+}
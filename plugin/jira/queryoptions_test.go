@@ -0,0 +1,236 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQueryOptions_Validate(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name          string
+		modify        func(o *QueryOptions)
+		expectedError string
+	}{
+		{
+			name:   "Default options are valid",
+			modify: func(o *QueryOptions) {},
+		},
+		{
+			name: "Raw JQL without template",
+			modify: func(o *QueryOptions) {
+				o.JQLTemplate = ""
+				o.RawJQL = "project = TEST ORDER BY updated"
+			},
+		},
+		{
+			name: "Raw JQL and template together",
+			modify: func(o *QueryOptions) {
+				o.RawJQL = "project = TEST"
+			},
+			expectedError: "mutually exclusive",
+		},
+		{
+			name: "Neither raw JQL nor template",
+			modify: func(o *QueryOptions) {
+				o.JQLTemplate = ""
+			},
+			expectedError: "either a JQL template, raw JQL or a saved filter is required",
+		},
+		{
+			name: "Saved filter without template",
+			modify: func(o *QueryOptions) {
+				o.JQLTemplate = ""
+				o.FilterID = " 12345 "
+			},
+		},
+		{
+			name: "Saved filter and template together",
+			modify: func(o *QueryOptions) {
+				o.FilterID = "12345"
+			},
+			expectedError: "mutually exclusive",
+		},
+		{
+			name: "Saved filter that is not an ID",
+			modify: func(o *QueryOptions) {
+				o.JQLTemplate = ""
+				o.FilterID = "12345 OR project = OPS"
+			},
+			expectedError: "numeric ID of a saved filter",
+		},
+		{
+			name: "Template with missing placeholders",
+			modify: func(o *QueryOptions) {
+				o.JQLTemplate = "project = %s"
+			},
+			expectedError: "3 %s placeholders",
+		},
+		{
+			name: "Max results too low",
+			modify: func(o *QueryOptions) {
+				o.MaxResults = 0
+			},
+			expectedError: "max results must be between 1 and 1000",
+		},
+		{
+			name: "Max results too high",
+			modify: func(o *QueryOptions) {
+				o.MaxResults = 5000
+			},
+			expectedError: "max results must be between 1 and 1000",
+		},
+		{
+			name: "Custom fields, selectors and exclusions",
+			modify: func(o *QueryOptions) {
+				o.Fields = []string{"*navigable", "-comment", "customfield_10020", "fixVersions"}
+			},
+		},
+		{
+			name: "Fields the plugin requests itself",
+			modify: func(o *QueryOptions) {
+				o.Fields = []string{"key", "worklog", "created", "duedate"}
+			},
+		},
+		{
+			name: "Unknown field",
+			modify: func(o *QueryOptions) {
+				o.Fields = []string{"summary", "sumary"}
+			},
+			expectedError: `unknown field "sumary"`,
+		},
+		{
+			name: "Invalid status filter",
+			modify: func(o *QueryOptions) {
+				o.ExcludeStatuses = nil
+				o.StatusFilter = "LIKE Done"
+			},
+			expectedError: "invalid status filter",
+		},
+		{
+			name: "Legacy status filter combined with lists",
+			modify: func(o *QueryOptions) {
+				o.StatusFilter = "= Done"
+			},
+			expectedError: "cannot be combined",
+		},
+		{
+			name: "Issue type both included and excluded",
+			modify: func(o *QueryOptions) {
+				o.IncludeIssueTypes = []string{"Bug"}
+				o.ExcludeIssueTypes = []string{"bug", "Epic"}
+			},
+			expectedError: `issue type "Bug" is both included and excluded`,
+		},
+		{
+			name: "Status both included and excluded",
+			modify: func(o *QueryOptions) {
+				o.IncludeStatuses = []string{"closed"}
+			},
+			expectedError: `status "closed" is both included and excluded`,
+		},
+		{
+			name: "Parent link custom field",
+			modify: func(o *QueryOptions) {
+				o.ParentLinkField = "customfield_10500"
+			},
+		},
+		{
+			name: "Parent link field that is not a custom field",
+			modify: func(o *QueryOptions) {
+				o.ParentLinkField = "Parent Link"
+			},
+			expectedError: "parent link field must be a custom field",
+		},
+		{
+			name: "Story points field that is not a custom field",
+			modify: func(o *QueryOptions) {
+				o.StoryPointsField = "Story Points"
+			},
+			expectedError: "story points field must be a custom field",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := DefaultQueryOptions()
+			tc.modify(&options)
+
+			err := options.Validate()
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("Expected error containing '%s', got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestReportFields_Known(t *testing.T) {
+	// Every field a report requests is also accepted as an extra field
+	for _, mode := range []ReportMode{ReportModeStandard, ReportModeEpic, ReportModeComponent, ReportModeRelease, ReportModeTriage} {
+		t.Run(string(mode), func(t *testing.T) {
+			report := DefaultReportOptions()
+			report.Mode = mode
+			report.Verbosity = VerbosityFull
+			report.IncludeOthersChanges = true
+			report.IncludeStats = true
+			report.DueWithinDays = 7
+			repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: DefaultQueryOptions(), ReportOptions: report}}
+
+			for _, field := range repo.searchFields() {
+				if !containsFold(reportFields, field) {
+					t.Errorf("Expected %q to be listed in reportFields", field)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryOptions_ValidateNormalizes(t *testing.T) {
+	options := DefaultQueryOptions()
+	options.ExcludeStatuses = nil
+	options.StatusFilter = "!Closed"
+	options.Fields = []string{" summary", "status ", "summary", ""}
+
+	if err := options.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if options.StatusFilter != "!= Closed" {
+		t.Errorf("Expected StatusFilter '!= Closed', got '%s'", options.StatusFilter)
+	}
+	if strings.Join(options.Fields, ",") != "summary,status" {
+		t.Errorf("Expected fields 'summary,status', got '%s'", strings.Join(options.Fields, ","))
+	}
+}
+
+func TestNormalizeStatusFilter(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		filter   string
+		expected string
+	}{
+		{filter: "!Closed", expected: "!= Closed"},
+		{filter: "!=Closed", expected: "!= Closed"},
+		{filter: "!= Closed", expected: "!= Closed"},
+		{filter: "!~ Done", expected: "!~ Done"},
+		{filter: "= Done", expected: "= Done"},
+		{filter: "!", expected: "!"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.filter, func(t *testing.T) {
+			if result := normalizeStatusFilter(tc.filter); result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
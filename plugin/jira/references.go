@@ -0,0 +1,210 @@
+package jira
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// MaxReferenceLookups is the most referenced issues a report may look up,
+// four searches by key
+const MaxReferenceLookups = 4 * keyLookupPageSize
+
+// issueKeyPattern matches a Jira issue key such as PAY-12: a project key of
+// uppercase letters, digits and underscores starting with a letter, and the
+// number of the issue
+var issueKeyPattern = regexp.MustCompile(`[A-Z][A-Z0-9_]+-[1-9][0-9]*`)
+
+// issueKeyMatches returns the start and end of the issue keys standing on
+// their own in the text, leaving out those within a longer word, a URL path,
+// a query or an existing link
+func issueKeyMatches(text string) [][]int {
+	matches := issueKeyPattern.FindAllStringIndex(text, -1)
+	result := matches[:0]
+	for _, match := range matches {
+		if match[0] > 0 && isKeyJoiner(text[match[0]-1], `/=[\.#@"'`) {
+			continue
+		}
+		if match[1] < len(text) && isKeyJoiner(text[match[1]], "/") {
+			continue
+		}
+		result = append(result, match)
+	}
+	return result
+}
+
+// isKeyJoiner reports whether a character next to an issue key joins it to
+// the text around it: a letter, a digit, an underscore, a hyphen or one of
+// the extra characters
+func isKeyJoiner(c byte, extra string) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || strings.IndexByte(extra, c) >= 0
+}
+
+// issueProject returns the project key of an issue key, e.g. PAY for PAY-12
+func issueProject(key string) string {
+	if i := strings.LastIndex(key, "-"); i > 0 {
+		return key[:i]
+	}
+	return key
+}
+
+// referencedIssues returns the other issues mentioned in the comments and
+// changes of an issue, in the order they are first mentioned. Only keys of
+// the known projects count, so that words shaped like keys, such as UTF-8,
+// are not taken for issues.
+func referencedIssues(issue Issue, projects map[string]bool) []IssueRef {
+	var references []IssueRef
+	seen := map[string]bool{issue.Key: true}
+	collect := func(text string) {
+		for _, match := range issueKeyMatches(text) {
+			key := text[match[0]:match[1]]
+			if !seen[key] && projects[issueProject(key)] {
+				seen[key] = true
+				references = append(references, IssueRef{Key: key})
+			}
+		}
+	}
+
+	for _, comment := range issue.Comments {
+		collect(comment.Content)
+	}
+	for _, change := range issue.Changes {
+		collect(change.FromValue)
+		collect(change.ToValue)
+	}
+	return references
+}
+
+// attachReferences sets the issues each issue refers to, among the projects
+// of every issue in the report
+func attachReferences(issues []Issue, others ...[]Issue) {
+	projects := make(map[string]bool)
+	for _, list := range append([][]Issue{issues}, others...) {
+		for _, issue := range list {
+			projects[issueProject(issue.Key)] = true
+		}
+	}
+	for i := range issues {
+		issues[i].ReferencedIssues = referencedIssues(issues[i], projects)
+	}
+}
+
+// resolveReferences sets the summary, status and type of the issues each
+// issue refers to. Issues in the report are taken as they are; the others are
+// looked up, at most limit of them in the order they are first mentioned, so
+// that a report full of mentions does not turn into as many lookups. It
+// returns the number of referenced issues left unresolved beyond the limit.
+func resolveReferences(issues []Issue, fetch func(keys []string) ([]Issue, error), limit int, others ...[]Issue) (int, error) {
+	known := make(map[string]Issue)
+	for _, list := range append([][]Issue{issues}, others...) {
+		for _, issue := range list {
+			known[issue.Key] = issue
+		}
+	}
+
+	missing := make([]string, 0)
+	skipped := make(map[string]bool)
+	for _, issue := range issues {
+		for _, reference := range issue.ReferencedIssues {
+			if _, ok := known[reference.Key]; ok || skipped[reference.Key] || containsFold(missing, reference.Key) {
+				continue
+			}
+			if len(missing) < limit {
+				missing = append(missing, reference.Key)
+			} else {
+				skipped[reference.Key] = true
+			}
+		}
+	}
+
+	var err error
+	if len(missing) > 0 {
+		var fetched []Issue
+		fetched, err = fetch(missing)
+		if err != nil {
+			err = fmt.Errorf("failed to resolve referenced issues: %w", err)
+		}
+		for _, issue := range fetched {
+			known[issue.Key] = issue
+		}
+	}
+
+	// Issues the lookup did not return, such as deleted ones, stay bare keys
+	for i := range issues {
+		for j, reference := range issues[i].ReferencedIssues {
+			if issue, ok := known[reference.Key]; ok {
+				issues[i].ReferencedIssues[j] = issueRef(issue)
+			}
+		}
+	}
+	return len(skipped), err
+}
+
+// referenceLabel returns what follows a resolved reference inline, e.g.
+// " — In Review", or nothing when its status is unknown
+func referenceLabel(reference IssueRef) string {
+	if reference.Status == "" {
+		return ""
+	}
+	return " — " + reference.Status
+}
+
+// linkReferences replaces the keys of the referenced issues in text already
+// escaped for the output with the references rendered by render. Other keys
+// are left as they are.
+func linkReferences(text string, references []IssueRef, render func(reference IssueRef) string) string {
+	if len(references) == 0 {
+		return text
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, match := range issueKeyMatches(text) {
+		reference, ok := findReference(references, text[match[0]:match[1]])
+		if !ok {
+			continue
+		}
+		sb.WriteString(text[last:match[0]])
+		sb.WriteString(render(reference))
+		last = match[1]
+	}
+	if last == 0 {
+		return text
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// findReference returns the reference with the key, and whether there is one
+func findReference(references []IssueRef, key string) (IssueRef, bool) {
+	for _, reference := range references {
+		if reference.Key == key {
+			return reference, true
+		}
+	}
+	return IssueRef{}, false
+}
+
+// referenceKeys returns the keys of the references
+func referenceKeys(references []IssueRef) []string {
+	keys := make([]string, len(references))
+	for i, reference := range references {
+		keys[i] = reference.Key
+	}
+	return keys
+}
+
+// htmlLinkKeys links the keys of the issues referenced by the issue in HTML
+// text to their browse pages, when deep links are enabled, and follows those
+// that were resolved with their status
+func htmlLinkKeys(text string, issue Issue, links DeepLinks) string {
+	return linkReferences(text, issue.ReferencedIssues, func(reference IssueRef) string {
+		label := reference.Key
+		if links.Enabled() {
+			label = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(links.Issue(reference.Key)), reference.Key)
+		}
+		return label + html.EscapeString(referenceLabel(reference))
+	})
+}
@@ -0,0 +1,322 @@
+package jira
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestIssueKeyMatches(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "Keys on their own",
+			text:     "Blocked by PAY-14, see OPS-7.",
+			expected: []string{"PAY-14", "OPS-7"},
+		},
+		{
+			name:     "Keys in parentheses and at the edges",
+			text:     "PAY-1 (MY_PROJ-22)",
+			expected: []string{"PAY-1", "MY_PROJ-22"},
+		},
+		{
+			name:     "Within a URL or a link",
+			text:     "https://example.atlassian.net/browse/PAY-14 and [PAY-15](https://example.atlassian.net/browse/PAY-15)",
+			expected: nil,
+		},
+		{
+			name:     "Within a longer word",
+			text:     "xPAY-14 PAY-14b PAY-14-2 PAY-0",
+			expected: nil,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var keys []string
+			for _, match := range issueKeyMatches(tc.text) {
+				keys = append(keys, tc.text[match[0]:match[1]])
+			}
+			if !reflect.DeepEqual(keys, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, keys)
+			}
+		})
+	}
+}
+
+func TestAttachReferences(t *testing.T) {
+	issues := []Issue{
+		{
+			Key:      "PAY-12",
+			Comments: []Comment{{Content: "Waiting on PAY-14 and OPS-7; UTF-8 is fine. PAY-12 itself, PAY-14 again"}},
+			Changes:  []Change{{Field: "description", FromValue: "Follows SEC-3", ToValue: "Follows PAY-9"}},
+		},
+		{Key: "PAY-14"},
+	}
+	attachReferences(issues, []Issue{{Key: "OPS-7"}})
+
+	// Only keys of projects in the report count, each once, without the issue itself
+	expected := []IssueRef{{Key: "PAY-14"}, {Key: "OPS-7"}, {Key: "PAY-9"}}
+	if !reflect.DeepEqual(issues[0].ReferencedIssues, expected) {
+		t.Errorf("Expected %v, got %v", expected, issues[0].ReferencedIssues)
+	}
+	if len(issues[1].ReferencedIssues) != 0 {
+		t.Errorf("Expected no references, got %v", issues[1].ReferencedIssues)
+	}
+}
+
+func TestResolveReferences(t *testing.T) {
+	issues := []Issue{
+		{Key: "PAY-12", Summary: "Card form", Status: "In Review", ReferencedIssues: []IssueRef{{Key: "PAY-14"}, {Key: "PAY-30"}, {Key: "OPS-7"}}},
+		{Key: "PAY-14", Summary: "Refund API", Status: "In Progress", ReferencedIssues: []IssueRef{{Key: "PAY-31"}, {Key: "PAY-30"}, {Key: "PAY-12"}}},
+	}
+	pinned := []Issue{{Key: "OPS-7", Summary: "Outage", Status: "Escalated"}}
+
+	// Setup test cases
+	testCases := []struct {
+		name            string
+		limit           int
+		fetchErr        error
+		expectedFetched []string
+		expectedSkipped int
+		expectedStatus  string
+	}{
+		{
+			name:            "Within the budget",
+			limit:           2,
+			expectedFetched: []string{"PAY-30", "PAY-31"},
+			expectedStatus:  "Open",
+		},
+		{
+			name:            "Beyond the budget",
+			limit:           1,
+			expectedFetched: []string{"PAY-30"},
+			expectedSkipped: 1,
+			expectedStatus:  "Open",
+		},
+		{
+			name:            "Failed lookup",
+			limit:           2,
+			fetchErr:        errors.New("403 Forbidden"),
+			expectedFetched: []string{"PAY-30", "PAY-31"},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			list := make([]Issue, len(issues))
+			for i, issue := range issues {
+				list[i] = issue
+				list[i].ReferencedIssues = append([]IssueRef{}, issue.ReferencedIssues...)
+			}
+
+			var fetched []string
+			fetch := func(keys []string) ([]Issue, error) {
+				fetched = append(fetched, keys...)
+				if tc.fetchErr != nil {
+					return nil, tc.fetchErr
+				}
+				result := make([]Issue, len(keys))
+				for i, key := range keys {
+					result[i] = Issue{Key: key, Summary: "Elsewhere", Status: "Open"}
+				}
+				return result, nil
+			}
+
+			skipped, err := resolveReferences(list, fetch, tc.limit, pinned)
+			if (err != nil) != (tc.fetchErr != nil) {
+				t.Fatalf("Expected error %v, got %v", tc.fetchErr, err)
+			}
+			if !reflect.DeepEqual(fetched, tc.expectedFetched) {
+				t.Errorf("Expected lookups of %v, got %v", tc.expectedFetched, fetched)
+			}
+			if skipped != tc.expectedSkipped {
+				t.Errorf("Expected %d skipped, got %d", tc.expectedSkipped, skipped)
+			}
+
+			// Issues in the report are resolved without a lookup
+			references := list[0].ReferencedIssues
+			if references[0].Status != "In Progress" || references[2].Summary != "Outage" {
+				t.Errorf("Expected the reported issues to be resolved, got %+v", references)
+			}
+			if references[1].Status != tc.expectedStatus {
+				t.Errorf("Expected PAY-30 status %q, got %+v", tc.expectedStatus, references[1])
+			}
+		})
+	}
+}
+
+func TestLinkReferences(t *testing.T) {
+	issue := Issue{Key: "PAY-12", ReferencedIssues: []IssueRef{{Key: "PAY-14"}}}
+	resolved := Issue{Key: "PAY-12", ReferencedIssues: []IssueRef{{Key: "PAY-14", Summary: "Refund API", Status: "In Review"}}}
+	links := NewDeepLinks("https://example.atlassian.net")
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		format   func(text string) string
+		text     string
+		expected string
+	}{
+		{
+			name:     "Markdown",
+			format:   func(text string) string { return (&MarkdownFormatter{}).linkKeys(text, issue, links) },
+			text:     "Refunds follow in PAY-14, not PAY-99",
+			expected: "Refunds follow in [PAY-14](https://example.atlassian.net/browse/PAY-14), not PAY-99",
+		},
+		{
+			name:     "HTML",
+			format:   func(text string) string { return htmlLinkKeys(text, issue, links) },
+			text:     "PAY-14 <a href=\"https://example.atlassian.net/browse/PAY-14\">link</a>",
+			expected: "<a href=\"https://example.atlassian.net/browse/PAY-14\">PAY-14</a> <a href=\"https://example.atlassian.net/browse/PAY-14\">link</a>",
+		},
+		{
+			name:     "Without deep links",
+			format:   func(text string) string { return htmlLinkKeys(text, issue, DeepLinks{}) },
+			text:     "Refunds follow in PAY-14",
+			expected: "Refunds follow in PAY-14",
+		},
+		{
+			name:     "Resolved without deep links",
+			format:   func(text string) string { return (&MarkdownFormatter{}).linkKeys(text, resolved, DeepLinks{}) },
+			text:     "Depends on PAY-14.",
+			expected: "Depends on PAY-14 — In Review.",
+		},
+		{
+			name:     "Resolved in HTML",
+			format:   func(text string) string { return htmlLinkKeys(text, resolved, links) },
+			text:     "Depends on PAY-14",
+			expected: "Depends on <a href=\"https://example.atlassian.net/browse/PAY-14\">PAY-14</a> — In Review",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := tc.format(tc.text); result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestActivityService_ReferenceLookups(t *testing.T) {
+	var requested [][]string
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{Key: "TEST-1", Status: "In Progress", Comments: []Comment{{Timestamp: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), Content: "Blocked by TEST-7, then TEST-8 and TEST-9"}}},
+			}, nil
+		},
+		MockGetIssuesByKey: func(keys []string) ([]Issue, error) {
+			requested = append(requested, keys)
+			return []Issue{{Key: "TEST-7", Summary: "Schema migration", Status: "In Review"}}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.ReferenceLookups = 2
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// One lookup within the budget; TEST-8 was not returned and stays bare
+	if len(requested) != 1 || strings.Join(requested[0], ",") != "TEST-7,TEST-8" {
+		t.Fatalf("Expected one lookup of TEST-7 and TEST-8, got %v", requested)
+	}
+	expected := []IssueRef{{Key: "TEST-7", Summary: "Schema migration", Status: "In Review"}, {Key: "TEST-8"}, {Key: "TEST-9"}}
+	if !reflect.DeepEqual(report.Issues[0].ReferencedIssues, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, report.Issues[0].ReferencedIssues)
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0].Message, "1 referenced issue left unresolved") {
+		t.Errorf("Expected a warning about the budget, got %+v", report.Warnings)
+	}
+}
+
+func FuzzIssueKeyMatches(f *testing.F) {
+	for _, seed := range []string{
+		"Blocked by PAY-14, see OPS-7.",
+		"PAY-1 (MY_PROJ-22)",
+		"https://jira.example.com/browse/PAY-3 and PAY-4/",
+		"[PAY-5|https://jira.example.com/browse/PAY-5] UTF-8 xPAY-6",
+		"{code}PAY-7{code} *PAY-8* _PAY-9_ ~PAY-10~",
+		"PAY-0 PAY-01 PAY-",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		last := 0
+		for _, match := range issueKeyMatches(text) {
+			if match[0] < last || match[1] > len(text) || match[0] >= match[1] {
+				t.Fatalf("Expected ordered matches within the text, got %v in %q", match, text)
+			}
+			last = match[1]
+
+			key := text[match[0]:match[1]]
+			if issueKeyPattern.FindString(key) != key {
+				t.Errorf("Expected a whole issue key, got %q in %q", key, text)
+			}
+			if match[0] > 0 && isKeyJoiner(text[match[0]-1], "") || match[1] < len(text) && isKeyJoiner(text[match[1]], "") {
+				t.Errorf("Expected %q to stand on its own in %q", key, text)
+			}
+		}
+	})
+}
+
+func FuzzLinkKeys(f *testing.F) {
+	for _, seed := range []struct{ text, status string }{
+		{"Blocked by OPS-14, see MY_PROJ-7.", "In Review"},
+		{"h1. OPS-1\n|| OPS-2 || <b>OPS-3</b> ||", "<script>alert(1)</script>"},
+		{"[OPS-4|https://jira.example.com/browse/OPS-4] {code}OPS-5{code}", "Done | Closed"},
+		{"OPS-6\r# OPS-7\n---", "*bold* [link](url)"},
+	} {
+		f.Add(seed.text, seed.status)
+	}
+
+	formatter := &MarkdownFormatter{}
+	links := NewDeepLinks("https://jira.example.com")
+	f.Fuzz(func(t *testing.T, text, status string) {
+		issues := []Issue{{Key: "PAY-1", Comments: []Comment{{Content: text}}}}
+		attachReferences(issues, []Issue{{Key: "OPS-1"}, {Key: "MY_PROJ-1"}})
+		issue := issues[0]
+		for i := range issue.ReferencedIssues {
+			issue.ReferencedIssues[i].Status = status
+		}
+
+		// Keys rendered as themselves leave the text as it was
+		if result := linkReferences(text, issue.ReferencedIssues, func(reference IssueRef) string { return reference.Key }); result != text {
+			t.Errorf("Expected the text unchanged, got %q from %q", result, text)
+		}
+
+		// Linked keys and their statuses keep the escaped markup safe
+		result := formatter.linkKeys(formatter.block(text), issue, links)
+		if strings.ContainsAny(result, "<>") {
+			t.Errorf("Expected raw HTML to be escaped, got %q", result)
+		}
+		if hasUnescapedPipe(result) {
+			t.Errorf("Expected pipes to be escaped, got %q", result)
+		}
+	})
+}
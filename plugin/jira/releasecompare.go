@@ -0,0 +1,157 @@
+package jira
+
+import (
+	"strings"
+	"time"
+)
+
+// ReleaseComparison compares the issues of a release with those of an earlier
+// release, such as the changes since the last one shipped, or the issues at
+// the end of a time range with those at its start
+type ReleaseComparison struct {
+	From      string  // The earlier version, or the start of the time range
+	To        string  // The later version, or the end of the time range
+	Completed []Issue // Resolved issues of the later release
+	New       []Issue // Issues of the later release that were not in the earlier one
+	Reopened  []Issue // Issues of both releases that moved out of a done status
+	Slipped   []Issue // Unresolved issues of the earlier release that were not reopened
+}
+
+// Sections returns the non-empty lists of the comparison with their titles, in
+// display order
+func (c *ReleaseComparison) Sections() []supplementarySection {
+	sections := make([]supplementarySection, 0, 4)
+	for _, section := range []supplementarySection{
+		{Title: "Completed", Issues: c.Completed},
+		{Title: "New", Issues: c.New},
+		{Title: "Reopened", Issues: c.Reopened},
+		{Title: "Slipped", Issues: c.Slipped},
+	} {
+		if len(section.Issues) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// CompareReleases compares the issues of two releases. Whether an issue was
+// reopened is read from its status history, so issues of both releases need
+// their StatusHistory set; an issue is reopened when it moved from one of the
+// done statuses to any other status.
+func CompareReleases(from, to string, fromIssues, toIssues []Issue, doneStatuses []string) *ReleaseComparison {
+	comparison := &ReleaseComparison{From: from, To: to}
+
+	earlier := make(map[string]bool, len(fromIssues))
+	for _, issue := range fromIssues {
+		earlier[issue.Key] = true
+	}
+
+	reopened := make(map[string]bool)
+	for _, issue := range toIssues {
+		if issue.Resolution != "" {
+			comparison.Completed = append(comparison.Completed, issue)
+		}
+		switch {
+		case !earlier[issue.Key]:
+			comparison.New = append(comparison.New, issue)
+		case wasReopened(issue, doneStatuses):
+			comparison.Reopened = append(comparison.Reopened, issue)
+			reopened[issue.Key] = true
+		}
+	}
+
+	for _, issue := range fromIssues {
+		if issue.Resolution == "" && !reopened[issue.Key] {
+			comparison.Slipped = append(comparison.Slipped, issue)
+		}
+	}
+
+	return comparison
+}
+
+// wasReopened reports whether the issue ever moved out of a done status
+func wasReopened(issue Issue, doneStatuses []string) bool {
+	for _, change := range statusChanges(issue) {
+		if strings.EqualFold(change.Field, statusField) && containsFold(doneStatuses, change.FromValue) && !containsFold(doneStatuses, change.ToValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompareDates compares the issues of the projects at the start of the time
+// range with those at its end, like two releases: the issues that existed at
+// the start stand for the earlier release, and the issues open at any time
+// within the range for the later one, each resolved as it was at the end of
+// the range. Only status changes within the range reopen an issue. Issues
+// need their Created time and StatusHistory set.
+func CompareDates(timeRange TimeRange, issues []Issue, doneStatuses []string) *ReleaseComparison {
+	// The state at the end includes the changes at the end of an inclusive range
+	end := timeRange.End
+	if timeRange.EndInclusive {
+		end = end.Add(time.Nanosecond)
+	}
+
+	from := make([]Issue, 0, len(issues))
+	to := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		createdWithin := timeRange.IsInRange(issue.Created)
+		if !createdWithin && !issue.Created.Before(timeRange.Start) {
+			continue
+		}
+
+		snapshot := issue
+		snapshot.StatusHistory = make([]Change, 0)
+		for _, change := range statusChanges(issue) {
+			if timeRange.IsInRange(change.Timestamp) {
+				snapshot.StatusHistory = append(snapshot.StatusHistory, change)
+			}
+		}
+		snapshot.Resolution = ""
+		if status := statusBefore(issue, end); containsFold(doneStatuses, status) {
+			snapshot.Resolution = issue.Resolution
+			if snapshot.Resolution == "" {
+				snapshot.Resolution = status
+			}
+		}
+
+		if !createdWithin {
+			from = append(from, snapshot)
+		}
+		// Issues done before the range and left alone were not worked on
+		if createdWithin || len(snapshot.StatusHistory) > 0 || !containsFold(doneStatuses, statusBefore(issue, timeRange.Start)) {
+			to = append(to, snapshot)
+		}
+	}
+
+	return CompareReleases(timeRange.Start.Format(jqlMinuteLayout), timeRange.End.Format(jqlMinuteLayout), from, to, doneStatuses)
+}
+
+// statusBefore returns the status the issue was in just before the given
+// time, read from its status history
+func statusBefore(issue Issue, at time.Time) string {
+	var before, after *Change
+	history := statusChanges(issue)
+	for i := range history {
+		change := &history[i]
+		if !strings.EqualFold(change.Field, statusField) {
+			continue
+		}
+		if change.Timestamp.Before(at) {
+			if before == nil || change.Timestamp.After(before.Timestamp) {
+				before = change
+			}
+		} else if after == nil || change.Timestamp.Before(after.Timestamp) {
+			after = change
+		}
+	}
+
+	switch {
+	case before != nil:
+		return before.ToValue
+	case after != nil:
+		return after.FromValue
+	default:
+		return issue.Status
+	}
+}
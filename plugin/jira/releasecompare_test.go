@@ -0,0 +1,265 @@
+package jira
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+// issueKeys returns the keys of the issues
+func issueKeys(issues []Issue) []string {
+	keys := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	return keys
+}
+
+func TestCompareReleases(t *testing.T) {
+	at := time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC)
+	reopenedHistory := []Change{
+		{Field: "status", FromValue: "In Progress", ToValue: "Done", Timestamp: at},
+		{Field: "status", FromValue: "Done", ToValue: "In Progress", Timestamp: at.Add(time.Hour)},
+	}
+	shippedHistory := []Change{
+		{Field: "status", FromValue: "In Progress", ToValue: "Done", Timestamp: at},
+	}
+
+	from := []Issue{
+		{Key: "PAY-1", Resolution: "Done"},
+		{Key: "PAY-2", StatusHistory: reopenedHistory},
+		{Key: "PAY-3"},
+		{Key: "PAY-4", StatusHistory: shippedHistory, Resolution: "Done"},
+	}
+	to := []Issue{
+		{Key: "PAY-2", StatusHistory: reopenedHistory},
+		{Key: "PAY-4", StatusHistory: shippedHistory, Resolution: "Done"},
+		{Key: "PAY-5", Resolution: "Fixed"},
+		{Key: "PAY-6"},
+	}
+
+	comparison := CompareReleases("1.1.0", "1.2.0", from, to, []string{"Done"})
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		issues   []Issue
+		expected []string
+	}{
+		{name: "Completed", issues: comparison.Completed, expected: []string{"PAY-4", "PAY-5"}},
+		{name: "New", issues: comparison.New, expected: []string{"PAY-5", "PAY-6"}},
+		{name: "Reopened", issues: comparison.Reopened, expected: []string{"PAY-2"}},
+		{name: "Slipped", issues: comparison.Slipped, expected: []string{"PAY-3"}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if keys := issueKeys(tc.issues); !reflect.DeepEqual(keys, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, keys)
+			}
+		})
+	}
+
+	titles := make([]string, 0)
+	for _, section := range comparison.Sections() {
+		titles = append(titles, section.Title)
+	}
+	if !reflect.DeepEqual(titles, []string{"Completed", "New", "Reopened", "Slipped"}) {
+		t.Errorf("Expected every section in order, got %v", titles)
+	}
+}
+
+func TestCompareDates(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	before := timeRange.Start.AddDate(0, -1, 0)
+	within := timeRange.Start.AddDate(0, 0, 10)
+	after := timeRange.End.AddDate(0, 0, 1)
+
+	issues := []Issue{
+		// Open at the start and still open at the end
+		{Key: "PAY-1", Status: "In Progress", Created: before, StatusHistory: []Change{}},
+		// Open at the start and done within the range
+		{Key: "PAY-2", Status: "Done", Resolution: "Fixed", Created: before, StatusHistory: []Change{
+			{Field: "status", FromValue: "In Progress", ToValue: "Done", Timestamp: within},
+		}},
+		// Done before the range and reopened within it
+		{Key: "PAY-3", Status: "In Progress", Created: before, StatusHistory: []Change{
+			{Field: "status", FromValue: "In Progress", ToValue: "Done", Timestamp: before},
+			{Field: "status", FromValue: "Done", ToValue: "In Progress", Timestamp: within},
+		}},
+		// Created and done within the range, reopened after it
+		{Key: "PAY-4", Status: "In Progress", Created: within, StatusHistory: []Change{
+			{Field: "status", FromValue: "To Do", ToValue: "Done", Timestamp: within.Add(time.Hour)},
+			{Field: "status", FromValue: "Done", ToValue: "In Progress", Timestamp: after},
+		}},
+		// Created after the range
+		{Key: "PAY-5", Status: "To Do", Created: after, StatusHistory: []Change{}},
+		// Done before the range and reopened after it
+		{Key: "PAY-6", Status: "In Progress", Created: before, StatusHistory: []Change{
+			{Field: "status", FromValue: "In Progress", ToValue: "Done", Timestamp: before},
+			{Field: "status", FromValue: "Done", ToValue: "In Progress", Timestamp: after},
+		}},
+	}
+
+	comparison := CompareDates(timeRange, issues, []string{"Done"})
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		issues   []Issue
+		expected []string
+	}{
+		{name: "Completed", issues: comparison.Completed, expected: []string{"PAY-2", "PAY-4"}},
+		{name: "New", issues: comparison.New, expected: []string{"PAY-4"}},
+		{name: "Reopened", issues: comparison.Reopened, expected: []string{"PAY-3"}},
+		{name: "Slipped", issues: comparison.Slipped, expected: []string{"PAY-1"}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if keys := issueKeys(tc.issues); !reflect.DeepEqual(keys, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, keys)
+			}
+		})
+	}
+
+	if comparison.From != "2023-01-01 00:00" || comparison.To != "2023-02-01 00:00" {
+		t.Errorf("Expected the comparison to be labelled by the range, got %s to %s", comparison.From, comparison.To)
+	}
+	if comparison.Completed[0].Resolution != "Fixed" || comparison.Completed[1].Resolution != "Done" {
+		t.Errorf("Expected the resolutions at the end of the range, got %+v", comparison.Completed)
+	}
+}
+
+func TestActivityService_RangeComparison(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	histories := make([]string, 0)
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			if kind != SupplementaryReleaseRange {
+				t.Errorf("Expected only the issues open within the range to be searched, got %s", kind)
+			}
+			return []Issue{
+				{Key: "PAY-1", Summary: "Refunds", Status: "In Progress", Created: start.AddDate(0, -1, 0)},
+				{Key: "PAY-2", Summary: "Payouts", Status: "Done", Resolution: "Done", Created: start.AddDate(0, 0, 3)},
+			}, nil
+		},
+		MockGetStatusHistory: func(key string) ([]Change, error) {
+			histories = append(histories, key)
+			if key == "PAY-2" {
+				return []Change{{Field: "status", FromValue: "To Do", ToValue: "Done", Timestamp: start.AddDate(0, 0, 4)}}, nil
+			}
+			return []Change{}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.Mode = ReportModeRelease
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{Start: start, End: start.AddDate(0, 0, 7)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Every issue may have changed status within the range
+	if !reflect.DeepEqual(histories, []string{"PAY-1", "PAY-2"}) {
+		t.Errorf("Expected the history of every issue to be fetched, got %v", histories)
+	}
+	if report.Comparison == nil || report.Comparison.From != "2023-01-01 00:00" || report.Comparison.To != "2023-01-08 00:00" {
+		t.Fatalf("Expected a comparison of the start and end of the range, got %+v", report.Comparison)
+	}
+	if keys := issueKeys(report.Comparison.Slipped); !reflect.DeepEqual(keys, []string{"PAY-1"}) {
+		t.Errorf("Expected PAY-1 to have slipped, got %v", keys)
+	}
+
+	// The release notes list the issues completed within the range
+	if report.Release == nil || report.Release.Version != "2023-01-08 00:00" || report.Release.IssueCount() != 1 {
+		t.Errorf("Expected release notes of the completed issue, got %+v", report.Release)
+	}
+}
+
+func TestActivityService_ReleaseComparison(t *testing.T) {
+	histories := make([]string, 0)
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			if kind == SupplementaryReleaseBaseline {
+				return []Issue{
+					{Key: "PAY-1", Summary: "Refunds", Status: "In Progress"},
+					{Key: "PAY-2", Summary: "Receipts", Status: "To Do"},
+				}, nil
+			}
+			return []Issue{
+				{Key: "PAY-1", Summary: "Refunds", Status: "In Progress"},
+				{Key: "PAY-3", Summary: "Payouts", Status: "Done", Resolution: "Done"},
+			}, nil
+		},
+		MockGetStatusHistory: func(key string) ([]Change, error) {
+			histories = append(histories, key)
+			return []Change{{Field: "status", FromValue: "Done", ToValue: "In Progress"}}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.Mode = ReportModeRelease
+	options.ReleaseVersion = "1.2.0"
+	options.ReleaseCompareTo = "1.1.0"
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Only the issue of both releases has its history fetched
+	if !reflect.DeepEqual(histories, []string{"PAY-1"}) {
+		t.Errorf("Expected only the history of PAY-1 to be fetched, got %v", histories)
+	}
+	if report.Comparison == nil || report.Comparison.From != "1.1.0" || report.Comparison.To != "1.2.0" {
+		t.Fatalf("Expected a comparison of 1.1.0 and 1.2.0, got %+v", report.Comparison)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		expected  []string
+	}{
+		{formatter: NewMarkdownFormatter(), expected: []string{"## Compared with 1.1.0", "### Reopened\n\n- [PAY-1] Refunds (In Progress)", "### Slipped\n\n- [PAY-2] Receipts (To Do)"}},
+		{formatter: NewHTMLFormatter(), expected: []string{"<h2>Compared with 1.1.0</h2>", "<h3>New</h3>"}},
+		{formatter: NewJSONFormatter(), expected: []string{`"comparison": {`, `"from": "1.1.0"`, `"reopened": [`}},
+		{formatter: NewXMLFormatter(), expected: []string{`<comparison from="1.1.0" to="1.2.0">`, "<slipped>\n      <issue>\n        <key>PAY-2</key>"}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected content to contain '%s', got '%s'", expected, result.Content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package jira
+
+import (
+	"sort"
+)
+
+// noResolution is shown for issues of a release that are not resolved yet
+const noResolution = "Unresolved"
+
+// otherIssueType is the group name for issues without a type
+const otherIssueType = "Other"
+
+// releaseNoteTypeOrder lists the issue types shown first in release notes,
+// in order; other types follow alphabetically
+var releaseNoteTypeOrder = []string{"Epic", "New Feature", "Story", "Improvement", "Bug", "Task", "Sub-task"}
+
+// ReleaseNotes lists the issues of a fix version grouped by issue type
+type ReleaseNotes struct {
+	Version string
+	Groups  []ReleaseNoteGroup
+}
+
+// ReleaseNoteGroup is the issues of a release with the same issue type
+type ReleaseNoteGroup struct {
+	Type   string
+	Issues []Issue
+}
+
+// IssueCount returns the number of issues in the release notes
+func (n ReleaseNotes) IssueCount() int {
+	count := 0
+	for _, group := range n.Groups {
+		count += len(group.Issues)
+	}
+	return count
+}
+
+// ResolutionLabel returns how the issue was resolved, or Unresolved
+func ResolutionLabel(issue Issue) string {
+	if issue.Resolution == "" {
+		return noResolution
+	}
+	return issue.Resolution
+}
+
+// BuildReleaseNotes groups the issues of a release by issue type. Common types
+// such as features, stories and bugs come first, then other types
+// alphabetically, with untyped issues last. Issues keep the order in which
+// Jira returned them.
+func BuildReleaseNotes(version string, issues []Issue) *ReleaseNotes {
+	groups := make(map[string]*ReleaseNoteGroup)
+	types := make([]string, 0)
+	for _, issue := range issues {
+		issueType := issue.Type
+		if issueType == "" {
+			issueType = otherIssueType
+		}
+
+		group, ok := groups[issueType]
+		if !ok {
+			group = &ReleaseNoteGroup{Type: issueType}
+			groups[issueType] = group
+			types = append(types, issueType)
+		}
+		group.Issues = append(group.Issues, issue)
+	}
+
+	rank := func(issueType string) int {
+		for i, preferred := range releaseNoteTypeOrder {
+			if issueType == preferred {
+				return i
+			}
+		}
+		if issueType == otherIssueType {
+			return len(releaseNoteTypeOrder) + 1
+		}
+		return len(releaseNoteTypeOrder)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if rank(types[i]) != rank(types[j]) {
+			return rank(types[i]) < rank(types[j])
+		}
+		return types[i] < types[j]
+	})
+
+	notes := &ReleaseNotes{Version: version, Groups: make([]ReleaseNoteGroup, 0, len(types))}
+	for _, issueType := range types {
+		notes.Groups = append(notes.Groups, *groups[issueType])
+	}
+	return notes
+}
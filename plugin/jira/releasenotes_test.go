@@ -0,0 +1,161 @@
+package jira
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestBuildReleaseNotes(t *testing.T) {
+	issues := []Issue{
+		{Key: "PAY-3", Type: "Task", Resolution: "Done"},
+		{Key: "PAY-1", Type: "Bug", Resolution: "Fixed"},
+		{Key: "PAY-7", Type: ""},
+		{Key: "PAY-2", Type: "Story", Resolution: "Done"},
+		{Key: "PAY-5", Type: "Chore", Resolution: "Done"},
+		{Key: "PAY-4", Type: "Bug", Resolution: "Won't Fix"},
+	}
+
+	notes := BuildReleaseNotes("1.2.0", issues)
+
+	if notes.Version != "1.2.0" || notes.IssueCount() != len(issues) {
+		t.Errorf("Expected all %d issues of 1.2.0, got %d of %s", len(issues), notes.IssueCount(), notes.Version)
+	}
+
+	types := make([]string, 0, len(notes.Groups))
+	for _, group := range notes.Groups {
+		types = append(types, group.Type)
+	}
+	expectedTypes := []string{"Story", "Bug", "Task", "Chore", "Other"}
+	if !reflect.DeepEqual(types, expectedTypes) {
+		t.Errorf("Expected types %v, got %v", expectedTypes, types)
+	}
+
+	// Issues keep the order Jira returned them in
+	bugs := notes.Groups[1].Issues
+	if len(bugs) != 2 || bugs[0].Key != "PAY-1" || bugs[1].Key != "PAY-4" {
+		t.Errorf("Expected PAY-1 and PAY-4 as bugs, got %+v", bugs)
+	}
+}
+
+func TestResolutionLabel(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		issue    Issue
+		expected string
+	}{
+		{name: "Resolved", issue: Issue{Resolution: "Won't Fix"}, expected: "Won't Fix"},
+		{name: "Unresolved", issue: Issue{}, expected: "Unresolved"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if label := ResolutionLabel(tc.issue); label != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, label)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_ReleaseIssues(t *testing.T) {
+	reportOptions := DefaultReportOptions()
+	reportOptions.Mode = ReportModeRelease
+	reportOptions.ReleaseVersion = "1.2.0"
+	repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: DefaultQueryOptions(), ReportOptions: reportOptions})
+	server.Issues = []extJira.Issue{
+		{Key: "TEST-1", Fields: &extJira.IssueFields{
+			Summary:    "Refunds",
+			Type:       extJira.IssueType{Name: "Story"},
+			Status:     &extJira.Status{Name: "Done"},
+			Resolution: &extJira.Resolution{Name: "Done"},
+		}},
+	}
+
+	issues, err := repo.GetSupplementaryIssues(SupplementaryRelease, TimeRange{}, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Type != "Story" || issues[0].Resolution != "Done" {
+		t.Errorf("Expected the story with its resolution, got %+v", issues)
+	}
+
+	requests := server.Requests("/rest/api/2/search")
+	if len(requests) != 1 {
+		t.Fatalf("Expected one search, got %d", len(requests))
+	}
+	fields := requests[0].Query.Get("fields")
+	if !strings.Contains(fields, "issuetype") || !strings.Contains(fields, "resolution") {
+		t.Errorf("Expected the type and resolution to be requested, got '%s'", fields)
+	}
+}
+
+func TestActivityService_ReleaseMode(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			t.Errorf("Expected release mode not to search the activity in the range")
+			return nil, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			if kind != SupplementaryRelease {
+				t.Errorf("Expected the release query, got %s", kind)
+			}
+			return []Issue{
+				{Key: "PAY-1", Summary: "Card | validation", Status: "Done", Type: "Bug", Resolution: "Fixed"},
+				{Key: "PAY-2", Summary: "Refund API", Status: "In Progress", Type: "Story"},
+			}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.Mode = ReportModeRelease
+	options.ReleaseVersion = "1.2.0"
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Release == nil || report.Release.IssueCount() != 2 {
+		t.Fatalf("Expected release notes with 2 issues, got %+v", report.Release)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		expected  []string
+	}{
+		{formatter: NewMarkdownFormatter(), expected: []string{"## Release Notes: 1.2.0", "### Story\n\n- [PAY-2] Refund API (Unresolved)", "### Bug\n\n- [PAY-1] Card \\| validation (Fixed)"}},
+		{formatter: NewHTMLFormatter(), expected: []string{"<h2>Release Notes: 1.2.0</h2>", "<h3>Bug</h3>", "Refund API <span class=\"timestamp\">(Unresolved)</span>"}},
+		{formatter: NewJSONFormatter(), expected: []string{`"release": {`, `"version": "1.2.0"`, `"type": "Bug"`, `"resolution": "Fixed"`}},
+		{formatter: NewXMLFormatter(), expected: []string{`<release version="1.2.0">`, `<group type="Story">`, `<resolution>Unresolved</resolution>`}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected content to contain '%s', got '%s'", expected, result.Content)
+				}
+			}
+		})
+	}
+}
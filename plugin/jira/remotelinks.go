@@ -0,0 +1,122 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// remoteLinkField is the changelog field recording remote links being added or removed
+const remoteLinkField = "RemoteIssueLink"
+
+// RemoteLink is a link from an issue to an external resource such as a
+// Confluence page or a web page
+type RemoteLink struct {
+	ID           string
+	Title        string
+	URL          string
+	Application  string // e.g. "Confluence"; empty for plain web links
+	Relationship string // e.g. "mentioned in"
+	AddedAt      time.Time
+	AddedBy      string
+}
+
+// Line renders the link, e.g. "Design doc (Confluence)"
+func (l RemoteLink) Line() string {
+	if l.Application == "" {
+		return l.Title
+	}
+	return fmt.Sprintf("%s (%s)", l.Title, l.Application)
+}
+
+// GetRemoteLinks retrieves the remote links of an issue
+func (r *JiraAPIRepository) GetRemoteLinks(key string) ([]RemoteLink, error) {
+	rawLinks, err := r.api.RemoteLinks(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the remote links of %s: %w", key, err)
+	}
+
+	links := make([]RemoteLink, 0, len(rawLinks))
+	for _, rawLink := range rawLinks {
+		links = append(links, remoteLinkFromJira(rawLink))
+	}
+	return links, nil
+}
+
+// remoteLinkFromJira converts an external Jira remote link to the domain model
+func remoteLinkFromJira(rawLink extJira.RemoteLink) RemoteLink {
+	link := RemoteLink{
+		ID:           strconv.Itoa(rawLink.ID),
+		Relationship: rawLink.Relationship,
+	}
+	if rawLink.Object != nil {
+		link.Title = rawLink.Object.Title
+		link.URL = rawLink.Object.URL
+	}
+	if rawLink.Application != nil {
+		link.Application = rawLink.Application.Name
+	}
+	if link.Title == "" {
+		link.Title = link.URL
+	}
+	return link
+}
+
+// addedRemoteLinks returns the changes of an issue that added a remote link,
+// keyed by link ID
+func addedRemoteLinks(issue Issue) map[string]Change {
+	added := make(map[string]Change)
+	for _, change := range issue.Changes {
+		if strings.EqualFold(change.Field, remoteLinkField) && change.ToID != "" {
+			added[change.ToID] = change
+		}
+	}
+	return added
+}
+
+// resolveRemoteLinks sets the remote links added within the time range on each
+// issue. Only issues whose changes added a link are fetched, in parallel and
+// bounded by the HTTP concurrency limit. Links removed since are skipped.
+func resolveRemoteLinks(issues []Issue, fetch func(key string) ([]RemoteLink, error)) error {
+	errs := make([]error, len(issues))
+
+	var wg sync.WaitGroup
+	for i := range issues {
+		added := addedRemoteLinks(issues[i])
+		if len(added) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, added map[string]Change) {
+			defer wg.Done()
+
+			links, err := fetch(issues[i].Key)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			for _, link := range links {
+				change, ok := added[link.ID]
+				if !ok {
+					continue
+				}
+				link.AddedAt = change.Timestamp
+				link.AddedBy = change.Author
+				issues[i].RemoteLinks = append(issues[i].RemoteLinks, link)
+			}
+			sort.Slice(issues[i].RemoteLinks, func(a, b int) bool {
+				return issues[i].RemoteLinks[a].AddedAt.Before(issues[i].RemoteLinks[b].AddedAt)
+			})
+		}(i, added)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
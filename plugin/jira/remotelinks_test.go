@@ -0,0 +1,219 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestRemoteLinkFromJira(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		rawLink  extJira.RemoteLink
+		expected RemoteLink
+		line     string
+	}{
+		{
+			name: "Confluence page",
+			rawLink: extJira.RemoteLink{
+				ID:           10000,
+				Application:  &extJira.RemoteLinkApplication{Type: "com.atlassian.confluence", Name: "Confluence"},
+				Relationship: "mentioned in",
+				Object:       &extJira.RemoteLinkObject{URL: "https://wiki.example.com/pages/123", Title: "Design doc"},
+			},
+			expected: RemoteLink{ID: "10000", Title: "Design doc", URL: "https://wiki.example.com/pages/123", Application: "Confluence", Relationship: "mentioned in"},
+			line:     "Design doc (Confluence)",
+		},
+		{
+			name:     "Untitled web link",
+			rawLink:  extJira.RemoteLink{ID: 10001, Object: &extJira.RemoteLinkObject{URL: "https://example.com/spec"}},
+			expected: RemoteLink{ID: "10001", Title: "https://example.com/spec", URL: "https://example.com/spec"},
+			line:     "https://example.com/spec",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			link := remoteLinkFromJira(tc.rawLink)
+
+			if link != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, link)
+			}
+			if link.Line() != tc.line {
+				t.Errorf("Expected '%s', got '%s'", tc.line, link.Line())
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_RemoteLinkChanges(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions()})
+	server.Issues = []extJira.Issue{
+		{
+			Key:    "JIRA-1",
+			Fields: &extJira.IssueFields{Summary: "Checkout"},
+			Changelog: &extJira.Changelog{
+				Histories: []extJira.ChangelogHistory{
+					{
+						Author:  extJira.User{AccountID: "user123", DisplayName: "Test User"},
+						Created: "2023-01-01T10:00:00.000+0000",
+						Items: []extJira.ChangelogItems{
+							{Field: "RemoteIssueLink", To: "10000", ToString: "This issue links to \"Design doc (Confluence)\""},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	issues, _, err := repo.GetIssues(TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(issues) != 1 || len(issues[0].Changes) != 1 || issues[0].Changes[0].ToID != "10000" {
+		t.Errorf("Expected the link change to carry the link ID, got %+v", issues)
+	}
+}
+
+func TestJiraAPIRepository_GetRemoteLinks(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions()})
+	server.RemoteLinks = map[string][]extJira.RemoteLink{
+		"JIRA-1": {
+			{
+				ID:          10000,
+				Application: &extJira.RemoteLinkApplication{Name: "Confluence"},
+				Object:      &extJira.RemoteLinkObject{URL: "https://wiki.example.com/pages/123", Title: "Design doc"},
+			},
+		},
+	}
+	server.Fail("/rest/api/2/issue/JIRA-2/remotelink", http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+
+	links, err := repo.GetRemoteLinks("JIRA-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(links) != 1 || links[0].ID != "10000" || links[0].Line() != "Design doc (Confluence)" {
+		t.Errorf("Expected the Confluence link, got %+v", links)
+	}
+
+	if _, err := repo.GetRemoteLinks("JIRA-2"); err == nil || !strings.Contains(err.Error(), "JIRA-2") {
+		t.Errorf("Expected an error naming the issue, got %v", err)
+	}
+}
+
+func TestResolveRemoteLinks(t *testing.T) {
+	added := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	issues := []Issue{
+		{Key: "JIRA-1", Changes: []Change{
+			{Field: "RemoteIssueLink", ToID: "10001", Author: "Test User", Timestamp: added.Add(time.Hour)},
+			{Field: "RemoteIssueLink", ToID: "10000", Author: "Test User", Timestamp: added},
+			{Field: "RemoteIssueLink", ToID: "10002", Author: "Test User", Timestamp: added},
+		}},
+		{Key: "JIRA-2", Changes: []Change{{Field: "status", ToValue: "Done"}}},
+		{Key: "JIRA-3", Changes: []Change{{Field: "RemoteIssueLink", ToID: "20000"}}},
+	}
+
+	var mu sync.Mutex
+	fetched := make(map[string]bool)
+	fetch := func(key string) ([]RemoteLink, error) {
+		mu.Lock()
+		fetched[key] = true
+		mu.Unlock()
+		if key == "JIRA-3" {
+			return nil, errors.New("forbidden")
+		}
+		// 10002 was removed again and 9999 was added before the range
+		return []RemoteLink{
+			{ID: "9999", Title: "Old link"},
+			{ID: "10000", Title: "Design doc", URL: "https://wiki.example.com/pages/123"},
+			{ID: "10001", Title: "Spec", URL: "https://example.com/spec"},
+		}, nil
+	}
+
+	err := resolveRemoteLinks(issues, fetch)
+	if err == nil || !strings.Contains(err.Error(), "forbidden") {
+		t.Errorf("Expected the fetch error, got %v", err)
+	}
+
+	if fetched["JIRA-2"] {
+		t.Errorf("Expected issues without link changes not to be fetched")
+	}
+	links := issues[0].RemoteLinks
+	if len(links) != 2 || links[0].Title != "Design doc" || links[1].Title != "Spec" {
+		t.Fatalf("Expected the two added links in order, got %+v", links)
+	}
+	if links[0].AddedBy != "Test User" || !links[0].AddedAt.Equal(added) {
+		t.Errorf("Expected the link to carry its change, got %+v", links[0])
+	}
+}
+
+func TestActivityService_RemoteLinks(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{Key: "JIRA-1", Summary: "Checkout", Status: "In Progress", Changes: []Change{
+					{Field: "RemoteIssueLink", ToID: "10000", ToValue: "This issue links to \"Design doc (Confluence)\"",
+						Author: "Test User", Timestamp: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)},
+				}},
+			}, nil
+		},
+		MockGetRemoteLinks: func(key string) ([]RemoteLink, error) {
+			return []RemoteLink{
+				{ID: "10000", Title: "Design doc", URL: "https://wiki.example.com/display/PAY/Design (v2)", Application: "Confluence"},
+			}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.IncludeRemoteLinks = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		expected  string
+	}{
+		{formatter: NewMarkdownFormatter(), expected: "- [Design doc (Confluence)](https://wiki.example.com/display/PAY/Design%20%28v2%29) - Test User, 2023-01-01 10:00"},
+		{formatter: NewHTMLFormatter(), expected: "<a href=\"https://wiki.example.com/display/PAY/Design (v2)\">Design doc (Confluence)</a>"},
+		{formatter: NewJSONFormatter(), expected: `"application": "Confluence"`},
+		{formatter: NewXMLFormatter(), expected: `<link url="https://wiki.example.com/display/PAY/Design (v2)" application="Confluence">`},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(result.Content, tc.expected) {
+				t.Errorf("Expected content to contain '%s', got '%s'", tc.expected, result.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// Reopening is a move of an issue from a done status back to an open one
+type Reopening struct {
+	Timestamp       time.Time
+	Author          string
+	AuthorAccountID string
+	AuthorRole      string // Role of the author on the issue; empty when the user reopened it
+	FromStatus      string
+	ToStatus        string
+}
+
+// detectReopening returns the last move from a done status to any other
+// status within the time range, whoever made it, or nil when the issue was
+// not reopened. Done statuses are compared case-insensitively.
+func detectReopening(histories []extJira.ChangelogHistory, timeRange TimeRange, doneStatuses []string, userAccountID string, issue Issue) *Reopening {
+	var reopening *Reopening
+	for _, history := range histories {
+		createdTime, err := parseJiraTime(history.Created)
+		if err != nil || !timeRange.IsInRange(createdTime) {
+			continue
+		}
+
+		for _, item := range history.Items {
+			if !strings.EqualFold(item.Field, statusField) {
+				continue
+			}
+			if !containsFold(doneStatuses, item.FromString) || containsFold(doneStatuses, item.ToString) {
+				continue
+			}
+			if reopening != nil && createdTime.Before(reopening.Timestamp) {
+				continue
+			}
+
+			role := ""
+			if !isAccount(userID(history.Author), userAccountID) {
+				role = issue.RoleOf(userID(history.Author))
+			}
+			reopening = &Reopening{
+				Timestamp:       createdTime,
+				Author:          displayName(history.Author),
+				AuthorAccountID: history.Author.AccountID,
+				AuthorRole:      role,
+				FromStatus:      item.FromString,
+				ToStatus:        item.ToString,
+			}
+		}
+	}
+	return reopening
+}
+
+// reopeningLine renders who reopened an issue and how, e.g.
+// "by QA (reporter): Done → In Progress"; it is empty when the issue was not reopened
+func reopeningLine(reopening *Reopening) string {
+	if reopening == nil {
+		return ""
+	}
+
+	author := reopening.Author
+	if reopening.AuthorRole != "" {
+		author = fmt.Sprintf("%s (%s)", author, reopening.AuthorRole)
+	}
+	return fmt.Sprintf("by %s: %s → %s", author, reopening.FromStatus, reopening.ToStatus)
+}
@@ -0,0 +1,107 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestDetectReopening(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	issue := Issue{Reporter: User{AccountID: "qa1"}, Assignee: User{AccountID: "user123"}}
+	history := func(created, accountID, from, to string) extJira.ChangelogHistory {
+		return extJira.ChangelogHistory{
+			Created: created,
+			Author:  extJira.User{AccountID: accountID, DisplayName: accountID},
+			Items:   []extJira.ChangelogItems{{Field: "status", FromString: from, ToString: to}},
+		}
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name      string
+		histories []extJira.ChangelogHistory
+		expected  *Reopening
+	}{
+		{
+			name:      "Reopened by the reporter",
+			histories: []extJira.ChangelogHistory{history("2023-01-02T10:00:00.000+0000", "qa1", "Done", "In Progress")},
+			expected:  &Reopening{Timestamp: time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC), Author: "qa1", AuthorAccountID: "qa1", AuthorRole: RoleReporter, FromStatus: "Done", ToStatus: "In Progress"},
+		},
+		{
+			name:      "Reopened by the user",
+			histories: []extJira.ChangelogHistory{history("2023-01-02T10:00:00.000+0000", "user123", "closed", "To Do")},
+			expected:  &Reopening{Timestamp: time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC), Author: "user123", AuthorAccountID: "user123", FromStatus: "closed", ToStatus: "To Do"},
+		},
+		{
+			name: "Last reopening wins",
+			histories: []extJira.ChangelogHistory{
+				history("2023-01-02T15:00:00.000+0000", "dev2", "Resolved", "In Progress"),
+				history("2023-01-02T09:00:00.000+0000", "qa1", "Done", "To Do"),
+			},
+			expected: &Reopening{Timestamp: time.Date(2023, 1, 2, 15, 0, 0, 0, time.UTC), Author: "dev2", AuthorAccountID: "dev2", AuthorRole: RoleThirdParty, FromStatus: "Resolved", ToStatus: "In Progress"},
+		},
+		{
+			name:      "Before the range",
+			histories: []extJira.ChangelogHistory{history("2023-01-01T10:00:00.000+0000", "qa1", "Done", "In Progress")},
+		},
+		{
+			name:      "Between done statuses",
+			histories: []extJira.ChangelogHistory{history("2023-01-02T10:00:00.000+0000", "qa1", "Resolved", "Closed")},
+		},
+		{
+			name:      "Completed",
+			histories: []extJira.ChangelogHistory{history("2023-01-02T10:00:00.000+0000", "user123", "In Progress", "Done")},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reopening := detectReopening(tc.histories, timeRange, []string{"Done", "Resolved", "Closed"}, "user123", issue)
+
+			if tc.expected == nil {
+				if reopening != nil {
+					t.Errorf("Expected no reopening, got %+v", reopening)
+				}
+				return
+			}
+			if reopening == nil {
+				t.Fatalf("Expected %+v, got nil", tc.expected)
+			}
+			if !reopening.Timestamp.Equal(tc.expected.Timestamp) {
+				t.Errorf("Expected reopened at %v, got %v", tc.expected.Timestamp, reopening.Timestamp)
+			}
+			reopening.Timestamp = tc.expected.Timestamp
+			if *reopening != *tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, reopening)
+			}
+		})
+	}
+}
+
+func TestReopeningLine(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name      string
+		reopening *Reopening
+		expected  string
+	}{
+		{name: "Not reopened", reopening: nil, expected: ""},
+		{name: "By someone else", reopening: &Reopening{Author: "QA", AuthorRole: RoleReporter, FromStatus: "Done", ToStatus: "In Progress"}, expected: "by QA (reporter): Done → In Progress"},
+		{name: "By the user", reopening: &Reopening{Author: "Test User", FromStatus: "Closed", ToStatus: "To Do"}, expected: "by Test User: Closed → To Do"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if line := reopeningLine(tc.reopening); line != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, line)
+			}
+		})
+	}
+}
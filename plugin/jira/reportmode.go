@@ -0,0 +1,44 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReportMode controls how issues are grouped in a report
+type ReportMode string
+
+const (
+	// ReportModeStandard groups issue activity by status
+	ReportModeStandard ReportMode = "standard"
+	// ReportModeEpic rolls child-issue activity up under each epic
+	ReportModeEpic ReportMode = "epic"
+	// ReportModeComponent digests all activity per component, regardless of assignee
+	ReportModeComponent ReportMode = "component"
+	// ReportModeInitiative rolls epic activity up under each initiative
+	ReportModeInitiative ReportMode = "initiative"
+	// ReportModeRelease lists the issues of a fix version as release notes
+	ReportModeRelease ReportMode = "release"
+	// ReportModeTriage lists the bugs and incidents created in the range, regardless of assignee
+	ReportModeTriage ReportMode = "triage"
+)
+
+// ParseReportMode converts a configuration value to a ReportMode
+func ParseReportMode(value string) (ReportMode, error) {
+	switch ReportMode(strings.ToLower(strings.TrimSpace(value))) {
+	case ReportModeStandard, "":
+		return ReportModeStandard, nil
+	case ReportModeEpic:
+		return ReportModeEpic, nil
+	case ReportModeComponent:
+		return ReportModeComponent, nil
+	case ReportModeInitiative:
+		return ReportModeInitiative, nil
+	case ReportModeRelease:
+		return ReportModeRelease, nil
+	case ReportModeTriage:
+		return ReportModeTriage, nil
+	default:
+		return "", fmt.Errorf("unknown report mode %q (expected standard, epic, component, initiative, release or triage)", value)
+	}
+}
@@ -0,0 +1,43 @@
+package jira
+
+import (
+	"testing"
+)
+
+func TestParseReportMode(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		value       string
+		expected    ReportMode
+		expectError bool
+	}{
+		{name: "Empty defaults to standard", value: "", expected: ReportModeStandard},
+		{name: "Standard", value: "standard", expected: ReportModeStandard},
+		{name: "Epic with whitespace and case", value: " Epic ", expected: ReportModeEpic},
+		{name: "Component", value: "component", expected: ReportModeComponent},
+		{name: "Initiative", value: "initiative", expected: ReportModeInitiative},
+		{name: "Release", value: "release", expected: ReportModeRelease},
+		{name: "Triage", value: "triage", expected: ReportModeTriage},
+		{name: "Unknown", value: "weekly", expectError: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, err := ParseReportMode(tc.value)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got mode '%s'", mode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if mode != tc.expected {
+				t.Errorf("Expected mode '%s', got '%s'", tc.expected, mode)
+			}
+		})
+	}
+}
@@ -1,184 +1,612 @@
 package jira
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	"net/http"
 	"time"
 
 	extJira "github.com/andygrunwald/go-jira"
-	plugin "github.com/iures/daivplug"
 )
 
 // JiraRepository defines the interface for accessing Jira data
 type JiraRepository interface {
 	GetUser() (*User, error)
-	GetIssues(timeRange TimeRange, userID string) ([]Issue, error)
+	GetIssues(timeRange TimeRange, userID string) ([]Issue, *SearchTruncation, error)
+	GetSupplementaryIssues(kind SupplementaryQuery, timeRange TimeRange, userID string) ([]Issue, error)
+	GetIssuesByKey(keys []string) ([]Issue, error)
+	GetUsers(accountIDs []string) ([]User, error)
+	GetStatusHistory(key string) ([]Change, error)
+	GetFieldHistory(key string, fields ...string) ([]Change, error)
+	GetAttention(key string) (Attention, error)
+	GetRemoteLinks(key string) ([]RemoteLink, error)
+	GetSprintScope(boardID int, timeRange TimeRange) (*SprintScope, error)
+	GetSprint(id int) (*Sprint, error)
+	ActivityQuery(timeRange TimeRange) (string, error)
+	EstimateSearch(timeRange TimeRange) (SearchEstimate, error)
 }
 
+// keyLookupPageSize is the number of issues looked up by key per search
+const keyLookupPageSize = 50
+
 // JiraAPIRepository implements JiraRepository using the Jira API
 type JiraAPIRepository struct {
-	client *extJira.Client
+	api    jiraAPI
 	config *JiraConfig
-	
-	// For testing purposes
-	getUserFunc func() (*User, error)
-	searchIssuesFunc func(jql string, options *extJira.SearchOptions) ([]extJira.Issue, error)
+	// Identical searches in flight, shared between reports produced concurrently
+	searches searchGroup
+	// Projects the last activity search skipped, left out of the supplementary queries
+	refused refusedProjects
 }
 
 // NewJiraAPIRepository creates a new JiraAPIRepository
 func NewJiraAPIRepository(client *extJira.Client, config *JiraConfig) *JiraAPIRepository {
 	return &JiraAPIRepository{
-		client: client,
+		api:    &goJiraAPI{client: client},
 		config: config,
 	}
 }
 
+// NewNativeJiraAPIRepository creates a JiraAPIRepository that reaches the Jira
+// site at the base URL through the built-in REST client instead of go-jira
+func NewNativeJiraAPIRepository(httpClient *http.Client, baseURL string, config *JiraConfig) (*JiraAPIRepository, error) {
+	api, err := newNativeAPI(httpClient, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &JiraAPIRepository{api: api, config: config}, nil
+}
+
 // GetUser retrieves the current user from Jira
 func (r *JiraAPIRepository) GetUser() (*User, error) {
-	// If a mock function is provided for testing, use it
-	if r.getUserFunc != nil {
-		return r.getUserFunc()
+	user, err := r.getSelf()
+	if err != nil {
+		return nil, err
 	}
-	
-	user, _, err := r.client.User.GetSelf()
+
+	// Jira Cloud hides the email address unless the user made it visible
+	if user.Email == "" && r.config.QueryOptions.ResolveEmail {
+		user.Email = r.lookupEmail(user.AccountID)
+	}
+
+	return user, nil
+}
+
+// getSelf retrieves the current user as reported by Jira
+func (r *JiraAPIRepository) getSelf() (*User, error) {
+	user, err := r.api.Self()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user from Jira: %w", err)
 	}
 
-	return &User{
-		AccountID:   user.AccountID,
-		DisplayName: user.DisplayName,
-		Email:       user.EmailAddress,
-	}, nil
+	result := userFromJira(user)
+	return &result, nil
+}
+
+// GetIssues retrieves issues from Jira based on the given time range and user
+// ID, along with the truncation of the search when it matched more issues than
+// it returned. Issues are converted page by page as they arrive, and paging
+// stops once the report's token budget is full. In multi-project mode each
+// project is searched on its own; projects Jira refuses access to are left
+// out and reported by a *PartialSearchError returned along with the issues.
+func (r *JiraAPIRepository) GetIssues(timeRange TimeRange, userID string) ([]Issue, *SearchTruncation, error) {
+	budget := newReportBudget(r.config.ReportOptions.MaxTokens)
+	issues := make([]Issue, 0)
+	index := make(map[string]int)
+	considered := 0
+
+	// Fetch raw issues from Jira, converting them to the domain model
+	handle := func(page []extJira.Issue) bool {
+		for _, rawIssue := range page {
+			issue := r.convertIssue(rawIssue, timeRange, userID)
+
+			// An issue updated while paging can come back on a later page
+			if i, ok := index[issue.Key]; ok {
+				issues[i] = mergeIssue(issues[i], issue)
+				considered++
+				continue
+			}
+
+			// Only include issues that have comments, changes or work logged within
+			// the time range, or that someone else reopened or escalated
+			if len(issue.Comments) > 0 || len(issue.Changes) > 0 || len(issue.Worklogs) > 0 || issue.Reopened != nil || issue.Escalation != nil {
+				if !budget.admit(issue) {
+					return false
+				}
+				index[issue.Key] = len(issues)
+				issues = append(issues, issue)
+			}
+			considered++
+		}
+		return true
+	}
+
+	projects := r.searchedProjects()
+	var skipped []SkippedProject
+	total := 0
+	for _, project := range projects {
+		projectTotal, err := r.fetchUpdatedIssues(timeRange, userID, project, handle)
+		if err != nil {
+			// One project the user cannot browse does not fail the others
+			if len(projects) > 1 && projectDenied(err) {
+				skipped = append(skipped, SkippedProject{Key: project, Err: err})
+				continue
+			}
+			return nil, nil, err
+		}
+		total += projectTotal
+		if budget.full {
+			break
+		}
+	}
+	r.refused.set(skipped)
+	if len(skipped) == len(projects) && len(skipped) > 1 {
+		return nil, nil, &PartialSearchError{Skipped: skipped}
+	}
+
+	truncation := newSearchTruncation(considered, total)
+	if truncation != nil {
+		truncation.BudgetReached = budget.full
+	}
+	if len(skipped) > 0 {
+		return issues, truncation, &PartialSearchError{Skipped: skipped}
+	}
+	return issues, truncation, nil
 }
 
-// GetIssues retrieves issues from Jira based on the given time range and user ID
-func (r *JiraAPIRepository) GetIssues(timeRange TimeRange, userID string) ([]Issue, error) {
-	// Convert domain TimeRange to plugin.TimeRange for the API call
-	pluginTimeRange := plugin.TimeRange{
-		Start: timeRange.Start,
-		End:   timeRange.End,
+// GetSupplementaryIssues retrieves the issues of a supplementary query. Unlike
+// GetIssues, issues are returned whether or not they had activity in the range.
+func (r *JiraAPIRepository) GetSupplementaryIssues(kind SupplementaryQuery, timeRange TimeRange, userID string) ([]Issue, error) {
+	jql, err := r.buildSupplementaryJQLQuery(kind, timeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	// Issues found by their creation are kept to the exact range by it, and
+	// the issues compared over the range are placed before or within it
+	options := r.searchOptions()
+	if kind.selectsCreated() || kind == SupplementaryReleaseRange {
+		options.Fields = appendMissing(options.Fields, "created")
 	}
 
-	// Fetch raw issues from Jira
-	rawIssues, err := r.fetchUpdatedIssues(pluginTimeRange, userID)
+	rawIssues, err := r.searchIssuesWithOptions(jql, options)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert raw issues to domain model
 	issues := make([]Issue, 0, len(rawIssues))
 	for _, rawIssue := range rawIssues {
-		issue := Issue{
-			Key:     rawIssue.Key,
-			Summary: rawIssue.Fields.Summary,
-			Status:  rawIssue.Fields.Status.Name,
+		issue := r.convertIssue(rawIssue, timeRange, userID)
+		if !kind.inRange(issue, time.Time(rawIssue.Fields.Created), timeRange) {
+			continue
+		}
+		if kind == SupplementaryReleaseRange {
+			issue.Created = time.Time(rawIssue.Fields.Created)
 		}
+		issues = append(issues, issue)
+	}
 
-		// Process comments
-		if rawIssue.Fields.Comments != nil {
-			issue.Comments = r.processComments(rawIssue.Fields.Comments.Comments, timeRange)
+	return issues, nil
+}
+
+// GetIssuesByKey retrieves the summary, status, type and parent of the given issues
+func (r *JiraAPIRepository) GetIssuesByKey(keys []string) ([]Issue, error) {
+	issues := make([]Issue, 0, len(keys))
+
+	// Fetch in pages so that the key list stays within the search page size
+	for start := 0; start < len(keys); start += keyLookupPageSize {
+		end := start + keyLookupPageSize
+		if end > len(keys) {
+			end = len(keys)
 		}
 
-		// Process changelog
-		if rawIssue.Changelog != nil {
-			issue.Changes = r.processChangelog(rawIssue.Changelog.Histories, timeRange, userID)
+		var query jqlBuilder
+		query.In("key", keys[start:end])
+
+		options := &extJira.SearchOptions{
+			MaxResults: end - start,
+			Fields:     append([]string{"summary", "status"}, r.hierarchyFields()...),
+		}
+
+		rawIssues, err := r.searchIssuesWithOptions(query.String(), options)
+		if err != nil {
+			return nil, err
 		}
 
-		// Only include issues that have comments or changes within the time range
-		if len(issue.Comments) > 0 || len(issue.Changes) > 0 {
-			issues = append(issues, issue)
+		for _, rawIssue := range rawIssues {
+			issues = append(issues, r.convertIssue(rawIssue, TimeRange{}, ""))
 		}
 	}
 
 	return issues, nil
 }
 
-// fetchUpdatedIssues retrieves issues from Jira based on the given time range and user ID
-func (r *JiraAPIRepository) fetchUpdatedIssues(timeRange plugin.TimeRange, userID string) ([]extJira.Issue, error) {
-	// If a mock function is provided for testing, use it
-	if r.searchIssuesFunc != nil {
-		// Convert the plugin.TimeRange to string format for the JQL query
-		fromTime := timeRange.Start.Format("2006-01-02")
-		toTime := timeRange.End.Format("2006-01-02")
-		
-		// Build the JQL query
-		jql := r.buildJQLQuery(fromTime, toTime)
-		
-		// Create search options
-		options := &extJira.SearchOptions{
-			MaxResults: r.config.QueryOptions.MaxResults,
-			Fields:     r.config.QueryOptions.Fields,
+// convertIssue converts a raw Jira issue to the domain model, keeping only the
+// comments and changes within the time range
+func (r *JiraAPIRepository) convertIssue(rawIssue extJira.Issue, timeRange TimeRange, userID string) Issue {
+	issue := Issue{
+		Key:         rawIssue.Key,
+		Summary:     rawIssue.Fields.Summary,
+		Description: rawIssue.Fields.Description,
+	}
+	if rawIssue.Fields.Status != nil {
+		issue.Status = rawIssue.Fields.Status.Name
+		issue.StatusCategory = rawIssue.Fields.Status.StatusCategory.Key
+	}
+
+	// Capture the issue type and hierarchy for epic rollups
+	issue.Type = rawIssue.Fields.Type.Name
+	if rawIssue.Fields.Resolution != nil {
+		issue.Resolution = rawIssue.Fields.Resolution.Name
+	}
+	if rawIssue.Fields.Priority != nil {
+		issue.Priority = rawIssue.Fields.Priority.Name
+	}
+	if rawIssue.Fields.Parent != nil && rawIssue.Fields.Parent.Key != "" {
+		issue.Parent = &IssueRef{Key: rawIssue.Fields.Parent.Key}
+	} else if field := r.config.QueryOptions.ParentLinkField; field != "" {
+		// Advanced Roadmaps on Data Center links epics to initiatives through a custom field
+		if key := parentLinkKey(rawIssue.Fields.Unknowns[field]); key != "" {
+			issue.Parent = &IssueRef{Key: key}
+		}
+	}
+	if rawIssue.Fields.Epic != nil && rawIssue.Fields.Epic.Key != "" {
+		issue.Epic = &IssueRef{
+			Key:     rawIssue.Fields.Epic.Key,
+			Summary: rawIssue.Fields.Epic.Summary,
+			Type:    IssueTypeEpic,
+		}
+	}
+
+	// Capture the due date for the due-soon preview
+	if dueDate := time.Time(rawIssue.Fields.Duedate); !dueDate.IsZero() {
+		issue.DueDate = dueDate
+	}
+
+	// Capture the story points for velocity statistics
+	if field := r.config.QueryOptions.StoryPointsField; field != "" {
+		if points, ok := rawIssue.Fields.Unknowns[field].(float64); ok {
+			issue.StoryPoints = points
+		}
+	}
+
+	// Capture the components for component digests
+	for _, component := range rawIssue.Fields.Components {
+		if component != nil && component.Name != "" {
+			issue.Components = append(issue.Components, component.Name)
+		}
+	}
+
+	// Capture the people involved for change attribution
+	if rawIssue.Fields.Reporter != nil {
+		issue.Reporter = userFromJira(rawIssue.Fields.Reporter)
+	}
+	if rawIssue.Fields.Assignee != nil {
+		issue.Assignee = userFromJira(rawIssue.Fields.Assignee)
+	}
+
+	// Process the activity of the included sections; the changelog can be
+	// expanded for statistics without its changes being reported
+	sections := r.config.ReportOptions.Sections
+	if rawIssue.Fields.Comments != nil && sections.Has(SectionComments) {
+		issue.Comments = r.processComments(rawIssue.Fields.Comments.Comments, timeRange)
+	}
+	if sections.Has(SectionWorklogs) {
+		issue.Worklogs = r.processWorklogs(rawIssue.Fields.Worklog, timeRange)
+	}
+	issue.unparsedEvents = unparsedEvents(rawIssue)
+
+	// Process changelog; reopenings, escalations and handoffs are flagged
+	// whichever sections are reported
+	if rawIssue.Changelog != nil {
+		if sections.Has(SectionChanges) {
+			issue.Changes = r.processChangelog(rawIssue.Changelog.Histories, timeRange, userID, issue)
 		}
-		
-		// If changelog should be expanded, add it to the expand options
-		if r.config.QueryOptions.ExpandChangelog {
-			options.Expand = "changelog"
+		issue.Reopened = detectReopening(rawIssue.Changelog.Histories, timeRange, r.config.ReportOptions.DoneStatuses, userID, issue)
+		issue.Escalation = detectEscalation(rawIssue.Changelog.Histories, timeRange, userID, issue)
+		issue.Handoff = detectHandoff(rawIssue.Changelog.Histories, timeRange, userID)
+
+		// The latest status change may be missing from a truncated changelog
+		if len(rawIssue.Changelog.Histories) < embeddedChangelogLimit {
+			issue.StatusSince = statusSince(statusHistory(rawIssue.Changelog.Histories), issue.Status, time.Time(rawIssue.Fields.Created))
+		}
+	}
+
+	// Keep the whole status history for cycle and lead times
+	if r.config.ReportOptions.computesStats() {
+		issue.Created = time.Time(rawIssue.Fields.Created)
+		if rawIssue.Changelog != nil {
+			issue.StatusHistory = statusHistory(rawIssue.Changelog.Histories)
+			issue.historyTruncated = len(rawIssue.Changelog.Histories) >= embeddedChangelogLimit
 		}
-		
-		return r.searchIssuesFunc(jql, options)
 	}
 
-	// Format time range for JQL query - use only the date part without time
-	fromTime := timeRange.Start.Format("2006-01-02")
-	toTime := timeRange.End.Format("2006-01-02")
+	return issue
+}
+
+// fetchUpdatedIssues retrieves the issues of a project from Jira based on the
+// given time range and user ID page by page, handing each page to handle, and
+// returns the number of issues matching the query
+func (r *JiraAPIRepository) fetchUpdatedIssues(timeRange TimeRange, userID, project string, handle func(page []extJira.Issue) bool) (int, error) {
+	// Search whole minutes covering the range; events are then filtered to
+	// the exact range as issues are converted
+	fromTime, toTime := r.config.QueryOptions.jqlRange(timeRange)
 
 	// Build the JQL query
-	jql := r.buildJQLQuery(fromTime, toTime)
+	jql, err := r.buildProjectJQLQuery(project, fromTime, toTime)
+	if err != nil {
+		return 0, err
+	}
+
+	// Check the query first, since Jira silently returns nothing for some invalid JQL
+	if r.config.QueryOptions.ValidateJQL {
+		if err := r.validateJQL(jql); err != nil {
+			return 0, err
+		}
+	}
+
+	return r.searchPages(jql, handle)
+}
 
-	// Create search options
+// searchPageSize is the number of issues requested per page of a paged search;
+// Jira caps pages at 100 issues, and at fewer when changelogs are expanded
+const searchPageSize = 100
+
+// searchPages runs a JQL search page by page, up to the configured maximum
+// results, handing each page to handle as it arrives. Paging stops early when
+// handle returns false, so that issues the report has no room for are never
+// fetched. It returns the number of issues matching the query.
+func (r *JiraAPIRepository) searchPages(jql string, handle func(page []extJira.Issue) bool) (int, error) {
+	limit := r.config.QueryOptions.MaxResults
+	if limit <= 0 {
+		limit = searchPageSize
+	}
+
+	fetched, total := 0, 0
+	for fetched < limit {
+		options := r.searchOptions()
+		options.StartAt = fetched
+		options.MaxResults = min(limit-fetched, searchPageSize)
+
+		page, pageTotal, err := r.searchIssuesWithTotal(jql, options)
+		if err != nil {
+			return 0, err
+		}
+		total = pageTotal
+		fetched += len(page)
+
+		if !handle(page) || len(page) == 0 || fetched >= total {
+			break
+		}
+	}
+	return total, nil
+}
+
+// searchIssues runs a JQL search with the configured search options
+func (r *JiraAPIRepository) searchIssues(jql string) ([]extJira.Issue, error) {
+	return r.searchIssuesWithOptions(jql, r.searchOptions())
+}
+
+// searchIssuesWithOptions runs a JQL search with the given search options
+func (r *JiraAPIRepository) searchIssuesWithOptions(jql string, options *extJira.SearchOptions) ([]extJira.Issue, error) {
+	issues, _, err := r.searchIssuesWithTotal(jql, options)
+	return issues, err
+}
+
+// searchIssuesWithTotal runs a JQL search with the given search options,
+// returning the number of issues matching the query along with the issues
+func (r *JiraAPIRepository) searchIssuesWithTotal(jql string, options *extJira.SearchOptions) ([]extJira.Issue, int, error) {
+	return r.searches.do(searchKey(jql, options), func() ([]extJira.Issue, int, error) {
+		// Search for issues
+		issues, total, err := r.api.Search(jql, options)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to search issues in Jira: %w", err)
+		}
+
+		return issues, total, nil
+	})
+}
+
+// searchOptions builds the search options from the query and report options
+func (r *JiraAPIRepository) searchOptions() *extJira.SearchOptions {
 	options := &extJira.SearchOptions{
 		MaxResults: r.config.QueryOptions.MaxResults,
-		Fields:     r.config.QueryOptions.Fields,
+		Fields:     r.searchFields(),
 	}
 
-	// If changelog should be expanded, add it to the expand options
-	if r.config.QueryOptions.ExpandChangelog {
+	if r.expandsChangelog() {
 		options.Expand = "changelog"
 	}
 
-	// Search for issues
-	issues, _, err := r.client.Issue.Search(jql, options)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search issues in Jira: %w", err)
+	return options
+}
+
+// expandsChangelog reports whether searches expand the changelog: when it is
+// configured and something reads it, since skipping it makes searches far
+// lighter on instances with long histories
+func (r *JiraAPIRepository) expandsChangelog() bool {
+	return r.config.QueryOptions.ExpandChangelog && r.config.ReportOptions.readsChangelog()
+}
+
+// searchFields returns the fields the report renders, derived from its sections
+// and settings so that nothing is fetched only to be dropped, followed by the
+// extra fields configured in jira.query.fields
+func (r *JiraAPIRepository) searchFields() []string {
+	fields := []string{"summary", "status"}
+
+	// Descriptions are only rendered at full verbosity
+	if r.config.ReportOptions.Verbosity.IncludeDescriptions() {
+		fields = append(fields, "description")
 	}
 
-	return issues, nil
+	// Comments and worklogs are fetched for their sections
+	if r.config.ReportOptions.Sections.Has(SectionComments) {
+		fields = append(fields, "comment")
+	}
+	if r.config.ReportOptions.Sections.Has(SectionWorklogs) {
+		fields = append(fields, "worklog")
+	}
+
+	// Creation time dates the status of issues that never changed it
+	if r.expandsChangelog() {
+		fields = append(fields, "created")
+	}
+
+	// Reporter and assignee are needed to annotate other people's changes
+	if r.config.ReportOptions.IncludeOthersChanges {
+		fields = appendMissing(fields, "reporter", "assignee")
+	}
+
+	// Type and parent are needed to roll issues up under their epic or
+	// initiative, and to leave out the issues of ignored epics
+	if r.config.ReportOptions.Mode == ReportModeEpic || r.config.ReportOptions.resolvesHierarchy() || len(r.config.ReportOptions.IgnoreEpics) > 0 {
+		fields = appendMissing(fields, r.hierarchyFields()...)
+	}
+
+	// Creation time and story points are needed for velocity statistics
+	if r.config.ReportOptions.computesStats() {
+		fields = appendMissing(fields, "created")
+		if r.config.QueryOptions.StoryPointsField != "" {
+			fields = appendMissing(fields, r.config.QueryOptions.StoryPointsField)
+		}
+	}
+
+	// Components are needed to group issues into component digests
+	if r.config.ReportOptions.Mode == ReportModeComponent {
+		fields = appendMissing(fields, "components")
+	}
+
+	// Type and resolution are needed to group and annotate release notes
+	if r.config.ReportOptions.Mode == ReportModeRelease {
+		fields = appendMissing(fields, "issuetype", "resolution")
+	}
+
+	// The due date is needed to preview upcoming work
+	if r.config.ReportOptions.DueWithinDays > 0 {
+		fields = appendMissing(fields, "duedate")
+	}
+
+	// Type, priority and reporter are needed to annotate triage issues
+	if r.config.ReportOptions.Mode == ReportModeTriage {
+		fields = appendMissing(fields, "issuetype", "priority", "reporter")
+	}
+
+	// Comments are a large part of each issue and stay out with their section,
+	// even when configured as an extra field
+	for _, field := range r.config.QueryOptions.Fields {
+		if field == "comment" && !r.config.ReportOptions.Sections.Has(SectionComments) {
+			continue
+		}
+		fields = appendMissing(fields, field)
+	}
+
+	return fields
+}
+
+// hierarchyFields returns the fields needed to walk up an issue's parent chain
+func (r *JiraAPIRepository) hierarchyFields() []string {
+	fields := []string{"issuetype", "parent"}
+	if r.config.QueryOptions.ParentLinkField != "" {
+		fields = append(fields, r.config.QueryOptions.ParentLinkField)
+	}
+	return fields
+}
+
+// appendMissing appends the values not already present in the slice
+func appendMissing(values []string, additions ...string) []string {
+	for _, addition := range additions {
+		found := false
+		for _, value := range values {
+			if value == addition {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, addition)
+		}
+	}
+	return values
+}
+
+// buildJQLQuery builds a JQL query for the configured project based on the
+// query options
+func (r *JiraAPIRepository) buildJQLQuery(fromTime, toTime string) (string, error) {
+	return r.buildProjectJQLQuery(r.config.QueryOptions.Project, fromTime, toTime)
 }
 
-// buildJQLQuery builds a JQL query based on the query options
-func (r *JiraAPIRepository) buildJQLQuery(fromTime, toTime string) string {
-	var conditions []string
+// buildProjectJQLQuery builds a JQL query for the project based on the query
+// options. Project keys, dates and filter values are quoted so that
+// user-controlled values cannot break or alter the structure of the query.
+// The saved filter ID and JQL template are written in as they are, so they
+// are checked here as well as by Validate, for options that never went
+// through it.
+func (r *JiraAPIRepository) buildProjectJQLQuery(project, fromTime, toTime string) (string, error) {
+	var query jqlBuilder
 	opts := r.config.QueryOptions
+	if err := errors.Join(opts.sourceErrors()...); err != nil {
+		return "", fmt.Errorf("invalid query options: %w", err)
+	}
 
-	// Start with the base JQL template
-	baseQuery := fmt.Sprintf(opts.JQLTemplate, opts.Project, fromTime, toTime)
-	conditions = append(conditions, baseQuery)
+	// Start with the saved filter, the raw JQL or the base JQL template
+	switch {
+	case opts.FilterID != "":
+		query.Raw(fmt.Sprintf("filter = %s AND updatedDate >= %s AND updatedDate < %s", opts.FilterID, QuoteJQL(fromTime), QuoteJQL(toTime)))
+	case opts.RawJQL != "":
+		query.Raw(opts.RawJQL)
+	default:
+		query.Raw(fmt.Sprintf(opts.JQLTemplate, QuoteJQL(project), QuoteJQL(fromTime), QuoteJQL(toTime)))
+	}
 
-	// Add assignee condition if needed
-	if opts.AssigneeCurrentUser {
-		conditions = append(conditions, "assignee = currentUser()")
+	// Add assignee condition if needed; component digests cover every assignee
+	if opts.AssigneeCurrentUser && r.config.ReportOptions.Mode != ReportModeComponent {
+		query.Raw("assignee = currentUser()")
 	}
 
-	// Add status filter if provided
-	if opts.StatusFilter != "" {
-		// Handle special case for "!Closed" which is not valid JQL
-		if opts.StatusFilter == "!Closed" {
-			conditions = append(conditions, "status != Closed")
-		} else if opts.StatusFilter == "!= Closed" {
-			conditions = append(conditions, "status != Closed")
-		} else {
-			conditions = append(conditions, fmt.Sprintf("status %s", opts.StatusFilter))
+	// Add status filters, falling back to the legacy free-form filter
+	query.In("status", opts.IncludeStatuses)
+	query.NotIn("status", opts.ExcludeStatuses)
+	if opts.StatusFilter != "" && len(opts.IncludeStatuses) == 0 && len(opts.ExcludeStatuses) == 0 {
+		if err := query.Condition("status", normalizeStatusFilter(opts.StatusFilter)); err != nil {
+			return "", fmt.Errorf("invalid status filter: %w", err)
 		}
 	}
 
 	// Add sprint condition if needed
 	if opts.InOpenSprints {
-		conditions = append(conditions, "sprint IN openSprints()")
+		query.Raw("sprint IN openSprints()")
 	}
 
-	// Join all conditions with AND
-	return strings.Join(conditions, " AND ")
+	// Add issue type filters if provided
+	query.In("issuetype", opts.IncludeIssueTypes)
+	query.NotIn("issuetype", opts.ExcludeIssueTypes)
+
+	// Add label and component filters if provided
+	query.In("labels", opts.Labels)
+	query.In("component", opts.Components)
+
+	return query.String(), nil
+}
+
+// unparsedEvents counts the comments and histories of an issue whose
+// timestamps cannot be read, which are left out of the report
+func unparsedEvents(rawIssue extJira.Issue) int {
+	count := 0
+	if rawIssue.Fields.Comments != nil {
+		for _, comment := range rawIssue.Fields.Comments.Comments {
+			if _, err := parseJiraTime(comment.Created); err != nil {
+				count++
+			}
+		}
+	}
+	if rawIssue.Changelog != nil {
+		for _, history := range rawIssue.Changelog.Histories {
+			if _, err := parseJiraTime(history.Created); err != nil {
+				count++
+			}
+		}
+	}
+	return count
 }
 
 // processComments converts external Jira comments to domain model comments
@@ -186,16 +614,27 @@ func (r *JiraAPIRepository) processComments(comments []*extJira.Comment, timeRan
 	result := make([]Comment, 0)
 
 	for _, comment := range comments {
-		createdTime, err := time.Parse("2006-01-02T15:04:05.000-0700", comment.Created)
+		createdTime, err := parseJiraTime(comment.Created)
 		if err != nil {
 			continue
 		}
 
+		// Jira keeps no earlier versions of a comment, so an edit is only flagged
+		edited := false
+		if updatedTime, err := parseJiraTime(comment.Updated); err == nil {
+			edited = updatedTime.After(createdTime)
+		}
+
 		if timeRange.IsInRange(createdTime) {
 			result = append(result, Comment{
-				Timestamp: createdTime,
-				Author:    comment.Author.DisplayName,
-				Content:   comment.Body,
+				ID:              comment.ID,
+				Timestamp:       createdTime,
+				Author:          displayName(comment.Author),
+				Content:         comment.Body,
+				AuthorAvatarURL: avatarURL(comment.Author.AvatarUrls),
+				AuthorAccountID: comment.Author.AccountID,
+				AuthorName:      comment.Author.Name,
+				Edited:          edited,
 			})
 		}
 	}
@@ -204,27 +643,62 @@ func (r *JiraAPIRepository) processComments(comments []*extJira.Comment, timeRan
 }
 
 // processChangelog converts external Jira changelog to domain model changes
-func (r *JiraAPIRepository) processChangelog(histories []extJira.ChangelogHistory, timeRange TimeRange, userAccountID string) []Change {
+func (r *JiraAPIRepository) processChangelog(histories []extJira.ChangelogHistory, timeRange TimeRange, userAccountID string, issue Issue) []Change {
 	result := make([]Change, 0)
+	includeOthers := r.config.ReportOptions.IncludeOthersChanges || r.config.ReportOptions.Mode == ReportModeComponent
 
 	for _, history := range histories {
-		createdTime, err := time.Parse("2006-01-02T15:04:05.000-0700", history.Created)
+		createdTime, err := parseJiraTime(history.Created)
 		if err != nil {
 			continue
 		}
 
-		if timeRange.IsInRange(createdTime) && history.Author.AccountID == userAccountID {
+		isOwnChange := isAccount(userID(history.Author), userAccountID)
+		if timeRange.IsInRange(createdTime) && (isOwnChange || includeOthers) {
+			// Annotate changes made by other people with their role on the issue
+			role := ""
+			if !isOwnChange {
+				role = issue.RoleOf(userID(history.Author))
+			}
+
 			for _, item := range history.Items {
 				result = append(result, Change{
-					Timestamp: createdTime,
-					Author:    history.Author.DisplayName,
-					Field:     item.Field,
-					FromValue: item.FromString,
-					ToValue:   item.ToString,
+					Timestamp:       createdTime,
+					Author:          displayName(history.Author),
+					AuthorRole:      role,
+					AuthorAccountID: history.Author.AccountID,
+					IsCurrentUser:   isOwnChange,
+					Field:           item.Field,
+					FromValue:       item.FromString,
+					ToValue:         item.ToString,
+					FromID:          changelogValue(item.From),
+					ToID:            changelogValue(item.To),
 				})
 			}
 		}
 	}
 
 	return result
-} 
+}
+
+// userFromJira converts an external Jira user to the domain model
+func userFromJira(user *extJira.User) User {
+	return User{
+		AccountID:   user.AccountID,
+		Name:        user.Name,
+		DisplayName: displayName(*user),
+		Email:       user.EmailAddress,
+		AvatarURL:   avatarURL(user.AvatarUrls),
+		TimeZone:    user.TimeZone,
+	}
+}
+
+// avatarURL returns the largest avatar Jira reported for a user
+func avatarURL(urls extJira.AvatarUrls) string {
+	for _, url := range []string{urls.Four8X48, urls.Three2X32, urls.Two4X24, urls.One6X16} {
+		if url != "" {
+			return url
+		}
+	}
+	return ""
+}
@@ -1,42 +1,36 @@
 package jira
 
 import (
-	"errors"
+	"daiv-jira/plugin/jira/internal/jiratest"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	extJira "github.com/andygrunwald/go-jira"
 )
 
-// MockJiraClient is a mock implementation of the external Jira client
-type MockJiraClient struct {
-	MockGetSelf       func() (*extJira.User, error)
-	MockSearchIssues  func(jql string, options *extJira.SearchOptions) ([]extJira.Issue, *extJira.Response, error)
+// newServerRepository creates a repository talking to a simulated Jira server
+func newServerRepository(t *testing.T, config *JiraConfig) (*JiraAPIRepository, *jiratest.Server) {
+	server := jiratest.NewServer(t)
+	return NewJiraAPIRepository(server.Client(t), config), server
 }
 
-// We'll use a simpler approach to mocking by directly patching the repository methods
-// instead of trying to mock the go-jira client which has complex interfaces
-
 func TestJiraAPIRepository_GetUser(t *testing.T) {
 	// Setup test cases
 	testCases := []struct {
-		name        string
-		setupMock   func(*JiraAPIRepository)
-		expectError bool
+		name         string
+		self         *extJira.User
+		expectError  bool
 		expectedUser *User
 	}{
 		{
 			name: "Successful user retrieval",
-			setupMock: func(repo *JiraAPIRepository) {
-				// Replace the client's User.GetSelf method with our mock
-				repo.client = &extJira.Client{}
-				repo.getUserFunc = func() (*User, error) {
-					return &User{
-						AccountID:   "user123",
-						DisplayName: "Test User",
-						Email:       "test@example.com",
-					}, nil
-				}
+			self: &extJira.User{
+				AccountID:    "user123",
+				DisplayName:  "Test User",
+				EmailAddress: "test@example.com",
 			},
 			expectError: false,
 			expectedUser: &User{
@@ -46,14 +40,9 @@ func TestJiraAPIRepository_GetUser(t *testing.T) {
 			},
 		},
 		{
-			name: "Error getting user",
-			setupMock: func(repo *JiraAPIRepository) {
-				repo.client = &extJira.Client{}
-				repo.getUserFunc = func() (*User, error) {
-					return nil, errors.New("failed to get user")
-				}
-			},
-			expectError: true,
+			name:         "Error getting user",
+			self:         nil,
+			expectError:  true,
 			expectedUser: nil,
 		},
 	}
@@ -63,16 +52,13 @@ func TestJiraAPIRepository_GetUser(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create repository with default config
 			config := &JiraConfig{
-				Username: "test",
-				Token:    "test",
-				URL:      "https://test.atlassian.net",
-				Project:  "TEST",
+				Username:     "test",
+				Token:        "test",
+				Project:      "TEST",
 				QueryOptions: DefaultQueryOptions(),
 			}
-			repo := NewJiraAPIRepository(&extJira.Client{}, config)
-			
-			// Setup the mock
-			tc.setupMock(repo)
+			repo, server := newServerRepository(t, config)
+			server.Self = tc.self
 
 			// Call the method being tested
 			user, err := repo.GetUser()
@@ -102,100 +88,71 @@ func TestJiraAPIRepository_GetUser(t *testing.T) {
 }
 
 func TestJiraAPIRepository_GetIssues(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
 	// Setup test cases
 	testCases := []struct {
-		name          string
-		setupMock     func(*JiraAPIRepository)
-		timeRange     TimeRange
-		userID        string
-		expectError   bool
+		name           string
+		issues         []extJira.Issue
+		failure        string
+		expectError    string
 		expectedIssues int
 	}{
 		{
 			name: "Successful issues retrieval",
-			setupMock: func(repo *JiraAPIRepository) {
-				repo.client = &extJira.Client{}
-				repo.searchIssuesFunc = func(jql string, options *extJira.SearchOptions) ([]extJira.Issue, error) {
-					return []extJira.Issue{
-						{
-							Key: "JIRA-123",
-							Fields: &extJira.IssueFields{
-								Summary: "Test Issue",
-								Status: &extJira.Status{
-									Name: "In Progress",
-								},
-								Comments: &extJira.Comments{
-									Comments: []*extJira.Comment{
-										{
-											Created: "2023-01-01T12:00:00.000-0700",
-											Author: extJira.User{
-												DisplayName: "Test User",
-											},
-											Body: "This is a test comment",
-										},
+			issues: []extJira.Issue{
+				{
+					Key: "JIRA-123",
+					Fields: &extJira.IssueFields{
+						Summary: "Test Issue",
+						Status: &extJira.Status{
+							Name: "In Progress",
+						},
+						Comments: &extJira.Comments{
+							Comments: []*extJira.Comment{
+								{
+									Created: "2023-01-01T12:00:00.000-0700",
+									Author: extJira.User{
+										DisplayName: "Test User",
 									},
+									Body: "This is a test comment",
 								},
 							},
-							Changelog: &extJira.Changelog{
-								Histories: []extJira.ChangelogHistory{
+						},
+					},
+					Changelog: &extJira.Changelog{
+						Histories: []extJira.ChangelogHistory{
+							{
+								Created: "2023-01-01T10:00:00.000-0700",
+								Author: extJira.User{
+									AccountID:   "user123",
+									DisplayName: "Test User",
+								},
+								Items: []extJira.ChangelogItems{
 									{
-										Created: "2023-01-01T10:00:00.000-0700",
-										Author: extJira.User{
-											AccountID:   "user123",
-											DisplayName: "Test User",
-										},
-										Items: []extJira.ChangelogItems{
-											{
-												Field:      "status",
-												FromString: "Open",
-												ToString:   "In Progress",
-											},
-										},
+										Field:      "status",
+										FromString: "Open",
+										ToString:   "In Progress",
 									},
 								},
 							},
 						},
-					}, nil
-				}
+					},
+				},
 			},
-			timeRange: TimeRange{
-				Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-				End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
-			},
-			userID:        "user123",
-			expectError:   false,
 			expectedIssues: 1,
 		},
 		{
-			name: "Error searching issues",
-			setupMock: func(repo *JiraAPIRepository) {
-				repo.client = &extJira.Client{}
-				repo.searchIssuesFunc = func(jql string, options *extJira.SearchOptions) ([]extJira.Issue, error) {
-					return nil, errors.New("failed to search issues")
-				}
-			},
-			timeRange: TimeRange{
-				Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-				End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
-			},
-			userID:        "user123",
-			expectError:   true,
-			expectedIssues: 0,
+			name:        "Error searching issues",
+			failure:     "Field 'sprint' does not exist or you do not have permission to view it.",
+			expectError: "Field 'sprint' does not exist",
 		},
 		{
-			name: "Empty issues list",
-			setupMock: func(repo *JiraAPIRepository) {
-				repo.client = &extJira.Client{}
-				repo.searchIssuesFunc = func(jql string, options *extJira.SearchOptions) ([]extJira.Issue, error) {
-					return []extJira.Issue{}, nil
-				}
-			},
-			timeRange: TimeRange{
-				Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-				End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
-			},
-			userID:        "user123",
-			expectError:   false,
+			name:           "Empty issues list",
+			issues:         []extJira.Issue{},
 			expectedIssues: 0,
 		},
 	}
@@ -205,47 +162,387 @@ func TestJiraAPIRepository_GetIssues(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create repository with default config
 			config := &JiraConfig{
-				Username: "test",
-				Token:    "test",
-				URL:      "https://test.atlassian.net",
-				Project:  "TEST",
+				Username:     "test",
+				Token:        "test",
+				Project:      "TEST",
 				QueryOptions: DefaultQueryOptions(),
 			}
-			repo := NewJiraAPIRepository(&extJira.Client{}, config)
-			
-			// Setup the mock
-			tc.setupMock(repo)
+			repo, server := newServerRepository(t, config)
+			server.Issues = tc.issues
+			if tc.failure != "" {
+				server.Fail("/rest/api/2/search", http.StatusBadRequest, tc.failure)
+			}
 
 			// Call the method being tested
-			issues, err := repo.GetIssues(tc.timeRange, tc.userID)
+			issues, _, err := repo.GetIssues(timeRange, "user123")
 
-			// Check error
-			if tc.expectError && err == nil {
-				t.Errorf("Expected an error but got nil")
+			// Check error, which carries Jira's error message
+			if tc.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+					t.Errorf("Expected an error containing '%s', got %v", tc.expectError, err)
+				}
+				return
 			}
-			if !tc.expectError && err != nil {
-				t.Errorf("Expected no error but got: %v", err)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
 			}
 
-			// If no error is expected, check the issues
-			if !tc.expectError && err == nil {
-				if len(issues) != tc.expectedIssues {
-					t.Errorf("Expected %d issues, got %d", tc.expectedIssues, len(issues))
+			if len(issues) != tc.expectedIssues {
+				t.Errorf("Expected %d issues, got %d", tc.expectedIssues, len(issues))
+			}
+
+			// Check issue details if issues were returned
+			if tc.expectedIssues > 0 {
+				if issues[0].Key != "JIRA-123" {
+					t.Errorf("Expected issue key JIRA-123, got %s", issues[0].Key)
+				}
+				if issues[0].Summary != "Test Issue" {
+					t.Errorf("Expected issue summary 'Test Issue', got %s", issues[0].Summary)
 				}
+				if issues[0].Status != "In Progress" {
+					t.Errorf("Expected issue status 'In Progress', got %s", issues[0].Status)
+				}
+			}
+
+			// The search asks for the configured page size and the changelog
+			requests := server.Requests("/rest/api/2/search")
+			if len(requests) != 1 || requests[0].Query.Get("expand") != "changelog" {
+				t.Errorf("Expected one search expanding the changelog, got %+v", requests)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_GetIssues_RateLimited(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: DefaultQueryOptions()})
+	server.Throttle(1)
+
+	_, _, err := repo.GetIssues(TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, "user123")
+	if err == nil || !strings.Contains(err.Error(), "Rate limit exceeded") {
+		t.Errorf("Expected the rate limit error, got %v", err)
+	}
+}
+
+func TestJiraAPIRepository_GetIssues_IncludeOthersChanges(t *testing.T) {
+	// Build an issue changed by the reporter, the user and a third party
+	history := func(accountID, displayName, created string) extJira.ChangelogHistory {
+		return extJira.ChangelogHistory{
+			Created: created,
+			Author:  extJira.User{AccountID: accountID, DisplayName: displayName},
+			Items:   []extJira.ChangelogItems{{Field: "status", FromString: "Done", ToString: "Reopened"}},
+		}
+	}
+	rawIssues := []extJira.Issue{
+		{
+			Key: "JIRA-123",
+			Fields: &extJira.IssueFields{
+				Summary:  "Test Issue",
+				Status:   &extJira.Status{Name: "Reopened"},
+				Reporter: &extJira.User{AccountID: "qa1", DisplayName: "QA"},
+				Assignee: &extJira.User{AccountID: "user123", DisplayName: "Test User"},
+			},
+			Changelog: &extJira.Changelog{
+				Histories: []extJira.ChangelogHistory{
+					history("qa1", "QA", "2023-01-01T10:00:00.000+0000"),
+					history("user123", "Test User", "2023-01-01T11:00:00.000+0000"),
+					history("pm1", "PM", "2023-01-01T12:00:00.000+0000"),
+				},
+			},
+		},
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name          string
+		includeOthers bool
+		expectedRoles []string
+	}{
+		{
+			name:          "Only own changes by default",
+			includeOthers: false,
+			expectedRoles: []string{""},
+		},
+		{
+			name:          "Others' changes annotated with roles",
+			includeOthers: true,
+			expectedRoles: []string{RoleReporter, "", RoleThirdParty},
+		},
+	}
 
-				// Check issue details if issues were returned
-				if tc.expectedIssues > 0 {
-					if issues[0].Key != "JIRA-123" {
-						t.Errorf("Expected issue key JIRA-123, got %s", issues[0].Key)
-					}
-					if issues[0].Summary != "Test Issue" {
-						t.Errorf("Expected issue summary 'Test Issue', got %s", issues[0].Summary)
-					}
-					if issues[0].Status != "In Progress" {
-						t.Errorf("Expected issue status 'In Progress', got %s", issues[0].Status)
-					}
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &JiraConfig{
+				Project:       "TEST",
+				QueryOptions:  DefaultQueryOptions(),
+				ReportOptions: ReportOptions{IncludeOthersChanges: tc.includeOthers},
+			}
+			repo, server := newServerRepository(t, config)
+			server.Issues = rawIssues
+
+			issues, _, err := repo.GetIssues(TimeRange{
+				Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			}, "user123")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			changes := issues[0].Changes
+			if len(changes) != len(tc.expectedRoles) {
+				t.Fatalf("Expected %d changes, got %d", len(tc.expectedRoles), len(changes))
+			}
+			for i, role := range tc.expectedRoles {
+				if changes[i].AuthorRole != role {
+					t.Errorf("Expected change %d to have role '%s', got '%s'", i, role, changes[i].AuthorRole)
+				}
+				if own := changes[i].AuthorAccountID == "user123"; changes[i].IsCurrentUser != own {
+					t.Errorf("Expected change %d to be the user's own: %v, got %v", i, own, changes[i].IsCurrentUser)
 				}
 			}
+
+			requestedFields := strings.Split(server.Requests("/rest/api/2/search")[0].Query.Get("fields"), ",")
+			if tc.includeOthers && !reflect.DeepEqual(requestedFields[len(requestedFields)-2:], []string{"reporter", "assignee"}) {
+				t.Errorf("Expected reporter and assignee fields to be requested, got %v", requestedFields)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_BuildJQLQuery_LabelsAndComponents(t *testing.T) {
+	options := DefaultQueryOptions()
+	options.Project = "TEST"
+	options.AssigneeCurrentUser = false
+	options.ExcludeStatuses = nil
+	options.InOpenSprints = false
+	options.Labels = []string{"backend", `say "hi"`}
+	options.Components = []string{"Billing API"}
+
+	repo := NewJiraAPIRepository(&extJira.Client{}, &JiraConfig{QueryOptions: options})
+
+	jql, err := repo.buildJQLQuery("2023-01-01", "2023-01-02")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `project = "TEST" AND updatedDate >= "2023-01-01" AND updatedDate < "2023-01-02" AND labels IN ("backend", "say \"hi\"") AND component IN ("Billing API")`
+	if jql != expected {
+		t.Errorf("Expected JQL '%s', got '%s'", expected, jql)
+	}
+}
+
+func TestJiraAPIRepository_BuildJQLQuery_RawJQL(t *testing.T) {
+	options := DefaultQueryOptions()
+	options.JQLTemplate = ""
+	options.RawJQL = "project = TEST OR reporter = currentUser()"
+	options.AssigneeCurrentUser = false
+	options.ExcludeStatuses = nil
+	options.InOpenSprints = false
+	options.Labels = []string{"backend"}
+
+	repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: options}}
+
+	jql, err := repo.buildJQLQuery("2023-01-01", "2023-01-02")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `(project = TEST OR reporter = currentUser()) AND labels IN ("backend")`
+	if jql != expected {
+		t.Errorf("Expected JQL '%s', got '%s'", expected, jql)
+	}
+}
+
+func TestJiraAPIRepository_BuildJQLQuery_FilterID(t *testing.T) {
+	options := DefaultQueryOptions()
+	options.JQLTemplate = ""
+	options.FilterID = "12345"
+	options.ExcludeStatuses = nil
+	options.InOpenSprints = false
+
+	repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: options}}
+
+	jql, err := repo.buildJQLQuery("2023-01-01", "2023-01-02")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `filter = 12345 AND updatedDate >= "2023-01-01" AND updatedDate < "2023-01-02" AND assignee = currentUser()`
+	if jql != expected {
+		t.Errorf("Expected JQL '%s', got '%s'", expected, jql)
+	}
+}
+
+func TestJiraAPIRepository_BuildJQLQuery_Unvalidated(t *testing.T) {
+	// Options that never went through Validate cannot smuggle JQL into the query
+	options := DefaultQueryOptions()
+	options.JQLTemplate = ""
+	options.FilterID = "12345 OR project = OPS"
+
+	repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: options}}
+
+	if jql, err := repo.buildJQLQuery("2023-01-01", "2023-01-02"); err == nil || !strings.Contains(err.Error(), "numeric ID of a saved filter") {
+		t.Errorf("Expected an error about the filter ID, got JQL '%s' and %v", jql, err)
+	}
+
+	// The legacy status filter is normalized as it is written
+	options = DefaultQueryOptions()
+	options.ExcludeStatuses = nil
+	options.InOpenSprints = false
+	options.StatusFilter = "!Closed"
+	repo = &JiraAPIRepository{config: &JiraConfig{QueryOptions: options}}
+
+	jql, err := repo.buildJQLQuery("2023-01-01", "2023-01-02")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(jql, `status != "Closed"`) {
+		t.Errorf("Expected the normalized status filter, got '%s'", jql)
+	}
+}
+
+func TestJiraAPIRepository_BuildJQLQuery_Statuses(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		legacy   string
+		expected string
+	}{
+		{
+			name:     "Default excludes closed issues",
+			exclude:  []string{"Closed"},
+			expected: `project = "TEST" AND status NOT IN ("Closed")`,
+		},
+		{
+			name:     "Include and exclude lists",
+			include:  []string{"In Progress", "In Review"},
+			exclude:  []string{"Done"},
+			expected: `project = "TEST" AND status IN ("In Progress", "In Review") AND status NOT IN ("Done")`,
+		},
+		{
+			name:     "Legacy filter used without lists",
+			legacy:   "= Blocked",
+			expected: `project = "TEST" AND status = "Blocked"`,
+		},
+		{
+			name:     "Lists take precedence over legacy filter",
+			include:  []string{"Open"},
+			legacy:   "= Blocked",
+			expected: `project = "TEST" AND status IN ("Open")`,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := DefaultQueryOptions()
+			options.JQLTemplate = ""
+			options.RawJQL = `project = "TEST"`
+			options.AssigneeCurrentUser = false
+			options.InOpenSprints = false
+			options.IncludeStatuses = tc.include
+			options.ExcludeStatuses = tc.exclude
+			options.StatusFilter = tc.legacy
+
+			repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: options}}
+
+			jql, err := repo.buildJQLQuery("2023-01-01", "2023-01-02")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if jql != tc.expected {
+				t.Errorf("Expected JQL '%s', got '%s'", tc.expected, jql)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_BuildJQLQuery_IssueTypes(t *testing.T) {
+	options := DefaultQueryOptions()
+	options.JQLTemplate = ""
+	options.RawJQL = `project = "TEST"`
+	options.AssigneeCurrentUser = false
+	options.ExcludeStatuses = nil
+	options.InOpenSprints = false
+	options.IncludeIssueTypes = []string{"Bug", "Story"}
+	options.ExcludeIssueTypes = []string{"Sub-task"}
+
+	repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: options}}
+
+	jql, err := repo.buildJQLQuery("2023-01-01", "2023-01-02")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `project = "TEST" AND issuetype IN ("Bug", "Story") AND issuetype NOT IN ("Sub-task")`
+	if jql != expected {
+		t.Errorf("Expected JQL '%s', got '%s'", expected, jql)
+	}
+}
+
+func TestJiraAPIRepository_GetIssuesByKey(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions()})
+	server.Issues = []extJira.Issue{
+		{
+			Key: "JIRA-10",
+			Fields: &extJira.IssueFields{
+				Summary: "Parent story",
+				Status:  &extJira.Status{Name: "Open"},
+				Type:    extJira.IssueType{Name: "Story"},
+				Parent:  &extJira.Parent{Key: "EPIC-1"},
+			},
+		},
+	}
+
+	issues, err := repo.GetIssuesByKey([]string{"JIRA-10"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	query := server.Requests("/rest/api/2/search")[0].Query
+	if query.Get("jql") != `key IN ("JIRA-10")` {
+		t.Errorf("Expected key lookup JQL, got '%s'", query.Get("jql"))
+	}
+	if query.Get("fields") != "summary,status,issuetype,parent" {
+		t.Errorf("Expected hierarchy fields, got %v", query.Get("fields"))
+	}
+	if len(issues) != 1 || issues[0].Type != "Story" || issues[0].Parent == nil || issues[0].Parent.Key != "EPIC-1" {
+		t.Errorf("Expected JIRA-10 with parent EPIC-1, got %+v", issues)
+	}
+}
+
+func TestAvatarURL(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		urls     extJira.AvatarUrls
+		expected string
+	}{
+		{
+			name:     "Largest size preferred",
+			urls:     extJira.AvatarUrls{Four8X48: "https://avatar/48", One6X16: "https://avatar/16"},
+			expected: "https://avatar/48",
+		},
+		{
+			name:     "Falls back to smaller sizes",
+			urls:     extJira.AvatarUrls{Two4X24: "https://avatar/24"},
+			expected: "https://avatar/24",
+		},
+		{
+			name:     "No avatar",
+			urls:     extJira.AvatarUrls{},
+			expected: "",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := avatarURL(tc.urls)
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
 		})
 	}
-} 
+}
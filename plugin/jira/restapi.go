@@ -0,0 +1,155 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// ClientBackend selects the HTTP client the repository reaches Jira through
+type ClientBackend string
+
+const (
+	// ClientGoJira talks to Jira through the go-jira library
+	ClientGoJira ClientBackend = "go-jira"
+	// ClientNative talks to Jira through the built-in REST client
+	ClientNative ClientBackend = "native"
+)
+
+// ParseClientBackend converts a configuration value to a ClientBackend
+func ParseClientBackend(value string) (ClientBackend, error) {
+	switch ClientBackend(strings.ToLower(strings.TrimSpace(value))) {
+	case ClientGoJira, "":
+		return ClientGoJira, nil
+	case ClientNative:
+		return ClientNative, nil
+	default:
+		return "", fmt.Errorf("unknown client %q (expected go-jira or native)", value)
+	}
+}
+
+// jiraAPI is the HTTP layer the repository reaches Jira through. Both
+// backends decode the responses into go-jira's types, so the repository is
+// the same whichever one is configured.
+type jiraAPI interface {
+	// Self retrieves the current user
+	Self() (*extJira.User, error)
+	// Search runs a JQL search, returning the number of issues matching it
+	// along with the page of issues
+	Search(jql string, options *extJira.SearchOptions) ([]extJira.Issue, int, error)
+	// FindUsers searches for users by name or email
+	FindUsers(query string) ([]extJira.User, error)
+	// RemoteLinks retrieves the remote links of an issue
+	RemoteLinks(key string) ([]extJira.RemoteLink, error)
+	// ActiveSprints retrieves the active sprints of a board
+	ActiveSprints(boardID int) ([]extJira.Sprint, error)
+	// Get decodes the response to a GET of the endpoint into v
+	Get(endpoint string, v interface{}) error
+	// Post sends the body as JSON to the endpoint, decoding the response into v
+	Post(endpoint string, body, v interface{}) error
+}
+
+// apiError is a request Jira answered with an error status
+type apiError struct {
+	StatusCode int
+	err        error
+}
+
+// Error returns the error as reported by the backend
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error reported by the backend
+func (e *apiError) Unwrap() error {
+	return e.err
+}
+
+// apiStatus returns the HTTP status of a failed request, or 0 when the
+// request got no response
+func apiStatus(err error) int {
+	var failed *apiError
+	if errors.As(err, &failed) {
+		return failed.StatusCode
+	}
+	return 0
+}
+
+// goJiraAPI reaches Jira through the go-jira client
+type goJiraAPI struct {
+	client *extJira.Client
+}
+
+// withStatus attaches the status of the response to the error, if any
+func withStatus(resp *extJira.Response, err error) error {
+	if err == nil || resp == nil {
+		return err
+	}
+	return &apiError{StatusCode: resp.StatusCode, err: err}
+}
+
+// Self implements jiraAPI
+func (a *goJiraAPI) Self() (*extJira.User, error) {
+	user, resp, err := a.client.User.GetSelf()
+	return user, withStatus(resp, err)
+}
+
+// Search implements jiraAPI
+func (a *goJiraAPI) Search(jql string, options *extJira.SearchOptions) ([]extJira.Issue, int, error) {
+	issues, resp, err := a.client.Issue.Search(jql, options)
+	if err != nil {
+		return nil, 0, withStatus(resp, err)
+	}
+	return issues, resp.Total, nil
+}
+
+// FindUsers implements jiraAPI
+func (a *goJiraAPI) FindUsers(query string) ([]extJira.User, error) {
+	// The client does not escape search parameters itself
+	users, resp, err := a.client.User.Find(url.QueryEscape(query))
+	return users, withStatus(resp, err)
+}
+
+// RemoteLinks implements jiraAPI
+func (a *goJiraAPI) RemoteLinks(key string) ([]extJira.RemoteLink, error) {
+	links, resp, err := a.client.Issue.GetRemoteLinks(key)
+	if err != nil {
+		return nil, withStatus(resp, err)
+	}
+	return *links, nil
+}
+
+// ActiveSprints implements jiraAPI
+func (a *goJiraAPI) ActiveSprints(boardID int) ([]extJira.Sprint, error) {
+	sprints, resp, err := a.client.Board.GetAllSprintsWithOptions(boardID, &extJira.GetAllSprintsOptions{State: "active"})
+	if err != nil {
+		return nil, withStatus(resp, err)
+	}
+	return sprints.Values, nil
+}
+
+// Get implements jiraAPI
+func (a *goJiraAPI) Get(endpoint string, v interface{}) error {
+	return a.send("GET", endpoint, nil, v)
+}
+
+// Post implements jiraAPI
+func (a *goJiraAPI) Post(endpoint string, body, v interface{}) error {
+	return a.send("POST", endpoint, body, v)
+}
+
+// send runs a request, decoding the response into v
+func (a *goJiraAPI) send(method, endpoint string, body, v interface{}) error {
+	req, err := a.client.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(req, v)
+	if err != nil {
+		return withStatus(resp, extJira.NewJiraError(resp, err))
+	}
+	return nil
+}
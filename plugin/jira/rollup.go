@@ -0,0 +1,192 @@
+package jira
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxParentDepth bounds how far up the parent chain epics are resolved
+// (sub-task → story → epic needs two steps)
+const maxParentDepth = 3
+
+// RollupCounts aggregates activity counts over a group of issues
+type RollupCounts struct {
+	IssuesAdvanced int // Issues with at least one status transition
+	Comments       int
+	Changes        int
+}
+
+// add counts the activity of an issue
+func (c *RollupCounts) add(issue Issue) {
+	c.Comments += len(issue.Comments)
+	c.Changes += len(issue.Changes)
+	if issueAdvanced(issue) {
+		c.IssuesAdvanced++
+	}
+}
+
+// CountsLine renders the rollup counts, e.g. "3 issues advanced, 2 comments, 5 changes"
+func (c RollupCounts) CountsLine() string {
+	return strings.Join([]string{
+		pluralize(c.IssuesAdvanced, "issue advanced", "issues advanced"),
+		pluralize(c.Comments, "comment", "comments"),
+		pluralize(c.Changes, "change", "changes"),
+	}, ", ")
+}
+
+// EpicRollup aggregates the activity of an epic's child issues
+type EpicRollup struct {
+	Epic   *IssueRef // Nil for issues without an epic
+	Issues []Issue
+	RollupCounts
+}
+
+// Title returns the epic's display title, or "No Epic" for unparented issues
+func (r EpicRollup) Title() string {
+	if r.Epic == nil {
+		return "No Epic"
+	}
+	return fmt.Sprintf("[%s] %s", r.Epic.Key, r.Epic.Summary)
+}
+
+// RollupByEpic groups issues under their epic, ordered by epic key with
+// issues without an epic last
+func RollupByEpic(issues []Issue) []EpicRollup {
+	rollups := make(map[string]*EpicRollup)
+	keys := make([]string, 0)
+
+	for _, issue := range issues {
+		key := ""
+		if issue.Epic != nil {
+			key = issue.Epic.Key
+		}
+
+		rollup, ok := rollups[key]
+		if !ok {
+			rollup = &EpicRollup{Epic: issue.Epic}
+			rollups[key] = rollup
+			keys = append(keys, key)
+		}
+
+		rollup.Issues = append(rollup.Issues, issue)
+		rollup.add(issue)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i] == "" || keys[j] == "" {
+			return keys[j] == ""
+		}
+		return keys[i] < keys[j]
+	})
+
+	result := make([]EpicRollup, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *rollups[key])
+	}
+	return result
+}
+
+// issueAdvanced reports whether an issue moved through its workflow in the range
+func issueAdvanced(issue Issue) bool {
+	if issue.Transitions != nil && issue.Transitions.Count > 0 {
+		return true
+	}
+	for _, change := range issue.Changes {
+		if strings.EqualFold(change.Field, statusField) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveEpics sets the epic of each issue by walking up its parent chain,
+// fetching parents that are not part of the report
+func resolveEpics(issues []Issue, fetch func(keys []string) ([]Issue, error)) error {
+	// Epics end the walk, so their own parents are never fetched
+	known, err := fetchAncestors(issues, fetch, maxParentDepth, func(issue Issue) string {
+		if issue.Epic != nil || issue.Type == IssueTypeEpic || issue.Parent == nil {
+			return ""
+		}
+		return issue.Parent.Key
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range issues {
+		issues[i].Epic = findEpic(issues[i], known)
+	}
+	return nil
+}
+
+// fetchAncestors fetches the ancestors of the issues level by level, following
+// the key returned by next, and returns every issue known by key
+func fetchAncestors(issues []Issue, fetch func(keys []string) ([]Issue, error), depth int, next func(issue Issue) string) (map[string]Issue, error) {
+	known := make(map[string]Issue, len(issues))
+	for _, issue := range issues {
+		known[issue.Key] = issue
+	}
+
+	for level := 0; level < depth; level++ {
+		missing := make([]string, 0)
+		for _, issue := range known {
+			if key := next(issue); key != "" {
+				if _, ok := known[key]; !ok {
+					missing = appendMissing(missing, key)
+				}
+			}
+		}
+		if len(missing) == 0 {
+			break
+		}
+
+		sort.Strings(missing)
+		parents, err := fetch(missing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve parent issues: %w", err)
+		}
+		for _, parent := range parents {
+			known[parent.Key] = parent
+		}
+		// Guard against parents the search did not return
+		for _, key := range missing {
+			if _, ok := known[key]; !ok {
+				known[key] = Issue{Key: key}
+			}
+		}
+	}
+	return known, nil
+}
+
+// findEpic walks up the parent chain of an issue until it finds an epic
+func findEpic(issue Issue, known map[string]Issue) *IssueRef {
+	current := issue
+	for depth := 0; depth <= maxParentDepth; depth++ {
+		if current.Epic != nil {
+			return current.Epic
+		}
+		if current.Type == IssueTypeEpic {
+			epic := issueRef(current)
+			return &epic
+		}
+		if current.Parent == nil {
+			return nil
+		}
+
+		parent, ok := known[current.Parent.Key]
+		if !ok {
+			return nil
+		}
+		current = parent
+	}
+	return nil
+}
+
+// pluralize renders a count with the singular or plural noun
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
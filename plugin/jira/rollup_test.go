@@ -0,0 +1,163 @@
+package jira
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestRollupByEpic(t *testing.T) {
+	epicA := &IssueRef{Key: "EPIC-1", Summary: "Checkout"}
+	epicB := &IssueRef{Key: "EPIC-2", Summary: "Search"}
+
+	issues := []Issue{
+		{Key: "JIRA-1", Epic: epicB, Changes: []Change{{Field: "status"}, {Field: "summary"}}},
+		{Key: "JIRA-2", Comments: []Comment{{Author: "Test User"}}},
+		{Key: "JIRA-3", Epic: epicA, Transitions: &TransitionSummary{Count: 2}},
+		{Key: "JIRA-4", Epic: epicB, Comments: []Comment{{}, {}}},
+	}
+
+	rollups := RollupByEpic(issues)
+
+	titles := make([]string, 0, len(rollups))
+	for _, rollup := range rollups {
+		titles = append(titles, rollup.Title())
+	}
+	expectedTitles := []string{"[EPIC-1] Checkout", "[EPIC-2] Search", "No Epic"}
+	if !reflect.DeepEqual(titles, expectedTitles) {
+		t.Fatalf("Expected titles %v, got %v", expectedTitles, titles)
+	}
+
+	search := rollups[1]
+	if len(search.Issues) != 2 || search.IssuesAdvanced != 1 || search.Comments != 2 || search.Changes != 2 {
+		t.Errorf("Unexpected Search rollup: %+v", search)
+	}
+	if search.CountsLine() != "1 issue advanced, 2 comments, 2 changes" {
+		t.Errorf("Expected counts line '1 issue advanced, 2 comments, 2 changes', got '%s'", search.CountsLine())
+	}
+	if rollups[0].IssuesAdvanced != 1 {
+		t.Errorf("Expected summarized transitions to count as advanced, got %d", rollups[0].IssuesAdvanced)
+	}
+}
+
+func TestResolveEpics(t *testing.T) {
+	issues := []Issue{
+		{Key: "JIRA-1", Type: "Sub-task", Parent: &IssueRef{Key: "JIRA-10"}},
+		{Key: "JIRA-2", Type: "Story", Parent: &IssueRef{Key: "EPIC-1"}},
+		{Key: "EPIC-2", Type: IssueTypeEpic, Summary: "Search"},
+		{Key: "JIRA-3", Type: "Story", Epic: &IssueRef{Key: "EPIC-3", Summary: "Known"}},
+		{Key: "JIRA-4", Type: "Story"},
+	}
+
+	var requested [][]string
+	fetch := func(keys []string) ([]Issue, error) {
+		requested = append(requested, keys)
+		parents := map[string]Issue{
+			"JIRA-10": {Key: "JIRA-10", Type: "Story", Parent: &IssueRef{Key: "EPIC-1"}},
+			"EPIC-1":  {Key: "EPIC-1", Type: IssueTypeEpic, Summary: "Checkout"},
+		}
+		result := make([]Issue, 0)
+		for _, key := range keys {
+			if parent, ok := parents[key]; ok {
+				result = append(result, parent)
+			}
+		}
+		return result, nil
+	}
+
+	if err := resolveEpics(issues, fetch); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"JIRA-1": "EPIC-1", "JIRA-2": "EPIC-1", "EPIC-2": "EPIC-2", "JIRA-3": "EPIC-3", "JIRA-4": ""}
+	for _, issue := range issues {
+		epicKey := ""
+		if issue.Epic != nil {
+			epicKey = issue.Epic.Key
+		}
+		if epicKey != expected[issue.Key] {
+			t.Errorf("Expected %s to roll up under '%s', got '%s'", issue.Key, expected[issue.Key], epicKey)
+		}
+	}
+
+	if issues[0].Epic == nil || issues[0].Epic.Summary != "Checkout" {
+		t.Errorf("Expected resolved epic summary 'Checkout', got %+v", issues[0].Epic)
+	}
+
+	// Parents are fetched level by level, each at most once
+	expectedRequests := [][]string{{"EPIC-1", "JIRA-10"}}
+	if !reflect.DeepEqual(requested, expectedRequests) {
+		t.Errorf("Expected requests %v, got %v", expectedRequests, requested)
+	}
+}
+
+func TestResolveEpics_FetchError(t *testing.T) {
+	issues := []Issue{{Key: "JIRA-1", Type: "Story", Parent: &IssueRef{Key: "EPIC-1"}}}
+
+	err := resolveEpics(issues, func(keys []string) ([]Issue, error) {
+		return nil, errors.New("boom")
+	})
+	if err == nil {
+		t.Fatalf("Expected an error")
+	}
+	if issues[0].Epic != nil {
+		t.Errorf("Expected no epic after a failed lookup, got %+v", issues[0].Epic)
+	}
+}
+
+func TestActivityService_EpicMode(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{Key: "JIRA-1", Summary: "Pay by card", Status: "In Review", Type: "Story", Parent: &IssueRef{Key: "EPIC-1"},
+					Changes: []Change{{Field: "status", FromValue: "In Progress", ToValue: "In Review"}}},
+			}, nil
+		},
+		MockGetIssuesByKey: func(keys []string) ([]Issue, error) {
+			return []Issue{{Key: "EPIC-1", Summary: "Checkout", Type: IssueTypeEpic}}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.Mode = ReportModeEpic
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(report.Epics) != 1 || report.Epics[0].Title() != "[EPIC-1] Checkout" {
+		t.Fatalf("Expected a single Checkout rollup, got %+v", report.Epics)
+	}
+
+	formatters := []ReportFormatter{
+		NewXMLFormatter(),
+		NewJSONFormatter(),
+		NewMarkdownFormatter(),
+		NewHTMLFormatter(),
+	}
+	for _, formatter := range formatters {
+		t.Run(formatter.Name(), func(t *testing.T) {
+			result, err := formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(result.Content, "EPIC-1") || !strings.Contains(result.Content, "Checkout") {
+				t.Errorf("Expected content to contain the epic rollup, got '%s'", result.Content)
+			}
+		})
+	}
+}
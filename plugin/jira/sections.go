@@ -0,0 +1,61 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ReportSection is a kind of issue activity the report can include
+type ReportSection string
+
+const (
+	// SectionComments is the comments posted on issues
+	SectionComments ReportSection = "comments"
+	// SectionChanges is the field changes from the changelog, along with the
+	// reopenings, escalations and handoffs read from it
+	SectionChanges ReportSection = "changes"
+	// SectionWorklogs is the work logged on issues
+	SectionWorklogs ReportSection = "worklogs"
+)
+
+// ReportSections is the set of activity sections a report includes. An empty
+// set includes the default sections, comments and changes.
+type ReportSections map[ReportSection]bool
+
+// ParseReportSections parses a list of section names such as
+// "comments, worklogs"; an empty list gives the default sections
+func ParseReportSections(values []string) (ReportSections, error) {
+	sections := make(ReportSections, len(values))
+	for _, value := range values {
+		section := ReportSection(strings.ToLower(strings.TrimSpace(value)))
+		switch section {
+		case "":
+			continue
+		case SectionComments, SectionChanges, SectionWorklogs:
+			sections[section] = true
+		default:
+			return nil, fmt.Errorf("unknown section %q (expected comments, changes or worklogs)", value)
+		}
+	}
+	if len(sections) == 0 && len(values) > 0 {
+		return nil, errors.New("at least one section is required")
+	}
+	return sections, nil
+}
+
+// Has reports whether the section is included
+func (s ReportSections) Has(section ReportSection) bool {
+	if len(s) == 0 {
+		return section == SectionComments || section == SectionChanges
+	}
+	return s[section]
+}
+
+// readsChangelog reports whether the search has to expand the changelog of
+// every issue: for the changes section, and for the features read from the
+// full history, such as velocity statistics, escalations and handoffs. Without
+// it, the search returns far less data on instances with long histories.
+func (o ReportOptions) readsChangelog() bool {
+	return o.Sections.Has(SectionChanges) || o.computesStats() || o.IncludeEscalations || o.IncludeHandoffs
+}
@@ -0,0 +1,184 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestParseReportSections(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		values      []string
+		included    []ReportSection
+		excluded    []ReportSection
+		expectedErr string
+	}{
+		{
+			name:     "Default",
+			included: []ReportSection{SectionComments, SectionChanges},
+			excluded: []ReportSection{SectionWorklogs},
+		},
+		{
+			name:     "Comments only",
+			values:   []string{" Comments "},
+			included: []ReportSection{SectionComments},
+			excluded: []ReportSection{SectionChanges, SectionWorklogs},
+		},
+		{
+			name:     "With worklogs",
+			values:   []string{"comments", "changes", "worklogs"},
+			included: []ReportSection{SectionComments, SectionChanges, SectionWorklogs},
+		},
+		{
+			name:        "Unknown section",
+			values:      []string{"comments", "attachments"},
+			expectedErr: `unknown section "attachments"`,
+		},
+		{
+			name:        "Only blanks",
+			values:      []string{" ", ""},
+			expectedErr: "at least one section",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sections, err := ParseReportSections(tc.values)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, section := range tc.included {
+				if !sections.Has(section) {
+					t.Errorf("Expected %s to be included", section)
+				}
+			}
+			for _, section := range tc.excluded {
+				if sections.Has(section) {
+					t.Errorf("Expected %s to be left out", section)
+				}
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_SearchFields(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		modify   func(query *QueryOptions, report *ReportOptions)
+		expected string
+	}{
+		{
+			name:     "Default",
+			expected: "summary,status,comment,created",
+		},
+		{
+			name: "Comments only",
+			modify: func(query *QueryOptions, report *ReportOptions) {
+				report.Sections = ReportSections{SectionComments: true}
+			},
+			expected: "summary,status,comment",
+		},
+		{
+			name: "Full verbosity with worklogs",
+			modify: func(query *QueryOptions, report *ReportOptions) {
+				report.Verbosity = VerbosityFull
+				report.Sections = ReportSections{SectionChanges: true, SectionWorklogs: true}
+			},
+			expected: "summary,status,description,worklog,created",
+		},
+		{
+			name: "Extra fields",
+			modify: func(query *QueryOptions, report *ReportOptions) {
+				query.Fields = []string{"status", "fixVersions"}
+			},
+			expected: "summary,status,comment,created,fixVersions",
+		},
+		{
+			name: "Extra comments without their section",
+			modify: func(query *QueryOptions, report *ReportOptions) {
+				query.Fields = []string{"comment", "labels"}
+				report.Sections = ReportSections{SectionChanges: true}
+			},
+			expected: "summary,status,created,labels",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queryOptions := DefaultQueryOptions()
+			reportOptions := DefaultReportOptions()
+			if tc.modify != nil {
+				tc.modify(&queryOptions, &reportOptions)
+			}
+			repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: queryOptions, ReportOptions: reportOptions}}
+
+			if fields := strings.Join(repo.searchFields(), ","); fields != tc.expected {
+				t.Errorf("Expected fields %q, got %q", tc.expected, fields)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_GetIssues_Sections(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	started := extJira.Time(time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC))
+
+	reportOptions := DefaultReportOptions()
+	reportOptions.Sections = ReportSections{SectionComments: true, SectionWorklogs: true}
+	repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: DefaultQueryOptions(), ReportOptions: reportOptions})
+	server.Issues = []extJira.Issue{
+		{
+			Key: "JIRA-1",
+			Fields: &extJira.IssueFields{
+				Summary: "Logged only",
+				Status:  &extJira.Status{Name: "In Progress"},
+				Worklog: &extJira.Worklog{Worklogs: []extJira.WorklogRecord{
+					{ID: "100", Started: &started, TimeSpentSeconds: 5400, Author: &extJira.User{AccountID: "user123", DisplayName: "Test User"}},
+				}},
+			},
+			Changelog: &extJira.Changelog{Histories: []extJira.ChangelogHistory{
+				{
+					Created: "2023-01-01T10:00:00.000+0000",
+					Author:  extJira.User{AccountID: "user123", DisplayName: "Test User"},
+					Items:   []extJira.ChangelogItems{{Field: "status", FromString: "Open", ToString: "In Progress"}},
+				},
+			}},
+		},
+	}
+
+	issues, _, err := repo.GetIssues(timeRange, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 1 || len(issues[0].Worklogs) != 1 || len(issues[0].Changes) != 0 {
+		t.Fatalf("Expected the issue with its worklog and without changes, got %+v", issues)
+	}
+	if worklog := issues[0].Worklogs[0]; worklog.TimeSpent != 90*time.Minute || worklog.Author != "Test User" {
+		t.Errorf("Expected 1h 30m logged by Test User, got %+v", worklog)
+	}
+
+	// Without the changes section the changelog is not expanded
+	requests := server.Requests("/rest/api/2/search")
+	if len(requests) != 1 || requests[0].Query.Get("expand") != "" {
+		t.Fatalf("Expected one search without expansion, got %+v", requests)
+	}
+	if fields := requests[0].Query.Get("fields"); !strings.Contains(fields, "worklog") || !strings.Contains(fields, "comment") {
+		t.Errorf("Expected worklogs and comments to be requested, got %q", fields)
+	}
+}
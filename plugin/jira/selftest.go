@@ -0,0 +1,237 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// serverInfoEndpoint describes the Jira site, and answers without
+// authentication, so it tells connectivity problems from credential ones
+const serverInfoEndpoint = "rest/api/2/serverInfo"
+
+// selfTestRange is how far back the self-test searches with the configured query
+const selfTestRange = 7 * 24 * time.Hour
+
+// SelfTestStatus is the outcome of a self-test check
+type SelfTestStatus string
+
+const (
+	SelfTestPass SelfTestStatus = "pass"
+	SelfTestFail SelfTestStatus = "fail"
+	// SelfTestSkip marks a check that could not run because one it depends on failed
+	SelfTestSkip SelfTestStatus = "skip"
+)
+
+// SelfTestCheck is one row of the self-test matrix
+type SelfTestCheck struct {
+	Name     string
+	Status   SelfTestStatus
+	Detail   string        // What was found, or why the check failed
+	Duration time.Duration // Zero for skipped checks
+}
+
+// SelfTestResult is the pass/fail matrix of a self-test, answering "is my
+// setup broken?" before a report is attempted
+type SelfTestResult struct {
+	Checks []SelfTestCheck
+}
+
+// Passed reports whether every check passed
+func (r *SelfTestResult) Passed() bool {
+	for _, check := range r.Checks {
+		if check.Status != SelfTestPass {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the matrix, one check per line, e.g.
+// "PASS  authentication    12ms  authenticated as Jane Doe"
+func (r *SelfTestResult) String() string {
+	width := 0
+	for _, check := range r.Checks {
+		if len(check.Name) > width {
+			width = len(check.Name)
+		}
+	}
+
+	var sb strings.Builder
+	for _, check := range r.Checks {
+		duration := "-"
+		if check.Duration > 0 {
+			duration = check.Duration.Round(time.Millisecond).String()
+		}
+		fmt.Fprintf(&sb, "%-4s  %-*s  %6s  %s\n", strings.ToUpper(string(check.Status)), width, check.Name, duration, check.Detail)
+	}
+	return sb.String()
+}
+
+// runCheck times a check, passing it with the detail returned or failing it
+// with the error
+func runCheck(name string, check func() (string, error)) SelfTestCheck {
+	start := time.Now()
+	detail, err := check()
+	result := SelfTestCheck{Name: name, Status: SelfTestPass, Detail: detail, Duration: time.Since(start)}
+	if err != nil {
+		result.Status = SelfTestFail
+		result.Detail = err.Error()
+	}
+	return result
+}
+
+// skipCheck records a check that depends on a failed one
+func skipCheck(name, dependency string) SelfTestCheck {
+	return SelfTestCheck{Name: name, Status: SelfTestSkip, Detail: fmt.Sprintf("skipped since %s failed", dependency)}
+}
+
+// SelfTest checks the connection to Jira step by step: that the site answers,
+// that the credentials are accepted, that the configured query is valid JQL
+// and that it can fetch an issue. Each check runs only when the previous one
+// passed, so the first failure points at the broken step.
+func (r *JiraAPIRepository) SelfTest() []SelfTestCheck {
+	checks := make([]SelfTestCheck, 0, 4)
+
+	connectivity := runCheck("connectivity", func() (string, error) {
+		var info ServerInfo
+		err := r.api.Get(serverInfoEndpoint, &info)
+		switch {
+		case err == nil:
+			return info.String(), nil
+		case apiStatus(err) != 0:
+			// Some sites require authentication even here, but they answered
+			return fmt.Sprintf("Jira answered with status %d", apiStatus(err)), nil
+		default:
+			return "", fmt.Errorf("failed to reach Jira at %s: %w", r.config.URL, err)
+		}
+	})
+	checks = append(checks, connectivity)
+	if connectivity.Status != SelfTestPass {
+		return append(checks, skipCheck("authentication", "connectivity"), skipCheck("jql", "connectivity"), skipCheck("fetch", "connectivity"))
+	}
+
+	auth := runCheck("authentication", func() (string, error) {
+		user, err := r.getSelf()
+		if err != nil {
+			if status := apiStatus(err); status == http.StatusUnauthorized || status == http.StatusForbidden {
+				return "", fmt.Errorf("Jira rejected the credentials (status %d); check jira.username and jira.token", status)
+			}
+			return "", err
+		}
+		return fmt.Sprintf("authenticated as %s", user.DisplayName), nil
+	})
+	checks = append(checks, auth)
+	if auth.Status != SelfTestPass {
+		return append(checks, skipCheck("jql", "authentication"), skipCheck("fetch", "authentication"))
+	}
+
+	now := time.Now()
+	var jql string
+	validation := runCheck("jql", func() (string, error) {
+		var err error
+		jql, err = r.buildJQLQuery(r.config.QueryOptions.jqlTime(now.Add(-selfTestRange), jqlDateLayout), r.config.QueryOptions.jqlTime(now.AddDate(0, 0, 1), jqlDateLayout))
+		if err != nil {
+			return "", err
+		}
+		if err := r.validateJQL(jql); err != nil {
+			return "", err
+		}
+		return jql, nil
+	})
+	checks = append(checks, validation)
+	if validation.Status != SelfTestPass {
+		return append(checks, skipCheck("fetch", "jql"))
+	}
+
+	return append(checks, runCheck("fetch", func() (string, error) {
+		options := r.searchOptions()
+		options.MaxResults = 1
+		issues, total, err := r.searchIssuesWithTotal(jql, options)
+		if err != nil {
+			return "", err
+		}
+		if len(issues) == 0 {
+			return "the query matched no issues updated in the last 7 days", nil
+		}
+		return fmt.Sprintf("fetched %s, %d issues updated in the last 7 days", issues[0].Key, total), nil
+	}))
+}
+
+// SelfTestFormatters formats a fixture report with each formatter, checking
+// that the output is produced and, for JSON and XML, well-formed
+func SelfTestFormatters(formatters []ReportFormatter) []SelfTestCheck {
+	report := selfTestReport()
+	checks := make([]SelfTestCheck, 0, len(formatters))
+	for _, formatter := range formatters {
+		checks = append(checks, runCheck("format "+formatter.Name(), func() (string, error) {
+			content, err := formatter.Format(report)
+			if err != nil {
+				return "", err
+			}
+			if strings.TrimSpace(content.Content) == "" {
+				return "", fmt.Errorf("the %s formatter produced no output", formatter.Name())
+			}
+			if err := wellFormed(content); err != nil {
+				return "", fmt.Errorf("the %s formatter produced malformed output: %w", formatter.Name(), err)
+			}
+			return fmt.Sprintf("%d bytes of %s", len(content.Content), content.ContentType), nil
+		}))
+	}
+	return checks
+}
+
+// wellFormed parses JSON and XML content, leaving other formats unchecked
+func wellFormed(content *FormattedContent) error {
+	switch {
+	case strings.Contains(content.ContentType, "json"):
+		var v interface{}
+		return json.Unmarshal([]byte(content.Content), &v)
+	case strings.Contains(content.ContentType, "xml"):
+		decoder := xml.NewDecoder(bytes.NewReader([]byte(content.Content)))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+}
+
+// selfTestReport is the fixture the formatters are checked against, with an
+// issue carrying a comment and a status change
+func selfTestReport() *ActivityReport {
+	start := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	return &ActivityReport{
+		TimeRange: TimeRange{Start: start, End: start.Add(24 * time.Hour)},
+		User:      User{AccountID: "self-test", DisplayName: "Self Test"},
+		Issues: []Issue{
+			{
+				Key:     "TEST-1",
+				Summary: "Self-test issue with <markup> & \"quotes\"",
+				Status:  "In Progress",
+				Comments: []Comment{
+					{ID: "1", Timestamp: start.Add(time.Hour), Author: "Self Test", AuthorAccountID: "self-test", Content: "A comment with *emphasis*"},
+				},
+				Changes: []Change{
+					{Field: "status", FromValue: "To Do", ToValue: "In Progress", Timestamp: start.Add(2 * time.Hour), Author: "Self Test"},
+				},
+			},
+		},
+		Options: DefaultReportOptions(),
+	}
+}
+
+// SelfTest runs the connection checks of the repository followed by a check of
+// each formatter over fixture data
+func (j *JiraClient) SelfTest(formatters []ReportFormatter) *SelfTestResult {
+	return &SelfTestResult{Checks: append(j.repository.SelfTest(), SelfTestFormatters(formatters)...)}
+}
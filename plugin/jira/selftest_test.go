@@ -0,0 +1,114 @@
+package jira
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestJiraAPIRepository_SelfTest(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name             string
+		self             *extJira.User
+		jqlErrors        bool
+		unreachable      bool
+		expectedStatuses []SelfTestStatus
+		expectedDetail   string
+	}{
+		{
+			name:             "Working setup",
+			self:             &extJira.User{AccountID: "user123", DisplayName: "Test User"},
+			expectedStatuses: []SelfTestStatus{SelfTestPass, SelfTestPass, SelfTestPass, SelfTestPass},
+			expectedDetail:   "fetched JIRA-1, 3 issues updated in the last 7 days",
+		},
+		{
+			name:             "Rejected credentials",
+			expectedStatuses: []SelfTestStatus{SelfTestPass, SelfTestFail, SelfTestSkip, SelfTestSkip},
+			expectedDetail:   "Jira rejected the credentials (status 401)",
+		},
+		{
+			name:             "Invalid JQL",
+			self:             &extJira.User{AccountID: "user123", DisplayName: "Test User"},
+			jqlErrors:        true,
+			expectedStatuses: []SelfTestStatus{SelfTestPass, SelfTestPass, SelfTestFail, SelfTestSkip},
+			expectedDetail:   "Expecting either 'OR' or 'AND'",
+		},
+		{
+			name:             "Unreachable site",
+			unreachable:      true,
+			expectedStatuses: []SelfTestStatus{SelfTestFail, SelfTestSkip, SelfTestSkip, SelfTestSkip},
+			expectedDetail:   "failed to reach Jira",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := DefaultQueryOptions()
+			options.JQLTemplate = ""
+			options.RawJQL = "status = In Progress"
+			options.AssigneeCurrentUser = false
+			options.ExcludeStatuses = nil
+			options.InOpenSprints = false
+			repo, server := newServerRepository(t, &JiraConfig{URL: "https://example.atlassian.net", QueryOptions: options})
+			server.Self = tc.self
+			server.Issues = []extJira.Issue{{Key: "JIRA-1", Fields: &extJira.IssueFields{}}, {Key: "JIRA-2", Fields: &extJira.IssueFields{}}, {Key: "JIRA-3", Fields: &extJira.IssueFields{}}}
+			if tc.jqlErrors {
+				server.JQLErrors = map[string][]string{
+					"status = In Progress": {"Error in the JQL Query: Expecting either 'OR' or 'AND' but got 'Progress'. (line 1, character 15)"},
+				}
+			}
+			if tc.unreachable {
+				repo, _ = NewNativeJiraAPIRepository(&http.Client{Timeout: time.Second}, "http://127.0.0.1:1", repo.config)
+			}
+
+			checks := repo.SelfTest()
+			if len(checks) != len(tc.expectedStatuses) {
+				t.Fatalf("Expected %d checks, got %+v", len(tc.expectedStatuses), checks)
+			}
+			matched := false
+			for i, check := range checks {
+				if check.Status != tc.expectedStatuses[i] {
+					t.Errorf("Expected the %s check to %s, got %+v", check.Name, tc.expectedStatuses[i], check)
+				}
+				matched = matched || strings.Contains(check.Detail, tc.expectedDetail)
+			}
+			if !matched {
+				t.Errorf("Expected a check to mention '%s', got %+v", tc.expectedDetail, checks)
+			}
+			if tc.expectedStatuses[0] == SelfTestPass && len(server.Requests("/rest/api/2/search")) > 1 {
+				t.Error("Expected at most one search")
+			}
+		})
+	}
+}
+
+func TestSelfTestFormatters(t *testing.T) {
+	checks := SelfTestFormatters([]ReportFormatter{NewJSONFormatter(), NewMarkdownFormatter(), NewXMLFormatter(), NewHTMLFormatter()})
+	result := &SelfTestResult{Checks: checks}
+	if len(checks) != 4 || !result.Passed() {
+		t.Errorf("Expected every formatter to pass, got:\n%s", result)
+	}
+}
+
+func TestSelfTestResult_String(t *testing.T) {
+	result := &SelfTestResult{Checks: []SelfTestCheck{
+		{Name: "connectivity", Status: SelfTestPass, Detail: "Jira Cloud 1001.0.0", Duration: 12 * time.Millisecond},
+		{Name: "authentication", Status: SelfTestFail, Detail: "Jira rejected the credentials", Duration: 8 * time.Millisecond},
+		{Name: "jql", Status: SelfTestSkip, Detail: "skipped since authentication failed"},
+	}}
+
+	expected := "PASS  connectivity      12ms  Jira Cloud 1001.0.0\n" +
+		"FAIL  authentication     8ms  Jira rejected the credentials\n" +
+		"SKIP  jql                  -  skipped since authentication failed\n"
+	if result.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, result.String())
+	}
+	if result.Passed() {
+		t.Error("Expected a failed check to fail the self-test")
+	}
+}
@@ -0,0 +1,148 @@
+package jira
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// deploymentCloud is the deployment type Jira Cloud reports; Jira Server and
+// Data Center report "Server" or "DataCenter"
+const deploymentCloud = "Cloud"
+
+// agileSince is the first Jira version serving the agile REST API
+var agileSince = []int{7, 0}
+
+// Feature is a capability of the Jira instance some settings depend on
+type Feature string
+
+const (
+	// FeatureAgile is the agile REST API behind boards and sprints
+	FeatureAgile Feature = "agile API"
+	// FeatureAccountIDs is the identification of users by account ID, which
+	// only Jira Cloud has; Server and Data Center identify them by username
+	FeatureAccountIDs Feature = "account IDs"
+	// FeatureADF is the Atlassian Document Format of the version 3 API. The
+	// plugin reads the wiki markup of the version 2 API, so no setting
+	// depends on it yet.
+	FeatureADF Feature = "ADF"
+)
+
+// features lists the features in the order they are reported
+var features = []Feature{FeatureAgile, FeatureAccountIDs, FeatureADF}
+
+// ServerInfo describes the Jira instance, as reported by its serverInfo endpoint
+type ServerInfo struct {
+	Version        string `json:"version"`
+	VersionNumbers []int  `json:"versionNumbers"`
+	DeploymentType string `json:"deploymentType"`
+}
+
+// Cloud reports whether the instance is Jira Cloud rather than Server or Data Center
+func (i *ServerInfo) Cloud() bool {
+	return strings.EqualFold(i.DeploymentType, deploymentCloud)
+}
+
+// String describes the instance, e.g. "Jira Server 8.20.1"
+func (i *ServerInfo) String() string {
+	return strings.TrimSpace(fmt.Sprintf("Jira %s %s", i.DeploymentType, i.Version))
+}
+
+// Supports reports whether the instance has the feature. Jira Cloud has them
+// all; Server and Data Center have the agile API from version 7.0.
+func (i *ServerInfo) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureAgile:
+		return i.Cloud() || i.atLeast(agileSince)
+	case FeatureAccountIDs, FeatureADF:
+		return i.Cloud()
+	default:
+		return false
+	}
+}
+
+// Features lists the features the instance has
+func (i *ServerInfo) Features() []Feature {
+	result := make([]Feature, 0, len(features))
+	for _, feature := range features {
+		if i.Supports(feature) {
+			result = append(result, feature)
+		}
+	}
+	return result
+}
+
+// atLeast reports whether the version is the given one or later. Versions
+// Jira did not report as numbers are read from the version string.
+func (i *ServerInfo) atLeast(version []int) bool {
+	numbers := i.VersionNumbers
+	if len(numbers) == 0 {
+		for _, part := range strings.Split(i.Version, ".") {
+			number, err := strconv.Atoi(part)
+			if err != nil {
+				break
+			}
+			numbers = append(numbers, number)
+		}
+	}
+
+	for index, wanted := range version {
+		got := 0
+		if index < len(numbers) {
+			got = numbers[index]
+		}
+		if got != wanted {
+			return got > wanted
+		}
+	}
+	return true
+}
+
+// GetServerInfo retrieves the description of the Jira instance
+func (r *JiraAPIRepository) GetServerInfo() (*ServerInfo, error) {
+	var info ServerInfo
+	if err := r.api.Get(serverInfoEndpoint, &info); err != nil {
+		return nil, fmt.Errorf("failed to get the Jira server info: %w", err)
+	}
+	return &info, nil
+}
+
+// UnsupportedFeature is the warning for a setting turned off because the
+// instance lacks the feature it depends on
+func UnsupportedFeature(info *ServerInfo, feature Feature, setting string) ReportWarning {
+	return ReportWarning{
+		Kind:    WarningIncomplete,
+		Message: fmt.Sprintf("%s was turned off since %s does not support the %s", setting, info, feature),
+	}
+}
+
+// GateFeatures turns off the settings of the configuration depending on a
+// feature the instance lacks, returning a warning for each one turned off
+func GateFeatures(info *ServerInfo, config *JiraConfig) []ReportWarning {
+	var warnings []ReportWarning
+
+	if !info.Supports(FeatureAgile) {
+		if config.ReportOptions.SprintBoardID > 0 {
+			config.ReportOptions.SprintBoardID = 0
+			warnings = append(warnings, UnsupportedFeature(info, FeatureAgile, "jira.sprint.board_id"))
+		}
+		// openSprints() comes with the agile API
+		if config.QueryOptions.InOpenSprints {
+			config.QueryOptions.InOpenSprints = false
+			warnings = append(warnings, UnsupportedFeature(info, FeatureAgile, "jira.query.in_open_sprints"))
+		}
+	}
+
+	// The email lookup matches the user search by account ID
+	if !info.Supports(FeatureAccountIDs) && config.QueryOptions.ResolveEmail {
+		config.QueryOptions.ResolveEmail = false
+		warnings = append(warnings, UnsupportedFeature(info, FeatureAccountIDs, "jira.query.resolve_email"))
+	}
+
+	return warnings
+}
+
+// GetServerInfo retrieves the description of the Jira instance
+func (j *JiraClient) GetServerInfo() (*ServerInfo, error) {
+	return j.repository.GetServerInfo()
+}
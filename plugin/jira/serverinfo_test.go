@@ -0,0 +1,131 @@
+package jira
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestServerInfo_Supports(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		info     ServerInfo
+		expected []Feature
+	}{
+		{name: "Cloud", info: ServerInfo{Version: "1001.0.0", VersionNumbers: []int{1001, 0, 0}, DeploymentType: "Cloud"}, expected: []Feature{FeatureAgile, FeatureAccountIDs, FeatureADF}},
+		{name: "Data Center", info: ServerInfo{Version: "9.12.4", VersionNumbers: []int{9, 12, 4}, DeploymentType: "Server"}, expected: []Feature{FeatureAgile}},
+		{name: "Server 7.0", info: ServerInfo{Version: "7.0.0", VersionNumbers: []int{7, 0, 0}, DeploymentType: "Server"}, expected: []Feature{FeatureAgile}},
+		{name: "Server 6.4", info: ServerInfo{Version: "6.4.14", VersionNumbers: []int{6, 4, 14}, DeploymentType: "Server"}, expected: []Feature{}},
+		{name: "Version string only", info: ServerInfo{Version: "6.4.14", DeploymentType: "Server"}, expected: []Feature{}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.info.Features(); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGateFeatures(t *testing.T) {
+	newConfig := func() *JiraConfig {
+		config := &JiraConfig{QueryOptions: DefaultQueryOptions(), ReportOptions: DefaultReportOptions()}
+		config.QueryOptions.InOpenSprints = true
+		config.QueryOptions.ResolveEmail = true
+		config.ReportOptions.SprintBoardID = 12
+		return config
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name             string
+		info             ServerInfo
+		expectedSettings []string
+	}{
+		{name: "Cloud", info: ServerInfo{Version: "1001.0.0", DeploymentType: "Cloud"}},
+		{name: "Data Center", info: ServerInfo{Version: "9.12.4", DeploymentType: "Server"}, expectedSettings: []string{"jira.query.resolve_email"}},
+		{name: "Server 6.4", info: ServerInfo{Version: "6.4.14", DeploymentType: "Server"}, expectedSettings: []string{"jira.sprint.board_id", "jira.query.in_open_sprints", "jira.query.resolve_email"}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newConfig()
+			warnings := GateFeatures(&tc.info, config)
+
+			if len(warnings) != len(tc.expectedSettings) {
+				t.Fatalf("Expected %d warnings, got %+v", len(tc.expectedSettings), warnings)
+			}
+			for i, setting := range tc.expectedSettings {
+				if warnings[i].Kind != WarningIncomplete || !strings.HasPrefix(warnings[i].Message, setting+" was turned off since "+tc.info.String()) {
+					t.Errorf("Expected a warning about %s, got %+v", setting, warnings[i])
+				}
+			}
+
+			gated := map[string]bool{
+				"jira.sprint.board_id":       config.ReportOptions.SprintBoardID == 0,
+				"jira.query.in_open_sprints": !config.QueryOptions.InOpenSprints,
+				"jira.query.resolve_email":   !config.QueryOptions.ResolveEmail,
+			}
+			for setting, off := range gated {
+				expected := false
+				for _, s := range tc.expectedSettings {
+					expected = expected || s == setting
+				}
+				if off != expected {
+					t.Errorf("Expected %s to be turned off: %v, got %v", setting, expected, off)
+				}
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_GetServerInfo(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions()})
+	server.Version = "8.20.1"
+	server.DeploymentType = "Server"
+
+	info, err := repo.GetServerInfo()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := &ServerInfo{Version: "8.20.1", VersionNumbers: []int{8, 20, 1}, DeploymentType: "Server"}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, info)
+	}
+	if info.String() != "Jira Server 8.20.1" {
+		t.Errorf("Expected the description Jira Server 8.20.1, got %q", info.String())
+	}
+}
+
+func TestActivityService_GatedFeatures(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return nil, nil
+		},
+	}
+	gated := UnsupportedFeature(&ServerInfo{Version: "6.4.14", DeploymentType: "Server"}, FeatureAgile, "jira.sprint.board_id")
+
+	service := NewActivityService(mockRepo)
+	service.SetLogger(NewNoopLogger())
+	service.SetGatedFeatures([]ReportWarning{gated})
+
+	// Every report warns about the settings turned off
+	for i := 0; i < 2; i++ {
+		report, err := service.GetActivityReport(plugin.TimeRange{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(report.Warnings, []ReportWarning{gated}) {
+			t.Errorf("Expected the gated setting as the only warning, got %+v", report.Warnings)
+		}
+	}
+}
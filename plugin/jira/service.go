@@ -1,7 +1,10 @@
 package jira
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,42 +14,760 @@ import (
 
 // ActivityService handles the processing of Jira data into domain models
 type ActivityService struct {
+	// Guards the summarizer, options and gated settings, which the host may
+	// change while reports are produced concurrently
+	mu sync.RWMutex
+
 	repository JiraRepository
+	summarizer Summarizer
+	options    ReportOptions
+	metrics    *MetricsRecorder
+	sizeGuard  SizeGuard
+	logger     Logger
+	gated      []ReportWarning // Settings turned off for the Jira instance
+	users      *UserDirectory
+	stats      StatsStore
+	attention  AttentionStore
+	standup    StandupStore
+	notes      AnnotationStore
+	sinks      []EventSink
+	shipped    shippedEvents // Events each sink received, so that each is shipped once
+	publishers []ReportPublisher
+	generation GenerationInfo // Plugin version and Jira instance recorded on every report
+	clock      Clock
 }
 
 // NewActivityService creates a new activity service
 func NewActivityService(repository JiraRepository) *ActivityService {
 	return &ActivityService{
 		repository: repository,
+		summarizer: NewNoopSummarizer(),
+		options:    DefaultReportOptions(),
+		logger:     NewStderrLogger(),
+		clock:      SystemClock,
+	}
+}
+
+// SetSummarizer sets the summarizer used to condense per-issue activity
+func (s *ActivityService) SetSummarizer(summarizer Summarizer) {
+	if summarizer == nil {
+		summarizer = NewNoopSummarizer()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summarizer = summarizer
+}
+
+// SetReportOptions sets the options controlling the report content
+func (s *ActivityService) SetReportOptions(options ReportOptions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.options = options
+}
+
+// SetMetricsRecorder sets the recorder used to report the traffic each report caused
+func (s *ActivityService) SetMetricsRecorder(metrics *MetricsRecorder) {
+	s.metrics = metrics
+}
+
+// SetSizeGuard sets the limit above which oversized reports are logged with slimming suggestions
+func (s *ActivityService) SetSizeGuard(guard SizeGuard) {
+	s.sizeGuard = guard
+}
+
+// SetUserDirectory sets the directory used to resolve comment and change
+// authors, which takes the service's clock
+func (s *ActivityService) SetUserDirectory(users *UserDirectory) {
+	s.users = users
+	if users != nil {
+		users.SetClock(s.clock)
+	}
+}
+
+// SetStatsStore sets the store keeping past windows' statistics for trailing comparisons
+func (s *ActivityService) SetStatsStore(store StatsStore) {
+	s.stats = store
+}
+
+// SetAttentionStore sets the store of watcher and vote snapshots that attention
+// changes are measured against
+func (s *ActivityService) SetAttentionStore(store AttentionStore) {
+	s.attention = store
+}
+
+// SetClock sets the clock the service and its user directory read the current
+// time from, such as a FrozenClock in tests
+func (s *ActivityService) SetClock(clock Clock) {
+	if clock == nil {
+		clock = SystemClock
+	}
+	s.clock = clock
+	if s.users != nil {
+		s.users.SetClock(clock)
+	}
+}
+
+// Now returns the current time on the service's clock
+func (s *ActivityService) Now() time.Time {
+	return s.clock.Now()
+}
+
+// SetStandupStore sets the store of the last report time that reports without
+// an explicit range start from
+func (s *ActivityService) SetStandupStore(store StandupStore) {
+	s.standup = store
+}
+
+// SetAnnotationStore sets the store of the notes pinned to issues
+func (s *ActivityService) SetAnnotationStore(store AnnotationStore) {
+	s.notes = store
+}
+
+// SetEventSinks sets the sinks receiving the activity events of every report
+func (s *ActivityService) SetEventSinks(sinks []EventSink) {
+	s.sinks = sinks
+	s.shipped.reset()
+}
+
+// SetPublishers sets the services the reports with the Publish option are
+// posted to
+func (s *ActivityService) SetPublishers(publishers []ReportPublisher) {
+	s.publishers = publishers
+}
+
+// Close releases the stores and caches of the service. The file stores write
+// through on every report, so there is nothing left to flush; stores holding
+// resources such as open files or connections release them by implementing
+// io.Closer, as does a logger writing to a log file. The summarizer belongs
+// to the caller and is left open.
+func (s *ActivityService) Close() error {
+	resources := []interface{}{s.stats, s.attention, s.standup, s.notes, s.logger}
+	if s.users != nil {
+		resources = append(resources, s.users.cache)
 	}
+	for _, sink := range s.sinks {
+		resources = append(resources, sink)
+	}
+
+	errs := make([]error, 0)
+	for _, resource := range resources {
+		if closer, ok := resource.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SetGatedFeatures records the settings turned off because the Jira instance
+// does not support them, so that every report warns about them
+func (s *ActivityService) SetGatedFeatures(warnings []ReportWarning) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gated = warnings
+}
+
+// SetLogger sets the logger used for diagnostic messages
+func (s *ActivityService) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NewNoopLogger()
+	}
+	s.logger = logger
 }
 
 // GetActivityReport retrieves and processes Jira activity data for the given time range
 func (s *ActivityService) GetActivityReport(pluginTimeRange plugin.TimeRange) (*ActivityReport, error) {
+	s.mu.RLock()
+	options := s.options
+	s.mu.RUnlock()
+
+	return s.GetActivityReportWithOptions(pluginTimeRange, options)
+}
+
+// GetStandupReport builds the report like GetActivityReport and posts it to
+// the configured chat services
+func (s *ActivityService) GetStandupReport(pluginTimeRange plugin.TimeRange) (*ActivityReport, error) {
+	s.mu.RLock()
+	options := s.options
+	s.mu.RUnlock()
+
+	options.Publish = true
+	return s.GetActivityReportWithOptions(pluginTimeRange, options)
+}
+
+// GetActivityReportWithOptions builds the report like GetActivityReport, with
+// report options that apply to this report only, such as those of a weekly
+// summary next to the configured standup report
+func (s *ActivityService) GetActivityReportWithOptions(pluginTimeRange plugin.TimeRange, options ReportOptions) (*ActivityReport, error) {
 	// Convert plugin.TimeRange to our domain TimeRange
 	timeRange := TimeRange{
 		Start: pluginTimeRange.Start,
 		End:   pluginTimeRange.End,
 	}
 
+	// Without a range, cover everything since the last standup
+	sinceLastStandup := timeRange.Start.IsZero() && timeRange.End.IsZero()
+	if sinceLastStandup {
+		timeRange = s.SinceLastStandupRange(s.Now())
+	}
+	timeRange.EndInclusive = options.EndInclusive
+	if err := timeRange.Validate(options.MaxRangeDays); err != nil {
+		return nil, err
+	}
+
+	// Snapshot the transfer metrics so the report only counts its own traffic
+	var metricsBefore TransferMetrics
+	if s.metrics != nil {
+		metricsBefore = s.metrics.Snapshot()
+	}
+
+	// Collect the problems that do not prevent the report, to show in its footer
+	warnings := newReportWarnings(s.logger)
+	s.mu.RLock()
+	warnings.include(s.gated)
+	s.mu.RUnlock()
+
 	// Get the current user
 	user, err := s.repository.GetUser()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	// Release notes list the issues of a version instead of the activity in the range
+	if options.Mode == ReportModeRelease {
+		return s.getReleaseReport(timeRange, *user, options, warnings)
+	}
+
+	// Triage lists what came in during the range instead of the user's activity
+	if options.Mode == ReportModeTriage {
+		return s.getTriageReport(timeRange, *user, options)
+	}
+
 	// Get issues for the user and time range
-	issues, err := s.repository.GetIssues(timeRange, user.AccountID)
-	if err != nil {
+	issues, truncation, err := s.repository.GetIssues(timeRange, user.ID())
+	var partial *PartialSearchError
+	if errors.As(err, &partial) && issues != nil {
+		// The projects the user can browse are still reported
+		for _, skipped := range partial.Skipped {
+			warnings.add(WarningPermission, "project %s was left out: %v", skipped.Key, skipped.Err)
+		}
+	} else if err != nil {
 		return nil, fmt.Errorf("failed to get issues: %w", err)
 	}
+	issues = withoutIgnored(issues, options)
+	attributeComments(issues, user.ID(), options.OwnCommentsOnly)
+	if truncation != nil {
+		warnings.add(WarningTruncation, "%s", truncation.Notice())
+	}
+	for _, issue := range issues {
+		if issue.unparsedEvents > 0 {
+			warnings.add(WarningParse, "%d comments or changes of %s were left out because their timestamps could not be read", issue.unparsedEvents, issue.Key)
+		}
+	}
+
+	// Add escalated issues, whoever they are assigned to, as activity
+	if options.IncludeEscalations {
+		escalated := s.getSupplementaryIssues(SupplementaryEscalated, timeRange, user.ID(), issues, warnings)
+		issues = append(issues, escalatedIssues(withoutIgnored(escalated, options))...)
+	}
+
+	// Add the pinned issues, whatever their activity and the query filters
+	var pinned []Issue
+	if len(options.PinnedIssues) > 0 {
+		pinned = s.getPinnedIssues(options, issues, warnings)
+	}
+
+	// Add open work that is still on the user's plate but had no activity
+	var carryOver []Issue
+	if options.IncludeCarryOver {
+		carryOver = s.getSupplementaryIssues(SupplementaryCarryOver, timeRange, user.ID(), issues, warnings)
+	}
+
+	// Add flagged issues as blockers, whether or not they had activity
+	var blockers []Issue
+	if options.IncludeFlagged {
+		blockers = s.getSupplementaryIssues(SupplementaryFlagged, timeRange, user.ID(), nil, warnings)
+	}
+
+	// Add issues the user filed, unless they already appear with their activity
+	var filed []Issue
+	if options.IncludeFiled {
+		filed = s.getSupplementaryIssues(SupplementaryFiled, timeRange, user.ID(), issues, warnings)
+	}
+
+	// Preview the work due soon, even if it was already reported with its activity
+	var dueSoon []Issue
+	if options.DueWithinDays > 0 {
+		dueSoon = s.getSupplementaryIssues(SupplementaryDueSoon, timeRange, user.ID(), nil, warnings)
+	}
+
+	// Sort work handed to and away from the user, including issues no longer assigned to them
+	var handoffs *Handoffs
+	if options.IncludeHandoffs {
+		handedOff, err := s.repository.GetSupplementaryIssues(SupplementaryHandoffs, timeRange, user.ID())
+		if err != nil {
+			warnings.add(warningKind(err), "failed to get %s issues: %v", SupplementaryHandoffs, err)
+		}
+		handoffs = BuildHandoffs(mergeIssues(append(append([]Issue{}, issues...), withoutIgnored(handedOff, options)...)))
+	}
+
+	// Summarize the scope change of the active sprint
+	var sprint *SprintScope
+	if options.SprintBoardID > 0 {
+		scope, err := s.repository.GetSprintScope(options.SprintBoardID, timeRange)
+		if err != nil {
+			// The report is still useful without the sprint header
+			warnings.addError(err)
+		}
+		sprint = scope
+	}
+
+	// Leave the ignored issues and epics out of the supplementary sections too
+	if options.ignoresIssues() {
+		carryOver = withoutIgnored(carryOver, options)
+		blockers = withoutIgnored(blockers, options)
+		filed = withoutIgnored(filed, options)
+		dueSoon = withoutIgnored(dueSoon, options)
+	}
+
+	// Resolve each issue's hierarchy path or epic for the rollups
+	switch {
+	case options.resolvesHierarchy():
+		if err := resolveHierarchy(issues, s.repository.GetIssuesByKey); err != nil {
+			// Unresolved issues are rolled up under "No Initiative"
+			warnings.addError(err)
+		}
+	case options.Mode == ReportModeEpic:
+		if err := resolveEpics(issues, s.repository.GetIssuesByKey); err != nil {
+			// Unresolved issues are rolled up under "No Epic"
+			warnings.addError(err)
+		}
+	}
+
+	// Resolve comment and change authors to consistent profiles
+	var authors []User
+	if s.users != nil {
+		resolved, err := s.users.ResolveAuthors(issues)
+		if err != nil {
+			// Unresolved authors keep the names Jira reported with the activity
+			warnings.addError(err)
+		}
+		authors = resolved
+	}
+
+	// Compute velocity statistics before transitions are collapsed
+	var stats *StatsBlock
+	if options.computesStats() {
+		s.completeStatusHistories(issues, warnings)
+		MeasureIssueTimes(issues, options)
+		stats = s.computeStats(issues, timeRange, options)
+	}
+
+	// Export the analytics separately from the report
+	if options.AnalyticsPath != "" {
+		if err := WriteAnalytics(options.AnalyticsPath, BuildAnalytics(timeRange, issues, stats)); err != nil {
+			// A failed export does not prevent the report
+			s.logger.Printf("%v", err)
+		}
+	}
+	if !options.IncludeStats {
+		stats = nil
+	}
+
+	// Report statuses and assignees as they were when the range ended
+	var asOf time.Time
+	if options.Historical && timeRange.End.Before(s.Now()) {
+		for _, list := range [][]Issue{issues, pinned, carryOver, blockers, filed} {
+			s.reconstructStates(list, timeRange.End, warnings)
+		}
+		asOf = timeRange.End
+	}
+
+	// Measure the change in watchers and votes since the previous report
+	if options.IncludeAttention {
+		s.trackAttention(issues, !options.SkipHistory, warnings)
+	}
+
+	// Resolve the remote links added within the range to their titles and URLs
+	if options.IncludeRemoteLinks {
+		if err := resolveRemoteLinks(issues, s.repository.GetRemoteLinks); err != nil {
+			// The raw link changes remain in the changelog
+			warnings.addError(err)
+		}
+	}
+
+	// Leave out the events already included in a previous report
+	if options.Dedupe && s.standup != nil {
+		issues = s.dedupe(issues)
+	}
+	eventIDs := reportedEventIDs(issues)
+
+	// Render the sprint, assignee, estimate and rank changes readably
+	var resolveUsers func(accountIDs []string) (map[string]User, error)
+	if s.users != nil {
+		resolveUsers = s.users.Resolve
+	}
+	if err := renderChangeFields(issues, s.repository.GetSprint, resolveUsers); err != nil {
+		// Unresolved values are reported as Jira recorded them
+		warnings.addError(err)
+	}
+
+	// Show description edits as word-level diffs instead of both full bodies
+	diffTextChanges(issues)
+
+	// Collect the issues mentioned in comments and changes, resolving them
+	// within the lookup budget
+	attachReferences(issues, pinned, carryOver, blockers, filed)
+	if options.ReferenceLookups > 0 {
+		skipped, err := resolveReferences(issues, s.repository.GetIssuesByKey, options.ReferenceLookups, pinned, carryOver, blockers, filed)
+		if err != nil {
+			// Unresolved references are shown as bare keys
+			warnings.addError(err)
+		}
+		if skipped > 0 {
+			warnings.add(WarningIncomplete, "%s left unresolved beyond the jira.report.reference_lookups budget of %d",
+				pluralize(skipped, "referenced issue", "referenced issues"), options.ReferenceLookups)
+		}
+	}
+
+	// Show the notes the user pinned next to the issues
+	if s.notes != nil {
+		for _, list := range [][]Issue{issues, pinned, carryOver, blockers, filed} {
+			s.attachNotes(list)
+		}
+	}
+
+	// Summarize action items added or completed within the range
+	for i := range issues {
+		issues[i].ActionItems = SummarizeActionItems(issues[i])
+	}
+
+	// Condense each issue's activity into a one-line summary
+	s.mu.RLock()
+	summarizer := s.summarizer
+	s.mu.RUnlock()
+	for i := range issues {
+		summary, err := summarizer.Summarize(issues[i])
+		if err != nil {
+			// A failed summary falls back to the raw activity
+			continue
+		}
+		issues[i].ActivitySummary = summary
+	}
+
+	// Summarize when the activity happened, in the user's time zone,
+	// before label, component and status changes are taken out of the changes
+	var heatmap *ActivityHeatmap
+	if options.IncludeHeatmap {
+		heatmap = BuildHeatmap(issues, user.TimeZone)
+	}
+
+	// Track label and component additions and removals separately
+	extractIssueCollectionChanges(issues)
+
+	// Collapse status transitions into journeys if configured
+	if options.SummarizeTransitions {
+		summarizeIssueTransitions(issues)
+	}
+
+	// Roll issue activity up under each epic
+	var epics []EpicRollup
+	if options.Mode == ReportModeEpic {
+		epics = RollupByEpic(issues)
+	}
+
+	// Roll epic activity up under each initiative
+	var initiatives []InitiativeRollup
+	if options.Mode == ReportModeInitiative {
+		initiatives = RollupByInitiative(issues)
+	}
+
+	// Digest all activity per component
+	var components []ComponentDigest
+	if options.Mode == ReportModeComponent {
+		components = DigestByComponent(issues)
+	}
+
+	// Create the activity report
+	report := &ActivityReport{
+		TimeRange:   timeRange,
+		User:        *user,
+		Issues:      issues,
+		CarryOver:   carryOver,
+		Blockers:    blockers,
+		Filed:       filed,
+		Handoffs:    handoffs,
+		Sprint:      sprint,
+		DueSoon:     dueSoon,
+		Pinned:      pinned,
+		Truncation:  truncation,
+		AsOf:        asOf,
+		Warnings:    warnings.list(),
+		Epics:       epics,
+		Initiatives: initiatives,
+		Components:  components,
+		Authors:     authors,
+		Heatmap:     heatmap,
+		Stats:       stats,
+		Options:     options,
+	}
+
+	// Record the bytes transferred while building the report
+	if s.metrics != nil {
+		report.Metrics.Transfer = s.metrics.Snapshot().Sub(metricsBefore)
+
+		// Warn when the responses were unusually large, with concrete
+		// suggestions when the query can be slimmed
+		if s.sizeGuard.Exceeded(report.Metrics.Transfer) {
+			message := fmt.Sprintf("report responses totalled %s, above the %s limit",
+				formatSize(report.Metrics.Transfer.BytesDecoded),
+				formatSize(s.sizeGuard.MaxReportBytes))
+			if suggestions := s.sizeGuard.Check(report.Metrics.Transfer); len(suggestions) > 0 {
+				message += "; to speed up reports consider: " + strings.Join(suggestions, "; ")
+			}
+			s.logger.Printf("%s", message)
+		}
+	}
 
-	// Create and return the activity report
-	return &ActivityReport{
+	// Record how the report was generated, for auditing archived reports
+	s.recordGeneration(report, true)
+
+	// Strip the detail excluded by the configured verbosity
+	applyVerbosity(report)
+
+	// Ship the events to the configured sinks, as they appear in the report
+	s.exportEvents(report, options)
+
+	// Post the standup report to the configured chat services
+	if options.Publish && !options.SkipHistory {
+		s.publish(report)
+	}
+
+	// Start the next report without a range where this one ended
+	s.recordStandup(timeRange, options, eventIDs, sinceLastStandup)
+
+	return report, nil
+}
+
+// exportEvents sends the events of the report that each sink has not received
+// yet, unless the options skip history, so that overlapping and repeated
+// reports ship each event once. A failed sink does not prevent the report or
+// the other sinks, and is sent the events again with the next report.
+func (s *ActivityService) exportEvents(report *ActivityReport, options ReportOptions) {
+	if len(s.sinks) == 0 || options.SkipHistory {
+		return
+	}
+	events := report.Events()
+	for i, sink := range s.sinks {
+		unsent := s.shipped.unsent(i, events)
+		if len(unsent) == 0 {
+			continue
+		}
+		if err := sink.Send(unsent); err != nil {
+			s.logger.Printf("failed to export the report events: %v", err)
+			continue
+		}
+		s.shipped.record(i, unsent, s.Now())
+	}
+}
+
+// publish posts the report to every publisher. A failed post does not
+// prevent the report or the other posts.
+func (s *ActivityService) publish(report *ActivityReport) {
+	for _, publisher := range s.publishers {
+		if err := publisher.Publish(report); err != nil {
+			s.logger.Printf("failed to publish the report to %s: %v", publisher.Name(), err)
+		}
+	}
+}
+
+// getReleaseReport builds the release notes of the configured version, or
+// compares the start and end of the time range without one
+func (s *ActivityService) getReleaseReport(timeRange TimeRange, user User, options ReportOptions, warnings *reportWarnings) (*ActivityReport, error) {
+	if options.ReleaseVersion == "" {
+		return s.getRangeComparisonReport(timeRange, user, options, warnings)
+	}
+
+	issues, err := s.repository.GetSupplementaryIssues(SupplementaryRelease, timeRange, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the issues of release %s: %w", options.ReleaseVersion, err)
+	}
+
+	report := &ActivityReport{
 		TimeRange: timeRange,
-		User:      *user,
-		Issues:    issues,
-	}, nil
+		User:      user,
+		Release:   BuildReleaseNotes(options.ReleaseVersion, issues),
+		Options:   options,
+	}
+	if options.ReleaseCompareTo == "" {
+		s.recordGeneration(report, false)
+		return report, nil
+	}
+
+	baseline, err := s.repository.GetSupplementaryIssues(SupplementaryReleaseBaseline, timeRange, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the issues of release %s: %w", options.ReleaseCompareTo, err)
+	}
+
+	// Only issues of both releases can have been reopened, so only their
+	// histories are fetched
+	inBaseline := make(map[string]bool, len(baseline))
+	for _, issue := range baseline {
+		inBaseline[issue.Key] = true
+	}
+	for i := range issues {
+		if !inBaseline[issues[i].Key] {
+			continue
+		}
+		history, err := s.repository.GetStatusHistory(issues[i].Key)
+		if err != nil {
+			warnings.addError(err)
+			continue
+		}
+		issues[i].StatusHistory = history
+	}
+
+	report.Comparison = CompareReleases(options.ReleaseCompareTo, options.ReleaseVersion, baseline, issues, options.DoneStatuses)
+	report.Warnings = warnings.list()
+	s.recordGeneration(report, false)
+	return report, nil
+}
+
+// getRangeComparisonReport compares the issues at the end of the time range
+// with those at its start, listing the issues completed within the range as
+// its release notes
+func (s *ActivityService) getRangeComparisonReport(timeRange TimeRange, user User, options ReportOptions, warnings *reportWarnings) (*ActivityReport, error) {
+	issues, err := s.repository.GetSupplementaryIssues(SupplementaryReleaseRange, timeRange, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the issues open within the range: %w", err)
+	}
+
+	// Every issue may have changed status within the range
+	for i := range issues {
+		history, err := s.repository.GetStatusHistory(issues[i].Key)
+		if err != nil {
+			warnings.addError(err)
+			continue
+		}
+		issues[i].StatusHistory = history
+	}
+
+	comparison := CompareDates(timeRange, issues, options.DoneStatuses)
+	report := &ActivityReport{
+		TimeRange:  timeRange,
+		User:       user,
+		Release:    BuildReleaseNotes(comparison.To, comparison.Completed),
+		Comparison: comparison,
+		Options:    options,
+		Warnings:   warnings.list(),
+	}
+	s.recordGeneration(report, false)
+	return report, nil
+}
+
+// completeStatusHistories replaces the status history of issues whose embedded
+// changelog may be truncated with the history from the full changelog. Issues
+// whose changelog cannot be fetched keep the embedded history, with a warning.
+func (s *ActivityService) completeStatusHistories(issues []Issue, warnings *reportWarnings) {
+	for i := range issues {
+		if !issues[i].historyTruncated {
+			continue
+		}
+
+		history, err := s.repository.GetStatusHistory(issues[i].Key)
+		if err != nil {
+			warnings.addError(err)
+			continue
+		}
+		issues[i].StatusHistory = history
+		issues[i].StatusSince = statusSince(history, issues[i].Status, issues[i].Created)
+		issues[i].historyTruncated = false
+	}
+}
+
+// computeStats computes the velocity of the report window and, when a stats
+// store is set, records it unless the options skip history and looks up the
+// trailing windows. Store failures are logged rather than failing the whole
+// report.
+func (s *ActivityService) computeStats(issues []Issue, timeRange TimeRange, options ReportOptions) *StatsBlock {
+	block := &StatsBlock{Current: ComputeVelocity(issues, timeRange, options)}
+	if s.stats == nil {
+		return block
+	}
+
+	if options.TrailingWindows > 0 {
+		trailing, err := s.stats.Trailing(timeRange.Start, options.TrailingWindows)
+		if err != nil {
+			s.logger.Printf("failed to load trailing stats: %v", err)
+		}
+		block.Trailing = trailing
+	}
+	if options.SkipHistory {
+		return block
+	}
+	if err := s.stats.Save(block.Current); err != nil {
+		s.logger.Printf("failed to save stats: %v", err)
+	}
+	return block
+}
+
+// trackAttention fetches the watchers and votes of each issue in parallel,
+// bounded by the HTTP concurrency limit, compares them with the stored
+// snapshots and, when record is set, records the new ones. Failures are
+// warned about per issue.
+func (s *ActivityService) trackAttention(issues []Issue, record bool, warnings *reportWarnings) {
+	now := s.Now()
+	snapshots := make([]Attention, len(issues))
+	errs := make([]error, len(issues))
+
+	var wg sync.WaitGroup
+	for i := range issues {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			snapshots[i], errs[i] = s.repository.GetAttention(issues[i].Key)
+		}(i)
+	}
+	wg.Wait()
+
+	recorded := make(map[string]Attention, len(issues))
+	for i := range issues {
+		if errs[i] != nil {
+			warnings.addError(errs[i])
+			continue
+		}
+		current := snapshots[i]
+		current.RecordedAt = now
+		recorded[issues[i].Key] = current
+
+		// Without a previous snapshot only the current counts are known
+		previous := current
+		if s.attention != nil {
+			if stored, ok := s.attention.Get(issues[i].Key); ok {
+				previous = stored
+			}
+		}
+		issues[i].Attention = compareAttention(previous, current)
+	}
+
+	if s.attention != nil && record {
+		if err := s.attention.Put(recorded); err != nil {
+			s.logger.Printf("failed to save attention snapshots: %v", err)
+		}
+	}
+}
+
+// getSupplementaryIssues runs a supplementary query, merging issues already in
+// the report into them rather than listing them twice. A failed query is
+// warned about rather than failing the whole report.
+func (s *ActivityService) getSupplementaryIssues(kind SupplementaryQuery, timeRange TimeRange, userID string, reported []Issue, warnings *reportWarnings) []Issue {
+	issues, err := s.repository.GetSupplementaryIssues(kind, timeRange, userID)
+	if err != nil {
+		warnings.add(warningKind(err), "failed to get %s issues: %v", kind, err)
+		return nil
+	}
+	return mergeReported(issues, reported)
 }
 
 // processIssues converts external Jira issues to domain model issues
@@ -69,9 +790,10 @@ func (s *ActivityService) processIssues(issues []extJira.Issue, timeRange TimeRa
 			
 			// Process the issue
 			domainIssue := Issue{
-				Key:     issue.Key,
-				Summary: issue.Fields.Summary,
-				Status:  issue.Fields.Status.Name,
+				Key:         issue.Key,
+				Summary:     issue.Fields.Summary,
+				Status:      issue.Fields.Status.Name,
+				Description: issue.Fields.Description,
 			}
 			
 			// Process comments
@@ -81,7 +803,7 @@ func (s *ActivityService) processIssues(issues []extJira.Issue, timeRange TimeRa
 			
 			// Process changelog
 			if issue.Changelog != nil {
-				domainIssue.Changes = s.processChangelog(issue.Changelog.Histories, timeRange, user.AccountID)
+				domainIssue.Changes = s.processChangelog(issue.Changelog.Histories, timeRange, user.ID())
 			}
 			
 			// Send the processed issue to the channel
@@ -127,16 +849,19 @@ func (s *ActivityService) processComments(comments []*extJira.Comment, timeRange
 		go func(comment *extJira.Comment) {
 			defer wg.Done()
 			
-			createdTime, err := time.Parse("2006-01-02T15:04:05.000-0700", comment.Created)
+			createdTime, err := parseJiraTime(comment.Created)
 			if err != nil {
 				return
 			}
 			
 			if timeRange.IsInRange(createdTime) {
 				resultChan <- Comment{
-					Timestamp: createdTime,
-					Author:    comment.Author.DisplayName,
-					Content:   comment.Body,
+					ID:              comment.ID,
+					Timestamp:       createdTime,
+					Author:          displayName(comment.Author),
+					AuthorAccountID: comment.Author.AccountID,
+					AuthorName:      comment.Author.Name,
+					Content:         comment.Body,
 				}
 			}
 		}(comment)
@@ -162,16 +887,19 @@ func (s *ActivityService) processCommentsSequential(comments []*extJira.Comment,
 	result := make([]Comment, 0)
 
 	for _, comment := range comments {
-		createdTime, err := time.Parse("2006-01-02T15:04:05.000-0700", comment.Created)
+		createdTime, err := parseJiraTime(comment.Created)
 		if err != nil {
 			continue
 		}
 
 		if timeRange.IsInRange(createdTime) {
 			result = append(result, Comment{
-				Timestamp: createdTime,
-				Author:    comment.Author.DisplayName,
-				Content:   comment.Body,
+				ID:              comment.ID,
+				Timestamp:       createdTime,
+				Author:          displayName(comment.Author),
+				AuthorAccountID: comment.Author.AccountID,
+				AuthorName:      comment.Author.Name,
+				Content:         comment.Body,
 			})
 		}
 	}
@@ -202,19 +930,21 @@ func (s *ActivityService) processChangelog(histories []extJira.ChangelogHistory,
 		go func(history extJira.ChangelogHistory) {
 			defer wg.Done()
 			
-			createdTime, err := time.Parse("2006-01-02T15:04:05.000-0700", history.Created)
+			createdTime, err := parseJiraTime(history.Created)
 			if err != nil {
 				return
 			}
 			
-			if timeRange.IsInRange(createdTime) && history.Author.AccountID == userAccountID {
+			if timeRange.IsInRange(createdTime) && isAccount(userID(history.Author), userAccountID) {
 				for _, item := range history.Items {
 					resultChan <- Change{
-						Timestamp: createdTime,
-						Author:    history.Author.DisplayName,
-						Field:     item.Field,
-						FromValue: item.FromString,
-						ToValue:   item.ToString,
+						Timestamp:       createdTime,
+						Author:          displayName(history.Author),
+						AuthorAccountID: history.Author.AccountID,
+						IsCurrentUser:   true,
+						Field:           item.Field,
+						FromValue:       item.FromString,
+						ToValue:         item.ToString,
 					}
 				}
 			}
@@ -241,19 +971,21 @@ func (s *ActivityService) processChangelogSequential(histories []extJira.Changel
 	result := make([]Change, 0)
 
 	for _, history := range histories {
-		createdTime, err := time.Parse("2006-01-02T15:04:05.000-0700", history.Created)
+		createdTime, err := parseJiraTime(history.Created)
 		if err != nil {
 			continue
 		}
 
-		if timeRange.IsInRange(createdTime) && history.Author.AccountID == userAccountID {
+		if timeRange.IsInRange(createdTime) && isAccount(userID(history.Author), userAccountID) {
 			for _, item := range history.Items {
 				result = append(result, Change{
-					Timestamp: createdTime,
-					Author:    history.Author.DisplayName,
-					Field:     item.Field,
-					FromValue: item.FromString,
-					ToValue:   item.ToString,
+					Timestamp:       createdTime,
+					Author:          displayName(history.Author),
+					AuthorAccountID: history.Author.AccountID,
+					IsCurrentUser:   true,
+					Field:           item.Field,
+					FromValue:       item.FromString,
+					ToValue:         item.ToString,
 				})
 			}
 		}
@@ -3,6 +3,7 @@ package jira
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -13,6 +14,18 @@ import (
 type MockJiraRepository struct {
 	MockGetUser   func() (*User, error)
 	MockGetIssues func(timeRange TimeRange, userAccountID string) ([]Issue, error)
+	MockTruncation *SearchTruncation
+	MockGetSupplementaryIssues func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error)
+	MockGetIssuesByKey func(keys []string) ([]Issue, error)
+	MockGetUsers func(accountIDs []string) ([]User, error)
+	MockGetStatusHistory func(key string) ([]Change, error)
+	MockGetFieldHistory func(key string, fields ...string) ([]Change, error)
+	MockGetAttention func(key string) (Attention, error)
+	MockGetRemoteLinks func(key string) ([]RemoteLink, error)
+	MockGetSprintScope func(boardID int, timeRange TimeRange) (*SprintScope, error)
+	MockGetSprint func(id int) (*Sprint, error)
+	MockActivityQuery func(timeRange TimeRange) (string, error)
+	MockEstimateSearch func(timeRange TimeRange) (SearchEstimate, error)
 }
 
 // GetUser implements the JiraRepository interface
@@ -21,8 +34,97 @@ func (m *MockJiraRepository) GetUser() (*User, error) {
 }
 
 // GetIssues implements the JiraRepository interface
-func (m *MockJiraRepository) GetIssues(timeRange TimeRange, userAccountID string) ([]Issue, error) {
-	return m.MockGetIssues(timeRange, userAccountID)
+func (m *MockJiraRepository) GetIssues(timeRange TimeRange, userAccountID string) ([]Issue, *SearchTruncation, error) {
+	issues, err := m.MockGetIssues(timeRange, userAccountID)
+	return issues, m.MockTruncation, err
+}
+
+// GetSupplementaryIssues implements the JiraRepository interface
+func (m *MockJiraRepository) GetSupplementaryIssues(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+	if m.MockGetSupplementaryIssues == nil {
+		return []Issue{}, nil
+	}
+	return m.MockGetSupplementaryIssues(kind, timeRange, userAccountID)
+}
+
+// GetIssuesByKey implements the JiraRepository interface
+func (m *MockJiraRepository) GetIssuesByKey(keys []string) ([]Issue, error) {
+	if m.MockGetIssuesByKey == nil {
+		return []Issue{}, nil
+	}
+	return m.MockGetIssuesByKey(keys)
+}
+
+// GetUsers implements the JiraRepository interface
+func (m *MockJiraRepository) GetUsers(accountIDs []string) ([]User, error) {
+	if m.MockGetUsers == nil {
+		return []User{}, nil
+	}
+	return m.MockGetUsers(accountIDs)
+}
+
+// GetStatusHistory implements the JiraRepository interface
+func (m *MockJiraRepository) GetStatusHistory(key string) ([]Change, error) {
+	if m.MockGetStatusHistory == nil {
+		return []Change{}, nil
+	}
+	return m.MockGetStatusHistory(key)
+}
+
+// GetFieldHistory implements the JiraRepository interface
+func (m *MockJiraRepository) GetFieldHistory(key string, fields ...string) ([]Change, error) {
+	if m.MockGetFieldHistory == nil {
+		return []Change{}, nil
+	}
+	return m.MockGetFieldHistory(key, fields...)
+}
+
+// GetAttention implements the JiraRepository interface
+func (m *MockJiraRepository) GetAttention(key string) (Attention, error) {
+	if m.MockGetAttention == nil {
+		return Attention{}, nil
+	}
+	return m.MockGetAttention(key)
+}
+
+// GetRemoteLinks implements the JiraRepository interface
+func (m *MockJiraRepository) GetRemoteLinks(key string) ([]RemoteLink, error) {
+	if m.MockGetRemoteLinks == nil {
+		return []RemoteLink{}, nil
+	}
+	return m.MockGetRemoteLinks(key)
+}
+
+// GetSprintScope implements the JiraRepository interface
+func (m *MockJiraRepository) GetSprintScope(boardID int, timeRange TimeRange) (*SprintScope, error) {
+	if m.MockGetSprintScope == nil {
+		return nil, nil
+	}
+	return m.MockGetSprintScope(boardID, timeRange)
+}
+
+// GetSprint implements the JiraRepository interface
+func (m *MockJiraRepository) GetSprint(id int) (*Sprint, error) {
+	if m.MockGetSprint == nil {
+		return nil, fmt.Errorf("sprint %d not found", id)
+	}
+	return m.MockGetSprint(id)
+}
+
+// ActivityQuery implements the JiraRepository interface
+func (m *MockJiraRepository) ActivityQuery(timeRange TimeRange) (string, error) {
+	if m.MockActivityQuery == nil {
+		return "", nil
+	}
+	return m.MockActivityQuery(timeRange)
+}
+
+// EstimateSearch implements the JiraRepository interface
+func (m *MockJiraRepository) EstimateSearch(timeRange TimeRange) (SearchEstimate, error) {
+	if m.MockEstimateSearch == nil {
+		return SearchEstimate{}, nil
+	}
+	return m.MockEstimateSearch(timeRange)
 }
 
 func TestActivityService_GetActivityReport(t *testing.T) {
@@ -285,3 +387,47 @@ func TestActivityService_ProcessIssuesConcurrently(t *testing.T) {
 	// Log the processing time for information
 	t.Logf("Processed %d issues concurrently in %v", numIssues, durationConcurrent)
 } 
+
+// closingStatsStore is a StatsStore that records being closed
+type closingStatsStore struct {
+	*FileStatsStore
+	closed   int
+	closeErr error
+}
+
+func (s *closingStatsStore) Close() error {
+	s.closed++
+	return s.closeErr
+}
+
+func TestActivityService_Close(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		closeErr    error
+		expectError bool
+	}{
+		{name: "Stores closed", closeErr: nil, expectError: false},
+		{name: "Close error surfaced", closeErr: errors.New("disk full"), expectError: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store := &closingStatsStore{FileStatsStore: NewFileStatsStore(filepath.Join(dir, "stats.json")), closeErr: tc.closeErr}
+			service := NewActivityService(&MockJiraRepository{})
+			service.SetStatsStore(store)
+			service.SetAttentionStore(NewFileAttentionStore(filepath.Join(dir, "attention.json")))
+			service.SetUserDirectory(NewUserDirectory(nil, NewMemoryUserCache(), time.Hour))
+
+			err := service.Close()
+			if tc.expectError != (err != nil) {
+				t.Errorf("Expected error %v, got %v", tc.expectError, err)
+			}
+			if store.closed != 1 {
+				t.Errorf("Expected the stats store to be closed once, got %d", store.closed)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sinkTimeout bounds a delivery to a webhook, so that a slow pipeline does
+// not hold up the report
+const sinkTimeout = 10 * time.Second
+
+// EventSink receives the activity events of every report generated, to ship
+// them to an external system such as a data pipeline
+type EventSink interface {
+	// Send delivers the events of one report
+	Send(events []Event) error
+}
+
+// shippedEvents remembers the IDs of the events each sink received, by the
+// position of the sink, for as long as reported events are remembered. The
+// zero value is ready to use.
+type shippedEvents struct {
+	mu   sync.Mutex
+	sent []map[string]time.Time
+}
+
+// reset forgets every shipped event, for a new set of sinks
+func (s *shippedEvents) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = nil
+}
+
+// unsent returns the events the sink has not received. Events without an ID
+// cannot be told apart and are always sent.
+func (s *shippedEvents) unsent(sink int, events []Event) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Event, 0, len(events))
+	for _, event := range events {
+		id := event.ID()
+		if id == "" || sink >= len(s.sent) || s.sent[sink][id].IsZero() {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// record remembers the events as received by the sink at the given time,
+// forgetting those received longer ago than the retention
+func (s *shippedEvents) record(sink int, events []Event, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.sent) <= sink {
+		s.sent = append(s.sent, make(map[string]time.Time))
+	}
+	sent := s.sent[sink]
+	for id, shippedAt := range sent {
+		if at.Sub(shippedAt) > reportedEventRetention {
+			delete(sent, id)
+		}
+	}
+	for _, event := range events {
+		if id := event.ID(); id != "" {
+			sent[id] = at
+		}
+	}
+}
+
+// eventRecord is the JSON form of an event shipped to a sink, one per line
+type eventRecord struct {
+	ID              string `json:"id"`
+	Kind            string `json:"kind"`
+	Issue           string `json:"issue"`
+	Timestamp       string `json:"timestamp"`
+	Author          string `json:"author"`
+	AuthorAccountID string `json:"authorAccountId,omitempty"`
+	CommentID       string `json:"commentId,omitempty"`
+	Content         string `json:"content,omitempty"`
+	Field           string `json:"field,omitempty"`
+	From            string `json:"from,omitempty"`
+	To              string `json:"to,omitempty"`
+	Title           string `json:"title,omitempty"`
+	URL             string `json:"url,omitempty"`
+}
+
+// encodeEvents renders the events as newline-delimited JSON
+func encodeEvents(events []Event) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, event := range events {
+		record := eventRecord{
+			ID:              event.ID(),
+			Kind:            string(event.Kind),
+			Issue:           event.IssueKey,
+			Timestamp:       event.Timestamp.Format(time.RFC3339),
+			Author:          event.Author,
+			AuthorAccountID: event.AuthorAccountID,
+		}
+		switch payload := event.Payload.(type) {
+		case Comment:
+			record.CommentID, record.Content = payload.ID, payload.Content
+		case Change:
+			record.Field, record.From, record.To = payload.Field, payload.FromValue, payload.ToValue
+		case RemoteLink:
+			record.Title, record.URL = payload.Title, payload.URL
+		}
+		if err := encoder.Encode(record); err != nil {
+			return nil, fmt.Errorf("failed to encode event %s: %w", record.ID, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// FileSink appends events to a file as newline-delimited JSON
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink creates a sink appending to the file at path
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Send appends the events to the file, creating it when missing
+func (s *FileSink) Send(events []Event) error {
+	data, err := encodeEvents(events)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create event sink directory: %w", err)
+	}
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open event sink %s: %w", s.path, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write event sink %s: %w", s.path, err)
+	}
+	return file.Close()
+}
+
+// WriterSink writes events to a writer as newline-delimited JSON, such as
+// stdout for a host piping them onwards
+type WriterSink struct {
+	writer io.Writer
+	mu     sync.Mutex
+}
+
+// NewWriterSink creates a sink writing to the writer
+func NewWriterSink(writer io.Writer) *WriterSink {
+	return &WriterSink{writer: writer}
+}
+
+// NewStdoutSink creates a sink writing to stdout
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink(os.Stdout)
+}
+
+// Send writes the events
+func (s *WriterSink) Send(events []Event) error {
+	data, err := encodeEvents(events)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.writer.Write(data); err != nil {
+		return fmt.Errorf("failed to write events: %w", err)
+	}
+	return nil
+}
+
+// HTTPSink posts the events of each report to a generic webhook as one
+// newline-delimited JSON body
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSink creates a sink posting to the URL
+func NewHTTPSink(url string, client *http.Client) *HTTPSink {
+	if client == nil {
+		client = &http.Client{Timeout: sinkTimeout}
+	}
+	return &HTTPSink{url: url, client: client}
+}
+
+// Send posts the events, failing on any status other than 2xx
+func (s *HTTPSink) Send(events []Event) error {
+	data, err := encodeEvents(events)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+
+	response, err := s.client.Post(s.url, "application/x-ndjson", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to post events to %s: %w", s.url, err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("failed to post events to %s: status %d", s.url, response.StatusCode)
+	}
+	return nil
+}
+
+// ParseEventSink creates the sink a jira.sinks entry names: "stdout",
+// "file:<path>" or an http(s) URL to post to
+func ParseEventSink(spec string) (EventSink, error) {
+	spec = strings.TrimSpace(spec)
+	switch {
+	case strings.EqualFold(spec, "stdout"):
+		return NewStdoutSink(), nil
+	case strings.HasPrefix(spec, "file:"):
+		path := strings.TrimSpace(strings.TrimPrefix(spec, "file:"))
+		if path == "" {
+			return nil, fmt.Errorf("file sink %q names no path", spec)
+		}
+		return NewFileSink(path), nil
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return NewHTTPSink(spec, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown event sink %q: expected stdout, file:<path> or an http(s) URL", spec)
+	}
+}
@@ -0,0 +1,231 @@
+package jira
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+// sinkEvents are a comment and a change on one issue
+func sinkEvents() []Event {
+	issue := Issue{
+		Key:      "PAY-12",
+		Comments: []Comment{{ID: "10042", Timestamp: time.Date(2023, 1, 2, 11, 0, 0, 0, time.UTC), Author: "QA", AuthorAccountID: "qa1", Content: "Found an edge case"}},
+		Changes:  []Change{{Timestamp: time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC), Author: "Test User", AuthorAccountID: "user123", Field: "status", FromValue: "To Do", ToValue: "In Progress"}},
+	}
+	return issue.Events()
+}
+
+const sinkNDJSON = `{"id":"change:PAY-12:status:1672650000:To Do:In Progress","kind":"change","issue":"PAY-12","timestamp":"2023-01-02T09:00:00Z","author":"Test User","authorAccountId":"user123","field":"status","from":"To Do","to":"In Progress"}
+{"id":"comment:PAY-12:10042","kind":"comment","issue":"PAY-12","timestamp":"2023-01-02T11:00:00Z","author":"QA","authorAccountId":"qa1","commentId":"10042","content":"Found an edge case"}
+`
+
+func TestEventSinks(t *testing.T) {
+	var posted []byte
+	var contentType string
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted, _ = io.ReadAll(r.Body)
+		contentType = r.Header.Get("Content-Type")
+	}))
+	defer webhook.Close()
+
+	path := filepath.Join(t.TempDir(), "sinks", "events.ndjson")
+	var written bytes.Buffer
+
+	// Setup test cases
+	testCases := []struct {
+		name string
+		sink EventSink
+		read func() string
+	}{
+		{name: "File", sink: NewFileSink(path), read: func() string { data, _ := os.ReadFile(path); return string(data) }},
+		{name: "Writer", sink: NewWriterSink(&written), read: func() string { return written.String() }},
+		{name: "HTTP", sink: NewHTTPSink(webhook.URL, nil), read: func() string { return string(posted) }},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.sink.Send(sinkEvents()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := tc.read(); got != sinkNDJSON {
+				t.Errorf("Expected:\n%s\ngot:\n%s", sinkNDJSON, got)
+			}
+		})
+	}
+
+	if contentType != "application/x-ndjson" {
+		t.Errorf("Expected the webhook to receive application/x-ndjson, got %s", contentType)
+	}
+
+	// The file sink appends the events of each report
+	if err := NewFileSink(path).Send(sinkEvents()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != sinkNDJSON+sinkNDJSON {
+		t.Errorf("Expected the events of both reports, got:\n%s", data)
+	}
+}
+
+func TestHTTPSink_Rejected(t *testing.T) {
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer webhook.Close()
+
+	err := NewHTTPSink(webhook.URL, nil).Send(sinkEvents())
+	if err == nil || !strings.Contains(err.Error(), "status 503") {
+		t.Errorf("Expected the status to be reported, got %v", err)
+	}
+}
+
+func TestParseEventSink(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		spec     string
+		expected interface{}
+	}{
+		{spec: "stdout", expected: &WriterSink{}},
+		{spec: " file:/tmp/events.ndjson ", expected: &FileSink{}},
+		{spec: "https://hooks.example.com/jira", expected: &HTTPSink{}},
+		{spec: "file:"},
+		{spec: "kafka://events"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.spec, func(t *testing.T) {
+			sink, err := ParseEventSink(tc.spec)
+			if tc.expected == nil {
+				if err == nil {
+					t.Errorf("Expected an error, got %T", sink)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got, want := fmt.Sprintf("%T", sink), fmt.Sprintf("%T", tc.expected); got != want {
+				t.Errorf("Expected a %s, got a %s", want, got)
+			}
+		})
+	}
+}
+
+// recordingSink keeps the events sent to it, failing when told to
+type recordingSink struct {
+	events []Event
+	err    error
+}
+
+func (s *recordingSink) Send(events []Event) error {
+	s.events = append(s.events, events...)
+	return s.err
+}
+
+func TestActivityService_EventSinks(t *testing.T) {
+	issues := []Issue{{Key: "PAY-12", Comments: []Comment{{ID: "10042", Author: "QA", Content: "Found an edge case"}}}}
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return issues, nil
+		},
+	}
+
+	failing := &recordingSink{err: errors.New("pipeline down")}
+	working := &recordingSink{}
+	options := DefaultReportOptions()
+	options.Verbosity = VerbosityMinimal
+
+	service := NewActivityService(mockRepo)
+	service.SetLogger(NewNoopLogger())
+	service.SetReportOptions(options)
+	service.SetEventSinks([]EventSink{failing, working})
+
+	if _, err := service.GetActivityReport(plugin.TimeRange{Start: time.Now().Add(-time.Hour), End: time.Now()}); err != nil {
+		t.Fatalf("Expected a failed sink not to fail the report, got %v", err)
+	}
+
+	// Every sink receives the events as they appear in the report
+	if len(working.events) != 1 || working.events[0].Kind != EventComment || working.events[0].Payload.(Comment).Content != "" {
+		t.Errorf("Expected the comment without its body at minimal verbosity, got %+v", working.events)
+	}
+}
+
+func TestActivityService_EventSinks_ShipOnce(t *testing.T) {
+	first := Comment{ID: "10042", Timestamp: time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC), Author: "QA", AuthorAccountID: "qa1", Content: "Found an edge case"}
+	second := Comment{ID: "10043", Timestamp: time.Date(2023, 1, 3, 9, 0, 0, 0, time.UTC), Author: "QA", AuthorAccountID: "qa1", Content: "Fixed"}
+	comments := []Comment{first}
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "PAY-12", Comments: append([]Comment{}, comments...)}}, nil
+		},
+	}
+
+	flaky := &recordingSink{err: errors.New("pipeline down")}
+	working := &recordingSink{}
+	service := NewActivityService(mockRepo)
+	service.SetLogger(NewNoopLogger())
+	service.SetEventSinks([]EventSink{flaky, working})
+	timeRange := plugin.TimeRange{Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 4, 0, 0, 0, 0, time.UTC)}
+	options := DefaultReportOptions()
+
+	// Setup test cases
+	testCases := []struct {
+		name            string
+		comments        []Comment
+		skipHistory     bool
+		flakyErr        error
+		expectedWorking []string
+		expectedFlaky   []string
+	}{
+		{name: "First report", comments: []Comment{first}, flakyErr: errors.New("pipeline down"), expectedWorking: []string{"10042"}, expectedFlaky: []string{"10042"}},
+		{name: "Same report again", comments: []Comment{first}, expectedFlaky: []string{"10042"}},
+		{name: "Overlapping report", comments: []Comment{first, second}, expectedWorking: []string{"10043"}, expectedFlaky: []string{"10043"}},
+		{name: "Report skipping history", comments: []Comment{first, second, {ID: "10044", Timestamp: time.Date(2023, 1, 3, 10, 0, 0, 0, time.UTC)}}, skipHistory: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			comments = tc.comments
+			flaky.events, flaky.err = nil, tc.flakyErr
+			working.events = nil
+			options.SkipHistory = tc.skipHistory
+
+			if _, err := service.GetActivityReportWithOptions(timeRange, options); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			for _, sink := range []struct {
+				name     string
+				events   []Event
+				expected []string
+			}{{"working", working.events, tc.expectedWorking}, {"flaky", flaky.events, tc.expectedFlaky}} {
+				var ids []string
+				for _, event := range sink.events {
+					ids = append(ids, event.Payload.(Comment).ID)
+				}
+				if strings.Join(ids, ",") != strings.Join(sink.expected, ",") {
+					t.Errorf("Expected the %s sink to receive %v, got %v", sink.name, sink.expected, ids)
+				}
+			}
+		})
+	}
+}
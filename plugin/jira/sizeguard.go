@@ -0,0 +1,98 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SizeGuard warns when the Jira responses behind a report grow beyond a limit
+// and suggests query changes that reduce the payload
+type SizeGuard struct {
+	// Maximum decoded response bytes per report before warning (0 disables the guard)
+	MaxReportBytes int64
+
+	// Query and report options the suggestions are derived from
+	QueryOptions  QueryOptions
+	ReportOptions ReportOptions
+}
+
+// Exceeded reports whether the transferred payload is above the limit
+func (g SizeGuard) Exceeded(metrics TransferMetrics) bool {
+	return g.MaxReportBytes > 0 && metrics.BytesDecoded > g.MaxReportBytes
+}
+
+// Check returns suggestions for slimming the query if the transferred payload
+// exceeds the limit, or nil if it is within bounds. A payload above the limit
+// may come with no suggestions when the query is already as slim as it gets.
+func (g SizeGuard) Check(metrics TransferMetrics) []string {
+	if !g.Exceeded(metrics) {
+		return nil
+	}
+
+	suggestions := make([]string, 0)
+	opts := g.QueryOptions
+
+	if g.ReportOptions.Verbosity.IncludeDescriptions() {
+		suggestions = append(suggestions, "lower jira.report.verbosity from full to stop fetching issue descriptions")
+	}
+
+	if len(opts.Fields) > 0 {
+		suggestions = append(suggestions, "remove the extra fields from jira.query.fields")
+	}
+
+	if opts.MaxResults > 50 {
+		suggestions = append(suggestions, fmt.Sprintf("reduce jira.query.max_results (currently %d)", opts.MaxResults))
+	}
+
+	if opts.ExpandChangelog && g.ReportOptions.readsChangelog() {
+		suggestions = append(suggestions, fmt.Sprintf("skip changelog expansion (expand=changelog), which returns the full history of every issue: %s",
+			strings.Join(g.changelogReaders(), " and ")))
+	}
+
+	if !opts.AssigneeCurrentUser {
+		suggestions = append(suggestions, "set jira.query.assignee_current_user to true to narrow the query")
+	}
+
+	if !opts.InOpenSprints {
+		suggestions = append(suggestions, "set jira.query.in_open_sprints to true to narrow the query")
+	}
+
+	return suggestions
+}
+
+// changelogReaders returns the settings to change so that nothing reads the
+// changelog and searches no longer expand it
+func (g SizeGuard) changelogReaders() []string {
+	var settings []string
+	if g.ReportOptions.Sections.Has(SectionChanges) {
+		settings = append(settings, "leave changes out of jira.report.sections")
+	}
+	if g.ReportOptions.IncludeStats {
+		settings = append(settings, "turn off jira.report.stats")
+	}
+	if g.ReportOptions.AnalyticsPath != "" {
+		settings = append(settings, "unset jira.analytics.output_path")
+	}
+	if g.ReportOptions.IncludeEscalations {
+		settings = append(settings, "turn off jira.report.always_include_escalations")
+	}
+	if g.ReportOptions.IncludeHandoffs {
+		settings = append(settings, "turn off jira.report.handoffs")
+	}
+	return settings
+}
+
+// formatSize renders a byte count in a human-readable unit
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,196 @@
+package jira
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestSizeGuard_Check(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		guard    SizeGuard
+		metrics  TransferMetrics
+		expected []string
+	}{
+		{
+			name:     "Guard disabled",
+			guard:    SizeGuard{MaxReportBytes: 0, QueryOptions: DefaultQueryOptions()},
+			metrics:  TransferMetrics{BytesDecoded: 10 << 20},
+			expected: nil,
+		},
+		{
+			name:     "Within limit",
+			guard:    SizeGuard{MaxReportBytes: 1024, QueryOptions: DefaultQueryOptions()},
+			metrics:  TransferMetrics{BytesDecoded: 1024},
+			expected: nil,
+		},
+		{
+			name:    "Default options over limit",
+			guard:   SizeGuard{MaxReportBytes: 1024, QueryOptions: DefaultQueryOptions(), ReportOptions: DefaultReportOptions()},
+			metrics: TransferMetrics{BytesDecoded: 2048},
+			expected: []string{
+				"reduce jira.query.max_results (currently 100)",
+				"skip changelog expansion (expand=changelog), which returns the full history of every issue: leave changes out of jira.report.sections",
+			},
+		},
+		{
+			name: "Changelog read by stats and handoffs",
+			guard: SizeGuard{
+				MaxReportBytes: 1024,
+				QueryOptions:   QueryOptions{MaxResults: 20, ExpandChangelog: true, AssigneeCurrentUser: true, InOpenSprints: true},
+				ReportOptions:  ReportOptions{Sections: ReportSections{SectionComments: true}, IncludeStats: true, IncludeHandoffs: true},
+			},
+			metrics: TransferMetrics{BytesDecoded: 2048},
+			expected: []string{
+				"skip changelog expansion (expand=changelog), which returns the full history of every issue: turn off jira.report.stats and turn off jira.report.handoffs",
+			},
+		},
+		{
+			name: "Changelog read by escalations",
+			guard: SizeGuard{
+				MaxReportBytes: 1024,
+				QueryOptions:   QueryOptions{MaxResults: 20, ExpandChangelog: true, AssigneeCurrentUser: true, InOpenSprints: true},
+				ReportOptions:  ReportOptions{Sections: ReportSections{SectionComments: true}, IncludeEscalations: true},
+			},
+			metrics: TransferMetrics{BytesDecoded: 2048},
+			expected: []string{
+				"skip changelog expansion (expand=changelog), which returns the full history of every issue: turn off jira.report.always_include_escalations",
+			},
+		},
+		{
+			name: "Descriptions and extra fields over limit",
+			guard: SizeGuard{
+				MaxReportBytes: 1024,
+				QueryOptions:   QueryOptions{MaxResults: 20, ExpandChangelog: true, AssigneeCurrentUser: true, InOpenSprints: true, Fields: []string{"fixVersions"}},
+				ReportOptions:  ReportOptions{Verbosity: VerbosityFull, Sections: ReportSections{SectionComments: true}},
+			},
+			metrics: TransferMetrics{BytesDecoded: 2048},
+			expected: []string{
+				"lower jira.report.verbosity from full to stop fetching issue descriptions",
+				"remove the extra fields from jira.query.fields",
+			},
+		},
+		{
+			name: "Broad query over limit",
+			guard: SizeGuard{MaxReportBytes: 1024, QueryOptions: QueryOptions{
+				MaxResults: 20,
+			}},
+			metrics: TransferMetrics{BytesDecoded: 2048},
+			expected: []string{
+				"set jira.query.assignee_current_user to true to narrow the query",
+				"set jira.query.in_open_sprints to true to narrow the query",
+			},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.guard.Check(tc.metrics)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		bytes    int64
+		expected string
+	}{
+		{bytes: 512, expected: "512 B"},
+		{bytes: 1536, expected: "1.5 KiB"},
+		{bytes: 5 << 20, expected: "5.0 MiB"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if result := formatSize(tc.bytes); result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestActivityService_SizeGuardWarning(t *testing.T) {
+	metrics := NewMetricsRecorder()
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			metrics.addDecoded(4096)
+			return []Issue{}, nil
+		},
+	}
+
+	var messages []string
+	service := NewActivityService(mockRepo)
+	service.SetMetricsRecorder(metrics)
+	service.SetSizeGuard(SizeGuard{MaxReportBytes: 1024, QueryOptions: DefaultQueryOptions()})
+	service.SetLogger(LoggerFunc(func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}))
+
+	_, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 warning, got %d", len(messages))
+	}
+	if !strings.Contains(messages[0], "4.0 KiB") || !strings.Contains(messages[0], "jira.query.max_results") {
+		t.Errorf("Expected warning with size and suggestions, got '%s'", messages[0])
+	}
+}
+
+func TestActivityService_SizeGuardWarningWithoutSuggestions(t *testing.T) {
+	metrics := NewMetricsRecorder()
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			metrics.addDecoded(4096)
+			return []Issue{}, nil
+		},
+	}
+
+	// A query already as slim as it gets leaves nothing to suggest
+	var messages []string
+	service := NewActivityService(mockRepo)
+	service.SetMetricsRecorder(metrics)
+	service.SetSizeGuard(SizeGuard{
+		MaxReportBytes: 1024,
+		QueryOptions:   QueryOptions{MaxResults: 20, AssigneeCurrentUser: true, InOpenSprints: true},
+	})
+	service.SetLogger(LoggerFunc(func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}))
+
+	_, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "report responses totalled 4.0 KiB, above the 1.0 KiB limit"
+	if len(messages) != 1 || messages[0] != expected {
+		t.Errorf("Expected the warning %q, got %q", expected, messages)
+	}
+}
@@ -0,0 +1,209 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// slackTextLimit is the longest message text Slack accepts
+	slackTextLimit = 40000
+	// slackAPIURL is the Slack Web API method posting a message as a bot
+	slackAPIURL = "https://slack.com/api/chat.postMessage"
+)
+
+// slackReplacer escapes the characters Slack reads as links, mentions and
+// entities in message text
+var slackReplacer = strings.NewReplacer(`&`, `&amp;`, `<`, `&lt;`, `>`, `&gt;`)
+
+// escapeSlack escapes a single-line value for Slack message text
+func escapeSlack(value string) string {
+	value = strings.ReplaceAll(normalizeLineEndings(value), "\n", " ")
+	return slackReplacer.Replace(value)
+}
+
+// SlackFormatter formats activity reports as Slack message text (mrkdwn): a
+// compact digest of the issues worked on, meant to be read in a channel
+// rather than to carry every comment and change
+type SlackFormatter struct{}
+
+// NewSlackFormatter creates a new Slack formatter
+func NewSlackFormatter() *SlackFormatter {
+	return &SlackFormatter{}
+}
+
+// Name returns the name of the formatter
+func (f *SlackFormatter) Name() string {
+	return "slack"
+}
+
+// Format formats an activity report as Slack message text
+func (f *SlackFormatter) Format(report *ActivityReport) (*FormattedContent, error) {
+	if report.emptyMessage(f.Name()) {
+		return &FormattedContent{
+			ContentType: "text/plain",
+			Content:     "No activity found for the specified time range.",
+		}, nil
+	}
+
+	sb := getBuffer()
+	defer putBuffer(sb)
+	links := NewDeepLinks(report.Options.LinkBaseURL)
+
+	// Add report header
+	sb.WriteString("*Jira Activity Report*\n")
+	if report.Options.Verbosity.IncludeMetadata() {
+		sb.WriteString(fmt.Sprintf("%s to %s · %s\n",
+			report.TimeRange.Start.Format("2006-01-02"),
+			report.TimeRange.End.Format("2006-01-02"),
+			escapeSlack(report.User.DisplayName)))
+	}
+	sb.WriteString("\n")
+
+	for _, notice := range chatNotices(report) {
+		sb.WriteString(fmt.Sprintf("> *Note:* %s\n\n", escapeSlack(notice)))
+	}
+
+	// Add each section as a bold title over a bullet per issue, linked when
+	// deep links are on
+	for _, section := range chatSections(report) {
+		sb.WriteString(fmt.Sprintf("%s*%s*\n", slackStatusEmoji(section.Category), escapeSlack(section.Title)))
+		if section.Subtitle != "" {
+			sb.WriteString(fmt.Sprintf("_%s_\n", escapeSlack(section.Subtitle)))
+		}
+		for _, item := range section.Items {
+			key := "*" + escapeSlack(item.Issue.Key) + "*"
+			if links.Enabled() {
+				key = fmt.Sprintf("<%s|%s>", links.Issue(item.Issue.Key), escapeSlack(item.Issue.Key))
+			}
+			sb.WriteString(fmt.Sprintf("• %s %s", key, escapeSlack(item.Issue.Summary)))
+			if item.Detail != "" {
+				sb.WriteString(fmt.Sprintf(" (%s)", escapeSlack(item.Detail)))
+			}
+			sb.WriteString("\n")
+			if item.Note != "" {
+				sb.WriteString(fmt.Sprintf("    _%s_\n", escapeSlack(item.Note)))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	// List the problems that left data out of the report
+	if warnings := footerWarnings(report.Warnings); len(warnings) > 0 {
+		sb.WriteString("*Warnings*\n")
+		for _, warning := range warnings {
+			sb.WriteString(fmt.Sprintf("• %s\n", escapeSlack(warning.Message)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Close with how the report was generated
+	if generation := report.Generation; generation != nil && report.Options.Verbosity.IncludeMetadata() {
+		sb.WriteString(fmt.Sprintf("_%s_\n", escapeSlack(generation.Line())))
+	}
+
+	return &FormattedContent{
+		ContentType: "text/plain",
+		Content:     truncateSlackText(strings.TrimRight(sb.String(), "\n")),
+	}, nil
+}
+
+// truncateSlackText cuts text longer than Slack accepts at the last line that
+// fits, saying that the rest was left out
+func truncateSlackText(text string) string {
+	const notice = "\n_The report was cut to fit in a Slack message._"
+	if len(text) <= slackTextLimit {
+		return text
+	}
+	text = text[:slackTextLimit-len(notice)]
+	if i := strings.LastIndex(text, "\n"); i > 0 {
+		text = text[:i]
+	}
+	return text + notice
+}
+
+// SlackPublisher posts every report as a Slack message, either to an incoming
+// webhook or as a bot to a channel
+type SlackPublisher struct {
+	formatter  *SlackFormatter
+	client     *http.Client
+	webhookURL string
+	token      string
+	channel    string
+	apiURL     string
+}
+
+// NewSlackWebhookPublisher creates a publisher posting to an incoming webhook.
+// A nil client uses one with a timeout.
+func NewSlackWebhookPublisher(webhookURL string, client *http.Client) *SlackPublisher {
+	return &SlackPublisher{
+		formatter:  NewSlackFormatter(),
+		client:     newPublishClient(client),
+		webhookURL: webhookURL,
+	}
+}
+
+// NewSlackBotPublisher creates a publisher posting to a channel with a bot
+// token allowed to chat:write. A nil client uses one with a timeout.
+func NewSlackBotPublisher(token, channel string, client *http.Client) *SlackPublisher {
+	return &SlackPublisher{
+		formatter: NewSlackFormatter(),
+		client:    newPublishClient(client),
+		token:     token,
+		channel:   channel,
+		apiURL:    slackAPIURL,
+	}
+}
+
+// Name returns the name of the service published to
+func (p *SlackPublisher) Name() string {
+	return "Slack"
+}
+
+// slackMessage is the body of a Slack message post
+type slackMessage struct {
+	Channel string `json:"channel,omitempty"`
+	Text    string `json:"text"`
+	Mrkdwn  bool   `json:"mrkdwn"`
+}
+
+// Publish posts the report formatted for Slack
+func (p *SlackPublisher) Publish(report *ActivityReport) error {
+	if report.Omitted(p.formatter.Name()) {
+		return nil
+	}
+	content, err := p.formatter.Format(report)
+	if err != nil {
+		return fmt.Errorf("failed to format the report for Slack: %w", err)
+	}
+	message := slackMessage{Channel: p.channel, Text: content.Content, Mrkdwn: true}
+
+	if p.webhookURL != "" {
+		if _, err := postJSON(p.client, p.webhookURL, nil, message); err != nil {
+			return fmt.Errorf("failed to post the report to the Slack webhook: %w", err)
+		}
+		return nil
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+p.token)
+	body, err := postJSON(p.client, p.apiURL, header, message)
+	if err != nil {
+		return fmt.Errorf("failed to post the report to Slack channel %s: %w", p.channel, err)
+	}
+
+	// The Web API answers 200 and reports failures such as a missing scope in the body
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to read the Slack response: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("failed to post the report to Slack channel %s: %s", p.channel, result.Error)
+	}
+	return nil
+}
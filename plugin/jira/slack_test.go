@@ -0,0 +1,121 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEscapeSlack(t *testing.T) {
+	if escaped := escapeSlack("<!channel> fix A & B\nnow"); escaped != "&lt;!channel&gt; fix A &amp; B now" {
+		t.Errorf("Expected mentions and entities to be escaped, got %q", escaped)
+	}
+}
+
+func TestTruncateSlackText(t *testing.T) {
+	line := strings.Repeat("x", 99) + "\n"
+	text := strings.Repeat(line, slackTextLimit/len(line)+10)
+
+	truncated := truncateSlackText(text)
+	if len(truncated) > slackTextLimit {
+		t.Errorf("Expected at most %d bytes, got %d", slackTextLimit, len(truncated))
+	}
+	if !strings.HasSuffix(truncated, "\n_The report was cut to fit in a Slack message._") {
+		t.Errorf("Expected the cut to be disclosed, got %q", truncated[len(truncated)-80:])
+	}
+	if short := "*Jira Activity Report*"; truncateSlackText(short) != short {
+		t.Errorf("Expected a short text to be kept")
+	}
+}
+
+func TestSlackPublisher(t *testing.T) {
+	report := goldenReport()
+
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		publisher   func(url string) *SlackPublisher
+		response    string
+		status      int
+		expectedErr string
+	}{
+		{
+			name:      "Webhook",
+			publisher: func(url string) *SlackPublisher { return NewSlackWebhookPublisher(url, nil) },
+			response:  "ok",
+			status:    http.StatusOK,
+		},
+		{
+			name:        "Webhook rejected",
+			publisher:   func(url string) *SlackPublisher { return NewSlackWebhookPublisher(url, nil) },
+			response:    "invalid_token",
+			status:      http.StatusForbidden,
+			expectedErr: "status 403: invalid_token",
+		},
+		{
+			name: "Bot",
+			publisher: func(url string) *SlackPublisher {
+				publisher := NewSlackBotPublisher("xoxb-secret", "C0123", nil)
+				publisher.apiURL = url
+				return publisher
+			},
+			response: `{"ok":true}`,
+			status:   http.StatusOK,
+		},
+		{
+			name: "Bot without scope",
+			publisher: func(url string) *SlackPublisher {
+				publisher := NewSlackBotPublisher("xoxb-secret", "C0123", nil)
+				publisher.apiURL = url
+				return publisher
+			},
+			response:    `{"ok":false,"error":"missing_scope"}`,
+			status:      http.StatusOK,
+			expectedErr: "channel C0123: missing_scope",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var message slackMessage
+			var authorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+				json.NewDecoder(r.Body).Decode(&message)
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			publisher := tc.publisher(server.URL)
+			err := publisher.Publish(report)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			expected, _ := NewSlackFormatter().Format(report)
+			if message.Text != expected.Content || !message.Mrkdwn {
+				t.Errorf("Expected the report formatted for Slack, got %+v", message)
+			}
+			if publisher.token != "" && (authorization != "Bearer xoxb-secret" || message.Channel != "C0123") {
+				t.Errorf("Expected a post to C0123 with the bot token, got %q to %q", authorization, message.Channel)
+			}
+		})
+	}
+}
+
+func TestSlackPublisher_HidesWebhookURL(t *testing.T) {
+	err := NewSlackWebhookPublisher("http://127.0.0.1:1/services/T000/B000/secret", nil).Publish(goldenReport())
+	if err == nil || strings.Contains(err.Error(), "secret") {
+		t.Errorf("Expected an error without the webhook URL, got %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package jira
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// sprintField is the changelog field of sprint changes
+const sprintField = "Sprint"
+
+// Sprint is a sprint of an agile board
+type Sprint struct {
+	ID    int
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// SprintScope is the active sprint of a board with the issues added to or
+// removed from it within the time range
+type SprintScope struct {
+	Sprint        Sprint
+	Added         []Issue
+	Removed       []Issue
+	PointsAdded   float64
+	PointsRemoved float64
+}
+
+// Changed reports whether any issue was added to or removed from the sprint
+func (s *SprintScope) Changed() bool {
+	return s != nil && (len(s.Added) > 0 || len(s.Removed) > 0)
+}
+
+// ScopeLine summarizes the scope change, e.g. "+3 issues / -1 issue, +8 points";
+// the points are the net change and are left out when no issue has points
+func (s *SprintScope) ScopeLine() string {
+	line := fmt.Sprintf("+%s / -%s", pluralize(len(s.Added), "issue", "issues"), pluralize(len(s.Removed), "issue", "issues"))
+	if s.PointsAdded == 0 && s.PointsRemoved == 0 {
+		return line
+	}
+
+	net := s.PointsAdded - s.PointsRemoved
+	sign := "+"
+	if net < 0 {
+		sign = "-"
+		net = -net
+	}
+	return fmt.Sprintf("%s, %s%s points", line, sign, formatPoints(net))
+}
+
+// Sections returns the non-empty lists of added and removed issues in display order
+func (s *SprintScope) Sections() []supplementarySection {
+	sections := make([]supplementarySection, 0, 2)
+	if len(s.Added) > 0 {
+		sections = append(sections, supplementarySection{Title: "Added", Issues: s.Added})
+	}
+	if len(s.Removed) > 0 {
+		sections = append(sections, supplementarySection{Title: "Removed", Issues: s.Removed})
+	}
+	return sections
+}
+
+// GetSprintScope retrieves the active sprint of a board and the issues of the
+// project added to or removed from it within the time range, or nil when the
+// board has no active sprint. Jira cannot search sprint changes, so the issues
+// updated within the range are searched and their changelogs read.
+func (r *JiraAPIRepository) GetSprintScope(boardID int, timeRange TimeRange) (*SprintScope, error) {
+	sprints, err := r.api.ActiveSprints(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the active sprint of board %d: %w", boardID, err)
+	}
+	if len(sprints) == 0 {
+		return nil, nil
+	}
+
+	// Boards running parallel sprints report the one that started first
+	rawSprint := sprints[0]
+	sprint := Sprint{ID: rawSprint.ID, Name: rawSprint.Name}
+	if rawSprint.StartDate != nil {
+		sprint.Start = *rawSprint.StartDate
+	}
+	if rawSprint.EndDate != nil {
+		sprint.End = *rawSprint.EndDate
+	}
+
+	fromTime, toTime := r.config.QueryOptions.jqlRange(timeRange)
+	var query jqlBuilder
+	query.Raw(fmt.Sprintf("project = %s", QuoteJQL(r.config.QueryOptions.Project)))
+	query.Raw(fmt.Sprintf("updated >= %s", QuoteJQL(fromTime)))
+	query.Raw(fmt.Sprintf("updated < %s", QuoteJQL(toTime)))
+
+	fields := []string{"summary", "status", "issuetype"}
+	if field := r.config.QueryOptions.StoryPointsField; field != "" {
+		fields = append(fields, field)
+	}
+	rawIssues, err := r.searchIssuesWithOptions(query.String(), &extJira.SearchOptions{
+		MaxResults: r.config.QueryOptions.MaxResults,
+		Fields:     fields,
+		Expand:     "changelog",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scope := &SprintScope{Sprint: sprint}
+	for _, rawIssue := range rawIssues {
+		if rawIssue.Changelog == nil {
+			continue
+		}
+		added, removed := sprintMembershipChange(rawIssue.Changelog.Histories, timeRange, sprint.ID)
+		if !added && !removed {
+			continue
+		}
+
+		issue := r.convertIssue(rawIssue, timeRange, "")
+		if added {
+			scope.Added = append(scope.Added, issue)
+			scope.PointsAdded += issue.StoryPoints
+		} else {
+			scope.Removed = append(scope.Removed, issue)
+			scope.PointsRemoved += issue.StoryPoints
+		}
+	}
+	return scope, nil
+}
+
+// GetSprint retrieves a sprint by ID
+func (r *JiraAPIRepository) GetSprint(id int) (*Sprint, error) {
+	var rawSprint extJira.Sprint
+	if err := r.getJSON(fmt.Sprintf("rest/agile/1.0/sprint/%d", id), &rawSprint); err != nil {
+		return nil, fmt.Errorf("failed to fetch sprint %d: %w", id, err)
+	}
+
+	sprint := &Sprint{ID: rawSprint.ID, Name: rawSprint.Name}
+	if rawSprint.StartDate != nil {
+		sprint.Start = *rawSprint.StartDate
+	}
+	if rawSprint.EndDate != nil {
+		sprint.End = *rawSprint.EndDate
+	}
+	return sprint, nil
+}
+
+// sprintMembershipChange compares whether an issue was in the sprint before
+// its first sprint change within the time range and after its last one. An
+// issue added and removed again within the range did not change the scope.
+func sprintMembershipChange(histories []extJira.ChangelogHistory, timeRange TimeRange, sprintID int) (added, removed bool) {
+	type sprintChange struct {
+		at       time.Time
+		from, to string
+	}
+
+	changes := make([]sprintChange, 0)
+	for _, history := range histories {
+		createdTime, err := parseJiraTime(history.Created)
+		if err != nil || !timeRange.IsInRange(createdTime) {
+			continue
+		}
+		for _, item := range history.Items {
+			if strings.EqualFold(item.Field, sprintField) {
+				changes = append(changes, sprintChange{at: createdTime, from: changelogValue(item.From), to: changelogValue(item.To)})
+			}
+		}
+	}
+	if len(changes) == 0 {
+		return false, false
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].at.Before(changes[j].at)
+	})
+	before := containsSprintID(changes[0].from, sprintID)
+	after := containsSprintID(changes[len(changes)-1].to, sprintID)
+	return !before && after, before && !after
+}
+
+// changelogValue returns the raw value of a changelog item, or an empty string when unset
+func changelogValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// containsSprintID reports whether a comma-separated list of sprint IDs, as
+// recorded in the changelog, contains the sprint
+func containsSprintID(ids string, sprintID int) bool {
+	for _, id := range strings.Split(ids, ",") {
+		if strings.TrimSpace(id) == fmt.Sprint(sprintID) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+// sprintHistory builds a changelog history moving an issue between sprints
+func sprintHistory(created string, from, to interface{}) extJira.ChangelogHistory {
+	return extJira.ChangelogHistory{
+		Created: created,
+		Author:  extJira.User{AccountID: "lead1", DisplayName: "Lead"},
+		Items:   []extJira.ChangelogItems{{Field: "Sprint", From: from, To: to}},
+	}
+}
+
+func TestSprintMembershipChange(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name            string
+		histories       []extJira.ChangelogHistory
+		expectedAdded   bool
+		expectedRemoved bool
+	}{
+		{
+			name:          "Added",
+			histories:     []extJira.ChangelogHistory{sprintHistory("2023-01-02T10:00:00.000+0000", nil, "7")},
+			expectedAdded: true,
+		},
+		{
+			name:          "Carried over from the previous sprint",
+			histories:     []extJira.ChangelogHistory{sprintHistory("2023-01-02T10:00:00.000+0000", "6", "6, 7")},
+			expectedAdded: true,
+		},
+		{
+			name:            "Removed",
+			histories:       []extJira.ChangelogHistory{sprintHistory("2023-01-02T10:00:00.000+0000", "7", "")},
+			expectedRemoved: true,
+		},
+		{
+			name: "Added and removed again",
+			histories: []extJira.ChangelogHistory{
+				sprintHistory("2023-01-02T15:00:00.000+0000", "7", ""),
+				sprintHistory("2023-01-02T09:00:00.000+0000", "", "7"),
+			},
+		},
+		{
+			name:      "Another sprint",
+			histories: []extJira.ChangelogHistory{sprintHistory("2023-01-02T10:00:00.000+0000", "", "17")},
+		},
+		{
+			name:      "Before the range",
+			histories: []extJira.ChangelogHistory{sprintHistory("2023-01-01T10:00:00.000+0000", "", "7")},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			added, removed := sprintMembershipChange(tc.histories, timeRange, 7)
+			if added != tc.expectedAdded || removed != tc.expectedRemoved {
+				t.Errorf("Expected added %v and removed %v, got %v and %v", tc.expectedAdded, tc.expectedRemoved, added, removed)
+			}
+		})
+	}
+}
+
+func TestSprintScope_ScopeLine(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		scope    SprintScope
+		expected string
+	}{
+		{
+			name:     "Without points",
+			scope:    SprintScope{Added: []Issue{{Key: "A"}, {Key: "B"}, {Key: "C"}}, Removed: []Issue{{Key: "D"}}},
+			expected: "+3 issues / -1 issue",
+		},
+		{
+			name:     "Points added",
+			scope:    SprintScope{Added: []Issue{{Key: "A"}}, PointsAdded: 8, PointsRemoved: 2.5},
+			expected: "+1 issue / -0 issues, +5.5 points",
+		},
+		{
+			name:     "Points removed",
+			scope:    SprintScope{Removed: []Issue{{Key: "D"}}, PointsRemoved: 3},
+			expected: "+0 issues / -1 issue, -3 points",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if line := tc.scope.ScopeLine(); line != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, line)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_GetSprintScope(t *testing.T) {
+	queryOptions := DefaultQueryOptions()
+	queryOptions.Project = "TEST"
+	queryOptions.StoryPointsField = "customfield_10016"
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: queryOptions, ReportOptions: DefaultReportOptions()})
+	server.Sprints = map[int][]extJira.Sprint{
+		12: {{ID: 6, Name: "Sprint 6", State: "closed"}, {ID: 7, Name: "Sprint 7", State: "active"}},
+	}
+	withPoints := func(key string, points float64, history extJira.ChangelogHistory) extJira.Issue {
+		return extJira.Issue{
+			Key: key,
+			Fields: &extJira.IssueFields{
+				Summary:  key,
+				Status:   &extJira.Status{Name: "To Do"},
+				Unknowns: map[string]interface{}{"customfield_10016": points},
+			},
+			Changelog: &extJira.Changelog{Histories: []extJira.ChangelogHistory{history}},
+		}
+	}
+	server.Issues = []extJira.Issue{
+		withPoints("TEST-1", 5, sprintHistory("2023-01-02T10:00:00.000+0000", nil, "7")),
+		withPoints("TEST-2", 3, sprintHistory("2023-01-02T11:00:00.000+0000", nil, "7")),
+		withPoints("TEST-3", 2, sprintHistory("2023-01-02T12:00:00.000+0000", "7", nil)),
+		withPoints("TEST-4", 1, extJira.ChangelogHistory{Created: "2023-01-02T12:00:00.000+0000"}),
+	}
+
+	scope, err := repo.GetSprintScope(12, TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if scope == nil || scope.Sprint.Name != "Sprint 7" {
+		t.Fatalf("Expected the active sprint, got %+v", scope)
+	}
+	if len(scope.Added) != 2 || len(scope.Removed) != 1 || scope.Removed[0].Key != "TEST-3" {
+		t.Errorf("Expected 2 added and TEST-3 removed, got %+v", scope)
+	}
+	if line := scope.ScopeLine(); line != "+2 issues / -1 issue, +6 points" {
+		t.Errorf("Expected '+2 issues / -1 issue, +6 points', got '%s'", line)
+	}
+
+	search := server.Requests("/rest/api/2/search")[0]
+	if jql := search.Query.Get("jql"); !strings.Contains(jql, `updated >= "2023-01-02 00:00"`) {
+		t.Errorf("Expected the issues updated in the range to be searched, got '%s'", jql)
+	}
+
+	// A board without an active sprint has no scope
+	server.Sprints[12] = server.Sprints[12][:1]
+	if scope, err := repo.GetSprintScope(12, TimeRange{}); err != nil || scope != nil {
+		t.Errorf("Expected no scope without an active sprint, got %+v (%v)", scope, err)
+	}
+}
+
+func TestActivityService_SprintScope(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{}, nil
+		},
+		MockGetSprintScope: func(boardID int, timeRange TimeRange) (*SprintScope, error) {
+			if boardID != 12 {
+				t.Errorf("Expected board 12, got %d", boardID)
+			}
+			return &SprintScope{Sprint: Sprint{ID: 7, Name: "Sprint 7"}, Added: []Issue{{Key: "TEST-1", Summary: "Refunds", Status: "To Do"}}}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.SprintBoardID = 12
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The scope change is reported even without activity of the user
+	result, err := NewMarkdownFormatter().Format(report)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "## Sprint: Sprint 7\n\n_Scope change: +1 issue / -0 issues_\n\n### Added\n\n- [TEST-1] Refunds (To Do)"
+	if !strings.Contains(result.Content, expected) {
+		t.Errorf("Expected content to contain '%s', got '%s'", expected, result.Content)
+	}
+}
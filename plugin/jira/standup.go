@@ -0,0 +1,209 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// reportedEventRetention is how long reported events are remembered, well
+// beyond the overlap of consecutive report ranges
+const reportedEventRetention = 30 * 24 * time.Hour
+
+// StandupStore keeps when the last standup report was generated, so that a
+// report without an explicit range covers everything since then, and which
+// events past reports included, so that they can be left out of the next one
+type StandupStore interface {
+	LastReport() (time.Time, bool, error)
+	RecordReport(at time.Time) error
+	ReportedEvents() (map[string]time.Time, error)
+	RecordEvents(ids []string, at time.Time) error
+}
+
+// standupRecord is the content of the standup store file
+type standupRecord struct {
+	LastReport time.Time            `json:"lastReport"`
+	Reported   map[string]time.Time `json:"reported,omitempty"` // When each event was first reported, by event ID
+}
+
+// FileStandupStore is a StandupStore persisted as a JSON file
+type FileStandupStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStandupStore creates a standup store stored at the given path
+func NewFileStandupStore(path string) *FileStandupStore {
+	return &FileStandupStore{path: path}
+}
+
+// DefaultStandupStorePath returns the default location of the standup store
+func DefaultStandupStorePath() (string, error) {
+	return defaultStorePath(os.UserCacheDir, "standup.json")
+}
+
+// LastReport returns when the last report was generated; the second result is
+// false when no report has been recorded yet
+func (s *FileStandupStore) LastReport() (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.load()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return record.LastReport, !record.LastReport.IsZero(), nil
+}
+
+// RecordReport records when a report was generated and writes the store file
+func (s *FileStandupStore) RecordReport(at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.load()
+	if err != nil {
+		return err
+	}
+	record.LastReport = at
+	return s.save(record)
+}
+
+// ReportedEvents returns when each event included in a past report was first
+// reported, by event ID
+func (s *FileStandupStore) ReportedEvents() (map[string]time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	if record.Reported == nil {
+		record.Reported = make(map[string]time.Time)
+	}
+	return record.Reported, nil
+}
+
+// RecordEvents records the events of a report generated at the given time,
+// forgetting those reported longer ago than the retention, and writes the store file
+func (s *FileStandupStore) RecordEvents(ids []string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.load()
+	if err != nil {
+		return err
+	}
+	if record.Reported == nil {
+		record.Reported = make(map[string]time.Time, len(ids))
+	}
+	for _, id := range ids {
+		if _, ok := record.Reported[id]; !ok {
+			record.Reported[id] = at
+		}
+	}
+	for id, reportedAt := range record.Reported {
+		if at.Sub(reportedAt) > reportedEventRetention {
+			delete(record.Reported, id)
+		}
+	}
+	return s.save(record)
+}
+
+// load reads the store file; a missing file is an empty store
+func (s *FileStandupStore) load() (standupRecord, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return standupRecord{}, nil
+	}
+	if err != nil {
+		return standupRecord{}, fmt.Errorf("failed to read the standup store: %w", err)
+	}
+
+	var record standupRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return standupRecord{}, fmt.Errorf("failed to decode the standup store: %w", err)
+	}
+	return record, nil
+}
+
+// save writes the store file
+func (s *FileStandupStore) save(record standupRecord) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the standup store: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create standup store directory: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to write the standup store: %w", err)
+	}
+	return nil
+}
+
+// SinceLastStandup computes the range of a report generated at now. It starts
+// at the last report when there is one, e.g. since Friday 09:30 on a Monday,
+// and otherwise at the same time on the previous working day, skipping the
+// weekend.
+func SinceLastStandup(lastReport, now time.Time) TimeRange {
+	if lastReport.IsZero() || !lastReport.Before(now) {
+		return TimeRange{Start: previousWorkday(now), End: now}
+	}
+	return TimeRange{Start: lastReport, End: now}
+}
+
+// previousWorkday returns the same time of day on the last weekday before t
+func previousWorkday(t time.Time) time.Time {
+	previous := t.AddDate(0, 0, -1)
+	for previous.Weekday() == time.Saturday || previous.Weekday() == time.Sunday {
+		previous = previous.AddDate(0, 0, -1)
+	}
+	return previous
+}
+
+// SinceLastStandupRange computes the range since the last recorded report,
+// falling back to the previous working day when nothing was recorded or the
+// store cannot be read. A last report longer ago than the maximum span of a
+// range is not refused; the range is cut to the most recent days instead.
+func (s *ActivityService) SinceLastStandupRange(now time.Time) TimeRange {
+	s.mu.RLock()
+	maxDays := s.options.MaxRangeDays
+	s.mu.RUnlock()
+
+	var lastReport time.Time
+	if s.standup != nil {
+		last, ok, err := s.standup.LastReport()
+		if err != nil {
+			s.logger.Printf("failed to read the last standup: %v", err)
+		} else if ok {
+			lastReport = last
+		}
+	}
+	return SinceLastStandup(lastReport, now).limitDays(maxDays)
+}
+
+// recordStandup records the events a report included when they are
+// deduplicated, and the end of the report as the last standup when its range
+// was the one since the last standup, unless the options skip history. A
+// report over an explicit range, such as a past week, leaves the last standup
+// as it was. Failures are logged, since the report itself is complete.
+func (s *ActivityService) recordStandup(timeRange TimeRange, options ReportOptions, eventIDs []string, sinceLastStandup bool) {
+	if s.standup == nil || options.SkipHistory {
+		return
+	}
+	if sinceLastStandup {
+		if err := s.standup.RecordReport(timeRange.End); err != nil {
+			s.logger.Printf("failed to record the standup: %v", err)
+		}
+	}
+	if options.Dedupe {
+		if err := s.standup.RecordEvents(eventIDs, timeRange.End); err != nil {
+			s.logger.Printf("failed to record the reported events: %v", err)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package jira
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+// memoryStandupStore is a StandupStore kept in memory
+type memoryStandupStore struct {
+	last     time.Time
+	reported map[string]time.Time
+}
+
+func (s *memoryStandupStore) LastReport() (time.Time, bool, error) {
+	return s.last, !s.last.IsZero(), nil
+}
+
+func (s *memoryStandupStore) RecordReport(at time.Time) error {
+	s.last = at
+	return nil
+}
+
+func (s *memoryStandupStore) ReportedEvents() (map[string]time.Time, error) {
+	return s.reported, nil
+}
+
+func (s *memoryStandupStore) RecordEvents(ids []string, at time.Time) error {
+	if s.reported == nil {
+		s.reported = make(map[string]time.Time)
+	}
+	for _, id := range ids {
+		s.reported[id] = at
+	}
+	return nil
+}
+
+func TestSinceLastStandup(t *testing.T) {
+	// Monday 2023-01-09 09:30
+	now := time.Date(2023, 1, 9, 9, 30, 0, 0, time.UTC)
+
+	// Setup test cases
+	testCases := []struct {
+		name          string
+		lastReport    time.Time
+		now           time.Time
+		expectedStart time.Time
+	}{
+		{
+			name:          "Since the last report",
+			lastReport:    time.Date(2023, 1, 6, 9, 30, 0, 0, time.UTC),
+			now:           now,
+			expectedStart: time.Date(2023, 1, 6, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:          "No report on a Monday",
+			now:           now,
+			expectedStart: time.Date(2023, 1, 6, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:          "No report midweek",
+			now:           time.Date(2023, 1, 11, 9, 30, 0, 0, time.UTC),
+			expectedStart: time.Date(2023, 1, 10, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:          "Last report in the future",
+			lastReport:    now.Add(time.Hour),
+			now:           now,
+			expectedStart: time.Date(2023, 1, 6, 9, 30, 0, 0, time.UTC),
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeRange := SinceLastStandup(tc.lastReport, tc.now)
+
+			if !timeRange.Start.Equal(tc.expectedStart) {
+				t.Errorf("Expected start %v, got %v", tc.expectedStart, timeRange.Start)
+			}
+			if !timeRange.End.Equal(tc.now) {
+				t.Errorf("Expected end %v, got %v", tc.now, timeRange.End)
+			}
+		})
+	}
+}
+
+func TestFileStandupStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daiv-jira", "standup.json")
+	store := NewFileStandupStore(path)
+
+	if _, ok, err := store.LastReport(); err != nil || ok {
+		t.Fatalf("Expected an empty store, got %v, %v", ok, err)
+	}
+
+	at := time.Date(2023, 1, 6, 9, 30, 0, 0, time.UTC)
+	if err := store.RecordReport(at); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A new store reads the recorded time back from the file
+	last, ok, err := NewFileStandupStore(path).LastReport()
+	if err != nil || !ok || !last.Equal(at) {
+		t.Errorf("Expected %v, got %v (%v, %v)", at, last, ok, err)
+	}
+
+	// Events are kept with the last report and forgotten after the retention
+	if err := store.RecordEvents([]string{"comment:TEST-1:100"}, at); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	later := at.Add(reportedEventRetention + time.Hour)
+	if err := store.RecordEvents([]string{"comment:TEST-1:101"}, later); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	reported, err := store.ReportedEvents()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := reported["comment:TEST-1:100"]; ok || len(reported) != 1 {
+		t.Errorf("Expected only the recent event, got %v", reported)
+	}
+	if last, _, _ := store.LastReport(); !last.Equal(at) {
+		t.Errorf("Expected the last report to be kept, got %v", last)
+	}
+
+	// A corrupt file is reported rather than silently reset
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, _, err := store.LastReport(); err == nil {
+		t.Errorf("Expected an error for a corrupt store but got nil")
+	}
+}
+
+func TestActivityService_SinceLastStandup(t *testing.T) {
+	lastReport := time.Now().Add(-20 * time.Hour).Truncate(time.Minute)
+
+	// Setup test cases
+	testCases := []struct {
+		name           string
+		timeRange      plugin.TimeRange
+		skipHistory    bool
+		expectedStart  time.Time
+		expectRecorded bool
+	}{
+		{
+			name:           "Zero range starts at the last report",
+			expectedStart:  lastReport,
+			expectRecorded: true,
+		},
+		{
+			name: "Explicit range is kept and not recorded",
+			timeRange: plugin.TimeRange{
+				Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			expectedStart: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "Skipped history is not recorded",
+			skipHistory:   true,
+			expectedStart: lastReport,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var queried TimeRange
+			mockRepo := &MockJiraRepository{
+				MockGetUser: func() (*User, error) {
+					return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+				},
+				MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+					queried = timeRange
+					return []Issue{}, nil
+				},
+			}
+			store := &memoryStandupStore{last: lastReport}
+
+			options := DefaultReportOptions()
+			options.SkipHistory = tc.skipHistory
+
+			service := NewActivityService(mockRepo)
+			service.SetReportOptions(options)
+			service.SetStandupStore(store)
+
+			report, err := service.GetActivityReport(tc.timeRange)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !queried.Start.Equal(tc.expectedStart) || !report.TimeRange.Start.Equal(tc.expectedStart) {
+				t.Errorf("Expected the range to start at %v, got %v", tc.expectedStart, queried.Start)
+			}
+			if recorded := !store.last.Equal(lastReport); recorded != tc.expectRecorded {
+				t.Errorf("Expected recorded %v, got last report %v", tc.expectRecorded, store.last)
+			}
+			if tc.expectRecorded && !store.last.Equal(report.TimeRange.End) {
+				t.Errorf("Expected the report end %v to be recorded, got %v", report.TimeRange.End, store.last)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VelocityStats summarizes the work completed in one report window
+type VelocityStats struct {
+	WindowStart      time.Time     `json:"windowStart"`
+	WindowEnd        time.Time     `json:"windowEnd"`
+	IssuesCompleted  int           `json:"issuesCompleted"`
+	PointsCompleted  float64       `json:"pointsCompleted"`
+	AverageCycleTime time.Duration `json:"averageCycleTime"` // Zero when no cycle time was measured
+}
+
+// StatsBlock holds the statistics of the report window and of the windows before it
+type StatsBlock struct {
+	Current  VelocityStats
+	Trailing []VelocityStats // Most recent first
+}
+
+// Windows returns the current window followed by the trailing windows
+func (b *StatsBlock) Windows() []VelocityStats {
+	return append([]VelocityStats{b.Current}, b.Trailing...)
+}
+
+// ComputeVelocity counts the issues that moved into a done status within the
+// time range, their story points and their average cycle time. The cycle time
+// runs from the first move into an in-progress status to the move into done.
+func ComputeVelocity(issues []Issue, timeRange TimeRange, options ReportOptions) VelocityStats {
+	stats := VelocityStats{WindowStart: timeRange.Start, WindowEnd: timeRange.End}
+
+	var totalCycleTime time.Duration
+	measured := 0
+	for _, issue := range issues {
+		completedAt, ok := completionTime(issue, options.DoneStatuses)
+		if !ok || !timeRange.IsInRange(completedAt) {
+			continue
+		}
+
+		stats.IssuesCompleted++
+		stats.PointsCompleted += issue.StoryPoints
+
+		if cycleTime, ok := cycleTime(statusChanges(issue), options.InProgressStatuses, completedAt); ok {
+			totalCycleTime += cycleTime
+			measured++
+		}
+	}
+
+	if measured > 0 {
+		stats.AverageCycleTime = totalCycleTime / time.Duration(measured)
+	}
+	return stats
+}
+
+// MeasureIssueTimes sets the cycle time and lead time of each completed issue.
+// The lead time runs from the issue's creation to the move into done.
+func MeasureIssueTimes(issues []Issue, options ReportOptions) {
+	for i := range issues {
+		completedAt, ok := completionTime(issues[i], options.DoneStatuses)
+		if !ok {
+			continue
+		}
+
+		if cycleTime, ok := cycleTime(statusChanges(issues[i]), options.InProgressStatuses, completedAt); ok {
+			issues[i].CycleTime = cycleTime
+		}
+		if created := issues[i].Created; !created.IsZero() && created.Before(completedAt) {
+			issues[i].LeadTime = completedAt.Sub(created)
+		}
+	}
+}
+
+// measuredIssues returns the issues with a cycle time or lead time
+func measuredIssues(issues []Issue) []Issue {
+	result := make([]Issue, 0)
+	for _, issue := range issues {
+		if issue.CycleTime > 0 || issue.LeadTime > 0 {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// statusChanges returns the changes to read status transitions from: the full
+// status history when it was fetched, otherwise the changes in the report range
+func statusChanges(issue Issue) []Change {
+	if issue.StatusHistory != nil {
+		return issue.StatusHistory
+	}
+	return issue.Changes
+}
+
+// completionTime returns when an issue last moved into one of the done statuses
+func completionTime(issue Issue, doneStatuses []string) (time.Time, bool) {
+	var completedAt time.Time
+	found := false
+	for _, change := range statusChanges(issue) {
+		if !strings.EqualFold(change.Field, statusField) || !containsFold(doneStatuses, change.ToValue) {
+			continue
+		}
+		if !found || change.Timestamp.After(completedAt) {
+			completedAt = change.Timestamp
+			found = true
+		}
+	}
+	return completedAt, found
+}
+
+// cycleTime returns the time between the first move into an in-progress status
+// and the completion
+func cycleTime(changes []Change, inProgressStatuses []string, completedAt time.Time) (time.Duration, bool) {
+	var startedAt time.Time
+	found := false
+	for _, change := range changes {
+		if !strings.EqualFold(change.Field, statusField) || !containsFold(inProgressStatuses, change.ToValue) {
+			continue
+		}
+		if change.Timestamp.After(completedAt) {
+			continue
+		}
+		if !found || change.Timestamp.Before(startedAt) {
+			startedAt = change.Timestamp
+			found = true
+		}
+	}
+	if !found {
+		return 0, false
+	}
+	return completedAt.Sub(startedAt), true
+}
+
+// formatDuration renders a duration in days and hours, e.g. "2d 4h" or "45m"
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "n/a"
+	}
+
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// formatPoints renders story points without a trailing ".0" for whole numbers
+func formatPoints(points float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", points), ".0")
+}
+
+// WindowLabel renders the window dates, e.g. "2023-01-01 – 2023-01-08"
+func (s VelocityStats) WindowLabel() string {
+	return fmt.Sprintf("%s – %s", s.WindowStart.Format("2006-01-02"), s.WindowEnd.Format("2006-01-02"))
+}
+
+// StatsStore keeps the statistics of past report windows so that trailing
+// windows can be shown alongside the current one
+type StatsStore interface {
+	Save(stats VelocityStats) error
+	Trailing(before time.Time, count int) ([]VelocityStats, error)
+}
+
+// FileStatsStore is a StatsStore persisted as a JSON file
+type FileStatsStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStatsStore creates a stats store stored at the given path
+func NewFileStatsStore(path string) *FileStatsStore {
+	return &FileStatsStore{path: path}
+}
+
+// DefaultStatsStorePath returns the default location of the stats store
+func DefaultStatsStorePath() (string, error) {
+	return defaultStorePath(os.UserCacheDir, "stats.json")
+}
+
+// Save records the statistics of a window, replacing any earlier record of it
+func (s *FileStatsStore) Save(stats VelocityStats) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	windows, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, window := range windows {
+		if window.WindowStart.Equal(stats.WindowStart) && window.WindowEnd.Equal(stats.WindowEnd) {
+			windows[i] = stats
+			replaced = true
+		}
+	}
+	if !replaced {
+		windows = append(windows, stats)
+	}
+
+	data, err := json.MarshalIndent(windows, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode stats: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create stats directory: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to write stats: %w", err)
+	}
+	return nil
+}
+
+// Trailing returns up to count recorded windows that ended at or before the
+// given time, most recent first
+func (s *FileStatsStore) Trailing(before time.Time, count int) ([]VelocityStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	windows, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]VelocityStats, 0, count)
+	for _, window := range windows {
+		if !window.WindowEnd.After(before) {
+			result = append(result, window)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].WindowEnd.After(result[j].WindowEnd)
+	})
+	if len(result) > count {
+		result = result[:count]
+	}
+	return result, nil
+}
+
+// load reads the recorded windows; a missing file is an empty store
+func (s *FileStatsStore) load() ([]VelocityStats, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stats: %w", err)
+	}
+
+	var windows []VelocityStats
+	if err := json.Unmarshal(data, &windows); err != nil {
+		return nil, fmt.Errorf("failed to decode stats: %w", err)
+	}
+	return windows, nil
+}
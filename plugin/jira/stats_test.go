@@ -0,0 +1,285 @@
+package jira
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+// statusChange returns a status change into the given status at the given time
+func statusChange(to string, at time.Time) Change {
+	return Change{Field: "status", ToValue: to, Timestamp: at}
+}
+
+func TestComputeVelocity(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2023, 1, d, h, 0, 0, 0, time.UTC) }
+	timeRange := TimeRange{Start: day(2, 0), End: day(9, 0)}
+
+	// Setup test cases
+	testCases := []struct {
+		name              string
+		issues            []Issue
+		expectedIssues    int
+		expectedPoints    float64
+		expectedCycleTime time.Duration
+	}{
+		{
+			name: "Completed issues with cycle times",
+			issues: []Issue{
+				{Key: "JIRA-1", StoryPoints: 3, Changes: []Change{
+					statusChange("In Progress", day(3, 9)),
+					statusChange("Done", day(4, 9)),
+				}},
+				{Key: "JIRA-2", StoryPoints: 2.5, Changes: []Change{
+					statusChange("In Progress", day(1, 9)),
+					statusChange("Code Review", day(2, 9)),
+					statusChange("resolved", day(4, 9)),
+				}},
+			},
+			expectedIssues:    2,
+			expectedPoints:    5.5,
+			expectedCycleTime: 48 * time.Hour,
+		},
+		{
+			name: "Issue without an in-progress change counts without a cycle time",
+			issues: []Issue{
+				{Key: "JIRA-1", StoryPoints: 1, Changes: []Change{statusChange("Done", day(4, 9))}},
+			},
+			expectedIssues:    1,
+			expectedPoints:    1,
+			expectedCycleTime: 0,
+		},
+		{
+			name: "Issues completed outside the window or not completed are skipped",
+			issues: []Issue{
+				{Key: "JIRA-1", StoryPoints: 5, Changes: []Change{statusChange("Done", day(1, 9))}},
+				{Key: "JIRA-2", StoryPoints: 8, Changes: []Change{statusChange("In Progress", day(4, 9))}},
+			},
+			expectedIssues:    0,
+			expectedPoints:    0,
+			expectedCycleTime: 0,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stats := ComputeVelocity(tc.issues, timeRange, DefaultReportOptions())
+
+			if stats.IssuesCompleted != tc.expectedIssues {
+				t.Errorf("Expected %d issues completed, got %d", tc.expectedIssues, stats.IssuesCompleted)
+			}
+			if stats.PointsCompleted != tc.expectedPoints {
+				t.Errorf("Expected %.1f points completed, got %.1f", tc.expectedPoints, stats.PointsCompleted)
+			}
+			if stats.AverageCycleTime != tc.expectedCycleTime {
+				t.Errorf("Expected average cycle time %v, got %v", tc.expectedCycleTime, stats.AverageCycleTime)
+			}
+			if !stats.WindowStart.Equal(timeRange.Start) || !stats.WindowEnd.Equal(timeRange.End) {
+				t.Errorf("Expected the report window, got %s", stats.WindowLabel())
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{duration: 0, expected: "n/a"},
+		{duration: 45 * time.Minute, expected: "45m"},
+		{duration: 3*time.Hour + 20*time.Minute, expected: "3h 20m"},
+		{duration: 48 * time.Hour, expected: "2d"},
+		{duration: 52 * time.Hour, expected: "2d 4h"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if result := formatDuration(tc.duration); result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestFileStatsStore(t *testing.T) {
+	week := func(n int) VelocityStats {
+		start := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*n)
+		return VelocityStats{WindowStart: start, WindowEnd: start.AddDate(0, 0, 7), IssuesCompleted: n}
+	}
+
+	path := filepath.Join(t.TempDir(), "daiv-jira", "stats.json")
+	store := NewFileStatsStore(path)
+
+	trailing, err := store.Trailing(week(0).WindowStart, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(trailing) != 0 {
+		t.Errorf("Expected an empty store, got %+v", trailing)
+	}
+
+	for n := 0; n < 4; n++ {
+		if err := store.Save(week(n)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// Saving a window again replaces it
+	updated := week(2)
+	updated.IssuesCompleted = 7
+	if err := store.Save(updated); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A new store reads the same file
+	trailing, err = NewFileStatsStore(path).Trailing(week(3).WindowStart, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(trailing) != 2 {
+		t.Fatalf("Expected 2 trailing windows, got %d", len(trailing))
+	}
+	if !trailing[0].WindowStart.Equal(week(2).WindowStart) || trailing[0].IssuesCompleted != 7 {
+		t.Errorf("Expected the updated window 2 first, got %+v", trailing[0])
+	}
+	if !trailing[1].WindowStart.Equal(week(1).WindowStart) {
+		t.Errorf("Expected window 1 second, got %+v", trailing[1])
+	}
+}
+
+func TestActivityService_Stats(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{Key: "JIRA-1", Summary: "Shipped", Status: "Done", StoryPoints: 3, Changes: []Change{
+					statusChange("In Progress", time.Date(2023, 1, 9, 9, 0, 0, 0, time.UTC)),
+					statusChange("Done", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC)),
+				}},
+			}, nil
+		},
+	}
+
+	store := NewFileStatsStore(filepath.Join(t.TempDir(), "stats.json"))
+	previous := VelocityStats{
+		WindowStart:     time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		WindowEnd:       time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC),
+		IssuesCompleted: 4,
+		PointsCompleted: 10,
+	}
+	if err := store.Save(previous); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	options := DefaultReportOptions()
+	options.IncludeStats = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+	service.SetStatsStore(store)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 16, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if report.Stats == nil {
+		t.Fatal("Expected stats in the report")
+	}
+	if report.Stats.Current.IssuesCompleted != 1 || report.Stats.Current.PointsCompleted != 3 {
+		t.Errorf("Expected 1 issue and 3 points completed, got %+v", report.Stats.Current)
+	}
+	if len(report.Stats.Trailing) != 1 || report.Stats.Trailing[0].PointsCompleted != 10 {
+		t.Errorf("Expected the previous window as trailing, got %+v", report.Stats.Trailing)
+	}
+
+	// The current window is recorded for later reports
+	recorded, err := store.Trailing(time.Date(2023, 1, 16, 0, 0, 0, 0, time.UTC), 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(recorded) != 1 || recorded[0].IssuesCompleted != 1 {
+		t.Errorf("Expected the current window to be recorded, got %+v", recorded)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		expected  []string
+	}{
+		{formatter: NewMarkdownFormatter(), expected: []string{"## Stats", "| 2023-01-09 – 2023-01-16 | 1 | 3 | 1d |", "| 2023-01-02 – 2023-01-09 | 4 | 10 | n/a |"}},
+		{formatter: NewHTMLFormatter(), expected: []string{"<table class=\"stats\">", "<td>2023-01-09 – 2023-01-16</td><td>1</td><td>3</td><td>1d</td>"}},
+		{formatter: NewJSONFormatter(), expected: []string{`"stats": {`, `"averageCycleTimeHours": 24`, `"pointsCompleted": 10`}},
+		{formatter: NewXMLFormatter(), expected: []string{`<window start="2023-01-09" end="2023-01-16" current="true">`, `<points_completed>10</points_completed>`}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected content to contain '%s', got '%s'", expected, result.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestActivityService_StatsSkipHistory(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{
+				{Key: "JIRA-1", Summary: "Shipped", Status: "Done", StoryPoints: 3, Changes: []Change{
+					statusChange("Done", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC)),
+				}},
+			}, nil
+		},
+	}
+
+	store := NewFileStatsStore(filepath.Join(t.TempDir(), "stats.json"))
+	service := NewActivityService(mockRepo)
+	service.SetStatsStore(store)
+
+	options := DefaultReportOptions()
+	options.IncludeStats = true
+	options.SkipHistory = true
+
+	report, err := service.GetActivityReportWithOptions(plugin.TimeRange{
+		Start: time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 16, 0, 0, 0, 0, time.UTC),
+	}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if report.Stats == nil || report.Stats.Current.IssuesCompleted != 1 {
+		t.Errorf("Expected the stats of the window in the report, got %+v", report.Stats)
+	}
+
+	// The window is not recorded for later reports
+	recorded, err := store.Trailing(time.Date(2023, 1, 16, 0, 0, 0, 0, time.UTC), 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(recorded) != 0 {
+		t.Errorf("Expected nothing to be recorded, got %+v", recorded)
+	}
+}
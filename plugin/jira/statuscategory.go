@@ -0,0 +1,62 @@
+package jira
+
+import (
+	"fmt"
+	"html"
+)
+
+// Status categories, which Jira sorts every status into, by the key Jira
+// gives them
+const (
+	StatusCategoryToDo       = "new"
+	StatusCategoryInProgress = "indeterminate"
+	StatusCategoryDone       = "done"
+)
+
+// statusCategoryStyle is how statuses of a category are shown: in the colors
+// of the lozenge Jira shows them in, or with a Slack emoji of the same color
+type statusCategoryStyle struct {
+	Background string
+	Text       string
+	Emoji      string
+}
+
+// statusCategories lists the categories in the order of the workflow
+var statusCategories = []string{StatusCategoryToDo, StatusCategoryInProgress, StatusCategoryDone}
+
+// statusCategoryStyles are Jira's standard colors for each status category:
+// gray for to do, blue for in progress and green for done
+var statusCategoryStyles = map[string]statusCategoryStyle{
+	StatusCategoryToDo:       {Background: "#DFE1E6", Text: "#42526E", Emoji: ":white_circle:"},
+	StatusCategoryInProgress: {Background: "#DEEBFF", Text: "#0747A6", Emoji: ":large_blue_circle:"},
+	StatusCategoryDone:       {Background: "#E3FCEF", Text: "#006644", Emoji: ":large_green_circle:"},
+}
+
+// htmlStatusBadgeStyles returns the style rules of the status badges of
+// each category
+func htmlStatusBadgeStyles() string {
+	rules := ".status-badge { border-radius: 3px; padding: 0 4px; font-size: 0.85em; font-weight: bold; text-transform: uppercase; }\n"
+	for _, category := range statusCategories {
+		style := statusCategoryStyles[category]
+		rules += fmt.Sprintf(".status-%s { background-color: %s; color: %s; }\n", category, style.Background, style.Text)
+	}
+	return rules
+}
+
+// htmlStatusBadge renders a status as a badge in the colors of its category,
+// or as plain text when the category is not known
+func htmlStatusBadge(status, category string) string {
+	if _, ok := statusCategoryStyles[category]; !ok {
+		return html.EscapeString(status)
+	}
+	return fmt.Sprintf("<span class=\"status-badge status-%s\">%s</span>", category, html.EscapeString(status))
+}
+
+// slackStatusEmoji returns the emoji of a status category followed by a
+// space, or nothing when the category is not known
+func slackStatusEmoji(category string) string {
+	if style, ok := statusCategoryStyles[category]; ok {
+		return style.Emoji + " "
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestHTMLStatusBadge(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		status   string
+		category string
+		expected string
+	}{
+		{
+			name:     "To do",
+			status:   "Open",
+			category: StatusCategoryToDo,
+			expected: `<span class="status-badge status-new">Open</span>`,
+		},
+		{
+			name:     "Done, escaped",
+			status:   "Done <QA>",
+			category: StatusCategoryDone,
+			expected: `<span class="status-badge status-done">Done &lt;QA&gt;</span>`,
+		},
+		{
+			name:     "Unknown category",
+			status:   "In Progress",
+			category: "",
+			expected: "In Progress",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := htmlStatusBadge(tc.status, tc.category); result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+
+	// The badge styles cover every category
+	styles := htmlStatusBadgeStyles()
+	for _, category := range statusCategories {
+		if !strings.Contains(styles, ".status-"+category+" ") {
+			t.Errorf("Expected a style for %s, got:\n%s", category, styles)
+		}
+	}
+}
+
+func TestSlackStatusEmoji(t *testing.T) {
+	expected := map[string]string{
+		StatusCategoryToDo:       ":white_circle: ",
+		StatusCategoryInProgress: ":large_blue_circle: ",
+		StatusCategoryDone:       ":large_green_circle: ",
+		"undefined":              "",
+	}
+	for category, emoji := range expected {
+		if result := slackStatusEmoji(category); result != emoji {
+			t.Errorf("Expected %q for %q, got %q", emoji, category, result)
+		}
+	}
+}
+
+func TestJiraAPIRepository_GetIssues_StatusCategory(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: DefaultQueryOptions(), ReportOptions: DefaultReportOptions()})
+	server.Issues = []extJira.Issue{
+		{
+			Key: "JIRA-1",
+			Fields: &extJira.IssueFields{
+				Summary: "Categorized",
+				Status:  &extJira.Status{Name: "In Review", StatusCategory: extJira.StatusCategory{Key: "indeterminate", ColorName: "yellow"}},
+				Comments: &extJira.Comments{Comments: []*extJira.Comment{
+					{ID: "1", Created: "2023-01-01T10:00:00.000+0000", Author: extJira.User{AccountID: "user123", DisplayName: "Test User"}, Body: "Ready"},
+				}},
+			},
+		},
+	}
+
+	issues, _, err := repo.GetIssues(TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].StatusCategory != StatusCategoryInProgress {
+		t.Fatalf("Expected the issue in the in-progress category, got %+v", issues)
+	}
+}
@@ -0,0 +1,50 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// statusSince returns when the issue entered its current status: the last
+// status change into it, or its creation when its status never changed. The
+// status history must be ordered oldest first; a zero time means unknown.
+func statusSince(history []Change, status string, created time.Time) time.Time {
+	for i := len(history) - 1; i >= 0; i-- {
+		if strings.EqualFold(history[i].ToValue, status) {
+			return history[i].Timestamp
+		}
+	}
+	if len(history) == 0 {
+		return created
+	}
+	return time.Time{}
+}
+
+// statusSinceLine tells how long an issue has been in its status relative to
+// the end of the report, in the user's time zone: the weekday within the last
+// week, e.g. "In Review since Tue", and the date before that, e.g.
+// "In Review since Mar 3". It is empty when the time is unknown.
+func statusSinceLine(issue Issue, reference time.Time, timeZone string) string {
+	if issue.StatusSince.IsZero() || issue.Status == "" {
+		return ""
+	}
+
+	since := issue.StatusSince
+	if timeZone != "" {
+		if loc, err := loadLocation(timeZone); err == nil {
+			since, reference = since.In(loc), reference.In(loc)
+		}
+	}
+
+	var when string
+	switch {
+	case reference.Sub(since) < 6*24*time.Hour:
+		when = since.Format("Mon")
+	case since.Year() == reference.Year():
+		when = since.Format("Jan 2")
+	default:
+		when = since.Format("Jan 2, 2006")
+	}
+	return fmt.Sprintf("%s since %s", issue.Status, when)
+}
@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestStatusSince(t *testing.T) {
+	created := time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)
+	history := []Change{
+		{Timestamp: time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC), FromValue: "Open", ToValue: "In Review"},
+		{Timestamp: time.Date(2023, 1, 3, 9, 0, 0, 0, time.UTC), FromValue: "In Review", ToValue: "In Progress"},
+		{Timestamp: time.Date(2023, 1, 4, 9, 0, 0, 0, time.UTC), FromValue: "In Progress", ToValue: "In Review"},
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		history  []Change
+		status   string
+		expected time.Time
+	}{
+		{name: "Last change into the status", history: history, status: "in review", expected: history[2].Timestamp},
+		{name: "Never changed status", history: nil, status: "Open", expected: created},
+		{name: "Change into the status missing", history: history, status: "Done", expected: time.Time{}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if since := statusSince(tc.history, tc.status, created); !since.Equal(tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, since)
+			}
+		})
+	}
+}
+
+func TestStatusSinceLine(t *testing.T) {
+	reference := time.Date(2023, 3, 10, 17, 0, 0, 0, time.UTC) // A Friday
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		since    time.Time
+		timeZone string
+		expected string
+	}{
+		{name: "Within the last week", since: time.Date(2023, 3, 7, 9, 0, 0, 0, time.UTC), expected: "In Review since Tue"},
+		{name: "Earlier this year", since: time.Date(2023, 2, 20, 9, 0, 0, 0, time.UTC), expected: "In Review since Feb 20"},
+		{name: "Previous year", since: time.Date(2022, 12, 20, 9, 0, 0, 0, time.UTC), expected: "In Review since Dec 20, 2022"},
+		{name: "In the user's time zone", since: time.Date(2023, 3, 7, 23, 0, 0, 0, time.UTC), timeZone: "Asia/Tokyo", expected: "In Review since Wed"},
+		{name: "Unknown", since: time.Time{}, expected: ""},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			issue := Issue{Key: "TEST-1", Status: "In Review", StatusSince: tc.since}
+			if line := statusSinceLine(issue, reference, tc.timeZone); line != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, line)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_GetIssues_StatusSince(t *testing.T) {
+	reportOptions := DefaultReportOptions()
+	reportOptions.IncludeOthersChanges = true
+	repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: DefaultQueryOptions(), ReportOptions: reportOptions})
+	server.Issues = []extJira.Issue{{
+		Key:    "TEST-1",
+		Fields: &extJira.IssueFields{Summary: "Issue", Status: &extJira.Status{Name: "In Review"}},
+		Changelog: &extJira.Changelog{Histories: []extJira.ChangelogHistory{
+			statusHistoryEntry(time.Date(2022, 12, 20, 9, 0, 0, 0, time.UTC), "Open", "In Progress"),
+			statusHistoryEntry(time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC), "In Progress", "In Review"),
+		}},
+	}}
+
+	issues, _, err := repo.GetIssues(TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)
+	if len(issues) != 1 || !issues[0].StatusSince.Equal(expected) {
+		t.Errorf("Expected the issue to be in review since %v, got %+v", expected, issues)
+	}
+}
@@ -0,0 +1,22 @@
+package jira
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// storeDirName is the directory the local stores are kept in, within the
+// user cache or config directory
+const storeDirName = "daiv-jira"
+
+// defaultStorePath returns the default location of a local store: the file
+// name within the plugin's directory of the user directory dirFunc locates,
+// such as os.UserCacheDir for state that can be rebuilt and os.UserConfigDir
+// for what the user entered
+func defaultStorePath(dirFunc func() (string, error), name string) (string, error) {
+	dir, err := dirFunc()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the user directory for %s: %w", name, err)
+	}
+	return filepath.Join(dir, storeDirName, name), nil
+}
@@ -0,0 +1,48 @@
+package jira
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultStorePath(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		dirFunc     func() (string, error)
+		expected    string
+		expectError string
+	}{
+		{
+			name:     "Within the plugin directory",
+			dirFunc:  func() (string, error) { return filepath.Join("home", ".cache"), nil },
+			expected: filepath.Join("home", ".cache", "daiv-jira", "standup.json"),
+		},
+		{
+			name:        "Directory not located",
+			dirFunc:     func() (string, error) { return "", errors.New("$HOME is not defined") },
+			expectError: "failed to locate the user directory for standup.json: $HOME is not defined",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := defaultStorePath(tc.dirFunc, "standup.json")
+			if tc.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+					t.Errorf("Expected an error containing '%s', got %v", tc.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if path != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, path)
+			}
+		})
+	}
+}
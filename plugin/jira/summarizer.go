@@ -0,0 +1,34 @@
+package jira
+
+// Summarizer condenses an issue's raw activity into a one-line summary.
+// The daiv host can wire an LLM-backed implementation so reports carry
+// pre-structured summaries instead of raw comment dumps.
+type Summarizer interface {
+	Summarize(issue Issue) (string, error)
+}
+
+// NoopSummarizer is the default Summarizer and never produces a summary
+type NoopSummarizer struct{}
+
+// NewNoopSummarizer creates a new no-op summarizer
+func NewNoopSummarizer() *NoopSummarizer {
+	return &NoopSummarizer{}
+}
+
+// Summarize returns an empty summary
+func (s *NoopSummarizer) Summarize(issue Issue) (string, error) {
+	return "", nil
+}
+
+// SummarizerFunc adapts an ordinary function to the Summarizer interface
+type SummarizerFunc func(issue Issue) (string, error)
+
+// Summarize calls f(issue)
+func (f SummarizerFunc) Summarize(issue Issue) (string, error) {
+	return f(issue)
+}
+
+// showSummaryOnly reports whether an issue should be rendered as its summary line only
+func showSummaryOnly(report *ActivityReport, issue Issue) bool {
+	return report.Options.SummaryOnly && issue.ActivitySummary != ""
+}
@@ -0,0 +1,117 @@
+package jira
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestActivityService_Summarizer(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name            string
+		summarizer      Summarizer
+		expectedSummary string
+	}{
+		{
+			name:            "Default no-op summarizer",
+			summarizer:      nil,
+			expectedSummary: "",
+		},
+		{
+			name: "Custom summarizer",
+			summarizer: SummarizerFunc(func(issue Issue) (string, error) {
+				return "Moved " + issue.Key + " to review", nil
+			}),
+			expectedSummary: "Moved JIRA-123 to review",
+		},
+		{
+			name: "Failing summarizer falls back to no summary",
+			summarizer: SummarizerFunc(func(issue Issue) (string, error) {
+				return "", errors.New("model unavailable")
+			}),
+			expectedSummary: "",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := &MockJiraRepository{
+				MockGetUser: func() (*User, error) {
+					return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+				},
+				MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+					return []Issue{{Key: "JIRA-123", Summary: "Test Issue", Status: "In Progress"}}, nil
+				},
+			}
+
+			service := NewActivityService(mockRepo)
+			service.SetSummarizer(tc.summarizer)
+
+			report, err := service.GetActivityReport(plugin.TimeRange{
+				Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if report.Issues[0].ActivitySummary != tc.expectedSummary {
+				t.Errorf("Expected summary '%s', got '%s'", tc.expectedSummary, report.Issues[0].ActivitySummary)
+			}
+		})
+	}
+}
+
+func TestFormatters_SummaryOnly(t *testing.T) {
+	report := &ActivityReport{
+		TimeRange: TimeRange{
+			Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		User: User{DisplayName: "Test User", Email: "test@example.com"},
+		Issues: []Issue{
+			{
+				Key:             "JIRA-123",
+				Summary:         "Test Issue",
+				Status:          "In Progress",
+				ActivitySummary: "Reviewed the parser fix",
+				Comments: []Comment{
+					{
+						Timestamp: time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC),
+						Author:    "Test User",
+						Content:   "This is a raw comment",
+					},
+				},
+			},
+		},
+		Options: ReportOptions{SummaryOnly: true},
+	}
+
+	formatters := []ReportFormatter{
+		NewXMLFormatter(),
+		NewJSONFormatter(),
+		NewMarkdownFormatter(),
+		NewHTMLFormatter(),
+	}
+
+	for _, formatter := range formatters {
+		t.Run(formatter.Name(), func(t *testing.T) {
+			result, err := formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !strings.Contains(result.Content, "Reviewed the parser fix") {
+				t.Errorf("Expected content to contain the activity summary, got '%s'", result.Content)
+			}
+			if strings.Contains(result.Content, "This is a raw comment") {
+				t.Errorf("Expected raw comments to be omitted in summary-only mode, got '%s'", result.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package jira
+
+import (
+	"fmt"
+	"time"
+)
+
+// SupplementaryQuery identifies an additional query whose issues are reported
+// in their own section regardless of activity in the time range
+type SupplementaryQuery string
+
+const (
+	// SupplementaryCarryOver finds work still assigned to the user in an active status
+	SupplementaryCarryOver SupplementaryQuery = "carry_over"
+
+	// SupplementaryFlagged finds issues flagged as impediments in the project
+	SupplementaryFlagged SupplementaryQuery = "flagged"
+
+	// SupplementaryRelease finds the issues of the release version, whoever worked on them
+	SupplementaryRelease SupplementaryQuery = "release"
+
+	// SupplementaryReleaseBaseline finds the issues of the release compared with
+	SupplementaryReleaseBaseline SupplementaryQuery = "release_baseline"
+
+	// SupplementaryReleaseRange finds the issues open at any time within the
+	// time range, whoever worked on them, to compare its start with its end
+	SupplementaryReleaseRange SupplementaryQuery = "release_range"
+
+	// SupplementaryFiled finds the issues the user created within the time range
+	SupplementaryFiled SupplementaryQuery = "filed"
+
+	// SupplementaryEscalated finds the issues whose priority changed within the
+	// time range, whoever they are assigned to
+	SupplementaryEscalated SupplementaryQuery = "escalated"
+
+	// SupplementaryHandoffs finds the issues assigned to or away from the user
+	// within the time range
+	SupplementaryHandoffs SupplementaryQuery = "handoffs"
+
+	// SupplementaryDueSoon finds the open issues assigned to the user that are
+	// overdue or due within the horizon after the time range, earliest first
+	SupplementaryDueSoon SupplementaryQuery = "due_soon"
+
+	// SupplementaryTriage finds the bugs and incidents created within the time
+	// range, whoever they are assigned to, highest priority first
+	SupplementaryTriage SupplementaryQuery = "triage"
+)
+
+const (
+	// jqlDateLayout formats a JQL date without the time of day
+	jqlDateLayout = "2006-01-02"
+	// jqlMinuteLayout formats a JQL date with the time of day
+	jqlMinuteLayout = "2006-01-02 15:04"
+)
+
+// buildSupplementaryJQLQuery builds the JQL for a supplementary query
+func (r *JiraAPIRepository) buildSupplementaryJQLQuery(kind SupplementaryQuery, timeRange TimeRange) (string, error) {
+	var query jqlBuilder
+	opts := r.config.QueryOptions
+	// Searched to the minute around the range; GetSupplementaryIssues keeps
+	// the issues within the exact range
+	fromTime, toTime := opts.jqlRange(timeRange)
+
+	if len(opts.Projects) > 1 {
+		// Jira rejects the whole query for one project it refuses
+		query.In("project", r.refused.without(opts.Projects))
+	} else {
+		query.Raw(fmt.Sprintf("project = %s", QuoteJQL(opts.Project)))
+	}
+
+	switch kind {
+	case SupplementaryCarryOver:
+		// Open work on the user's plate, whether or not it was touched recently
+		query.Raw("assignee = currentUser()")
+		query.In("status", opts.CarryOverStatuses)
+	case SupplementaryFlagged:
+		// Flags are Jira's impediment signal and bypass every other filter
+		query.Raw("Flagged IS NOT EMPTY")
+	case SupplementaryFiled:
+		// Filing an issue is activity even when it is assigned to someone else
+		query.Raw("creator = currentUser()")
+		query.Raw(fmt.Sprintf("created >= %s", QuoteJQL(fromTime)))
+		query.Raw(fmt.Sprintf("created < %s", QuoteJQL(toTime)))
+	case SupplementaryEscalated:
+		// Jira cannot compare priorities in JQL, so every change is fetched and
+		// the escalations are picked from the changelog
+		query.Raw(fmt.Sprintf("priority CHANGED DURING (%s, %s)", QuoteJQL(fromTime), QuoteJQL(toTime)))
+	case SupplementaryHandoffs:
+		// Work handed away is no longer assigned to the user, so the main query misses it
+		during := fmt.Sprintf("DURING (%s, %s)", QuoteJQL(fromTime), QuoteJQL(toTime))
+		query.Raw(fmt.Sprintf("assignee CHANGED TO currentUser() %s OR assignee CHANGED FROM currentUser() %s", during, during))
+	case SupplementaryDueSoon:
+		// Work to plan for, whether or not it was touched in the range
+		query.Raw("assignee = currentUser()")
+		query.Raw("statusCategory != Done")
+		query.Raw(fmt.Sprintf("duedate <= %s", QuoteJQL(opts.jqlTime(dueHorizon(timeRange, r.config.ReportOptions.DueWithinDays), dueDateLayout))))
+		return query.String() + " ORDER BY duedate ASC", nil
+	case SupplementaryRelease, SupplementaryReleaseBaseline:
+		version := r.config.ReportOptions.ReleaseVersion
+		if kind == SupplementaryReleaseBaseline {
+			version = r.config.ReportOptions.ReleaseCompareTo
+		}
+		if version == "" {
+			return "", fmt.Errorf("release notes require a release version")
+		}
+		query.Raw(fmt.Sprintf("fixVersion = %s", QuoteJQL(version)))
+	case SupplementaryReleaseRange:
+		// Issues resolved before the range were done at its start, unless
+		// reopened since, which leaves them unresolved
+		query.Raw(fmt.Sprintf("created < %s", QuoteJQL(toTime)))
+		query.Raw(fmt.Sprintf("resolution IS EMPTY OR resolved >= %s", QuoteJQL(fromTime)))
+	case SupplementaryTriage:
+		// Everything that came in, to the minute, ordered by Jira's priority ranking
+		query.In("issuetype", r.config.ReportOptions.TriageIssueTypes)
+		query.Raw(fmt.Sprintf("created >= %s", QuoteJQL(fromTime)))
+		query.Raw(fmt.Sprintf("created < %s", QuoteJQL(toTime)))
+		return query.String() + " ORDER BY priority DESC, created ASC", nil
+	default:
+		return "", fmt.Errorf("unknown supplementary query %q", kind)
+	}
+
+	return query.String(), nil
+}
+
+// selectsCreated reports whether the query finds issues by their creation
+// within the time range
+func (kind SupplementaryQuery) selectsCreated() bool {
+	return kind == SupplementaryFiled || kind == SupplementaryTriage
+}
+
+// inRange reports whether an issue the query found, searching whole minutes
+// around the range, belongs to the exact range: by its creation for filed and
+// triage issues, and by the escalation or handoff that converting the issue
+// kept to the range. The other queries are not bounded by the range.
+func (kind SupplementaryQuery) inRange(issue Issue, created time.Time, timeRange TimeRange) bool {
+	switch {
+	case kind.selectsCreated():
+		return timeRange.IsInRange(created)
+	case kind == SupplementaryEscalated:
+		return issue.Escalation != nil
+	case kind == SupplementaryHandoffs:
+		return issue.Handoff != nil
+	default:
+		return true
+	}
+}
+
+// supplementarySection is a titled list of issues rendered outside the per-status activity
+type supplementarySection struct {
+	Title  string
+	Issues []Issue
+}
+
+// supplementarySections returns the non-empty supplementary sections of a report in display order
+func supplementarySections(report *ActivityReport) []supplementarySection {
+	sections := make([]supplementarySection, 0)
+	if len(report.Pinned) > 0 {
+		sections = append(sections, supplementarySection{Title: "Pinned", Issues: report.Pinned})
+	}
+	if len(report.Blockers) > 0 {
+		sections = append(sections, supplementarySection{Title: "Blockers", Issues: report.Blockers})
+	}
+	if len(report.CarryOver) > 0 {
+		sections = append(sections, supplementarySection{Title: "Carry-over Work", Issues: report.CarryOver})
+	}
+	if len(report.Filed) > 0 {
+		sections = append(sections, supplementarySection{Title: "Filed", Issues: report.Filed})
+	}
+	return sections
+}
+
+// hasContent reports whether the report has any activity, supplementary issues,
+// due issues, handoffs, sprint scope changes, release notes, triage issues or a
+// truncation notice to render
+func (r *ActivityReport) hasContent() bool {
+	return len(r.Issues) > 0 || len(supplementarySections(r)) > 0 || len(r.DueSoon) > 0 || !r.Handoffs.IsEmpty() || r.Sprint.Changed() ||
+		(r.Release != nil && r.Release.IssueCount() > 0) || len(r.Triage) > 0 || r.Truncation != nil
+}
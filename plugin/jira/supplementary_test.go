@@ -0,0 +1,460 @@
+package jira
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestJiraAPIRepository_BuildSupplementaryJQLQuery(t *testing.T) {
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 18, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 9, 30, 0, 0, time.UTC),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		kind        SupplementaryQuery
+		version     string
+		dueWithin   int
+		projects    []string
+		timeZone    *time.Location
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "Carry-over work",
+			kind:     SupplementaryCarryOver,
+			expected: `project = "TEST" AND assignee = currentUser() AND status IN ("In Progress")`,
+		},
+		{
+			name:     "Flagged issues",
+			kind:     SupplementaryFlagged,
+			expected: `project = "TEST" AND Flagged IS NOT EMPTY`,
+		},
+		{
+			name:     "Flagged issues in several projects",
+			kind:     SupplementaryFlagged,
+			projects: []string{"TEST", "OPS"},
+			expected: `project IN ("TEST", "OPS") AND Flagged IS NOT EMPTY`,
+		},
+		{
+			name:     "Release issues",
+			kind:     SupplementaryRelease,
+			version:  "2.1 \"beta\"",
+			expected: `project = "TEST" AND fixVersion = "2.1 \"beta\""`,
+		},
+		{
+			name:        "Release without version",
+			kind:        SupplementaryRelease,
+			expectError: true,
+		},
+		{
+			name:     "Issues open within the range",
+			kind:     SupplementaryReleaseRange,
+			expected: `project = "TEST" AND created < "2023-01-02 09:30" AND (resolution IS EMPTY OR resolved >= "2023-01-01 18:00")`,
+		},
+		{
+			name:     "Filed issues",
+			kind:     SupplementaryFiled,
+			expected: `project = "TEST" AND creator = currentUser() AND created >= "2023-01-01 18:00" AND created < "2023-01-02 09:30"`,
+		},
+		{
+			name:     "Escalated issues",
+			kind:     SupplementaryEscalated,
+			expected: `project = "TEST" AND priority CHANGED DURING ("2023-01-01 18:00", "2023-01-02 09:30")`,
+		},
+		{
+			name:     "Handoffs",
+			kind:     SupplementaryHandoffs,
+			expected: `project = "TEST" AND (assignee CHANGED TO currentUser() DURING ("2023-01-01 18:00", "2023-01-02 09:30") OR assignee CHANGED FROM currentUser() DURING ("2023-01-01 18:00", "2023-01-02 09:30"))`,
+		},
+		{
+			name:     "Filed issues in the Jira time zone",
+			kind:     SupplementaryFiled,
+			timeZone: time.FixedZone("PST", -8*60*60),
+			expected: `project = "TEST" AND creator = currentUser() AND created >= "2023-01-01 10:00" AND created < "2023-01-02 01:30"`,
+		},
+		{
+			name:      "Due soon",
+			kind:      SupplementaryDueSoon,
+			dueWithin: 7,
+			expected:  `project = "TEST" AND assignee = currentUser() AND statusCategory != Done AND duedate <= "2023-01-09" ORDER BY duedate ASC`,
+		},
+		{
+			name:     "Triage issues",
+			kind:     SupplementaryTriage,
+			expected: `project = "TEST" AND issuetype IN ("Bug", "Incident") AND created >= "2023-01-01 18:00" AND created < "2023-01-02 09:30" ORDER BY priority DESC, created ASC`,
+		},
+		{
+			name:        "Unknown query",
+			kind:        SupplementaryQuery("unknown"),
+			expectError: true,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := DefaultQueryOptions()
+			options.Project = "TEST"
+			options.Projects = tc.projects
+			options.TimeZone = tc.timeZone
+			reportOptions := DefaultReportOptions()
+			reportOptions.ReleaseVersion = tc.version
+			reportOptions.DueWithinDays = tc.dueWithin
+			repo := &JiraAPIRepository{config: &JiraConfig{QueryOptions: options, ReportOptions: reportOptions}}
+
+			jql, err := repo.buildSupplementaryJQLQuery(tc.kind, timeRange)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got JQL '%s'", jql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if jql != tc.expected {
+				t.Errorf("Expected JQL '%s', got '%s'", tc.expected, jql)
+			}
+		})
+	}
+}
+
+func TestActivityService_CarryOver(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name              string
+		includeCarryOver  bool
+		supplementaryErr  error
+		expectedCarryOver []string
+	}{
+		{
+			name:              "Disabled",
+			includeCarryOver:  false,
+			expectedCarryOver: nil,
+		},
+		{
+			name:              "Enabled drops issues with activity",
+			includeCarryOver:  true,
+			expectedCarryOver: []string{"JIRA-2"},
+		},
+		{
+			name:              "Failing query is tolerated",
+			includeCarryOver:  true,
+			supplementaryErr:  errors.New("boom"),
+			expectedCarryOver: nil,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := &MockJiraRepository{
+				MockGetUser: func() (*User, error) {
+					return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+				},
+				MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+					return []Issue{{Key: "JIRA-1", Status: "In Progress"}}, nil
+				},
+				MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+					if kind != SupplementaryCarryOver {
+						t.Errorf("Expected carry-over query, got %s", kind)
+					}
+					if tc.supplementaryErr != nil {
+						return nil, tc.supplementaryErr
+					}
+					return []Issue{{Key: "JIRA-1", Status: "In Progress"}, {Key: "JIRA-2", Status: "In Progress"}}, nil
+				},
+			}
+
+			options := DefaultReportOptions()
+			options.IncludeCarryOver = tc.includeCarryOver
+
+			service := NewActivityService(mockRepo)
+			service.SetReportOptions(options)
+			service.SetLogger(NewNoopLogger())
+
+			report, err := service.GetActivityReport(plugin.TimeRange{
+				Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			keys := make([]string, 0)
+			for _, issue := range report.CarryOver {
+				keys = append(keys, issue.Key)
+			}
+			if strings.Join(keys, ",") != strings.Join(tc.expectedCarryOver, ",") {
+				t.Errorf("Expected carry-over %v, got %v", tc.expectedCarryOver, keys)
+			}
+		})
+	}
+}
+
+func TestFormatters_CarryOverWithoutActivity(t *testing.T) {
+	report := &ActivityReport{
+		TimeRange: TimeRange{
+			Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		User:      User{DisplayName: "Test User", Email: "test@example.com"},
+		Issues:    []Issue{},
+		CarryOver: []Issue{{Key: "JIRA-7", Summary: "Finish migration", Status: "In Progress"}},
+		Options:   DefaultReportOptions(),
+	}
+
+	formatters := []ReportFormatter{
+		NewXMLFormatter(),
+		NewJSONFormatter(),
+		NewMarkdownFormatter(),
+		NewHTMLFormatter(),
+	}
+
+	for _, formatter := range formatters {
+		t.Run(formatter.Name(), func(t *testing.T) {
+			result, err := formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !strings.Contains(result.Content, "JIRA-7") || !strings.Contains(result.Content, "Finish migration") {
+				t.Errorf("Expected content to contain the carry-over issue, got '%s'", result.Content)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_GetSupplementaryIssues(t *testing.T) {
+	options := DefaultQueryOptions()
+	options.Project = "TEST"
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: options})
+	server.Issues = []extJira.Issue{
+		{
+			Key: "JIRA-7",
+			Fields: &extJira.IssueFields{
+				Summary: "Finish migration",
+				Status:  &extJira.Status{Name: "In Progress"},
+			},
+		},
+	}
+
+	issues, err := repo.GetSupplementaryIssues(SupplementaryCarryOver, TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requestedJQL := server.Requests("/rest/api/2/search")[0].Query.Get("jql"); !strings.Contains(requestedJQL, "assignee = currentUser()") {
+		t.Errorf("Expected carry-over JQL, got '%s'", requestedJQL)
+	}
+	// Issues without activity in the range are kept
+	if len(issues) != 1 || issues[0].Key != "JIRA-7" || issues[0].Status != "In Progress" {
+		t.Errorf("Expected issue JIRA-7 in progress, got %+v", issues)
+	}
+}
+
+func TestActivityService_FlaggedBlockers(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "JIRA-1", Status: "In Progress"}}, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			if kind != SupplementaryFlagged {
+				t.Errorf("Expected flagged query, got %s", kind)
+			}
+			return []Issue{{Key: "JIRA-1", Status: "In Progress"}, {Key: "JIRA-9", Status: "Blocked"}}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.IncludeFlagged = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Flagged issues are blockers even when they also had activity
+	if len(report.Blockers) != 2 {
+		t.Errorf("Expected 2 blockers, got %d", len(report.Blockers))
+	}
+}
+
+func TestActivityService_FiledIssues(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "JIRA-1", Status: "In Progress"}}, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			if kind != SupplementaryFiled {
+				t.Errorf("Expected filed query, got %s", kind)
+			}
+			return []Issue{{Key: "JIRA-1", Status: "In Progress"}, {Key: "JIRA-5", Summary: "Login fails on Safari", Status: "Open", Type: "Bug"}}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.IncludeFiled = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Filed issues that also had activity are only listed with their activity
+	if len(report.Filed) != 1 || report.Filed[0].Key != "JIRA-5" {
+		t.Fatalf("Expected JIRA-5 as filed, got %+v", report.Filed)
+	}
+
+	result, err := NewMarkdownFormatter().Format(report)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(result.Content, "## Filed") || !strings.Contains(result.Content, "JIRA-5") {
+		t.Errorf("Expected a Filed section, got '%s'", result.Content)
+	}
+}
+
+func TestSupplementarySections_Order(t *testing.T) {
+	report := &ActivityReport{
+		Filed:     []Issue{{Key: "JIRA-3"}},
+		CarryOver: []Issue{{Key: "JIRA-2"}},
+		Blockers:  []Issue{{Key: "JIRA-1"}},
+	}
+
+	sections := supplementarySections(report)
+	if len(sections) != 3 || sections[0].Title != "Blockers" || sections[1].Title != "Carry-over Work" || sections[2].Title != "Filed" {
+		t.Errorf("Expected Blockers, Carry-over Work then Filed, got %+v", sections)
+	}
+}
+
+func TestJiraAPIRepository_GetSupplementaryIssues_BoundaryMinute(t *testing.T) {
+	// The range ends within a minute: the searches cover that whole minute and
+	// its issues are kept to the exact range
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 18, 0, 30, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 9, 30, 45, 0, time.UTC),
+	}
+	priorityRaised := []extJira.ChangelogItems{{Field: "priority", FromString: "Medium", ToString: "Blocker"}}
+	assigned := []extJira.ChangelogItems{{Field: "assignee", From: "user456", To: "user123", FromString: "Other", ToString: "Test User"}}
+
+	// Setup test cases
+	testCases := []struct {
+		name       string
+		kind       SupplementaryQuery
+		created    map[string]time.Time
+		changelogs map[string][]extJira.ChangelogHistory
+		expected   []string
+	}{
+		{
+			name: "Filed",
+			kind: SupplementaryFiled,
+			created: map[string]time.Time{
+				"JIRA-1": time.Date(2023, 1, 2, 9, 30, 30, 0, time.UTC),
+				"JIRA-2": time.Date(2023, 1, 2, 9, 30, 50, 0, time.UTC),
+				"JIRA-3": time.Date(2023, 1, 1, 18, 0, 10, 0, time.UTC),
+			},
+			expected: []string{"JIRA-1"},
+		},
+		{
+			name: "Triage",
+			kind: SupplementaryTriage,
+			created: map[string]time.Time{
+				"JIRA-1": time.Date(2023, 1, 2, 9, 30, 30, 0, time.UTC),
+				"JIRA-2": time.Date(2023, 1, 2, 9, 30, 50, 0, time.UTC),
+				"JIRA-3": time.Date(2023, 1, 1, 18, 0, 10, 0, time.UTC),
+			},
+			expected: []string{"JIRA-1"},
+		},
+		{
+			name: "Escalated",
+			kind: SupplementaryEscalated,
+			changelogs: map[string][]extJira.ChangelogHistory{
+				"JIRA-1": {{Created: "2023-01-02T09:30:30.000+0000", Items: priorityRaised}},
+				"JIRA-2": {{Created: "2023-01-02T09:30:50.000+0000", Items: priorityRaised}},
+				"JIRA-3": {{Created: "2023-01-01T18:00:10.000+0000", Items: priorityRaised}},
+			},
+			expected: []string{"JIRA-1"},
+		},
+		{
+			name: "Handoffs",
+			kind: SupplementaryHandoffs,
+			changelogs: map[string][]extJira.ChangelogHistory{
+				"JIRA-1": {{Created: "2023-01-02T09:30:30.000+0000", Items: assigned}},
+				"JIRA-2": {{Created: "2023-01-02T09:30:50.000+0000", Items: assigned}},
+				"JIRA-3": {{Created: "2023-01-01T18:00:10.000+0000", Items: assigned}},
+			},
+			expected: []string{"JIRA-1"},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queryOptions := DefaultQueryOptions()
+			queryOptions.Project = "TEST"
+			reportOptions := DefaultReportOptions()
+			reportOptions.IncludeHandoffs = true
+			repo, server := newServerRepository(t, &JiraConfig{QueryOptions: queryOptions, ReportOptions: reportOptions})
+			server.Changelogs = tc.changelogs
+			for _, key := range []string{"JIRA-1", "JIRA-2", "JIRA-3"} {
+				server.Issues = append(server.Issues, extJira.Issue{
+					Key: key,
+					Fields: &extJira.IssueFields{
+						Summary: "Boundary " + key,
+						Status:  &extJira.Status{Name: "Open"},
+						Created: extJira.Time(tc.created[key]),
+					},
+				})
+			}
+
+			issues, err := repo.GetSupplementaryIssues(tc.kind, timeRange, "user123")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			requestedJQL := server.Requests("/rest/api/2/search")[0].Query.Get("jql")
+			if !strings.Contains(requestedJQL, `"2023-01-01 18:00"`) || !strings.Contains(requestedJQL, `"2023-01-02 09:31"`) {
+				t.Errorf("Expected the search to cover the whole minutes of the range, got '%s'", requestedJQL)
+			}
+			var keys []string
+			for _, issue := range issues {
+				keys = append(keys, issue.Key)
+			}
+			if !reflect.DeepEqual(keys, tc.expected) {
+				t.Errorf("Expected issues %v, got %v", tc.expected, keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// teamsPayloadLimit is the largest message a Teams incoming webhook accepts
+	teamsPayloadLimit = 28000
+	// adaptiveCardSchema and adaptiveCardVersion are the schema of the cards
+	// produced, a version every Teams client renders
+	adaptiveCardSchema  = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion = "1.4"
+)
+
+// adaptiveCard is an Adaptive Card with a body of text blocks
+type adaptiveCard struct {
+	Type    string          `json:"type"`
+	Schema  string          `json:"$schema"`
+	Version string          `json:"version"`
+	Body    []adaptiveBlock `json:"body"`
+}
+
+// adaptiveBlock is a TextBlock of an Adaptive Card
+type adaptiveBlock struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Size     string `json:"size,omitempty"`
+	Weight   string `json:"weight,omitempty"`
+	Color    string `json:"color,omitempty"`
+	IsSubtle bool   `json:"isSubtle,omitempty"`
+	Wrap     bool   `json:"wrap"`
+	Spacing  string `json:"spacing,omitempty"`
+}
+
+// textBlock creates a wrapping text block
+func textBlock(text string) adaptiveBlock {
+	return adaptiveBlock{Type: "TextBlock", Text: text, Wrap: true}
+}
+
+// adaptiveReplacer escapes the characters Adaptive Card text reads as
+// Markdown emphasis, links and lists
+var adaptiveReplacer = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`)
+
+// escapeAdaptive escapes a single-line value for Adaptive Card text
+func escapeAdaptive(value string) string {
+	value = strings.ReplaceAll(normalizeLineEndings(value), "\n", " ")
+	return adaptiveReplacer.Replace(value)
+}
+
+// TeamsFormatter formats activity reports as a Microsoft Teams Adaptive Card,
+// the same compact digest as the Slack format
+type TeamsFormatter struct{}
+
+// NewTeamsFormatter creates a new Teams formatter
+func NewTeamsFormatter() *TeamsFormatter {
+	return &TeamsFormatter{}
+}
+
+// Name returns the name of the formatter
+func (f *TeamsFormatter) Name() string {
+	return "teams"
+}
+
+// Format formats an activity report as an Adaptive Card in JSON
+func (f *TeamsFormatter) Format(report *ActivityReport) (*FormattedContent, error) {
+	card, err := json.MarshalIndent(f.card(report), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the Adaptive Card: %w", err)
+	}
+	return &FormattedContent{
+		ContentType: "application/vnd.microsoft.card.adaptive+json",
+		Content:     string(card),
+	}, nil
+}
+
+// card lays out the report as an Adaptive Card
+func (f *TeamsFormatter) card(report *ActivityReport) adaptiveCard {
+	card := adaptiveCard{Type: "AdaptiveCard", Schema: adaptiveCardSchema, Version: adaptiveCardVersion}
+
+	title := textBlock("Jira Activity Report")
+	title.Size, title.Weight = "Large", "Bolder"
+	card.Body = append(card.Body, title)
+
+	if report.emptyMessage(f.Name()) {
+		card.Body = append(card.Body, textBlock("No activity found for the specified time range."))
+		return card
+	}
+
+	if report.Options.Verbosity.IncludeMetadata() {
+		metadata := textBlock(fmt.Sprintf("%s to %s · %s",
+			report.TimeRange.Start.Format("2006-01-02"),
+			report.TimeRange.End.Format("2006-01-02"),
+			escapeAdaptive(report.User.DisplayName)))
+		metadata.IsSubtle, metadata.Spacing = true, "None"
+		card.Body = append(card.Body, metadata)
+	}
+
+	for _, notice := range chatNotices(report) {
+		note := textBlock("**Note:** " + escapeAdaptive(notice))
+		note.Color = "Warning"
+		card.Body = append(card.Body, note)
+	}
+
+	// Add each section as a heading over a list item per issue, linked when
+	// deep links are on
+	links := NewDeepLinks(report.Options.LinkBaseURL)
+	for _, section := range chatSections(report) {
+		heading := textBlock(escapeAdaptive(section.Title))
+		heading.Size, heading.Weight, heading.Spacing = "Medium", "Bolder", "Medium"
+		card.Body = append(card.Body, heading)
+		if section.Subtitle != "" {
+			subtitle := textBlock(escapeAdaptive(section.Subtitle))
+			subtitle.IsSubtle, subtitle.Spacing = true, "None"
+			card.Body = append(card.Body, subtitle)
+		}
+
+		lines := make([]string, 0, len(section.Items))
+		for _, item := range section.Items {
+			key := "**" + escapeAdaptive(item.Issue.Key) + "**"
+			if links.Enabled() {
+				key = fmt.Sprintf("[%s](%s)", escapeAdaptive(item.Issue.Key), markdownURL(links.Issue(item.Issue.Key)))
+			}
+			line := fmt.Sprintf("- %s %s", key, escapeAdaptive(item.Issue.Summary))
+			if item.Detail != "" {
+				line += fmt.Sprintf(" (%s)", escapeAdaptive(item.Detail))
+			}
+			if item.Note != "" {
+				line += fmt.Sprintf(" — _%s_", escapeAdaptive(item.Note))
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) > 0 {
+			list := textBlock(strings.Join(lines, "\n"))
+			list.Spacing = "Small"
+			card.Body = append(card.Body, list)
+		}
+	}
+
+	// List the problems that left data out of the report
+	if warnings := footerWarnings(report.Warnings); len(warnings) > 0 {
+		heading := textBlock("Warnings")
+		heading.Weight, heading.Color, heading.Spacing = "Bolder", "Attention", "Medium"
+		lines := make([]string, 0, len(warnings))
+		for _, warning := range warnings {
+			lines = append(lines, "- "+escapeAdaptive(warning.Message))
+		}
+		card.Body = append(card.Body, heading, textBlock(strings.Join(lines, "\n")))
+	}
+
+	// Close with how the report was generated
+	if generation := report.Generation; generation != nil && report.Options.Verbosity.IncludeMetadata() {
+		footer := textBlock(escapeAdaptive(generation.Line()))
+		footer.IsSubtle, footer.Size, footer.Spacing = true, "Small", "Medium"
+		card.Body = append(card.Body, footer)
+	}
+	return card
+}
+
+// teamsMessage is the body of a Teams incoming-webhook post carrying a card
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+// teamsAttachment is a card attached to a Teams message
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+// newTeamsMessage wraps the card in a message. A card too large for the
+// webhook loses its last blocks and says that the rest was left out.
+func newTeamsMessage(card adaptiveCard) (teamsMessage, error) {
+	message := teamsMessage{Type: "message", Attachments: []teamsAttachment{{ContentType: "application/vnd.microsoft.card.adaptive", Content: card}}}
+	notice := textBlock("_The report was cut to fit in a Teams message._")
+	notice.IsSubtle = true
+
+	body := card.Body
+	for {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return message, fmt.Errorf("failed to encode the Teams message: %w", err)
+		}
+		if len(data) <= teamsPayloadLimit || len(body) <= 1 {
+			return message, nil
+		}
+		body = body[:len(body)-1]
+		message.Attachments[0].Content.Body = append(body[:len(body):len(body)], notice)
+	}
+}
+
+// TeamsPublisher posts every report as an Adaptive Card to a Microsoft Teams
+// incoming webhook
+type TeamsPublisher struct {
+	formatter  *TeamsFormatter
+	client     *http.Client
+	webhookURL string
+}
+
+// NewTeamsPublisher creates a publisher posting to the incoming webhook. A nil
+// client uses one with a timeout.
+func NewTeamsPublisher(webhookURL string, client *http.Client) *TeamsPublisher {
+	return &TeamsPublisher{
+		formatter:  NewTeamsFormatter(),
+		client:     newPublishClient(client),
+		webhookURL: webhookURL,
+	}
+}
+
+// Name returns the name of the service published to
+func (p *TeamsPublisher) Name() string {
+	return "Teams"
+}
+
+// Publish posts the report as an Adaptive Card
+func (p *TeamsPublisher) Publish(report *ActivityReport) error {
+	if report.Omitted(p.formatter.Name()) {
+		return nil
+	}
+	message, err := newTeamsMessage(p.formatter.card(report))
+	if err != nil {
+		return err
+	}
+	if _, err := postJSON(p.client, p.webhookURL, nil, message); err != nil {
+		return fmt.Errorf("failed to post the report to the Teams webhook: %w", err)
+	}
+	return nil
+}
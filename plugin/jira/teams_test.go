@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTeamsFormatter_Empty(t *testing.T) {
+	content, err := NewTeamsFormatter().Format(&ActivityReport{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var card adaptiveCard
+	if err := json.Unmarshal([]byte(content.Content), &card); err != nil {
+		t.Fatalf("Expected an Adaptive Card, got %v", err)
+	}
+	if card.Type != "AdaptiveCard" || len(card.Body) != 2 || card.Body[1].Text != "No activity found for the specified time range." {
+		t.Errorf("Expected a card saying there was no activity, got %+v", card)
+	}
+}
+
+func TestNewTeamsMessage_Cut(t *testing.T) {
+	card := adaptiveCard{Type: "AdaptiveCard", Schema: adaptiveCardSchema, Version: adaptiveCardVersion}
+	for i := 0; i < 100; i++ {
+		card.Body = append(card.Body, textBlock(strings.Repeat("x", 1000)))
+	}
+
+	message, err := newTeamsMessage(card)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, _ := json.Marshal(message)
+	if len(data) > teamsPayloadLimit {
+		t.Errorf("Expected at most %d bytes, got %d", teamsPayloadLimit, len(data))
+	}
+	body := message.Attachments[0].Content.Body
+	if last := body[len(body)-1].Text; !strings.Contains(last, "cut to fit") {
+		t.Errorf("Expected the cut to be disclosed, got %q", last)
+	}
+	if len(card.Body) != 100 {
+		t.Errorf("Expected the card itself to be left whole, got %d blocks", len(card.Body))
+	}
+}
+
+func TestTeamsPublisher(t *testing.T) {
+	report := goldenReport()
+
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		status      int
+		expectedErr string
+	}{
+		{name: "Accepted", status: http.StatusOK},
+		{name: "Rejected", status: http.StatusBadRequest, expectedErr: "status 400"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var message teamsMessage
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewDecoder(r.Body).Decode(&message)
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			err := NewTeamsPublisher(server.URL, nil).Publish(report)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if message.Type != "message" || len(message.Attachments) != 1 {
+				t.Fatalf("Expected a message with one attachment, got %+v", message)
+			}
+			attachment := message.Attachments[0]
+			if attachment.ContentType != "application/vnd.microsoft.card.adaptive" || attachment.Content.Body[0].Text != "Jira Activity Report" {
+				t.Errorf("Expected the report as an Adaptive Card, got %+v", attachment)
+			}
+		})
+	}
+}
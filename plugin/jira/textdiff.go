@@ -0,0 +1,184 @@
+package jira
+
+import (
+	"strings"
+)
+
+// diffContextWords is the number of unchanged words kept around each edit
+const diffContextWords = 3
+
+// maxDiffCells bounds the work of a word diff; larger edits keep their full bodies
+const maxDiffCells = 1 << 20
+
+// diffedFields are the long text fields whose edits are shown as a word-level diff
+var diffedFields = map[string]bool{
+	"description": true,
+	"environment": true,
+}
+
+// DiffOp is the kind of a diff segment
+type DiffOp int
+
+const (
+	// DiffEqual is text present in both versions
+	DiffEqual DiffOp = iota
+	// DiffDelete is text only present in the previous version
+	DiffDelete
+	// DiffInsert is text only present in the new version
+	DiffInsert
+)
+
+// DiffSegment is a run of words that were kept, removed or added
+type DiffSegment struct {
+	Op   DiffOp
+	Text string
+}
+
+// TextDiff is a compact word-level diff of an edited text, in which long runs
+// of unchanged words are elided with "…"
+type TextDiff []DiffSegment
+
+// String renders the diff in git's word-diff notation, e.g.
+// "… the [-old-] {+new+} text …"
+func (d TextDiff) String() string {
+	parts := make([]string, 0, len(d))
+	for _, segment := range d {
+		switch segment.Op {
+		case DiffDelete:
+			parts = append(parts, "[-"+segment.Text+"-]")
+		case DiffInsert:
+			parts = append(parts, "{+"+segment.Text+"+}")
+		default:
+			parts = append(parts, segment.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// diffTextChanges sets the word-level diff of every edit of a long text field
+// that had both a previous and a new version
+func diffTextChanges(issues []Issue) {
+	for i := range issues {
+		for j := range issues[i].Changes {
+			change := &issues[i].Changes[j]
+			if !diffedFields[strings.ToLower(change.Field)] || change.FromValue == "" || change.ToValue == "" {
+				continue
+			}
+			change.Diff = wordDiff(change.FromValue, change.ToValue)
+		}
+	}
+}
+
+// wordDiff compares two texts word by word, returning nil when the texts are
+// identical or too long to compare
+func wordDiff(from, to string) TextDiff {
+	a, b := strings.Fields(from), strings.Fields(to)
+
+	// Only the middle that differs needs the quadratic comparison
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(middleA) == 0 && len(middleB) == 0 {
+		return nil
+	}
+	if (len(middleA)+1)*(len(middleB)+1) > maxDiffCells {
+		return nil
+	}
+
+	// Build the edit script over the words, then merge runs of the same kind
+	var ops []DiffSegment
+	for _, word := range a[:prefix] {
+		ops = append(ops, DiffSegment{Op: DiffEqual, Text: word})
+	}
+	ops = append(ops, diffWords(middleA, middleB)...)
+	for _, word := range a[len(a)-suffix:] {
+		ops = append(ops, DiffSegment{Op: DiffEqual, Text: word})
+	}
+
+	return elideUnchanged(mergeSegments(ops))
+}
+
+// diffWords returns the word-by-word edit script turning a into b, from their
+// longest common subsequence
+func diffWords(a, b []string) []DiffSegment {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]DiffSegment, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, DiffSegment{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, DiffSegment{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			ops = append(ops, DiffSegment{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, DiffSegment{Op: DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, DiffSegment{Op: DiffInsert, Text: b[j]})
+	}
+	return ops
+}
+
+// mergeSegments joins consecutive words of the same kind into one segment
+func mergeSegments(words []DiffSegment) TextDiff {
+	merged := make(TextDiff, 0)
+	for _, word := range words {
+		if last := len(merged) - 1; last >= 0 && merged[last].Op == word.Op {
+			merged[last].Text += " " + word.Text
+			continue
+		}
+		merged = append(merged, word)
+	}
+	return merged
+}
+
+// elideUnchanged shortens the unchanged runs to the words next to an edit
+func elideUnchanged(diff TextDiff) TextDiff {
+	for i := range diff {
+		if diff[i].Op != DiffEqual {
+			continue
+		}
+		words := strings.Fields(diff[i].Text)
+		first, last := i == 0, i == len(diff)-1
+
+		switch {
+		case first && !last && len(words) > diffContextWords:
+			words = append([]string{"…"}, words[len(words)-diffContextWords:]...)
+		case last && !first && len(words) > diffContextWords:
+			words = append(words[:diffContextWords:diffContextWords], "…")
+		case !first && !last && len(words) > 2*diffContextWords:
+			words = append(append(words[:diffContextWords:diffContextWords], "…"), words[len(words)-diffContextWords:]...)
+		}
+		diff[i].Text = strings.Join(words, " ")
+	}
+	return diff
+}
@@ -0,0 +1,89 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestWordDiff(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{name: "Inserted words", from: "Reject cards that fail the Luhn check", to: "Reject cards that fail the Luhn or expiry check", expected: "… fail the Luhn {+or expiry+} check"},
+		{name: "Replaced word", from: "Retry twice", to: "Retry three times", expected: "Retry [-twice-] {+three times+}"},
+		{name: "Deleted words", from: "one two three four five six seven eight nine ten", to: "one two three four six seven eight nine ten", expected: "… two three four [-five-] six seven eight …"},
+		{name: "Only whitespace changed", from: "Keep  it\nsimple", to: "Keep it simple", expected: ""},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := wordDiff(tc.from, tc.to).String(); diff != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestWordDiff_TooLong(t *testing.T) {
+	from := strings.Repeat("a ", 2000)
+	to := strings.Repeat("b ", 2000)
+
+	if diff := wordDiff(from, to); diff != nil {
+		t.Errorf("Expected edits beyond the size cap to keep their full bodies, got %d segments", len(diff))
+	}
+}
+
+func TestDiffTextChanges(t *testing.T) {
+	issues := []Issue{{
+		Key: "TEST-1",
+		Changes: []Change{
+			{Field: "Description", FromValue: "Add a retry", ToValue: "Add a retry with backoff"},
+			{Field: "description", FromValue: "", ToValue: "First draft"},
+			{Field: "summary", FromValue: "Retry", ToValue: "Retry with backoff"},
+		},
+	}}
+
+	diffTextChanges(issues)
+
+	changes := issues[0].Changes
+	if diff := changes[0].Diff.String(); diff != "Add a retry {+with backoff+}" {
+		t.Errorf("Expected the description edit to be diffed, got %q", diff)
+	}
+	if changes[1].Diff != nil {
+		t.Error("Expected a first description to keep its full body")
+	}
+	if changes[2].Diff != nil {
+		t.Error("Expected a summary edit to keep its full values")
+	}
+}
+
+func TestProcessComments_Edited(t *testing.T) {
+	repo := &JiraAPIRepository{}
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	comments := repo.processComments([]*extJira.Comment{
+		{ID: "1", Created: "2023-01-01T12:00:00.000+0000", Updated: "2023-01-01T12:00:00.000+0000", Body: "As posted"},
+		{ID: "2", Created: "2023-01-01T12:00:00.000+0000", Updated: "2023-01-01T15:30:00.000+0000", Body: "Fixed a typo"},
+	}, timeRange)
+
+	if len(comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %d", len(comments))
+	}
+	if comments[0].Edited {
+		t.Error("Expected an unedited comment not to be marked edited")
+	}
+	if !comments[1].Edited {
+		t.Error("Expected a comment updated after it was posted to be marked edited")
+	}
+}
@@ -0,0 +1,150 @@
+package jira
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// timeRangeDateLayout is the layout of the dates of an explicit time range
+	timeRangeDateLayout = "2006-01-02"
+	// timeRangeTimeLayout is the layout of the bounds of a range in errors
+	timeRangeTimeLayout = "2006-01-02 15:04 MST"
+)
+
+// relativeRangePattern matches a rolling range such as "last 3 days" or
+// "last 2 workdays"
+var relativeRangePattern = regexp.MustCompile(`^last\s+(\d+)\s+(days?|workdays?)$`)
+
+// ParseTimeRange builds the time range described by an expression, relative
+// to now and in its location, for hosts taking a range from a user and for
+// tests. The expressions are:
+//
+//   - "today": since midnight
+//   - "yesterday": the whole of the previous day
+//   - "last workday": the whole of the previous weekday, e.g. Friday on a Monday
+//   - "last 3 days": since midnight three days ago
+//   - "last 2 workdays": since midnight two weekdays ago, skipping weekends
+//   - "2024-05-01": the whole of that day
+//   - "2024-05-01..2024-05-07": from the first day to the end of the last one
+//   - "2024-05-01..": from that day until now
+//
+// Expressions are read without regard to case or surrounding spaces. Ranges
+// end at midnight or now and are half-open, so that consecutive days do not
+// share an event at their boundary.
+func ParseTimeRange(expression string, now time.Time) (TimeRange, error) {
+	value := strings.ToLower(strings.Join(strings.Fields(expression), " "))
+	today := startOfDay(now)
+
+	switch value {
+	case "":
+		return TimeRange{}, fmt.Errorf("empty time range")
+	case "today":
+		return TimeRange{Start: today, End: now}, nil
+	case "yesterday":
+		return TimeRange{Start: today.AddDate(0, 0, -1), End: today}, nil
+	case "last workday":
+		start := previousWorkday(today)
+		return TimeRange{Start: start, End: start.AddDate(0, 0, 1)}, nil
+	}
+
+	if match := relativeRangePattern.FindStringSubmatch(value); match != nil {
+		count, err := strconv.Atoi(match[1])
+		if err != nil || count < 1 {
+			return TimeRange{}, fmt.Errorf("invalid time range %q: expected at least 1 day", expression)
+		}
+		if strings.HasPrefix(match[2], "workday") {
+			return TimeRange{Start: workdaysBefore(today, count), End: now}, nil
+		}
+		return TimeRange{Start: today.AddDate(0, 0, -count), End: now}, nil
+	}
+
+	from, to, isSpan := strings.Cut(value, "..")
+	start, err := parseRangeDate(from, now.Location())
+	if err != nil {
+		return TimeRange{}, fmt.Errorf("invalid time range %q: %w", expression, err)
+	}
+	if !isSpan {
+		return TimeRange{Start: start, End: start.AddDate(0, 0, 1)}, nil
+	}
+	if strings.TrimSpace(to) == "" {
+		return TimeRange{Start: start, End: now}, nil
+	}
+	last, err := parseRangeDate(to, now.Location())
+	if err != nil {
+		return TimeRange{}, fmt.Errorf("invalid time range %q: %w", expression, err)
+	}
+	if last.Before(start) {
+		return TimeRange{}, fmt.Errorf("invalid time range %q: ends before it starts", expression)
+	}
+	return TimeRange{Start: start, End: last.AddDate(0, 0, 1)}, nil
+}
+
+// MustParseTimeRange is like ParseTimeRange but panics when the expression
+// is invalid, for ranges fixed in code such as those of tests
+func MustParseTimeRange(expression string, now time.Time) TimeRange {
+	timeRange, err := ParseTimeRange(expression, now)
+	if err != nil {
+		panic(err)
+	}
+	return timeRange
+}
+
+// parseRangeDate parses a date of an explicit range at midnight in the location
+func parseRangeDate(value string, location *time.Location) (time.Time, error) {
+	date, err := time.ParseInLocation(timeRangeDateLayout, strings.TrimSpace(value), location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected today, yesterday, last workday, last N days, last N workdays or YYYY-MM-DD..YYYY-MM-DD")
+	}
+	return date, nil
+}
+
+// startOfDay returns midnight of the day of t, in its location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// workdaysBefore returns the same time of day count weekdays before t
+func workdaysBefore(t time.Time, count int) time.Time {
+	for i := 0; i < count; i++ {
+		t = previousWorkday(t)
+	}
+	return t
+}
+
+// Validate checks that a report can be built over the range before anything
+// is queried: it needs a start and an end, must end after it starts, and may
+// span at most maxDays days when maxDays is above zero. A swapped start and
+// end would otherwise match no activity and give an empty report.
+func (tr TimeRange) Validate(maxDays int) error {
+	switch {
+	case tr.Start.IsZero() || tr.End.IsZero():
+		return fmt.Errorf("invalid time range: both a start and an end are needed")
+	case tr.End.Before(tr.Start):
+		return fmt.Errorf("invalid time range: it ends at %s, before it starts at %s; are the start and end swapped?",
+			tr.End.Format(timeRangeTimeLayout), tr.Start.Format(timeRangeTimeLayout))
+	case tr.End.Equal(tr.Start) && !tr.EndInclusive:
+		return fmt.Errorf("invalid time range: it starts and ends at %s, covering no time", tr.Start.Format(timeRangeTimeLayout))
+	case maxDays > 0 && tr.End.Sub(tr.Start) > time.Duration(maxDays)*24*time.Hour:
+		days := int(math.Ceil(tr.End.Sub(tr.Start).Hours() / 24))
+		return fmt.Errorf("invalid time range: it spans %d days, more than the %d allowed by jira.report.max_range_days", days, maxDays)
+	}
+	return nil
+}
+
+// limitDays returns the range cut to its last maxDays days, or the range as
+// it is when it is shorter or maxDays is zero
+func (tr TimeRange) limitDays(maxDays int) TimeRange {
+	if maxDays <= 0 {
+		return tr
+	}
+	if earliest := tr.End.Add(-time.Duration(maxDays) * 24 * time.Hour); tr.Start.Before(earliest) {
+		tr.Start = earliest
+	}
+	return tr
+}
@@ -0,0 +1,199 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	// Monday 2023-01-09 09:30
+	now := time.Date(2023, 1, 9, 9, 30, 0, 0, time.UTC)
+	day := func(month time.Month, day int) time.Time {
+		return time.Date(2023, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		expression  string
+		expected    TimeRange
+		expectedErr string
+	}{
+		{expression: "today", expected: TimeRange{Start: day(1, 9), End: now}},
+		{expression: " Yesterday ", expected: TimeRange{Start: day(1, 8), End: day(1, 9)}},
+		{expression: "last workday", expected: TimeRange{Start: day(1, 6), End: day(1, 7)}},
+		{expression: "last 3 days", expected: TimeRange{Start: day(1, 6), End: now}},
+		{expression: "last  1 day", expected: TimeRange{Start: day(1, 8), End: now}},
+		{expression: "last 2 workdays", expected: TimeRange{Start: day(1, 5), End: now}},
+		{expression: "2023-01-02", expected: TimeRange{Start: day(1, 2), End: day(1, 3)}},
+		{expression: "2023-01-02..2023-01-06", expected: TimeRange{Start: day(1, 2), End: day(1, 7)}},
+		{expression: "2023-01-02 .. 2023-01-02", expected: TimeRange{Start: day(1, 2), End: day(1, 3)}},
+		{expression: "2023-01-02..", expected: TimeRange{Start: day(1, 2), End: now}},
+		{expression: "", expectedErr: "empty time range"},
+		{expression: "last 0 days", expectedErr: "at least 1 day"},
+		{expression: "last week", expectedErr: "expected today, yesterday"},
+		{expression: "2023-01-06..2023-01-02", expectedErr: "ends before it starts"},
+		{expression: "2023-02-30", expectedErr: "invalid time range"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			timeRange, err := ParseTimeRange(tc.expression, now)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !timeRange.Start.Equal(tc.expected.Start) || !timeRange.End.Equal(tc.expected.End) {
+				t.Errorf("Expected %v to %v, got %v to %v", tc.expected.Start, tc.expected.End, timeRange.Start, timeRange.End)
+			}
+		})
+	}
+}
+
+func TestParseTimeRange_Location(t *testing.T) {
+	berlin := time.FixedZone("CET", 60*60)
+	now := time.Date(2023, 1, 9, 0, 30, 0, 0, berlin)
+
+	// Days start at midnight where now is, not in UTC
+	timeRange := MustParseTimeRange("yesterday", now)
+	if expected := time.Date(2023, 1, 8, 0, 0, 0, 0, berlin); !timeRange.Start.Equal(expected) {
+		t.Errorf("Expected the range to start at %v, got %v", expected, timeRange.Start)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustParseTimeRange to panic on an invalid expression")
+		}
+	}()
+	MustParseTimeRange("someday", now)
+}
+
+func TestTimeRange_Validate(t *testing.T) {
+	start := time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC)
+
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		timeRange   TimeRange
+		maxDays     int
+		expectedErr string
+	}{
+		{
+			name:      "Within the maximum span",
+			timeRange: TimeRange{Start: start, End: start.AddDate(0, 0, 90)},
+			maxDays:   90,
+		},
+		{
+			name:      "Unlimited",
+			timeRange: TimeRange{Start: start, End: start.AddDate(1, 0, 0)},
+		},
+		{
+			name:        "Swapped start and end",
+			timeRange:   TimeRange{Start: start.AddDate(0, 0, 1), End: start},
+			expectedErr: "it ends at 2023-01-09 00:00 UTC, before it starts at 2023-01-10 00:00 UTC; are the start and end swapped?",
+		},
+		{
+			name:        "Zero span",
+			timeRange:   TimeRange{Start: start, End: start},
+			expectedErr: "covering no time",
+		},
+		{
+			name:      "Zero span with an inclusive end",
+			timeRange: TimeRange{Start: start, End: start, EndInclusive: true},
+		},
+		{
+			name:        "Missing start",
+			timeRange:   TimeRange{End: start},
+			expectedErr: "both a start and an end are needed",
+		},
+		{
+			name:        "Beyond the maximum span",
+			timeRange:   TimeRange{Start: start, End: start.AddDate(0, 0, 90).Add(time.Hour)},
+			maxDays:     90,
+			expectedErr: "it spans 91 days, more than the 90 allowed by jira.report.max_range_days",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.timeRange.Validate(tc.maxDays)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("Expected an error containing %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestActivityService_InvalidTimeRange(t *testing.T) {
+	queried := false
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			queried = true
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+	}
+	service := NewActivityService(mockRepo)
+
+	_, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err == nil || !strings.Contains(err.Error(), "swapped") {
+		t.Errorf("Expected an error about a swapped range, got %v", err)
+	}
+	if queried {
+		t.Error("Expected nothing to be queried for an invalid range")
+	}
+
+	// A last standup beyond the maximum span cuts the default range instead
+	service.SetStandupStore(&memoryStandupStore{last: time.Now().AddDate(-1, 0, 0)})
+	timeRange := service.SinceLastStandupRange(time.Now())
+	if days := timeRange.End.Sub(timeRange.Start); days != 90*24*time.Hour {
+		t.Errorf("Expected the default range to span 90 days, got %v", days)
+	}
+}
+
+func TestActivityService_EndInclusive(t *testing.T) {
+	var queried TimeRange
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			queried = timeRange
+			return []Issue{}, nil
+		},
+	}
+	options := DefaultReportOptions()
+	options.EndInclusive = true
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	_, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 23, 59, 59, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The boundary semantics reach the filtering of events
+	if !queried.EndInclusive || !queried.IsInRange(queried.End) {
+		t.Errorf("Expected the queried range to include its end, got %+v", queried)
+	}
+}
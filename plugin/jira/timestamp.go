@@ -0,0 +1,27 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// jiraTimeLayouts are the formats Jira uses for comment and changelog
+// timestamps. Jira sends "2023-01-01T10:00:00.000+0000"; some Data Center
+// versions and proxies send an RFC 3339 offset such as "+00:00" or "Z".
+// Fractional seconds are optional in both when parsing.
+var jiraTimeLayouts = []string{
+	"2006-01-02T15:04:05-0700",
+	time.RFC3339,
+}
+
+// parseJiraTime parses a comment or changelog timestamp
+func parseJiraTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range jiraTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized Jira timestamp %q", value)
+}
@@ -0,0 +1,91 @@
+package jira
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseJiraTime(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		value       string
+		expected    time.Time
+		expectError bool
+	}{
+		{
+			name:     "Jira format",
+			value:    "2023-01-01T10:00:00.000-0700",
+			expected: time.Date(2023, 1, 1, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Without fractional seconds",
+			value:    "2023-01-01T10:00:00+0000",
+			expected: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "RFC 3339 offset",
+			value:    "2023-01-01T10:00:00.123+02:00",
+			expected: time.Date(2023, 1, 1, 8, 0, 0, 123000000, time.UTC),
+		},
+		{
+			name:     "UTC designator and surrounding space",
+			value:    " 2023-01-01T10:00:00Z\n",
+			expected: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{name: "Localized date", value: "01/Jan/23 10:00 AM", expectError: true},
+		{name: "Missing offset", value: "2023-01-01T10:00:00.000", expectError: true},
+		{name: "Empty", value: "", expectError: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := parseJiraTime(tc.value)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !result.Equal(tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func FuzzParseJiraTime(f *testing.F) {
+	for _, seed := range []string{
+		"2023-01-01T10:00:00.000+0000",
+		"2023-01-01T10:00:00.000-0700",
+		"2023-01-01T10:00:00+00:00",
+		"2023-01-01T10:00:00Z",
+		"2023-13-45T25:61:61.000+9999",
+		"01/Jan/23 10:00 AM",
+		"2023-01-01T10:00:00.000+0000\x00",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		parsed, err := parseJiraTime(value)
+		if err != nil {
+			return
+		}
+
+		// Anything accepted survives a round trip through the Jira format
+		again, err := parseJiraTime(parsed.Format("2006-01-02T15:04:05.000000000-0700"))
+		if err != nil {
+			t.Fatalf("Failed to parse the formatted %q: %v", value, err)
+		}
+		if !again.Equal(parsed) {
+			t.Errorf("Expected %v after a round trip, got %v", parsed, again)
+		}
+	})
+}
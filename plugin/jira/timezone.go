@@ -0,0 +1,87 @@
+package jira
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// locations caches time zones by name, since loading one reads the zoneinfo database
+var locations sync.Map
+
+// loadLocation returns the time zone with the given IANA name
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// authorLocalTime converts an event timestamp to its author's time zone when the
+// report options ask for it and the zone is known. The second result reports
+// whether the timestamp was converted.
+func authorLocalTime(options ReportOptions, timestamp time.Time, timeZone string) (time.Time, bool) {
+	if !options.AuthorLocalTime || timeZone == "" {
+		return timestamp, false
+	}
+	loc, err := loadLocation(timeZone)
+	if err != nil {
+		return timestamp, false
+	}
+	return timestamp.In(loc), true
+}
+
+// machineTimeLayout is RFC 3339 with the millisecond precision of Jira
+// timestamps, used for every timestamp of the machine formats
+const machineTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// machineTime formats an event timestamp for the machine formats, in author
+// local time when the report asks for it; the offset tells the zone apart
+func machineTime(options ReportOptions, timestamp time.Time, timeZone string) string {
+	local, _ := authorLocalTime(options, timestamp, timeZone)
+	return local.Format(machineTimeLayout)
+}
+
+// epochMillis returns the milliseconds since the Unix epoch of a timestamp,
+// for sorting in downstream tools without parsing
+func epochMillis(timestamp time.Time) int64 {
+	return timestamp.UnixMilli()
+}
+
+// eventTime formats an event timestamp with the layout. In author local time the
+// UTC offset is appended, e.g. "2023-01-01 03:12 (UTC+09:00)".
+func eventTime(options ReportOptions, timestamp time.Time, timeZone, layout string) string {
+	local, converted := authorLocalTime(options, timestamp, timeZone)
+	if !converted {
+		return timestamp.Format(layout)
+	}
+	return fmt.Sprintf("%s (UTC%s)", local.Format(layout), local.Format("-07:00"))
+}
+
+// UserTimeZone returns the time zone of the authenticated user's Jira
+// profile. Jira reads the dates of a JQL query in that time zone, whatever the
+// time zone of the server's clock, which is UTC on Jira Cloud.
+func (r *JiraAPIRepository) UserTimeZone() (*time.Location, error) {
+	user, err := r.getSelf()
+	if err != nil {
+		return nil, err
+	}
+	if user.TimeZone == "" {
+		return nil, fmt.Errorf("the Jira profile of %s has no time zone", user.DisplayName)
+	}
+	location, err := loadLocation(user.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q in the Jira profile of %s: %w", user.TimeZone, user.DisplayName, err)
+	}
+	return location, nil
+}
+
+// UserTimeZone returns the time zone of the authenticated user's Jira profile
+func (j *JiraClient) UserTimeZone() (*time.Location, error) {
+	return j.repository.UserTimeZone()
+}
@@ -0,0 +1,191 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestEventTime(t *testing.T) {
+	timestamp := time.Date(2023, 1, 1, 18, 12, 0, 0, time.UTC)
+
+	// Setup test cases
+	testCases := []struct {
+		name            string
+		authorLocalTime bool
+		timeZone        string
+		expected        string
+	}{
+		{
+			name:            "Author local time",
+			authorLocalTime: true,
+			timeZone:        "Asia/Tokyo",
+			expected:        "2023-01-02 03:12 (UTC+09:00)",
+		},
+		{
+			name:            "Negative offset",
+			authorLocalTime: true,
+			timeZone:        "America/New_York",
+			expected:        "2023-01-01 13:12 (UTC-05:00)",
+		},
+		{
+			name:            "Disabled",
+			authorLocalTime: false,
+			timeZone:        "Asia/Tokyo",
+			expected:        "2023-01-01 18:12",
+		},
+		{
+			name:            "Unknown time zone",
+			authorLocalTime: true,
+			timeZone:        "",
+			expected:        "2023-01-01 18:12",
+		},
+		{
+			name:            "Invalid time zone",
+			authorLocalTime: true,
+			timeZone:        "Mars/Olympus",
+			expected:        "2023-01-01 18:12",
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := DefaultReportOptions()
+			options.AuthorLocalTime = tc.authorLocalTime
+
+			result := eventTime(options, timestamp, tc.timeZone, "2006-01-02 15:04")
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatters_AuthorLocalTime(t *testing.T) {
+	options := DefaultReportOptions()
+	options.AuthorLocalTime = true
+
+	report := &ActivityReport{
+		TimeRange: TimeRange{
+			Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		User: User{DisplayName: "Test User"},
+		Issues: []Issue{
+			{
+				Key:     "JIRA-123",
+				Summary: "Test Issue",
+				Status:  "In Progress",
+				Comments: []Comment{
+					{
+						Timestamp:      time.Date(2023, 1, 1, 18, 12, 0, 0, time.UTC),
+						Author:         "Other User",
+						Content:        "Late night fix",
+						AuthorTimeZone: "Asia/Tokyo",
+					},
+				},
+			},
+		},
+		Options: options,
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		expected  string
+	}{
+		{formatter: NewMarkdownFormatter(), expected: "2023-01-02 03:12 (UTC+09:00)"},
+		{formatter: NewHTMLFormatter(), expected: "2023-01-02 03:12:00 (UTC+09:00)"},
+		{formatter: NewXMLFormatter(), expected: ">2023-01-02T03:12:00.000+09:00<"},
+		{formatter: NewJSONFormatter(), expected: `"2023-01-02T03:12:00.000+09:00"`},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(result.Content, tc.expected) {
+				t.Errorf("Expected content to contain '%s', got '%s'", tc.expected, result.Content)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_UserTimeZone(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		timeZone    string
+		expected    string
+		expectedErr string
+	}{
+		{name: "Profile time zone", timeZone: "Australia/Sydney", expected: "Australia/Sydney"},
+		{name: "No time zone", expectedErr: "has no time zone"},
+		{name: "Unknown time zone", timeZone: "Mars/Olympus_Mons", expectedErr: `unknown time zone "Mars/Olympus_Mons"`},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions()})
+			server.Self = &extJira.User{AccountID: "user123", DisplayName: "Test User", TimeZone: tc.timeZone}
+
+			location, err := repo.UserTimeZone()
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Errorf("Expected an error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if location.String() != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, location)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_ActivityQuery_UserTimeZone(t *testing.T) {
+	// The server's clock runs in UTC, as on Jira Cloud, while the user is in
+	// Sydney, where Jira reads the dates of the query
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions()})
+	server.Self = &extJira.User{AccountID: "user123", DisplayName: "Test User", TimeZone: "Australia/Sydney"}
+
+	location, err := repo.UserTimeZone()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	repo.config.QueryOptions.TimeZone = location
+
+	// Midnight to midnight in UTC is 11:00 to 11:00 in Sydney, in summer time
+	query, err := repo.ActivityQuery(TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(query, `updatedDate >= "2023-01-02 11:00" AND updatedDate < "2023-01-03 11:00"`) {
+		t.Errorf("Expected the dates in the user's time zone, got %q", query)
+	}
+
+	// Half a year later daylight saving time is over, which a fixed offset would miss
+	query, err = repo.ActivityQuery(TimeRange{
+		Start: time.Date(2023, 7, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(query, `updatedDate >= "2023-07-02 10:00" AND updatedDate < "2023-07-03 10:00"`) {
+		t.Errorf("Expected the dates in the user's time zone, got %q", query)
+	}
+}
@@ -0,0 +1,66 @@
+package jira
+
+import (
+	"sort"
+	"strings"
+)
+
+// statusField is the changelog field name for workflow transitions
+const statusField = "status"
+
+// SummarizeTransitions collapses the status changes of an issue into a journey
+// (Open → In Progress → In Review) and returns the remaining non-status changes
+func SummarizeTransitions(changes []Change) (*TransitionSummary, []Change) {
+	transitions := make([]Change, 0)
+	remaining := make([]Change, 0, len(changes))
+
+	for _, change := range changes {
+		if strings.EqualFold(change.Field, statusField) {
+			transitions = append(transitions, change)
+		} else {
+			remaining = append(remaining, change)
+		}
+	}
+
+	if len(transitions) == 0 {
+		return nil, changes
+	}
+
+	// Order transitions chronologically before building the journey
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].Timestamp.Before(transitions[j].Timestamp)
+	})
+
+	summary := &TransitionSummary{
+		Journey: make([]string, 0, len(transitions)+1),
+		Count:   len(transitions),
+		Changes: transitions,
+	}
+
+	appendStatus := func(status string) {
+		// Skip repeated statuses so duplicate transitions don't stutter the journey
+		if status == "" || (len(summary.Journey) > 0 && summary.Journey[len(summary.Journey)-1] == status) {
+			return
+		}
+		summary.Journey = append(summary.Journey, status)
+	}
+
+	appendStatus(transitions[0].FromValue)
+	for _, transition := range transitions {
+		appendStatus(transition.ToValue)
+	}
+
+	return summary, remaining
+}
+
+// summarizeIssueTransitions replaces the status change rows of each issue with its journey
+func summarizeIssueTransitions(issues []Issue) {
+	for i := range issues {
+		issues[i].Transitions, issues[i].Changes = SummarizeTransitions(issues[i].Changes)
+	}
+}
+
+// String renders the journey, e.g. "Open → In Progress → In Review"
+func (t TransitionSummary) String() string {
+	return strings.Join(t.Journey, " → ")
+}
@@ -0,0 +1,91 @@
+package jira
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSummarizeTransitions(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2023, 1, 1, hour, 0, 0, 0, time.UTC)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name              string
+		changes           []Change
+		expectedJourney   []string
+		expectedCount     int
+		expectedRemaining int
+		expectNil         bool
+	}{
+		{
+			name: "No status changes",
+			changes: []Change{
+				{Timestamp: at(10), Field: "summary", FromValue: "Old", ToValue: "New"},
+			},
+			expectedRemaining: 1,
+			expectNil:         true,
+		},
+		{
+			name: "Out of order transitions are sorted",
+			changes: []Change{
+				{Timestamp: at(12), Field: "status", FromValue: "In Progress", ToValue: "In Review"},
+				{Timestamp: at(10), Field: "status", FromValue: "Open", ToValue: "In Progress"},
+				{Timestamp: at(11), Field: "assignee", FromValue: "", ToValue: "Test User"},
+			},
+			expectedJourney:   []string{"Open", "In Progress", "In Review"},
+			expectedCount:     2,
+			expectedRemaining: 1,
+		},
+		{
+			name: "Duplicate transitions are collapsed",
+			changes: []Change{
+				{Timestamp: at(10), Field: "status", FromValue: "Open", ToValue: "In Progress"},
+				{Timestamp: at(11), Field: "status", FromValue: "In Progress", ToValue: "In Progress"},
+				{Timestamp: at(12), Field: "status", FromValue: "In Progress", ToValue: "Open"},
+			},
+			expectedJourney:   []string{"Open", "In Progress", "Open"},
+			expectedCount:     3,
+			expectedRemaining: 0,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			summary, remaining := SummarizeTransitions(tc.changes)
+
+			if len(remaining) != tc.expectedRemaining {
+				t.Errorf("Expected %d remaining changes, got %d", tc.expectedRemaining, len(remaining))
+			}
+
+			if tc.expectNil {
+				if summary != nil {
+					t.Errorf("Expected no summary, got %v", summary)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(summary.Journey, tc.expectedJourney) {
+				t.Errorf("Expected journey %v, got %v", tc.expectedJourney, summary.Journey)
+			}
+			if summary.Count != tc.expectedCount {
+				t.Errorf("Expected %d transitions, got %d", tc.expectedCount, summary.Count)
+			}
+		})
+	}
+}
+
+func TestTransitionLine(t *testing.T) {
+	summary := &TransitionSummary{Journey: []string{"Open", "In Progress"}, Count: 1}
+	if line := transitionLine(summary); line != "Open → In Progress (1 transition)" {
+		t.Errorf("Unexpected transition line '%s'", line)
+	}
+
+	summary = &TransitionSummary{Count: 3}
+	if line := transitionLine(summary); line != "3 transitions" {
+		t.Errorf("Unexpected transition line '%s'", line)
+	}
+}
@@ -0,0 +1,184 @@
+package jira
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// limitedTransport caps the number of in-flight requests to Jira regardless of
+// how many goroutines are issuing them. A slot is held until the response body
+// is closed, since the connection stays busy while the body is read.
+type limitedTransport struct {
+	base  http.RoundTripper
+	slots chan struct{}
+}
+
+// newLimitedTransport wraps the base transport so that at most maxConcurrent
+// requests are in flight. A non-positive limit returns the base transport unchanged.
+func newLimitedTransport(base http.RoundTripper, maxConcurrent int) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if maxConcurrent <= 0 {
+		return base
+	}
+
+	return &limitedTransport{
+		base:  base,
+		slots: make(chan struct{}, maxConcurrent),
+	}
+}
+
+// RoundTrip waits for a free slot, then performs the request with the base transport
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		t.release()
+		return resp, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.release}
+	return resp, nil
+}
+
+// release frees a slot for the next request
+func (t *limitedTransport) release() {
+	<-t.slots
+}
+
+// releasingBody frees its transport slot once the response body is closed
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close closes the body and frees the slot exactly once
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// compressedTransport requests gzip-compressed responses and decompresses them
+// itself so that both the wire size and the decoded size can be recorded.
+// The standard transport only reports the decoded size when it handles gzip.
+type compressedTransport struct {
+	base    http.RoundTripper
+	metrics *MetricsRecorder
+}
+
+// newCompressedTransport wraps the base transport with gzip handling and metrics
+func newCompressedTransport(base http.RoundTripper, metrics *MetricsRecorder) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if metrics == nil {
+		metrics = NewMetricsRecorder()
+	}
+
+	return &compressedTransport{
+		base:    base,
+		metrics: metrics,
+	}
+}
+
+// RoundTrip asks for gzip, then decodes and meters the response body
+func (t *compressedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only ask for gzip if the caller has not negotiated an encoding itself
+	requestedGzip := false
+	if req.Header.Get("Accept-Encoding") == "" && req.Method != http.MethodHead {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", "gzip")
+		requestedGzip = true
+	}
+
+	t.metrics.addRequest()
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+
+	wire := &countingReader{reader: resp.Body, count: t.metrics.addTransferred}
+
+	if requestedGzip && strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		resp.Body = &gzipBody{wire: wire, closer: resp.Body, metrics: t.metrics}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+		return resp, nil
+	}
+
+	resp.Body = &meteredBody{
+		Reader: &countingReader{reader: wire, count: t.metrics.addDecoded},
+		closer: resp.Body,
+	}
+	return resp, nil
+}
+
+// countingReader reports the number of bytes read through it
+type countingReader struct {
+	reader io.Reader
+	count  func(int)
+}
+
+// Read reads from the underlying reader and counts the bytes
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.count(n)
+	}
+	return n, err
+}
+
+// meteredBody is an uncompressed response body whose bytes are counted
+type meteredBody struct {
+	io.Reader
+	closer io.Closer
+}
+
+// Close closes the underlying response body
+func (b *meteredBody) Close() error {
+	return b.closer.Close()
+}
+
+// gzipBody lazily decompresses a gzip response body, counting the decoded bytes
+type gzipBody struct {
+	wire    io.Reader
+	closer  io.Closer
+	metrics *MetricsRecorder
+	reader  io.Reader
+	err     error
+}
+
+// Read decompresses the next chunk of the body
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		gz, err := gzip.NewReader(b.wire)
+		if err != nil {
+			b.err = err
+		} else {
+			b.reader = &countingReader{reader: gz, count: b.metrics.addDecoded}
+		}
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+// Close closes the underlying response body
+func (b *gzipBody) Close() error {
+	return b.closer.Close()
+}
@@ -0,0 +1,158 @@
+package jira
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimitedTransport_MaxConcurrent(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name          string
+		maxConcurrent int
+		requests      int
+		expectedMax   int32
+	}{
+		{
+			name:          "Limited to one request",
+			maxConcurrent: 1,
+			requests:      5,
+			expectedMax:   1,
+		},
+		{
+			name:          "Limited to two requests",
+			maxConcurrent: 2,
+			requests:      6,
+			expectedMax:   2,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var inFlight, maxInFlight int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					observed := atomic.LoadInt32(&maxInFlight)
+					if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				w.Write([]byte("ok"))
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: newLimitedTransport(http.DefaultTransport, tc.maxConcurrent)}
+
+			var wg sync.WaitGroup
+			for i := 0; i < tc.requests; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					resp, err := client.Get(server.URL)
+					if err != nil {
+						t.Errorf("Unexpected error: %v", err)
+						return
+					}
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}()
+			}
+			wg.Wait()
+
+			if maxInFlight > tc.expectedMax {
+				t.Errorf("Expected at most %d concurrent requests, got %d", tc.expectedMax, maxInFlight)
+			}
+		})
+	}
+}
+
+func TestNewLimitedTransport_Unlimited(t *testing.T) {
+	transport := newLimitedTransport(http.DefaultTransport, 0)
+	if transport != http.DefaultTransport {
+		t.Errorf("Expected the base transport to be returned when unlimited")
+	}
+}
+
+func TestCompressedTransport(t *testing.T) {
+	payload := strings.Repeat(`{"key":"JIRA-123","summary":"Test Issue"}`, 100)
+
+	// Setup test cases
+	testCases := []struct {
+		name             string
+		gzipResponse     bool
+		expectCompressed bool
+	}{
+		{
+			name:             "Gzip response is decoded and metered",
+			gzipResponse:     true,
+			expectCompressed: true,
+		},
+		{
+			name:             "Plain response is metered",
+			gzipResponse:     false,
+			expectCompressed: false,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept-Encoding") != "gzip" {
+					t.Errorf("Expected Accept-Encoding gzip, got '%s'", r.Header.Get("Accept-Encoding"))
+				}
+				if !tc.gzipResponse {
+					w.Write([]byte(payload))
+					return
+				}
+				w.Header().Set("Content-Encoding", "gzip")
+				gz := gzip.NewWriter(w)
+				gz.Write([]byte(payload))
+				gz.Close()
+			}))
+			defer server.Close()
+
+			metrics := NewMetricsRecorder()
+			client := &http.Client{Transport: newCompressedTransport(&http.Transport{}, metrics)}
+
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if string(body) != payload {
+				t.Errorf("Expected the decoded payload, got %d bytes", len(body))
+			}
+
+			snapshot := metrics.Snapshot()
+			if snapshot.Requests != 1 {
+				t.Errorf("Expected 1 request, got %d", snapshot.Requests)
+			}
+			if snapshot.BytesDecoded != int64(len(payload)) {
+				t.Errorf("Expected %d decoded bytes, got %d", len(payload), snapshot.BytesDecoded)
+			}
+			if tc.expectCompressed && snapshot.BytesTransferred >= snapshot.BytesDecoded {
+				t.Errorf("Expected fewer bytes transferred than decoded, got %d >= %d", snapshot.BytesTransferred, snapshot.BytesDecoded)
+			}
+			if !tc.expectCompressed && snapshot.BytesTransferred != snapshot.BytesDecoded {
+				t.Errorf("Expected %d bytes transferred, got %d", snapshot.BytesDecoded, snapshot.BytesTransferred)
+			}
+		})
+	}
+}
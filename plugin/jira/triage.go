@@ -0,0 +1,45 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// noPriority is shown for triage issues without a priority
+const noPriority = "No priority"
+
+// TriageLabel returns the priority, type and status of a triage issue, e.g.
+// "High · Bug · Open"
+func TriageLabel(issue Issue) string {
+	priority := issue.Priority
+	if priority == "" {
+		priority = noPriority
+	}
+
+	parts := []string{priority}
+	if issue.Type != "" {
+		parts = append(parts, issue.Type)
+	}
+	if issue.Status != "" {
+		parts = append(parts, issue.Status)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// getTriageReport lists the bugs and incidents created within the time range,
+// whoever they are assigned to. Jira orders them by priority, so the order is kept.
+func (s *ActivityService) getTriageReport(timeRange TimeRange, user User, options ReportOptions) (*ActivityReport, error) {
+	issues, err := s.repository.GetSupplementaryIssues(SupplementaryTriage, timeRange, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the issues created for triage: %w", err)
+	}
+
+	report := &ActivityReport{
+		TimeRange: timeRange,
+		User:      user,
+		Triage:    issues,
+		Options:   options,
+	}
+	s.recordGeneration(report, false)
+	return report, nil
+}
@@ -0,0 +1,135 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+	plugin "github.com/iures/daivplug"
+)
+
+func TestTriageLabel(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		issue    Issue
+		expected string
+	}{
+		{name: "Complete", issue: Issue{Priority: "Highest", Type: "Incident", Status: "Open"}, expected: "Highest · Incident · Open"},
+		{name: "No priority", issue: Issue{Type: "Bug", Status: "Open"}, expected: "No priority · Bug · Open"},
+		{name: "Priority only", issue: Issue{Priority: "Low"}, expected: "Low"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if label := TriageLabel(tc.issue); label != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, label)
+			}
+		})
+	}
+}
+
+func TestJiraAPIRepository_TriageIssues(t *testing.T) {
+	reportOptions := DefaultReportOptions()
+	reportOptions.Mode = ReportModeTriage
+	repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: DefaultQueryOptions(), ReportOptions: reportOptions})
+	server.Issues = []extJira.Issue{
+		{Key: "TEST-1", Fields: &extJira.IssueFields{
+			Summary:  "Checkout returns 500",
+			Type:     extJira.IssueType{Name: "Incident"},
+			Status:   &extJira.Status{Name: "Open"},
+			Priority: &extJira.Priority{Name: "Highest"},
+			Created:  extJira.Time(time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)),
+		}},
+	}
+
+	timeRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	issues, err := repo.GetSupplementaryIssues(SupplementaryTriage, timeRange, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Type != "Incident" || issues[0].Priority != "Highest" {
+		t.Errorf("Expected the incident with its priority, got %+v", issues)
+	}
+
+	requests := server.Requests("/rest/api/2/search")
+	if len(requests) != 1 {
+		t.Fatalf("Expected one search, got %d", len(requests))
+	}
+	if jql := requests[0].Query.Get("jql"); !strings.Contains(jql, `created >= "2023-01-01 00:00"`) || strings.Contains(jql, "assignee") {
+		t.Errorf("Expected issues created in the range whoever the assignee, got '%s'", jql)
+	}
+	fields := requests[0].Query.Get("fields")
+	if !strings.Contains(fields, "issuetype") || !strings.Contains(fields, "priority") {
+		t.Errorf("Expected the type and priority to be requested, got '%s'", fields)
+	}
+}
+
+func TestActivityService_TriageMode(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			t.Errorf("Expected triage mode not to search the user's activity")
+			return nil, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			if kind != SupplementaryTriage {
+				t.Errorf("Expected the triage query, got %s", kind)
+			}
+			return []Issue{
+				{Key: "OPS-7", Summary: "Checkout | 500s", Status: "Open", Type: "Incident", Priority: "Highest", Reporter: User{DisplayName: "On Call"}},
+				{Key: "OPS-3", Summary: "Typo on receipt", Status: "Open", Type: "Bug", Priority: "Low"},
+			}, nil
+		},
+	}
+
+	options := DefaultReportOptions()
+	options.Mode = ReportModeTriage
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Triage) != 2 || report.Triage[0].Key != "OPS-7" {
+		t.Fatalf("Expected the triage issues in priority order, got %+v", report.Triage)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		formatter ReportFormatter
+		expected  []string
+	}{
+		{formatter: NewMarkdownFormatter(), expected: []string{"## New Bugs and Incidents\n\n- [OPS-7] Checkout \\| 500s (Highest · Incident · Open)\n- [OPS-3] Typo on receipt (Low · Bug · Open)"}},
+		{formatter: NewHTMLFormatter(), expected: []string{"<h2>New Bugs and Incidents</h2>", "Typo on receipt <span class=\"timestamp\">(Low · Bug · Open)</span>"}},
+		{formatter: NewJSONFormatter(), expected: []string{`"triage": [`, `"priority": "Highest"`, `"reporter": "On Call"`}},
+		{formatter: NewXMLFormatter(), expected: []string{"<triage>", "<priority>Low</priority>"}},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.formatter.Name(), func(t *testing.T) {
+			result, err := tc.formatter.Format(report)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected content to contain '%s', got '%s'", expected, result.Content)
+				}
+			}
+		})
+	}
+}
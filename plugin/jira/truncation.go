@@ -0,0 +1,29 @@
+package jira
+
+import "fmt"
+
+// SearchTruncation discloses that the activity search matched more issues than
+// it returned, because the results are limited to jira.query.max_results or
+// the report ran out of its jira.report.max_tokens budget
+type SearchTruncation struct {
+	Shown         int  // Issues returned by the search
+	Total         int  // Issues matching the query
+	BudgetReached bool // Set when paging stopped because the report was full
+}
+
+// newSearchTruncation returns the truncation of a search that returned shown
+// of total matching issues, or nil if nothing was left out
+func newSearchTruncation(shown, total int) *SearchTruncation {
+	if total <= shown {
+		return nil
+	}
+	return &SearchTruncation{Shown: shown, Total: total}
+}
+
+// Notice renders the truncation for readers, e.g. "Showing 100 of 342 issues"
+func (t SearchTruncation) Notice() string {
+	if t.BudgetReached {
+		return fmt.Sprintf("Showing %d of %d issues; the report reached its jira.report.max_tokens budget", t.Shown, t.Total)
+	}
+	return fmt.Sprintf("Showing %d of %d issues; raise jira.query.max_results to include the rest", t.Shown, t.Total)
+}
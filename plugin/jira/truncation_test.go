@@ -0,0 +1,47 @@
+package jira
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestNewSearchTruncation(t *testing.T) {
+	if truncation := newSearchTruncation(20, 20); truncation != nil {
+		t.Errorf("Expected no truncation when every issue was returned, got %+v", truncation)
+	}
+
+	truncation := newSearchTruncation(100, 342)
+	if truncation == nil {
+		t.Fatal("Expected a truncation")
+	}
+	expected := "Showing 100 of 342 issues; raise jira.query.max_results to include the rest"
+	if notice := truncation.Notice(); notice != expected {
+		t.Errorf("Expected notice '%s', got '%s'", expected, notice)
+	}
+}
+
+func TestJiraAPIRepository_GetIssues_Truncation(t *testing.T) {
+	options := DefaultQueryOptions()
+	options.MaxResults = 100
+	repo, server := newServerRepository(t, &JiraConfig{Project: "TEST", QueryOptions: options})
+	for i := 1; i <= 342; i++ {
+		server.Issues = append(server.Issues, extJira.Issue{
+			Key:    fmt.Sprintf("JIRA-%d", i),
+			Fields: &extJira.IssueFields{Summary: "Issue", Status: &extJira.Status{Name: "In Progress"}},
+		})
+	}
+
+	_, truncation, err := repo.GetIssues(TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, "user123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if truncation == nil || truncation.Shown != 100 || truncation.Total != 342 {
+		t.Errorf("Expected 100 of 342 issues shown, got %+v", truncation)
+	}
+}
@@ -0,0 +1,156 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// LatestReleaseURL is the GitHub API endpoint describing the latest
+	// release of the plugin
+	LatestReleaseURL = "https://api.github.com/repos/iures/daiv-jira/releases/latest"
+	// upgradeCheckTimeout bounds the release lookup, so that an unreachable
+	// GitHub does not hold up the start of the plugin
+	upgradeCheckTimeout = 5 * time.Second
+)
+
+// Release is a published release of the plugin
+type Release struct {
+	Version string // The tag of the release, e.g. v1.4.0
+	URL     string // The release page
+}
+
+// CheckForUpgrade looks up the latest release at releaseURL and returns it
+// when it is newer than the current version, or nil otherwise. Development
+// builds, whose version is not a release tag, are never told to upgrade.
+// A nil client uses one with a timeout.
+func CheckForUpgrade(client *http.Client, releaseURL, current string) (*Release, error) {
+	currentVersion, ok := parseReleaseVersion(current)
+	if !ok {
+		return nil, nil
+	}
+	if client == nil {
+		client = &http.Client{Timeout: upgradeCheckTimeout}
+	}
+
+	request, err := http.NewRequest(http.MethodGet, releaseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the request: %w", err)
+	}
+	request.Header.Set("Accept", "application/vnd.github+json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the latest release: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to look up the latest release: status %d", response.StatusCode)
+	}
+
+	var latest struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&latest); err != nil {
+		return nil, fmt.Errorf("failed to read the latest release: %w", err)
+	}
+	latestVersion, ok := parseReleaseVersion(latest.TagName)
+	if !ok {
+		return nil, fmt.Errorf("unexpected release tag %q", latest.TagName)
+	}
+	// Pre-releases are for those who seek them out, not an upgrade to offer
+	if latestVersion.prerelease != "" {
+		return nil, nil
+	}
+
+	if compareReleaseVersions(latestVersion, currentVersion) <= 0 {
+		return nil, nil
+	}
+	return &Release{Version: latest.TagName, URL: latest.HTMLURL}, nil
+}
+
+// describeSuffixPattern matches what git describe appends to the tag of a
+// build made after it, as in v1.4.0-3-gabc1234 or v1.4.0-dirty
+var describeSuffixPattern = regexp.MustCompile(`(-[0-9]+-g[0-9a-f]+)?(-dirty)?$`)
+
+// releaseVersion is a parsed semantic version
+type releaseVersion struct {
+	numbers    [3]int // Major, minor and patch
+	prerelease string // e.g. rc1 in v1.4.0-rc1; empty for a release
+}
+
+// parseReleaseVersion parses a version such as v1.4.0 or v1.4.0-rc1. Build
+// metadata and the suffix git describe adds to builds made after a tag, as in
+// v1.4.0-3-gabc1234, are ignored, so such builds count as their tag.
+func parseReleaseVersion(version string) (releaseVersion, bool) {
+	var parsed releaseVersion
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if end := strings.Index(version, "+"); end >= 0 {
+		version = version[:end]
+	}
+	version = describeSuffixPattern.ReplaceAllString(version, "")
+	version, parsed.prerelease, _ = strings.Cut(version, "-")
+
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return parsed, false
+	}
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return parsed, false
+		}
+		parsed.numbers[i] = number
+	}
+	return parsed, true
+}
+
+// compareReleaseVersions returns a negative number when a is older than b, a
+// positive one when it is newer, and 0 when they are the same. As in semantic
+// versioning, a pre-release comes before its release, and pre-releases of the
+// same version compare by their dot-separated identifiers.
+func compareReleaseVersions(a, b releaseVersion) int {
+	for i := range a.numbers {
+		if a.numbers[i] != b.numbers[i] {
+			return a.numbers[i] - b.numbers[i]
+		}
+	}
+	switch {
+	case a.prerelease == b.prerelease:
+		return 0
+	case a.prerelease == "":
+		return 1
+	case b.prerelease == "":
+		return -1
+	}
+
+	aParts, bParts := strings.Split(a.prerelease, "."), strings.Split(b.prerelease, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if c := comparePrereleaseIdentifiers(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+	return len(aParts) - len(bParts)
+}
+
+// comparePrereleaseIdentifiers compares two identifiers of a pre-release:
+// numbers numerically and before words, words in ASCII order
+func comparePrereleaseIdentifiers(a, b string) int {
+	aNumber, aErr := strconv.Atoi(a)
+	bNumber, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return aNumber - bNumber
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,123 @@
+package jira
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckForUpgrade(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		current     string
+		response    string
+		status      int
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "Newer release",
+			current:  "v1.3.2",
+			response: `{"tag_name":"v1.4.0","html_url":"https://github.com/iures/daiv-jira/releases/tag/v1.4.0"}`,
+			status:   http.StatusOK,
+			expected: "v1.4.0",
+		},
+		{
+			name:     "Up to date",
+			current:  "v1.4.0",
+			response: `{"tag_name":"v1.4.0"}`,
+			status:   http.StatusOK,
+		},
+		{
+			name:     "Built after the release",
+			current:  "v1.4.0-3-gabc1234-dirty",
+			response: `{"tag_name":"v1.4.0"}`,
+			status:   http.StatusOK,
+		},
+		{
+			name:     "Release of the running pre-release",
+			current:  "v1.4.0-rc1",
+			response: `{"tag_name":"v1.4.0"}`,
+			status:   http.StatusOK,
+			expected: "v1.4.0",
+		},
+		{
+			name:     "Pre-release",
+			current:  "v1.3.2",
+			response: `{"tag_name":"v1.4.0-rc1"}`,
+			status:   http.StatusOK,
+		},
+		{
+			name:    "Development build",
+			current: "dev",
+			status:  http.StatusInternalServerError,
+		},
+		{
+			name:        "Rate limited",
+			current:     "v1.3.2",
+			status:      http.StatusForbidden,
+			expectedErr: "status 403",
+		},
+		{
+			name:        "Unexpected tag",
+			current:     "v1.3.2",
+			response:    `{"tag_name":"nightly"}`,
+			status:      http.StatusOK,
+			expectedErr: `unexpected release tag "nightly"`,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			release, err := CheckForUpgrade(nil, server.URL, tc.current)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			switch {
+			case tc.expected == "" && release != nil:
+				t.Errorf("Expected no upgrade, got %+v", release)
+			case tc.expected != "" && (release == nil || release.Version != tc.expected):
+				t.Errorf("Expected an upgrade to %s, got %+v", tc.expected, release)
+			}
+		})
+	}
+}
+
+func TestCompareReleaseVersions(t *testing.T) {
+	parse := func(version string) releaseVersion {
+		parsed, ok := parseReleaseVersion(version)
+		if !ok {
+			t.Fatalf("Expected %s to parse", version)
+		}
+		return parsed
+	}
+
+	// Each version is older than the next
+	versions := []string{"v1.3.2", "v1.4.0-alpha", "v1.4.0-alpha.1", "v1.4.0-alpha.beta", "v1.4.0-rc.2", "v1.4.0-rc.10", "v1.4.0", "v1.4.1"}
+	for i := 1; i < len(versions); i++ {
+		if c := compareReleaseVersions(parse(versions[i]), parse(versions[i-1])); c <= 0 {
+			t.Errorf("Expected %s to be newer than %s, got %d", versions[i], versions[i-1], c)
+		}
+	}
+
+	// A build made after a tag counts as the tag
+	if c := compareReleaseVersions(parse("v1.4.0-rc.10-2-gabc1234"), parse("v1.4.0-rc.10")); c != 0 {
+		t.Errorf("Expected a build after v1.4.0-rc.10 to count as it, got %d", c)
+	}
+}
@@ -0,0 +1,96 @@
+package jira
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// lookupEmail resolves the email address of an account through the user search
+// API. The search is keyed by the configured username, which on Jira Cloud is
+// the account's email address. Failures are not fatal since the lookup is only
+// permitted on some instances; an empty string is returned instead.
+func (r *JiraAPIRepository) lookupEmail(accountID string) string {
+	query := strings.TrimSpace(r.config.Username)
+	if query == "" || accountID == "" {
+		return ""
+	}
+
+	users, err := r.findUsers(query)
+	if err != nil {
+		return ""
+	}
+
+	for _, user := range users {
+		if user.AccountID != accountID {
+			continue
+		}
+		if user.EmailAddress != "" {
+			return user.EmailAddress
+		}
+		// A search by email that matched the account confirms the address
+		if strings.Contains(query, "@") {
+			return query
+		}
+	}
+	return ""
+}
+
+// findUsers searches for users matching the query
+func (r *JiraAPIRepository) findUsers(query string) ([]extJira.User, error) {
+	return r.api.FindUsers(query)
+}
+
+// userLookupPageSize is the number of accounts resolved per bulk request, the
+// maximum the bulk user API accepts
+const userLookupPageSize = 90
+
+// GetUsers retrieves the profiles of the given accounts using the bulk user API
+func (r *JiraAPIRepository) GetUsers(accountIDs []string) ([]User, error) {
+	users := make([]User, 0, len(accountIDs))
+
+	for start := 0; start < len(accountIDs); start += userLookupPageSize {
+		end := start + userLookupPageSize
+		if end > len(accountIDs) {
+			end = len(accountIDs)
+		}
+
+		rawUsers, err := r.bulkUsers(accountIDs[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up users: %w", err)
+		}
+		for i := range rawUsers {
+			users = append(users, userFromJira(&rawUsers[i]))
+		}
+	}
+
+	return users, nil
+}
+
+// bulkUsers fetches a single page of accounts from the bulk user API
+func (r *JiraAPIRepository) bulkUsers(accountIDs []string) ([]extJira.User, error) {
+	params := url.Values{}
+	params.Set("maxResults", fmt.Sprintf("%d", len(accountIDs)))
+	for _, accountID := range accountIDs {
+		params.Add("accountId", accountID)
+	}
+
+	var page struct {
+		Values []extJira.User `json:"values"`
+	}
+	if err := r.getJSON("rest/api/2/user/bulk?"+params.Encode(), &page); err != nil {
+		return nil, err
+	}
+	return page.Values, nil
+}
+
+// reportEmail returns the user's email address for the report header, or an
+// empty string when it is unknown or hidden by the report options
+func reportEmail(report *ActivityReport) string {
+	if report.Options.HideEmail {
+		return ""
+	}
+	return report.User.Email
+}
@@ -0,0 +1,135 @@
+package jira
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestJiraAPIRepository_GetUser_HiddenEmail(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name          string
+		username      string
+		resolveEmail  bool
+		users         []extJira.User
+		findErr       bool
+		expectedEmail string
+		expectSearch  bool
+	}{
+		{
+			name:          "Search returns the email",
+			username:      "test@example.com",
+			resolveEmail:  true,
+			users:         []extJira.User{{AccountID: "user123", EmailAddress: "test@example.com"}},
+			expectedEmail: "test@example.com",
+			expectSearch:  true,
+		},
+		{
+			name:          "Search by email matches the account",
+			username:      "test@example.com",
+			resolveEmail:  true,
+			users:         []extJira.User{{AccountID: "user123"}},
+			expectedEmail: "test@example.com",
+			expectSearch:  true,
+		},
+		{
+			name:         "Search matches another account",
+			username:     "test@example.com",
+			resolveEmail: true,
+			users:        []extJira.User{{AccountID: "other"}},
+			expectSearch: true,
+		},
+		{
+			name:         "Search not permitted",
+			username:     "test@example.com",
+			resolveEmail: true,
+			findErr:      true,
+			expectSearch: true,
+		},
+		{
+			name:         "Lookup disabled",
+			username:     "test@example.com",
+			resolveEmail: false,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := DefaultQueryOptions()
+			options.ResolveEmail = tc.resolveEmail
+			repo, server := newServerRepository(t, &JiraConfig{Username: tc.username, QueryOptions: options})
+			server.Self = &extJira.User{AccountID: "user123", DisplayName: "Test User"}
+			server.UserSearch = map[string][]extJira.User{tc.username: tc.users}
+			if tc.findErr {
+				server.Fail("/rest/api/2/user/search", http.StatusForbidden, "Browse users and groups permission required.")
+			}
+
+			user, err := repo.GetUser()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if user.Email != tc.expectedEmail {
+				t.Errorf("Expected email '%s', got '%s'", tc.expectedEmail, user.Email)
+			}
+			searches := server.Requests("/rest/api/2/user/search")
+			if searched := len(searches) > 0; searched != tc.expectSearch {
+				t.Errorf("Expected search %v, got %v", tc.expectSearch, searched)
+			}
+			if len(searches) > 0 && searches[0].Query.Get("query") != tc.username {
+				t.Errorf("Expected search for '%s', got '%s'", tc.username, searches[0].Query.Get("query"))
+			}
+		})
+	}
+}
+
+func TestFormatters_UserWithoutEmail(t *testing.T) {
+	report := &ActivityReport{
+		TimeRange: TimeRange{
+			Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		User:    User{DisplayName: "Test User", Email: "test@example.com"},
+		Issues:  []Issue{{Key: "JIRA-1", Summary: "Test issue", Status: "Open"}},
+		Options: DefaultReportOptions(),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name      string
+		email     string
+		hideEmail bool
+	}{
+		{name: "Email hidden by Jira", email: ""},
+		{name: "Email hidden by the report options", email: "test@example.com", hideEmail: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report.User.Email = tc.email
+			report.Options.HideEmail = tc.hideEmail
+
+			formatters := []ReportFormatter{NewJSONFormatter(), NewMarkdownFormatter(), NewHTMLFormatter()}
+			for _, formatter := range formatters {
+				result, err := formatter.Format(report)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if !strings.Contains(result.Content, "Test User") {
+					t.Errorf("Expected %s content to contain the user, got '%s'", formatter.Name(), result.Content)
+				}
+				for _, unexpected := range []string{"()", "test@example.com", `"email"`} {
+					if strings.Contains(result.Content, unexpected) {
+						t.Errorf("Expected %s content not to contain '%s', got '%s'", formatter.Name(), unexpected, result.Content)
+					}
+				}
+			}
+		})
+	}
+}
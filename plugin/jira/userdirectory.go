@@ -0,0 +1,272 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultUserCacheTTL is how long a resolved user profile is reused before it is refreshed
+const DefaultUserCacheTTL = 24 * time.Hour
+
+// CachedUser is a user profile together with the time it was resolved
+type CachedUser struct {
+	User      User      `json:"user"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// UserCache stores resolved user profiles between reports
+type UserCache interface {
+	Get(accountID string) (CachedUser, bool)
+	Put(users []CachedUser) error
+}
+
+// MemoryUserCache is a UserCache that lives for the lifetime of the process
+type MemoryUserCache struct {
+	mu    sync.Mutex
+	users map[string]CachedUser
+}
+
+// NewMemoryUserCache creates an empty in-memory user cache
+func NewMemoryUserCache() *MemoryUserCache {
+	return &MemoryUserCache{users: make(map[string]CachedUser)}
+}
+
+// Get returns the cached profile of an account
+func (c *MemoryUserCache) Get(accountID string) (CachedUser, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	user, ok := c.users[accountID]
+	return user, ok
+}
+
+// Put stores the given profiles
+func (c *MemoryUserCache) Put(users []CachedUser) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, user := range users {
+		c.users[user.User.AccountID] = user
+	}
+	return nil
+}
+
+// FileUserCache is a UserCache persisted as a JSON file, so that profiles are
+// reused across daiv runs
+type FileUserCache struct {
+	path   string
+	mu     sync.Mutex
+	users  map[string]CachedUser
+	loaded bool
+}
+
+// NewFileUserCache creates a user cache stored at the given path
+func NewFileUserCache(path string) *FileUserCache {
+	return &FileUserCache{path: path}
+}
+
+// DefaultUserCachePath returns the default location of the persistent user cache
+func DefaultUserCachePath() (string, error) {
+	return defaultStorePath(os.UserCacheDir, "users.json")
+}
+
+// Get returns the cached profile of an account
+func (c *FileUserCache) Get(accountID string) (CachedUser, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	user, ok := c.users[accountID]
+	return user, ok
+}
+
+// Put stores the given profiles and writes the cache file
+func (c *FileUserCache) Put(users []CachedUser) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	for _, user := range users {
+		c.users[user.User.AccountID] = user
+	}
+
+	data, err := json.MarshalIndent(c.users, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode user cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create user cache directory: %w", err)
+	}
+
+	if err := writeFileAtomic(c.path, data); err != nil {
+		return fmt.Errorf("failed to write user cache: %w", err)
+	}
+	return nil
+}
+
+// load reads the cache file once; a missing or corrupt file starts an empty cache
+func (c *FileUserCache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	c.users = make(map[string]CachedUser)
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &c.users); err != nil {
+		c.users = make(map[string]CachedUser)
+	}
+}
+
+// UserDirectory batch-resolves the account IDs appearing in a report to user
+// profiles, consulting the cache before asking Jira
+type UserDirectory struct {
+	fetch func(accountIDs []string) ([]User, error)
+	cache UserCache
+	ttl   time.Duration
+	clock Clock
+}
+
+// NewUserDirectory creates a user directory that resolves uncached accounts
+// with fetch. A non-positive ttl uses DefaultUserCacheTTL.
+func NewUserDirectory(fetch func(accountIDs []string) ([]User, error), cache UserCache, ttl time.Duration) *UserDirectory {
+	if cache == nil {
+		cache = NewMemoryUserCache()
+	}
+	if ttl <= 0 {
+		ttl = DefaultUserCacheTTL
+	}
+	return &UserDirectory{
+		fetch: fetch,
+		cache: cache,
+		ttl:   ttl,
+		clock: SystemClock,
+	}
+}
+
+// SetClock sets the clock that the age of cached profiles is measured on
+func (d *UserDirectory) SetClock(clock Clock) {
+	if clock == nil {
+		clock = SystemClock
+	}
+	d.clock = clock
+}
+
+// Resolve returns the profiles of the given accounts keyed by account ID. Fresh
+// cache entries are used as-is and the remaining accounts are fetched in one
+// batch. When the fetch fails, stale cache entries are returned with the error.
+func (d *UserDirectory) Resolve(accountIDs []string) (map[string]User, error) {
+	now := d.clock.Now()
+	result := make(map[string]User, len(accountIDs))
+	missing := make([]string, 0)
+
+	for _, accountID := range normalizeList(accountIDs) {
+		cached, ok := d.cache.Get(accountID)
+		if ok {
+			result[accountID] = cached.User
+			if now.Sub(cached.FetchedAt) < d.ttl {
+				continue
+			}
+		}
+		missing = append(missing, accountID)
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	users, err := d.fetch(missing)
+	if err != nil {
+		return result, err
+	}
+
+	fetched := make([]CachedUser, 0, len(users))
+	for _, user := range users {
+		if user.AccountID == "" {
+			continue
+		}
+		result[user.AccountID] = user
+		fetched = append(fetched, CachedUser{User: user, FetchedAt: now})
+	}
+	if err := d.cache.Put(fetched); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// ResolveAuthors resolves the authors of the issues' comments, changes and worklogs and
+// rewrites their names and avatars so that every formatter shows the same
+// profile. It returns the resolved authors ordered by display name.
+func (d *UserDirectory) ResolveAuthors(issues []Issue) ([]User, error) {
+	accountIDs := make([]string, 0)
+	for _, issue := range issues {
+		for _, comment := range issue.Comments {
+			accountIDs = append(accountIDs, comment.AuthorAccountID)
+		}
+		for _, change := range issue.Changes {
+			accountIDs = append(accountIDs, change.AuthorAccountID)
+		}
+		for _, worklog := range issue.Worklogs {
+			accountIDs = append(accountIDs, worklog.AuthorAccountID)
+		}
+	}
+
+	users, err := d.Resolve(accountIDs)
+	applyAuthors(issues, users)
+
+	authors := make([]User, 0, len(users))
+	for _, user := range users {
+		authors = append(authors, user)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].DisplayName != authors[j].DisplayName {
+			return authors[i].DisplayName < authors[j].DisplayName
+		}
+		return authors[i].AccountID < authors[j].AccountID
+	})
+
+	if err != nil {
+		return authors, fmt.Errorf("failed to resolve authors: %w", err)
+	}
+	return authors, nil
+}
+
+// applyAuthors replaces author names and avatars with the resolved profiles and
+// records the authors' time zones
+func applyAuthors(issues []Issue, users map[string]User) {
+	for i := range issues {
+		for j := range issues[i].Comments {
+			comment := &issues[i].Comments[j]
+			if user, ok := users[comment.AuthorAccountID]; ok {
+				if user.DisplayName != "" {
+					comment.Author = user.DisplayName
+				}
+				if user.AvatarURL != "" {
+					comment.AuthorAvatarURL = user.AvatarURL
+				}
+				comment.AuthorTimeZone = user.TimeZone
+			}
+		}
+		for j := range issues[i].Changes {
+			change := &issues[i].Changes[j]
+			if user, ok := users[change.AuthorAccountID]; ok {
+				if user.DisplayName != "" {
+					change.Author = user.DisplayName
+				}
+				change.AuthorTimeZone = user.TimeZone
+			}
+		}
+		for j := range issues[i].Worklogs {
+			worklog := &issues[i].Worklogs[j]
+			if user, ok := users[worklog.AuthorAccountID]; ok {
+				if user.DisplayName != "" {
+					worklog.Author = user.DisplayName
+				}
+				worklog.AuthorTimeZone = user.TimeZone
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+func TestUserDirectory_Resolve(t *testing.T) {
+	now := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	cache := NewMemoryUserCache()
+	cache.Put([]CachedUser{
+		{User: User{AccountID: "fresh", DisplayName: "Fresh User"}, FetchedAt: now.Add(-time.Hour)},
+		{User: User{AccountID: "stale", DisplayName: "Old Name"}, FetchedAt: now.Add(-48 * time.Hour)},
+	})
+
+	var requested []string
+	directory := NewUserDirectory(func(accountIDs []string) ([]User, error) {
+		requested = append(requested, accountIDs...)
+		users := make([]User, 0, len(accountIDs))
+		for _, accountID := range accountIDs {
+			users = append(users, User{AccountID: accountID, DisplayName: "Fetched " + accountID, TimeZone: "Europe/Berlin"})
+		}
+		return users, nil
+	}, cache, 24*time.Hour)
+	directory.SetClock(FrozenClock(now))
+
+	users, err := directory.Resolve([]string{"fresh", "stale", "new", "new", ""})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sort.Strings(requested)
+	if !reflect.DeepEqual(requested, []string{"new", "stale"}) {
+		t.Errorf("Expected one batch for the stale and unknown accounts, got %v", requested)
+	}
+	if users["fresh"].DisplayName != "Fresh User" {
+		t.Errorf("Expected the fresh cache entry to be used, got %+v", users["fresh"])
+	}
+	if users["stale"].DisplayName != "Fetched stale" {
+		t.Errorf("Expected the stale cache entry to be refreshed, got %+v", users["stale"])
+	}
+	if cached, ok := cache.Get("new"); !ok || cached.User.TimeZone != "Europe/Berlin" || !cached.FetchedAt.Equal(now) {
+		t.Errorf("Expected the fetched profile to be cached, got %+v", cached)
+	}
+}
+
+func TestUserDirectory_ResolveFetchError(t *testing.T) {
+	cache := NewMemoryUserCache()
+	cache.Put([]CachedUser{{User: User{AccountID: "stale", DisplayName: "Old Name"}}})
+
+	directory := NewUserDirectory(func(accountIDs []string) ([]User, error) {
+		return nil, errors.New("404 Not Found")
+	}, cache, time.Hour)
+
+	users, err := directory.Resolve([]string{"stale", "unknown"})
+	if err == nil {
+		t.Fatalf("Expected an error but got nil")
+	}
+	if users["stale"].DisplayName != "Old Name" {
+		t.Errorf("Expected the stale profile to be returned, got %+v", users)
+	}
+}
+
+func TestUserDirectory_ResolveAuthors(t *testing.T) {
+	directory := NewUserDirectory(func(accountIDs []string) ([]User, error) {
+		return []User{
+			{AccountID: "a1", DisplayName: "Alice Smith", AvatarURL: "https://avatar/a1", TimeZone: "Asia/Tokyo"},
+			{AccountID: "b2", DisplayName: "Bob Jones"},
+		}, nil
+	}, nil, 0)
+
+	issues := []Issue{
+		{
+			Key: "JIRA-1",
+			Comments: []Comment{
+				{Author: "alice", AuthorAccountID: "a1"},
+				{Author: "Former User"},
+			},
+			Changes: []Change{
+				{Author: "bob", AuthorAccountID: "b2"},
+			},
+		},
+	}
+
+	authors, err := directory.ResolveAuthors(issues)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(authors) != 2 || authors[0].DisplayName != "Alice Smith" || authors[1].DisplayName != "Bob Jones" {
+		t.Errorf("Expected authors ordered by name, got %+v", authors)
+	}
+	if issues[0].Comments[0].Author != "Alice Smith" || issues[0].Comments[0].AuthorAvatarURL != "https://avatar/a1" || issues[0].Comments[0].AuthorTimeZone != "Asia/Tokyo" {
+		t.Errorf("Expected the comment author to be resolved, got %+v", issues[0].Comments[0])
+	}
+	if issues[0].Comments[1].Author != "Former User" {
+		t.Errorf("Expected the unknown author to be kept, got %+v", issues[0].Comments[1])
+	}
+	if issues[0].Changes[0].Author != "Bob Jones" {
+		t.Errorf("Expected the change author to be resolved, got %+v", issues[0].Changes[0])
+	}
+}
+
+func TestFileUserCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "users.json")
+	fetchedAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cache := NewFileUserCache(path)
+	if _, ok := cache.Get("a1"); ok {
+		t.Fatalf("Expected an empty cache")
+	}
+	if err := cache.Put([]CachedUser{{User: User{AccountID: "a1", DisplayName: "Alice", TimeZone: "UTC"}, FetchedAt: fetchedAt}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A new cache instance reads the persisted profiles
+	reloaded := NewFileUserCache(path)
+	cached, ok := reloaded.Get("a1")
+	if !ok || cached.User.DisplayName != "Alice" || cached.User.TimeZone != "UTC" || !cached.FetchedAt.Equal(fetchedAt) {
+		t.Errorf("Expected the persisted profile, got %+v (found: %v)", cached, ok)
+	}
+}
+
+func TestJiraAPIRepository_GetUsers(t *testing.T) {
+	repo, server := newServerRepository(t, &JiraConfig{QueryOptions: DefaultQueryOptions()})
+
+	accountIDs := make([]string, userLookupPageSize+1)
+	for i := range accountIDs {
+		accountIDs[i] = fmt.Sprintf("account-%d", i)
+		server.Users = append(server.Users, extJira.User{AccountID: accountIDs[i], TimeZone: "UTC"})
+	}
+
+	users, err := repo.GetUsers(accountIDs)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pages := server.Requests("/rest/api/2/user/bulk")
+	if len(pages) != 2 || len(pages[0].Query["accountId"]) != userLookupPageSize || len(pages[1].Query["accountId"]) != 1 {
+		t.Errorf("Expected two pages, got %d", len(pages))
+	}
+	if len(users) != len(accountIDs) || users[0].TimeZone != "UTC" {
+		t.Errorf("Expected %d users with time zones, got %d", len(accountIDs), len(users))
+	}
+}
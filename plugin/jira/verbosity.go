@@ -0,0 +1,89 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Verbosity controls how much detail a report contains
+type Verbosity string
+
+const (
+	// VerbosityMinimal lists issues with field names and comment authors only
+	VerbosityMinimal Verbosity = "minimal"
+	// VerbosityNormal includes comment bodies, change details and report metadata
+	VerbosityNormal Verbosity = "normal"
+	// VerbosityFull additionally includes issue descriptions
+	VerbosityFull Verbosity = "full"
+)
+
+// ParseVerbosity converts a configuration value to a Verbosity
+func ParseVerbosity(value string) (Verbosity, error) {
+	switch Verbosity(strings.ToLower(strings.TrimSpace(value))) {
+	case VerbosityMinimal:
+		return VerbosityMinimal, nil
+	case VerbosityNormal, "":
+		return VerbosityNormal, nil
+	case VerbosityFull:
+		return VerbosityFull, nil
+	default:
+		return "", fmt.Errorf("unknown verbosity %q (expected minimal, normal or full)", value)
+	}
+}
+
+// IncludeCommentBodies reports whether comment bodies are part of the report
+func (v Verbosity) IncludeCommentBodies() bool {
+	return v != VerbosityMinimal
+}
+
+// IncludeChangeDetails reports whether the from/to values of changes are part of the report
+func (v Verbosity) IncludeChangeDetails() bool {
+	return v != VerbosityMinimal
+}
+
+// IncludeMetadata reports whether the report header (time range, user) is rendered
+func (v Verbosity) IncludeMetadata() bool {
+	return v != VerbosityMinimal
+}
+
+// IncludeDescriptions reports whether issue descriptions are part of the report
+func (v Verbosity) IncludeDescriptions() bool {
+	return v == VerbosityFull
+}
+
+// applyVerbosity strips the report content excluded by its verbosity level so
+// that every formatter renders the same amount of detail
+func applyVerbosity(report *ActivityReport) {
+	verbosity := report.Options.Verbosity
+
+	for i := range report.Issues {
+		issue := &report.Issues[i]
+
+		if !verbosity.IncludeDescriptions() {
+			issue.Description = ""
+		}
+
+		if !verbosity.IncludeCommentBodies() {
+			for j := range issue.Comments {
+				issue.Comments[j].Content = ""
+			}
+			for j := range issue.Worklogs {
+				issue.Worklogs[j].Comment = ""
+			}
+		}
+
+		if !verbosity.IncludeChangeDetails() {
+			for j := range issue.Changes {
+				issue.Changes[j].FromValue = ""
+				issue.Changes[j].ToValue = ""
+				issue.Changes[j].Diff = nil
+			}
+			if issue.Transitions != nil {
+				issue.Transitions.Journey = nil
+			}
+			// Label, component and watcher changes are nothing but their details
+			issue.CollectionChanges = nil
+			issue.Attention = nil
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseVerbosity(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		value       string
+		expected    Verbosity
+		expectError bool
+	}{
+		{name: "Minimal", value: "minimal", expected: VerbosityMinimal},
+		{name: "Normal", value: "normal", expected: VerbosityNormal},
+		{name: "Full with mixed case", value: " Full ", expected: VerbosityFull},
+		{name: "Empty defaults to normal", value: "", expected: VerbosityNormal},
+		{name: "Unknown level", value: "verbose", expectError: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseVerbosity(tc.value)
+
+			if tc.expectError && err == nil {
+				t.Errorf("Expected an error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if !tc.expectError && result != tc.expected {
+				t.Errorf("Expected verbosity %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func newVerbosityTestReport(verbosity Verbosity) *ActivityReport {
+	return &ActivityReport{
+		TimeRange: TimeRange{
+			Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		User: User{DisplayName: "Test User", Email: "test@example.com"},
+		Issues: []Issue{
+			{
+				Key:         "JIRA-123",
+				Summary:     "Test Issue",
+				Status:      "In Progress",
+				Description: "Long description",
+				Comments: []Comment{
+					{Timestamp: time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC), Author: "Test User", Content: "Comment body"},
+				},
+				Changes: []Change{
+					{Timestamp: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC), Author: "Test User", Field: "status", FromValue: "Open", ToValue: "Reviewing"},
+				},
+				CollectionChanges: []CollectionChange{
+					{Timestamp: time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC), Author: "Test User", Field: LabelsField, Added: []string{"hotfix-candidate"}},
+				},
+				Attention: &AttentionChange{Watchers: 3, WatchersGained: 2},
+			},
+		},
+		Options: ReportOptions{Verbosity: verbosity},
+	}
+}
+
+func TestApplyVerbosity(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name                string
+		verbosity           Verbosity
+		expectDescription   bool
+		expectCommentBody   bool
+		expectChangeDetails bool
+	}{
+		{name: "Minimal", verbosity: VerbosityMinimal},
+		{name: "Normal", verbosity: VerbosityNormal, expectCommentBody: true, expectChangeDetails: true},
+		{name: "Full", verbosity: VerbosityFull, expectDescription: true, expectCommentBody: true, expectChangeDetails: true},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := newVerbosityTestReport(tc.verbosity)
+			applyVerbosity(report)
+			issue := report.Issues[0]
+
+			if (issue.Description != "") != tc.expectDescription {
+				t.Errorf("Expected description present=%v, got '%s'", tc.expectDescription, issue.Description)
+			}
+			if (issue.Comments[0].Content != "") != tc.expectCommentBody {
+				t.Errorf("Expected comment body present=%v, got '%s'", tc.expectCommentBody, issue.Comments[0].Content)
+			}
+			if (issue.Changes[0].ToValue != "") != tc.expectChangeDetails {
+				t.Errorf("Expected change details present=%v, got '%s'", tc.expectChangeDetails, issue.Changes[0].ToValue)
+			}
+			if (len(issue.CollectionChanges) > 0) != tc.expectChangeDetails {
+				t.Errorf("Expected label changes present=%v, got %+v", tc.expectChangeDetails, issue.CollectionChanges)
+			}
+			if (issue.Attention != nil) != tc.expectChangeDetails {
+				t.Errorf("Expected watcher changes present=%v, got %+v", tc.expectChangeDetails, issue.Attention)
+			}
+		})
+	}
+}
+
+func TestFormatters_RespectVerbosity(t *testing.T) {
+	formatters := []ReportFormatter{
+		NewXMLFormatter(),
+		NewJSONFormatter(),
+		NewMarkdownFormatter(),
+		NewHTMLFormatter(),
+	}
+
+	for _, formatter := range formatters {
+		t.Run(formatter.Name(), func(t *testing.T) {
+			minimal := newVerbosityTestReport(VerbosityMinimal)
+			applyVerbosity(minimal)
+			result, err := formatter.Format(minimal)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, unexpected := range []string{"Comment body", "Reviewing", "test@example.com", "Long description", "hotfix-candidate"} {
+				if strings.Contains(result.Content, unexpected) {
+					t.Errorf("Expected minimal output not to contain '%s', got '%s'", unexpected, result.Content)
+				}
+			}
+
+			full := newVerbosityTestReport(VerbosityFull)
+			applyVerbosity(full)
+			result, err = formatter.Format(full)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range []string{"Comment body", "Reviewing", "Long description", "hotfix-candidate"} {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("Expected full output to contain '%s', got '%s'", expected, result.Content)
+				}
+			}
+		})
+	}
+}
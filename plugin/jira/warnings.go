@@ -0,0 +1,108 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WarningKind classifies a problem that did not prevent the report
+type WarningKind string
+
+const (
+	// WarningPermission is a request Jira refused, such as a project the user
+	// cannot browse
+	WarningPermission WarningKind = "permission"
+	// WarningParse is Jira data that could not be read, such as a malformed timestamp
+	WarningParse WarningKind = "parse"
+	// WarningTruncation is a search that returned fewer issues than it matched
+	WarningTruncation WarningKind = "truncation"
+	// WarningIncomplete is a section or detail left out because a request failed
+	WarningIncomplete WarningKind = "incomplete"
+)
+
+// ReportWarning is a non-fatal problem met while building a report, shown in
+// its footer so that missing data is not silently mistaken for no activity
+type ReportWarning struct {
+	Kind    WarningKind
+	Message string
+}
+
+// warningKind classifies the error behind a warning
+func warningKind(err error) WarningKind {
+	var timeErr *time.ParseError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var numErr *strconv.NumError
+	switch {
+	case apiStatus(err) == http.StatusUnauthorized || apiStatus(err) == http.StatusForbidden:
+		return WarningPermission
+	case errors.As(err, &timeErr), errors.As(err, &syntaxErr), errors.As(err, &typeErr), errors.As(err, &numErr):
+		return WarningParse
+	default:
+		return WarningIncomplete
+	}
+}
+
+// reportWarnings collects the warnings of a report as it is built, logging
+// each one as well. It is safe for concurrent use.
+type reportWarnings struct {
+	logger Logger
+
+	mu       sync.Mutex
+	warnings []ReportWarning
+}
+
+// newReportWarnings creates a collector logging to the logger
+func newReportWarnings(logger Logger) *reportWarnings {
+	return &reportWarnings{logger: logger}
+}
+
+// add records a warning of the given kind
+func (w *reportWarnings) add(kind WarningKind, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	w.logger.Printf("%s", message)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.warnings = append(w.warnings, ReportWarning{Kind: kind, Message: message})
+}
+
+// addError records a failed request or read, classified by its error
+func (w *reportWarnings) addError(err error) {
+	w.add(warningKind(err), "%v", err)
+}
+
+// include records warnings logged elsewhere, such as those of settings
+// turned off when the plugin started
+func (w *reportWarnings) include(warnings []ReportWarning) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.warnings = append(w.warnings, warnings...)
+}
+
+// list returns the warnings recorded so far, or nil when there are none
+func (w *reportWarnings) list() []ReportWarning {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.warnings) == 0 {
+		return nil
+	}
+	return append([]ReportWarning{}, w.warnings...)
+}
+
+// footerWarnings returns the warnings for the footer of a rendered report,
+// leaving out the truncation, which is disclosed at the top
+func footerWarnings(warnings []ReportWarning) []ReportWarning {
+	result := make([]ReportWarning, 0, len(warnings))
+	for _, warning := range warnings {
+		if warning.Kind != WarningTruncation {
+			result = append(result, warning)
+		}
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	plugin "github.com/iures/daivplug"
+)
+
+func TestWarningKind(t *testing.T) {
+	_, parseErr := time.Parse(time.RFC3339, "yesterday")
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		err      error
+		expected WarningKind
+	}{
+		{name: "Forbidden", err: fmt.Errorf("failed: %w", &apiError{StatusCode: http.StatusForbidden, err: errors.New("forbidden")}), expected: WarningPermission},
+		{name: "Unauthorized", err: &apiError{StatusCode: http.StatusUnauthorized, err: errors.New("unauthorized")}, expected: WarningPermission},
+		{name: "Malformed timestamp", err: fmt.Errorf("failed to read: %w", parseErr), expected: WarningParse},
+		{name: "Server error", err: &apiError{StatusCode: http.StatusInternalServerError, err: errors.New("boom")}, expected: WarningIncomplete},
+		{name: "No response", err: errors.New("connection refused"), expected: WarningIncomplete},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if kind := warningKind(tc.err); kind != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, kind)
+			}
+		})
+	}
+}
+
+func TestActivityService_Warnings(t *testing.T) {
+	mockRepo := &MockJiraRepository{
+		MockGetUser: func() (*User, error) {
+			return &User{AccountID: "user123", DisplayName: "Test User"}, nil
+		},
+		MockGetIssues: func(timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return []Issue{{Key: "JIRA-1", Comments: []Comment{{ID: "1", Content: "Done"}}, unparsedEvents: 2}}, nil
+		},
+		MockGetSupplementaryIssues: func(kind SupplementaryQuery, timeRange TimeRange, userAccountID string) ([]Issue, error) {
+			return nil, &apiError{StatusCode: http.StatusForbidden, err: errors.New("You do not have permission to browse OPS.")}
+		},
+	}
+
+	var logged []string
+	options := DefaultReportOptions()
+	options.IncludeFlagged = true
+
+	service := NewActivityService(mockRepo)
+	service.SetReportOptions(options)
+	service.SetLogger(LoggerFunc(func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}))
+
+	report, err := service.GetActivityReport(plugin.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []ReportWarning{
+		{Kind: WarningParse, Message: "2 comments or changes of JIRA-1 were left out because their timestamps could not be read"},
+		{Kind: WarningPermission, Message: "failed to get flagged issues: You do not have permission to browse OPS."},
+	}
+	if len(report.Warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %+v", len(expected), report.Warnings)
+	}
+	for i := range expected {
+		if report.Warnings[i] != expected[i] {
+			t.Errorf("Expected warning %+v, got %+v", expected[i], report.Warnings[i])
+		}
+	}
+	if len(logged) != len(expected) {
+		t.Errorf("Expected every warning to be logged too, got %v", logged)
+	}
+
+	result, err := NewMarkdownFormatter().Format(report)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(result.Content, "## Warnings") || !strings.Contains(result.Content, "You do not have permission to browse OPS.") {
+		t.Errorf("Expected a Warnings footer, got '%s'", result.Content)
+	}
+}
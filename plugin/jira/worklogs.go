@@ -0,0 +1,65 @@
+package jira
+
+import (
+	"fmt"
+	"time"
+
+	extJira "github.com/andygrunwald/go-jira"
+)
+
+// Worklog is work logged on an issue
+type Worklog struct {
+	ID              string
+	Started         time.Time // When the work started, which places the worklog in a range
+	Author          string
+	AuthorAccountID string
+	AuthorTimeZone  string // IANA time zone of the author, set when authors are resolved
+	TimeSpent       time.Duration
+	Comment         string
+}
+
+// processWorklogs converts the worklogs embedded in a search result to the
+// domain model, keeping only the work started within the time range. Jira
+// embeds at most the 20 latest worklogs of an issue.
+func (r *JiraAPIRepository) processWorklogs(worklog *extJira.Worklog, timeRange TimeRange) []Worklog {
+	if worklog == nil {
+		return nil
+	}
+
+	result := make([]Worklog, 0)
+	for _, record := range worklog.Worklogs {
+		if record.Started == nil {
+			continue
+		}
+		started := time.Time(*record.Started)
+		if !timeRange.IsInRange(started) {
+			continue
+		}
+
+		entry := Worklog{
+			ID:        record.ID,
+			Started:   started,
+			TimeSpent: time.Duration(record.TimeSpentSeconds) * time.Second,
+			Comment:   record.Comment,
+		}
+		if record.Author != nil {
+			entry.Author = displayName(*record.Author)
+			entry.AuthorAccountID = record.Author.AccountID
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// worklogEventID identifies a worklog across reports
+func worklogEventID(key string, worklog Worklog) string {
+	if worklog.ID != "" {
+		return fmt.Sprintf("worklog:%s:%s", key, worklog.ID)
+	}
+	return fmt.Sprintf("worklog:%s:%d:%s", key, worklog.Started.Unix(), worklog.AuthorAccountID)
+}
+
+// worklogLine describes a worklog, e.g. "Test User logged 2h 30m"
+func worklogLine(worklog Worklog) string {
+	return fmt.Sprintf("%s logged %s", worklog.Author, formatDuration(worklog.TimeSpent))
+}
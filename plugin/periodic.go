@@ -0,0 +1,87 @@
+package plugin
+
+import (
+	"daiv-jira/plugin/jira"
+	"fmt"
+	"time"
+
+	plug "github.com/iures/daivplug"
+)
+
+// weekLength is the range covered by the weekly context
+const weekLength = 7 * 24 * time.Hour
+
+// PeriodicPlugin is implemented by plugins that provide context over longer
+// periods than a standup, such as a weekly summary or a sprint retrospective
+type PeriodicPlugin interface {
+	plug.Plugin
+
+	// GetWeeklyContext summarizes the week ending at the given time
+	GetWeeklyContext(end time.Time) (plug.StandupContext, error)
+	// GetRetroContext summarizes a sprint or other iteration for its retrospective
+	GetRetroContext(timeRange plug.TimeRange) (plug.StandupContext, error)
+}
+
+// GetWeeklyContext summarizes the seven days ending at the given time. Child
+// issue activity is rolled up under each epic, and the issues completed with
+// their cycle times are included, whatever the standup report is configured to
+// show.
+func (p *JiraPlugin) GetWeeklyContext(end time.Time) (plug.StandupContext, error) {
+	timeRange := plug.TimeRange{Start: end.Add(-weekLength), End: end}
+	return p.getPeriodicContext(timeRange, func(options *jira.ReportOptions) {
+		if options.Mode == jira.ReportModeStandard {
+			options.Mode = jira.ReportModeEpic
+		}
+	})
+}
+
+// GetRetroContext summarizes an iteration, such as a sprint whose dates the
+// host knows, for its retrospective: the weekly summary plus when during the
+// day the work happened and how each issue moved through its statuses.
+func (p *JiraPlugin) GetRetroContext(timeRange plug.TimeRange) (plug.StandupContext, error) {
+	return p.getPeriodicContext(timeRange, func(options *jira.ReportOptions) {
+		if options.Mode == jira.ReportModeStandard {
+			options.Mode = jira.ReportModeEpic
+		}
+		options.IncludeHeatmap = true
+		options.SummarizeTransitions = true
+	})
+}
+
+// getPeriodicContext produces a report over a longer range with the configured
+// report options adjusted for it. The statistics are shown without trailing
+// windows and, like the watchers and votes, are not recorded, so that the
+// longer range leaves the history of the standup reports untouched. The
+// analytics export is skipped for the same reason, and the events the standup
+// reports already showed are kept, since the longer range summarizes them.
+func (p *JiraPlugin) getPeriodicContext(timeRange plug.TimeRange, adjust func(options *jira.ReportOptions)) (plug.StandupContext, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.service == nil {
+		return plug.StandupContext{}, fmt.Errorf("the Jira plugin is not initialized")
+	}
+
+	options := p.config.ReportOptions
+	options.IncludeStats = true
+	options.TrailingWindows = 0
+	options.SkipHistory = true
+	options.Dedupe = false
+	options.AnalyticsPath = ""
+	adjust(&options)
+
+	report, err := p.service.GetActivityReportWithOptions(timeRange, options)
+	if err != nil {
+		return plug.StandupContext{}, fmt.Errorf("failed to get activity report: %w", err)
+	}
+
+	formattedContent, err := p.formatter.Format(report)
+	if err != nil {
+		return plug.StandupContext{}, fmt.Errorf("failed to format activity report: %w", err)
+	}
+
+	return plug.StandupContext{
+		PluginName: p.Name(),
+		Content:    formattedContent.Content,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"daiv-jira/plugin/jira"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	plug "github.com/iures/daivplug"
+)
+
+func TestJiraPlugin_GetWeeklyContext(t *testing.T) {
+	p, repository := newStubPlugin()
+	end := time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC)
+
+	standupContext, err := p.GetWeeklyContext(end)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(repository.searched) != 1 {
+		t.Fatalf("Expected one search, got %d", len(repository.searched))
+	}
+	if searched := repository.searched[0]; !searched.Start.Equal(end.AddDate(0, 0, -7)) || !searched.End.Equal(end) {
+		t.Errorf("Expected the week ending %v, got %v to %v", end, searched.Start, searched.End)
+	}
+
+	// Activity is rolled up by epic and the statistics are shown
+	for _, expected := range []string{"No Epic", "## Stats"} {
+		if !strings.Contains(standupContext.Content, expected) {
+			t.Errorf("Expected the weekly context to contain '%s', got:\n%s", expected, standupContext.Content)
+		}
+	}
+
+	// The configured report options are left unchanged
+	if p.config.ReportOptions.IncludeStats {
+		t.Errorf("Expected the configured report options to be left unchanged")
+	}
+}
+
+func TestJiraPlugin_GetRetroContext(t *testing.T) {
+	p, _ := newStubPlugin()
+	timeRange := plug.TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 16, 0, 0, 0, 0, time.UTC),
+	}
+
+	standupContext, err := p.GetRetroContext(timeRange)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{"No Epic", "## Stats", "## Activity by Hour"} {
+		if !strings.Contains(standupContext.Content, expected) {
+			t.Errorf("Expected the retro context to contain '%s', got:\n%s", expected, standupContext.Content)
+		}
+	}
+}
+
+func TestJiraPlugin_GetWeeklyContext_Dedupe(t *testing.T) {
+	p, _ := newStubPlugin()
+	p.config.ReportOptions.Dedupe = true
+	p.service.SetReportOptions(p.config.ReportOptions)
+	p.service.SetStandupStore(jira.NewFileStandupStore(filepath.Join(t.TempDir(), "standup.json")))
+	end := time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC)
+
+	// A standup over the week records its events as reported
+	if _, err := p.GetStandupContext(plug.TimeRange{Start: end.AddDate(0, 0, -7), End: end}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	standupContext, err := p.GetWeeklyContext(end)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The weekly context summarizes the events the standup already showed
+	if !strings.Contains(standupContext.Content, "TEST-1") || !strings.Contains(standupContext.Content, "1 comment") {
+		t.Errorf("Expected the weekly context to keep the reported comment, got:\n%s", standupContext.Content)
+	}
+}
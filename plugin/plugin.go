@@ -4,18 +4,58 @@ import (
 	// Import contexts package
 	"daiv-jira/plugin/jira"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	plug "github.com/iures/daivplug"
 )
 
 type JiraPlugin struct {
+	// Held for reading while a report is produced, so that Reload and
+	// Shutdown wait for reports in flight
+	mu sync.RWMutex
+
 	client    *jira.JiraClient
 	config    *jira.JiraConfig
 	service   *jira.ActivityService
 	formatter jira.ReportFormatter
+	summarizer jira.Summarizer
+	clock      jira.Clock // Set by the host to freeze time, e.g. in tests and replays
+	// The issues and epics ignored, and the issues pinned, at runtime, merged
+	// into the configured ones
+	ignore jira.IgnoreStore
+	pins   jira.PinStore
+	// The jira.format.options of each formatter, by format name
+	formatOptions map[string]jira.FormatterOptions
+	// The settings as given, before the active profile is applied
+	settings map[string]interface{}
 }
 
+// Keys of the report metadata returned by GetReport
+const (
+	// MetadataContentType is the MIME type of the content, e.g. text/markdown
+	MetadataContentType = "contentType"
+	// MetadataFormat is the name of the format, e.g. markdown
+	MetadataFormat = "format"
+	// MetadataIssuesShown and MetadataIssuesTotal are the number of issues the
+	// activity search returned and matched; set only when it was truncated
+	MetadataIssuesShown = "issuesShown"
+	MetadataIssuesTotal = "issuesTotal"
+	// MetadataTruncationNotice is the notice disclosing a truncated search
+	MetadataTruncationNotice = "truncationNotice"
+	// MetadataEmpty is whether the report had no activity to render
+	MetadataEmpty = "empty"
+	// MetadataPluginVersion is the version of the plugin that produced the
+	// report, e.g. "v1.2.3 (abc1234)"
+	MetadataPluginVersion = "pluginVersion"
+	// MetadataPart and MetadataParts are the number of a part, from 1, and
+	// the number of parts of a report returned by GetReportParts
+	MetadataPart  = "part"
+	MetadataParts = "parts"
+)
+
 // New creates a new instance of the plugin
 func New() *JiraPlugin {
 	return &JiraPlugin{}
@@ -46,6 +86,22 @@ func (p *JiraPlugin) Manifest() *plug.PluginManifest {
 				Required:    true,
 				EnvVar:      "JIRA_API_TOKEN",
 			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.auth.type",
+				Name:        "Auth Type",
+				Description: "How to authenticate: basic (username and API token, the default) or pat (personal access token on Jira Data Center)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.active_profile",
+				Name:        "Active Profile",
+				Description: "Profile whose jira.profiles.<name>.* keys replace the matching jira.* keys, e.g. clientA for jira.profiles.clientA.project",
+				Required:    false,
+				Secret:      false,
+			},
 			{
 				Type:        plug.ConfigTypeString,
 				Key:         "jira.url",
@@ -58,7 +114,7 @@ func (p *JiraPlugin) Manifest() *plug.PluginManifest {
 				Type:        plug.ConfigTypeString,
 				Key:         "jira.project",
 				Name:        "Jira Project",
-				Description: "The project to generate the report for",
+				Description: "The project to generate the report for; a comma-separated list searches each project, leaving out those you cannot browse",
 				Required:    true,
 				Secret:      false,
 			},
@@ -66,7 +122,15 @@ func (p *JiraPlugin) Manifest() *plug.PluginManifest {
 				Type:        plug.ConfigTypeString,
 				Key:         "jira.format",
 				Name:        "Report Format",
-				Description: "The format for the activity report (xml, json, markdown, or html)",
+				Description: "The format for the activity report (xml, json, markdown, html, slack, or teams)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.format.style",
+				Name:        "Report Style",
+				Description: "The layout of Markdown reports: full for the activity of each issue under its heading, or compact for one line per issue (default: full)",
 				Required:    false,
 				Secret:      false,
 			},
@@ -78,6 +142,22 @@ func (p *JiraPlugin) Manifest() *plug.PluginManifest {
 				Required:    false,
 				Secret:      false,
 			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.jql",
+				Name:        "Raw JQL",
+				Description: "A complete JQL query used instead of the JQL template (cannot be combined with jira.query.jql_template)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.filter_id",
+				Name:        "Saved Filter ID",
+				Description: "The ID of a saved Jira filter the report is based on instead of the project (cannot be combined with jira.query.jql_template or jira.query.jql)",
+				Required:    false,
+				Secret:      false,
+			},
 			{
 				Type:        plug.ConfigTypeString,
 				Key:         "jira.query.assignee_current_user",
@@ -86,11 +166,27 @@ func (p *JiraPlugin) Manifest() *plug.PluginManifest {
 				Required:    false,
 				Secret:      false,
 			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.statuses.include",
+				Name:        "Included Statuses",
+				Description: "Comma-separated list of statuses; only issues in any of them are included",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.statuses.exclude",
+				Name:        "Excluded Statuses",
+				Description: "Comma-separated list of statuses to exclude (default: Closed)",
+				Required:    false,
+				Secret:      false,
+			},
 			{
 				Type:        plug.ConfigTypeString,
 				Key:         "jira.query.status_filter",
-				Name:        "Status Filter",
-				Description: "Filter issues by status using JQL syntax (e.g., '!= Closed' to exclude closed issues)",
+				Name:        "Status Filter (Legacy)",
+				Description: "Legacy status filter using JQL syntax (e.g., '!= Closed'); prefer jira.query.statuses.include/exclude",
 				Required:    false,
 				Secret:      false,
 			},
@@ -102,6 +198,70 @@ func (p *JiraPlugin) Manifest() *plug.PluginManifest {
 				Required:    false,
 				Secret:      false,
 			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.issue_types",
+				Name:        "Issue Types",
+				Description: "Comma-separated list of issue types; only issues of any of them are included (e.g. Bug)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.issue_types.exclude",
+				Name:        "Excluded Issue Types",
+				Description: "Comma-separated list of issue types to exclude (e.g. Sub-task, Epic)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.labels",
+				Name:        "Labels",
+				Description: "Comma-separated list of labels; only issues with any of them are included",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.components",
+				Name:        "Components",
+				Description: "Comma-separated list of components; only issues in any of them are included",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.parent_link_field",
+				Name:        "Parent Link Field",
+				Description: "Custom field holding the Advanced Roadmaps parent link on Jira Data Center (e.g. customfield_10500); not needed on Jira Cloud",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.story_points_field",
+				Name:        "Story Points Field",
+				Description: "Custom field holding story points, used for velocity statistics (e.g. customfield_10016)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.carry_over_statuses",
+				Name:        "Carry-over Statuses",
+				Description: "Comma-separated list of statuses of assigned issues reported as carry-over work (default: In Progress)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.always_include_flagged",
+				Name:        "Always Include Flagged Issues",
+				Description: "Whether to list flagged (impediment) issues in a Blockers section regardless of other filters (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
 			{
 				Type:        plug.ConfigTypeString,
 				Key:         "jira.query.max_results",
@@ -110,121 +270,1480 @@ func (p *JiraPlugin) Manifest() *plug.PluginManifest {
 				Required:    false,
 				Secret:      false,
 			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.timezone",
+				Name:        "Jira Time Zone",
+				Description: "Time zone Jira reads the dates of JQL queries in, as an IANA name such as America/New_York (default: the time zone of the Jira user's profile, detected at startup)",
+				Required:    false,
+				Secret:      false,
+			},
 			{
 				Type:        plug.ConfigTypeString,
 				Key:         "jira.query.fields",
 				Name:        "Fields",
-				Description: "Comma-separated list of fields to include in the response",
+				Description: "Comma-separated extra fields to fetch on top of those the report renders, which are derived from the report sections and settings",
 				Required:    false,
 				Secret:      false,
 			},
-		},
-	}
-}
-
-// Initialize sets up the plugin with its configuration
-func (p *JiraPlugin) Initialize(settings map[string]interface{}) error {
-	// Create default query options
-	queryOptions := jira.DefaultQueryOptions()
-
-	// Override with user-provided options if available
-	if jqlTemplate, ok := settings["jira.query.jql_template"].(string); ok && jqlTemplate != "" {
-		queryOptions.JQLTemplate = jqlTemplate
-	}
-
-	if assigneeCurrentUserStr, ok := settings["jira.query.assignee_current_user"].(string); ok && assigneeCurrentUserStr != "" {
-		queryOptions.AssigneeCurrentUser = assigneeCurrentUserStr == "true"
-	}
-
-	if statusFilter, ok := settings["jira.query.status_filter"].(string); ok && statusFilter != "" {
-		queryOptions.StatusFilter = statusFilter
-	}
-
-	if inOpenSprintsStr, ok := settings["jira.query.in_open_sprints"].(string); ok && inOpenSprintsStr != "" {
-		queryOptions.InOpenSprints = inOpenSprintsStr == "true"
-	}
-
-	if maxResultsStr, ok := settings["jira.query.max_results"].(string); ok && maxResultsStr != "" {
-		var maxResults int
-		if _, err := fmt.Sscanf(maxResultsStr, "%d", &maxResults); err == nil && maxResults > 0 {
-			queryOptions.MaxResults = maxResults
-		}
-	}
-
-	if fieldsStr, ok := settings["jira.query.fields"].(string); ok && fieldsStr != "" {
-		queryOptions.Fields = strings.Split(fieldsStr, ",")
-		// Trim whitespace from each field
-		for i, field := range queryOptions.Fields {
-			queryOptions.Fields[i] = strings.TrimSpace(field)
-		}
-	}
-
-	// Create the config
-	config := &jira.JiraConfig{
-		Username:     settings["jira.username"].(string),
-		Token:        settings["jira.token"].(string),
-		URL:          settings["jira.url"].(string),
-		Project:      settings["jira.project"].(string),
-		QueryOptions: queryOptions,
-	}
-
-	client, err := jira.NewJiraClient(config)
-	if err != nil {
-		return fmt.Errorf("failed to create Jira client: %w", err)
-	}
-
-	p.client = client
-	p.config = config
-	
-	// Create the service
-	p.service = jira.NewActivityService(client.GetRepository())
-
-	// Set the formatter based on configuration
-	format, ok := settings["jira.format"].(string)
-	if !ok || format == "" {
-		format = "json" // Default to JSON if not specified
-	}
-
-	switch format {
-	case "json":
-		p.formatter = jira.NewJSONFormatter()
-	case "markdown":
-		p.formatter = jira.NewMarkdownFormatter()
-	case "xml":
-		p.formatter = jira.NewXMLFormatter()
-	case "html":
-		p.formatter = jira.NewHTMLFormatter()
-	default:
-		p.formatter = jira.NewJSONFormatter()
-	}
-
-	return nil
-}
-
-// Shutdown performs cleanup when the plugin is being disabled/removed
-func (p *JiraPlugin) Shutdown() error {
-	// No resources to clean up
-	return nil
-}
-
-// GetStandupContext implements the StandupPlugin interface
-func (p *JiraPlugin) GetStandupContext(timeRange plug.TimeRange) (plug.StandupContext, error) {
-	// Get activity report from service
-	report, err := p.service.GetActivityReport(timeRange)
-	if err != nil {
-		return plug.StandupContext{}, fmt.Errorf("failed to get activity report: %w", err)
-	}
-	
-	// Format the report using the configured formatter
-	formattedContent, err := p.formatter.Format(report)
-	if err != nil {
-		return plug.StandupContext{}, fmt.Errorf("failed to format activity report: %w", err)
-	}
-
-	// Note: We're only using the content here, but in a more advanced implementation
-	// we could use the content type information for additional processing
-	return plug.StandupContext{
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.resolve_email",
+				Name:        "Resolve Email",
+				Description: "Whether to look up your email address through the user search API when Jira Cloud privacy settings hide it (true/false, default: true)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.query.validate_jql",
+				Name:        "Validate JQL",
+				Description: "Whether to check the query with Jira's JQL parse API before searching, reporting syntax errors with their position (true/false, default: true)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.format.markdown.allow_raw",
+				Name:        "Allow Raw Markdown",
+				Description: "Whether to pass Jira content through the Markdown formatter without escaping (true/false); the older form of jira.format.options.markdown.allow_raw",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.client",
+				Name:        "HTTP Client",
+				Description: "The client Jira is reached through: go-jira, or native for the built-in REST client (default: go-jira)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.http.max_concurrent",
+				Name:        "Max Concurrent Requests",
+				Description: "Maximum number of concurrent HTTP requests to Jira (0 for unlimited)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.http.max_report_bytes",
+				Name:        "Max Report Size",
+				Description: "Response bytes per report above which a warning with query slimming suggestions is logged (0 to disable)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.mode",
+				Name:        "Report Mode",
+				Description: "How issues are grouped: standard (by status), epic (activity rolled up under each epic), component (digest per component regardless of assignee), initiative (epics rolled up under each initiative), release (release notes of jira.release.version, or a comparison of the start and end of the time range without one), or triage (bugs and incidents created in the range regardless of assignee)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.release.version",
+				Name:        "Release Version",
+				Description: "Fix version whose issues the release mode lists as release notes, grouped by issue type, e.g. 1.2.0; without one the release mode compares the start and end of the time range",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.release.compare_to",
+				Name:        "Compare to Release",
+				Description: "Earlier fix version the release mode compares jira.release.version with, listing the issues completed, new, reopened and slipped since, e.g. 1.1.0",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.triage.issue_types",
+				Name:        "Triage Issue Types",
+				Description: "Comma-separated issue types the triage mode lists when created in the range (default: Bug,Incident)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.ignore_issues",
+				Name:        "Ignored Issues",
+				Description: "Comma-separated issue keys left out of reports, such as umbrella tickets with constant bot churn",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.ignore_epics",
+				Name:        "Ignored Epics",
+				Description: "Comma-separated epic keys whose issues, and the epics themselves, are left out of reports",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.ignore.store_path",
+				Name:        "Ignore List Store Path",
+				Description: "File in which the issues and epics ignored through the plugin API are kept (default: daiv-jira/ignore.json in the user config directory)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.pinned_issues",
+				Name:        "Pinned Issues",
+				Description: "Comma-separated issue keys always listed with their latest status, whatever their activity and the query filters",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.pins.store_path",
+				Name:        "Pin Store Path",
+				Description: "File in which the issues pinned through the plugin API are kept (default: daiv-jira/pins.json in the user config directory)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.verbosity",
+				Name:        "Report Verbosity",
+				Description: "How much detail the report includes (minimal, normal, or full)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.sections",
+				Name:        "Report Sections",
+				Description: "Comma-separated kinds of activity to include: comments, changes and worklogs (default: comments,changes). Leaving out changes skips fetching changelogs",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.empty",
+				Name:        "Empty Report",
+				Description: "What a report without activity produces: message, document for the regular document without issues, or omit to leave the plugin out of the standup; per format with e.g. \"message, json:document\"",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.part_size",
+				Name:        "Report Part Size",
+				Description: "Largest part, in bytes, that GetReportParts cuts a report into for hosts with message size limits (0 keeps reports whole)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.max_range_days",
+				Name:        "Maximum Range",
+				Description: "Longest time range, in days, a report may cover; longer, inverted and empty ranges are refused before anything is queried (default: 90, 0 for unlimited)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.end_inclusive",
+				Name:        "Inclusive Range End",
+				Description: "Whether events exactly at the end of the time range are included, for hosts whose daily ranges end at 23:59:59 rather than midnight; leave off for ranges that end where the next one starts (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.include_others_changes",
+				Name:        "Include Others' Changes",
+				Description: "Whether to include changes made by other people, annotated with their role on the issue (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.own_comments_only",
+				Name:        "Own Comments Only",
+				Description: "Whether to include only your own comments, recognized by account ID, leaving out other people's (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.summarize_transitions",
+				Name:        "Summarize Status Transitions",
+				Description: "Whether to collapse status changes into a single journey line (e.g. Open → In Progress → In Review) (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.hierarchy",
+				Name:        "Hierarchy Path",
+				Description: "Whether to resolve each issue's parent chain, including initiatives above epics, and show it as a hierarchy path (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.carry_over",
+				Name:        "Carry-over Work",
+				Description: "Whether to list issues assigned to you in an active status even without recent activity (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.filed",
+				Name:        "Filed Issues",
+				Description: "Whether to list issues you created in the time range, whoever they are assigned to (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.due_within_days",
+				Name:        "Due Within Days",
+				Description: "Number of days after the time range within which open issues assigned to you are listed as due soon, overdue ones included (0 to disable)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.sprint.board_id",
+				Name:        "Sprint Board ID",
+				Description: "ID of the agile board whose active sprint heads the report with the issues added to or removed from it in the time range",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.handoffs",
+				Name:        "Handoffs",
+				Description: "Whether to list issues assigned to you (incoming) or away from you (outgoing) in the time range (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.always_include_escalations",
+				Name:        "Always Include Escalations",
+				Description: "Whether to report issues in the project whose priority was raised in the time range, whoever they are assigned to (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.summary_only",
+				Name:        "Summary Only",
+				Description: "Whether to render only the per-issue activity summary when a summarizer is available (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.hide_email",
+				Name:        "Hide Email",
+				Description: "Whether to leave your email address out of the report header (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.author_local_time",
+				Name:        "Author Local Time",
+				Description: "Whether to show comment and change times in the author's local time with its UTC offset; requires jira.users.resolve (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.heatmap",
+				Name:        "Activity Heatmap",
+				Description: "Whether to add a section showing comments and changes per hour of day, with the number outside working hours (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.stats",
+				Name:        "Velocity Statistics",
+				Description: "Whether to add a section with issues and story points completed and average cycle time, next to previous report windows (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.stats.trailing_windows",
+				Name:        "Trailing Windows",
+				Description: "Number of previous report windows shown next to the current one (default: 4)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.stats.store_path",
+				Name:        "Stats Store Path",
+				Description: "File in which the statistics of past report windows are kept (default: daiv-jira/stats.json in the user cache directory)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.done_statuses",
+				Name:        "Done Statuses",
+				Description: "Comma-separated list of statuses that count as completed (default: Done, Resolved, Closed)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.in_progress_statuses",
+				Name:        "In Progress Statuses",
+				Description: "Comma-separated list of statuses that start the cycle time (default: In Progress)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.attention",
+				Name:        "Watcher and Vote Activity",
+				Description: "Whether to show how many watchers and votes each issue gained or lost since the previous report (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.attention.store_path",
+				Name:        "Attention Store Path",
+				Description: "File in which the watchers and votes of each issue are kept between reports (default: daiv-jira/attention.json in the user cache directory)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.dedupe",
+				Name:        "Dedupe Events",
+				Description: "Whether comments, changes and remote links already included in a previous report are left out, so that overlapping time ranges do not repeat them (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.standup.store_path",
+				Name:        "Standup Store Path",
+				Description: "File in which the time of the last report is kept, so that a report without a time range covers everything since then (default: daiv-jira/standup.json in the user cache directory)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.notes.store_path",
+				Name:        "Notes Store Path",
+				Description: "File in which the notes pinned to issues are kept (default: daiv-jira/notes.json in the user config directory)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.remote_links",
+				Name:        "Remote Links",
+				Description: "Whether to list remote links, such as Confluence pages and web links, added within the time range with their titles and URLs (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.historical",
+				Name:        "Historical Report",
+				Description: "Whether to show each issue's status and assignee as they were at the end of the time range, reconstructed from its changelog, instead of as they are now (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.max_tokens",
+				Name:        "Report Token Budget",
+				Description: "Approximate number of tokens the activity of a report may take up; the activity search stops fetching issues once it is reached (0 for unlimited)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.deep_links",
+				Name:        "Deep Links",
+				Description: "Whether Markdown and HTML reports link to each issue, its change history and each comment (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.report.reference_lookups",
+				Name:        "Reference Lookups",
+				Description: "Number of issues mentioned in comments and changes, outside the report, looked up per report to show their status next to the mention (at most 200; 0 leaves them unresolved)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.analytics.output_path",
+				Name:        "Analytics Output Path",
+				Description: "File to which time-in-status, cycle time and throughput analytics are written on each report, as CSV for a .csv path and JSON otherwise",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.log.mode",
+				Name:        "Log Mode",
+				Description: "How the plugin's diagnostics are written, always to stderr or jira.log.path and never to stdout: text, quiet for none, or machine for JSON lines",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.log.path",
+				Name:        "Log Path",
+				Description: "File to which diagnostics are appended instead of stderr",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.upgrade_check",
+				Name:        "Upgrade Check",
+				Description: "Whether to look up the latest release on GitHub at startup and log when a newer version of the plugin is available (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.sinks",
+				Name:        "Event Sinks",
+				Description: "Comma-separated destinations receiving the activity events of every report as newline-delimited JSON: stdout, file:<path> or an http(s) webhook URL",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.publish.slack_webhook",
+				Name:        "Slack Webhook",
+				Description: "Slack incoming webhook URL to which the standup report is posted, formatted for Slack",
+				Required:    false,
+				Secret:      true,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.publish.slack_token",
+				Name:        "Slack Bot Token",
+				Description: "Slack bot token with the chat:write scope, used with jira.publish.slack_channel instead of a webhook",
+				Required:    false,
+				Secret:      true,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.publish.slack_channel",
+				Name:        "Slack Channel",
+				Description: "Slack channel ID or name the bot token posts the standup report to",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.publish.teams_webhook",
+				Name:        "Teams Webhook",
+				Description: "Microsoft Teams incoming webhook URL to which the standup report is posted as an Adaptive Card",
+				Required:    false,
+				Secret:      true,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.users.resolve",
+				Name:        "Resolve Authors",
+				Description: "Whether to batch-resolve comment and change authors to consistent profiles, cached between runs (true/false)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.users.cache_path",
+				Name:        "User Cache Path",
+				Description: "File in which resolved author profiles are cached (default: daiv-jira/users.json in the user cache directory)",
+				Required:    false,
+				Secret:      false,
+			},
+			{
+				Type:        plug.ConfigTypeString,
+				Key:         "jira.users.cache_ttl",
+				Name:        "User Cache TTL",
+				Description: "How long cached author profiles are reused before they are refreshed, e.g. 12h (default: 24h)",
+				Required:    false,
+				Secret:      false,
+			},
+		},
+	}
+}
+
+// Initialize sets up the plugin with its configuration
+func (p *JiraPlugin) Initialize(settings map[string]interface{}) error {
+	p.settings = settings
+
+	// Environment variables take precedence over the active profile, which
+	// takes precedence over the top-level keys
+	overrides := envOverrides(p.Manifest().ConfigKeys)
+	settings, err := applyProfile(withOverrides(settings, overrides))
+	if err != nil {
+		return err
+	}
+	settings = withOverrides(settings, overrides)
+
+	reader := newSettingsReader(settings)
+
+	// Create default query options
+	queryOptions := jira.DefaultQueryOptions()
+
+	// Override with user-provided options if available
+	if jqlTemplate := reader.String("jira.query.jql_template"); jqlTemplate != "" {
+		queryOptions.JQLTemplate = jqlTemplate
+	}
+
+	if rawJQL := reader.String("jira.query.jql"); rawJQL != "" {
+		queryOptions.RawJQL = rawJQL
+		// Raw JQL replaces the default template; a custom template is reported as a conflict
+		if queryOptions.JQLTemplate == jira.DefaultQueryOptions().JQLTemplate {
+			queryOptions.JQLTemplate = ""
+		}
+	}
+
+	if filterID := reader.String("jira.query.filter_id"); filterID != "" {
+		queryOptions.FilterID = filterID
+		// A saved filter replaces the default template as well
+		if queryOptions.JQLTemplate == jira.DefaultQueryOptions().JQLTemplate {
+			queryOptions.JQLTemplate = ""
+		}
+	}
+
+	reader.Bool("jira.query.assignee_current_user", &queryOptions.AssigneeCurrentUser)
+	reader.List("jira.query.statuses.include", &queryOptions.IncludeStatuses)
+	reader.List("jira.query.statuses.exclude", &queryOptions.ExcludeStatuses)
+
+	if statusFilter := reader.String("jira.query.status_filter"); statusFilter != "" {
+		// The legacy filter replaces the default excluded statuses
+		queryOptions.StatusFilter = statusFilter
+		if reader.String("jira.query.statuses.exclude") == "" {
+			queryOptions.ExcludeStatuses = nil
+		}
+	}
+
+	reader.Bool("jira.query.in_open_sprints", &queryOptions.InOpenSprints)
+	reader.List("jira.query.issue_types", &queryOptions.IncludeIssueTypes)
+	reader.List("jira.query.issue_types.exclude", &queryOptions.ExcludeIssueTypes)
+	reader.List("jira.query.labels", &queryOptions.Labels)
+	reader.List("jira.query.components", &queryOptions.Components)
+
+	if parentLinkField := reader.String("jira.query.parent_link_field"); parentLinkField != "" {
+		queryOptions.ParentLinkField = parentLinkField
+	}
+
+	if storyPointsField := reader.String("jira.query.story_points_field"); storyPointsField != "" {
+		queryOptions.StoryPointsField = storyPointsField
+	}
+
+	reader.List("jira.query.carry_over_statuses", &queryOptions.CarryOverStatuses)
+	reader.Int("jira.query.max_results", &queryOptions.MaxResults, 1)
+	reader.List("jira.query.fields", &queryOptions.Fields)
+	reader.Bool("jira.query.resolve_email", &queryOptions.ResolveEmail)
+	reader.Bool("jira.query.validate_jql", &queryOptions.ValidateJQL)
+	if timeZone := reader.String("jira.query.timezone"); timeZone != "" {
+		location, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return fmt.Errorf("invalid jira.query.timezone: %w", err)
+		}
+		queryOptions.TimeZone = location
+	}
+
+	// Create default report options
+	reportOptions := jira.DefaultReportOptions()
+
+	reader.Bool("jira.report.summary_only", &reportOptions.SummaryOnly)
+	reader.Bool("jira.report.hide_email", &reportOptions.HideEmail)
+	reader.Bool("jira.report.author_local_time", &reportOptions.AuthorLocalTime)
+	reader.Bool("jira.report.heatmap", &reportOptions.IncludeHeatmap)
+	reader.Bool("jira.report.stats", &reportOptions.IncludeStats)
+	reader.Int("jira.report.stats.trailing_windows", &reportOptions.TrailingWindows, 0)
+	reader.List("jira.report.done_statuses", &reportOptions.DoneStatuses)
+	reader.List("jira.report.in_progress_statuses", &reportOptions.InProgressStatuses)
+	reader.Bool("jira.report.attention", &reportOptions.IncludeAttention)
+	reader.Bool("jira.report.remote_links", &reportOptions.IncludeRemoteLinks)
+	reader.Bool("jira.report.historical", &reportOptions.Historical)
+	reader.Int("jira.report.max_tokens", &reportOptions.MaxTokens, 0)
+	reader.Int("jira.report.part_size", &reportOptions.PartSize, 0)
+	reader.Int("jira.report.max_range_days", &reportOptions.MaxRangeDays, 0)
+	reader.Bool("jira.report.end_inclusive", &reportOptions.EndInclusive)
+
+	deepLinks := false
+	reader.Bool("jira.report.deep_links", &deepLinks)
+	if deepLinks {
+		reportOptions.LinkBaseURL = reader.String("jira.url")
+	}
+	reader.Int("jira.report.reference_lookups", &reportOptions.ReferenceLookups, 0)
+	if reportOptions.ReferenceLookups > jira.MaxReferenceLookups {
+		reader.fail("jira.report.reference_lookups", "%d is more than the %d allowed", reportOptions.ReferenceLookups, jira.MaxReferenceLookups)
+	}
+
+	if analyticsPath := reader.String("jira.analytics.output_path"); analyticsPath != "" {
+		reportOptions.AnalyticsPath = analyticsPath
+	}
+
+	reader.Bool("jira.report.include_others_changes", &reportOptions.IncludeOthersChanges)
+	reader.Bool("jira.report.own_comments_only", &reportOptions.OwnCommentsOnly)
+	reader.Bool("jira.report.summarize_transitions", &reportOptions.SummarizeTransitions)
+	reader.Bool("jira.query.always_include_flagged", &reportOptions.IncludeFlagged)
+	reader.Bool("jira.report.hierarchy", &reportOptions.IncludeHierarchy)
+	reader.Bool("jira.report.carry_over", &reportOptions.IncludeCarryOver)
+	reader.Bool("jira.report.filed", &reportOptions.IncludeFiled)
+	reader.Bool("jira.report.handoffs", &reportOptions.IncludeHandoffs)
+	reader.Int("jira.sprint.board_id", &reportOptions.SprintBoardID, 0)
+	reader.Int("jira.report.due_within_days", &reportOptions.DueWithinDays, 0)
+	reader.Bool("jira.report.dedupe", &reportOptions.Dedupe)
+	reader.Bool("jira.report.always_include_escalations", &reportOptions.IncludeEscalations)
+
+	if modeStr := reader.String("jira.report.mode"); modeStr != "" {
+		mode, err := jira.ParseReportMode(modeStr)
+		if err != nil {
+			return fmt.Errorf("invalid jira.report.mode: %w", err)
+		}
+		reportOptions.Mode = mode
+	}
+
+	reportOptions.ReleaseVersion = reader.String("jira.release.version")
+	reportOptions.ReleaseCompareTo = reader.String("jira.release.compare_to")
+	if reportOptions.ReleaseCompareTo != "" && reportOptions.ReleaseVersion == "" {
+		return fmt.Errorf("invalid jira.release.compare_to: comparing releases requires jira.release.version")
+	}
+
+	reader.List("jira.triage.issue_types", &reportOptions.TriageIssueTypes)
+	reader.List("jira.report.ignore_issues", &reportOptions.IgnoreIssues)
+	reader.List("jira.report.ignore_epics", &reportOptions.IgnoreEpics)
+
+	// The local stores are optional: one that cannot be located or read is
+	// left out with a warning, logged once the logger is set up
+	var storeWarnings []string
+
+	// Add the issues and epics ignored at runtime to the configured ones
+	var ignore jira.IgnoreStore
+	if path, warning := storePath(reader, "jira.report.ignore.store_path", jira.DefaultIgnoreStorePath); warning != "" {
+		storeWarnings = append(storeWarnings, "running without the local ignore list: "+warning)
+	} else {
+		ignore = jira.NewFileIgnoreStore(path)
+		if ignored, err := ignore.Load(); err != nil {
+			storeWarnings = append(storeWarnings, fmt.Sprintf("leaving out the local ignore list: %v", err))
+		} else {
+			reportOptions.IgnoreIssues = append(reportOptions.IgnoreIssues, ignored.Issues...)
+			reportOptions.IgnoreEpics = append(reportOptions.IgnoreEpics, ignored.Epics...)
+		}
+	}
+
+	// Add the issues pinned at runtime to the configured ones
+	reader.List("jira.report.pinned_issues", &reportOptions.PinnedIssues)
+	var pins jira.PinStore
+	if path, warning := storePath(reader, "jira.report.pins.store_path", jira.DefaultPinStorePath); warning != "" {
+		storeWarnings = append(storeWarnings, "running without the local pins: "+warning)
+	} else {
+		pins = jira.NewFilePinStore(path)
+		if pinned, err := pins.Load(); err != nil {
+			storeWarnings = append(storeWarnings, fmt.Sprintf("leaving out the local pins: %v", err))
+		} else {
+			reportOptions.PinnedIssues = append(reportOptions.PinnedIssues, pinned...)
+		}
+	}
+
+	emptyReports, err := jira.ParseEmptyReportPolicy(reader.String("jira.report.empty"))
+	if err != nil {
+		return fmt.Errorf("invalid jira.report.empty: %w", err)
+	}
+	reportOptions.EmptyReports = emptyReports
+
+	var sectionNames []string
+	reader.List("jira.report.sections", &sectionNames)
+	sections, err := jira.ParseReportSections(sectionNames)
+	if err != nil {
+		return fmt.Errorf("invalid jira.report.sections: %w", err)
+	}
+	reportOptions.Sections = sections
+
+	if verbosityStr := reader.String("jira.report.verbosity"); verbosityStr != "" {
+		verbosity, err := jira.ParseVerbosity(verbosityStr)
+		if err != nil {
+			return fmt.Errorf("invalid jira.report.verbosity: %w", err)
+		}
+		reportOptions.Verbosity = verbosity
+	}
+
+	// Create default HTTP options
+	httpOptions := jira.DefaultHTTPOptions()
+
+	reader.Int("jira.http.max_concurrent", &httpOptions.MaxConcurrent, 0)
+	reader.Int64("jira.http.max_report_bytes", &httpOptions.MaxReportBytes, 0)
+	if clientStr := reader.String("jira.client"); clientStr != "" {
+		client, err := jira.ParseClientBackend(clientStr)
+		if err != nil {
+			return fmt.Errorf("invalid jira.client: %w", err)
+		}
+		httpOptions.Client = client
+	}
+
+	resolveUsers := false
+	reader.Bool("jira.users.resolve", &resolveUsers)
+	ttl := jira.DefaultUserCacheTTL
+	reader.Duration("jira.users.cache_ttl", &ttl)
+
+	formatOptions, err := readFormatOptions(reader)
+	if err != nil {
+		return err
+	}
+
+	logMode, err := jira.ParseLogMode(reader.String("jira.log.mode"))
+	if err != nil {
+		return fmt.Errorf("invalid jira.log.mode: %w", err)
+	}
+	upgradeCheck := false
+	reader.Bool("jira.upgrade_check", &upgradeCheck)
+
+	var sinkSpecs []string
+	reader.List("jira.sinks", &sinkSpecs)
+	sinks := make([]jira.EventSink, 0, len(sinkSpecs))
+	for _, spec := range sinkSpecs {
+		// In machine mode stdout carries the report and nothing else
+		if logMode == jira.LogModeMachine && strings.EqualFold(strings.TrimSpace(spec), "stdout") {
+			return fmt.Errorf("invalid jira.sinks: the stdout sink cannot be used in machine mode")
+		}
+		sink, err := jira.ParseEventSink(spec)
+		if err != nil {
+			return fmt.Errorf("invalid jira.sinks: %w", err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	publishers, err := reportPublishers(reader)
+	if err != nil {
+		return err
+	}
+
+	// Create the config
+	config := &jira.JiraConfig{
+		Username:      reader.Required("jira.username"),
+		Token:         reader.Required("jira.token"),
+		URL:           reader.Required("jira.url"),
+		Project:       reader.Required("jira.project"),
+		QueryOptions:  queryOptions,
+		ReportOptions: reportOptions,
+		HTTPOptions:   httpOptions,
+	}
+
+	// Report every missing or invalid setting at once
+	if err := reader.Err(); err != nil {
+		return fmt.Errorf("invalid settings: %w", err)
+	}
+
+	// Several comma-separated projects are searched one by one
+	if projects := splitList(config.Project); len(projects) > 1 {
+		config.Project = projects[0]
+		config.QueryOptions.Projects = projects
+	}
+
+	// Normalize and validate the query options before they reach the repository
+	if err := config.QueryOptions.Validate(); err != nil {
+		return fmt.Errorf("invalid query options: %w", err)
+	}
+
+	auth, err := jira.NewAuthProvider(reader.String("jira.auth.type"), config.Username, config.Token)
+	if err != nil {
+		return fmt.Errorf("invalid jira.auth.type: %w", err)
+	}
+	config.Auth = auth
+
+	client, err := jira.NewJiraClient(config)
+	if err != nil {
+		return fmt.Errorf("failed to create Jira client: %w", err)
+	}
+
+	// Diagnostics go to stderr or the log file, never to stdout
+	logger, err := jira.NewLogger(logMode, reader.String("jira.log.path"), p.Version())
+	if err != nil {
+		return fmt.Errorf("invalid jira.log.path: %w", err)
+	}
+
+	for _, warning := range storeWarnings {
+		logger.Printf("%s", warning)
+	}
+
+	// Looking up the latest release calls GitHub, so it is left to the user to opt in
+	if upgradeCheck {
+		p.checkForUpgrade(logger, jira.LatestReleaseURL)
+	}
+
+	// Turn off the settings depending on features the Jira instance lacks,
+	// such as the agile API on old Jira Server versions
+	var gated []jira.ReportWarning
+	if info, err := client.GetServerInfo(); err != nil {
+		logger.Printf("could not detect the Jira instance, leaving every setting on: %v", err)
+	} else {
+		gated = jira.GateFeatures(info, config)
+		if resolveUsers && !info.Supports(jira.FeatureAccountIDs) {
+			resolveUsers = false
+			gated = append(gated, jira.UnsupportedFeature(info, jira.FeatureAccountIDs, "jira.users.resolve"))
+		}
+		for _, warning := range gated {
+			logger.Printf("%s", warning.Message)
+		}
+	}
+
+	// Jira reads JQL dates in the time zone of the user's profile, unless
+	// jira.query.timezone says otherwise
+	if config.QueryOptions.TimeZone == nil {
+		if location, err := client.UserTimeZone(); err != nil {
+			logger.Printf("could not detect the time zone of the Jira profile, writing JQL dates in the time zone of each report's range: %v", err)
+		} else {
+			config.QueryOptions.TimeZone = location
+		}
+	}
+
+	p.client = client
+	p.ignore = ignore
+	p.pins = pins
+	p.config = config
+	
+	// Create the service
+	p.service = jira.NewActivityService(client.GetRepository())
+	p.service.SetReportOptions(config.ReportOptions)
+	p.service.SetLogger(logger)
+	p.service.SetGatedFeatures(gated)
+	p.service.SetEventSinks(sinks)
+	p.service.SetPublishers(publishers)
+	p.service.SetGenerationInfo(jira.GenerationInfo{PluginVersion: p.Version(), InstanceURL: config.URL})
+	p.service.SetMetricsRecorder(client.GetMetrics())
+	p.service.SetSizeGuard(jira.SizeGuard{
+		MaxReportBytes: config.HTTPOptions.MaxReportBytes,
+		QueryOptions:   config.QueryOptions,
+		ReportOptions:  config.ReportOptions,
+	})
+	if p.summarizer != nil {
+		p.service.SetSummarizer(p.summarizer)
+	}
+	if p.clock != nil {
+		p.service.SetClock(p.clock)
+	}
+
+	// Resolve comment and change authors through a cached user directory
+	if resolveUsers {
+		cachePath := reader.String("jira.users.cache_path")
+		if cachePath == "" {
+			if cachePath, err = jira.DefaultUserCachePath(); err != nil {
+				return err
+			}
+		}
+
+		p.service.SetUserDirectory(jira.NewUserDirectory(client.GetRepository().GetUsers, jira.NewFileUserCache(cachePath), ttl))
+	}
+
+	// Keep the statistics of each report window for the trailing windows
+	if reportOptions.IncludeStats {
+		storePath := reader.String("jira.report.stats.store_path")
+		if storePath == "" {
+			if storePath, err = jira.DefaultStatsStorePath(); err != nil {
+				return err
+			}
+		}
+		p.service.SetStatsStore(jira.NewFileStatsStore(storePath))
+	}
+
+	// Keep the watchers and votes of each issue to measure attention changes
+	if reportOptions.IncludeAttention {
+		storePath := reader.String("jira.report.attention.store_path")
+		if storePath == "" {
+			if storePath, err = jira.DefaultAttentionStorePath(); err != nil {
+				return err
+			}
+		}
+		p.service.SetAttentionStore(jira.NewFileAttentionStore(storePath))
+	}
+
+	// Keep the time of the last report for reports without a time range;
+	// without the store they cover the previous working day
+	if path, warning := storePath(reader, "jira.standup.store_path", jira.DefaultStandupStorePath); warning != "" {
+		logger.Printf("running without the standup store: %s", warning)
+	} else {
+		p.service.SetStandupStore(jira.NewFileStandupStore(path))
+	}
+
+	// Keep the notes pinned to issues
+	if path, warning := storePath(reader, "jira.notes.store_path", jira.DefaultAnnotationStorePath); warning != "" {
+		logger.Printf("running without the notes store: %s", warning)
+	} else {
+		p.service.SetAnnotationStore(jira.NewFileAnnotationStore(path))
+	}
+
+	// Set the formatter based on configuration
+	format := reader.String("jira.format")
+	if format == "" {
+		format = "json" // Default to JSON if not specified
+	}
+
+	p.formatOptions = formatOptions
+	if p.formatter = p.formatterFor(format); p.formatter == nil {
+		p.formatter = jira.NewJSONFormatter()
+	}
+
+	return nil
+}
+
+// storePath returns the path of a local store: the one set under the key, or
+// its default location. When the default cannot be located, such as in a
+// container without a home directory, the path is empty and the warning says
+// why, so that the plugin runs without the store rather than failing to start.
+func storePath(reader *settingsReader, key string, defaultPath func() (string, error)) (string, string) {
+	if path := reader.String(key); path != "" {
+		return path, ""
+	}
+	path, err := defaultPath()
+	if err != nil {
+		return "", fmt.Sprintf("%v; set %s to keep it", err, key)
+	}
+	return path, ""
+}
+
+// reportPublishers creates the publishers the settings configure: Slack
+// through an incoming webhook or a bot token with the channel to post to, and
+// Teams through an incoming webhook
+func reportPublishers(reader *settingsReader) ([]jira.ReportPublisher, error) {
+	publishers := make([]jira.ReportPublisher, 0, 2)
+
+	webhook := reader.String("jira.publish.slack_webhook")
+	token := reader.String("jira.publish.slack_token")
+	channel := reader.String("jira.publish.slack_channel")
+	switch {
+	case webhook != "" && token != "":
+		return nil, fmt.Errorf("invalid jira.publish.slack_webhook: set either a webhook or a bot token, not both")
+	case webhook != "":
+		if !strings.HasPrefix(webhook, "https://") {
+			return nil, fmt.Errorf("invalid jira.publish.slack_webhook: expected an https URL")
+		}
+		publishers = append(publishers, jira.NewSlackWebhookPublisher(webhook, nil))
+	case token != "":
+		if channel == "" {
+			return nil, fmt.Errorf("invalid jira.publish.slack_token: jira.publish.slack_channel is required with a bot token")
+		}
+		publishers = append(publishers, jira.NewSlackBotPublisher(token, channel, nil))
+	}
+
+	if webhook := reader.String("jira.publish.teams_webhook"); webhook != "" {
+		if !strings.HasPrefix(webhook, "https://") {
+			return nil, fmt.Errorf("invalid jira.publish.teams_webhook: expected an https URL")
+		}
+		publishers = append(publishers, jira.NewTeamsPublisher(webhook, nil))
+	}
+	return publishers, nil
+}
+
+// formatterFor returns a formatter for the named format (json, markdown, xml,
+// html, slack or teams) with its jira.format.options applied, or nil when the
+// format is unknown
+func (p *JiraPlugin) formatterFor(format string) jira.ReportFormatter {
+	format = strings.ToLower(strings.TrimSpace(format))
+	// The options were checked against the formatter when the settings were read
+	formatter, _ := newFormatter(format, p.formatOptions[format])
+	return formatter
+}
+
+// newFormatter creates the formatter for the named format with the options
+// applied, returning an error for an unknown format, or options the
+// formatter does not take
+func newFormatter(format string, options jira.FormatterOptions) (jira.ReportFormatter, error) {
+	var formatter jira.ReportFormatter
+	switch format {
+	case "json":
+		formatter = jira.NewJSONFormatter()
+	case "markdown":
+		formatter = jira.NewMarkdownFormatter()
+	case "xml":
+		formatter = jira.NewXMLFormatter()
+	case "html":
+		formatter = jira.NewHTMLFormatter()
+	case "slack":
+		formatter = jira.NewSlackFormatter()
+	case "teams":
+		formatter = jira.NewTeamsFormatter()
+	default:
+		return nil, fmt.Errorf("unknown format %q: expected json, markdown, xml, html, slack or teams", format)
+	}
+
+	if configurable, ok := formatter.(jira.ConfigurableFormatter); ok {
+		if err := configurable.SetOptions(options); err != nil {
+			return nil, err
+		}
+	} else if err := options.CheckKeys(); err != nil {
+		return nil, err
+	}
+	return formatter, nil
+}
+
+// formatOptionsPrefix starts the keys of the formatter options, e.g.
+// jira.format.options.markdown.allow_raw
+const formatOptionsPrefix = "jira.format.options."
+
+// formatOptionShorthands are the top-level settings that set a formatter
+// option, by the format and option they set
+var formatOptionShorthands = map[string][2]string{
+	"jira.format.markdown.allow_raw": {"markdown", "allow_raw"},
+	"jira.format.style":              {"markdown", "style"},
+}
+
+// readFormatOptions reads the jira.format.options.<formatter>.<key> settings
+// by formatter, checking them against the formatters they name. A shorthand
+// such as jira.format.style sets its option unless that is set too.
+func readFormatOptions(reader *settingsReader) (map[string]jira.FormatterOptions, error) {
+	result := make(map[string]jira.FormatterOptions)
+	for key, value := range reader.Prefixed(formatOptionsPrefix) {
+		format, option, ok := strings.Cut(strings.ToLower(key), ".")
+		if !ok || format == "" || option == "" {
+			continue
+		}
+		if result[format] == nil {
+			result[format] = make(jira.FormatterOptions)
+		}
+		result[format][option] = value
+	}
+
+	for key, target := range formatOptionShorthands {
+		value := reader.String(key)
+		if value == "" {
+			continue
+		}
+		format, option := target[0], target[1]
+		if result[format] == nil {
+			result[format] = make(jira.FormatterOptions)
+		}
+		if _, ok := result[format][option]; !ok {
+			result[format][option] = value
+		}
+	}
+
+	// Check the formats in order, so that the same settings give the same error
+	formats := make([]string, 0, len(result))
+	for format := range result {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	for _, format := range formats {
+		if _, err := newFormatter(format, result[format]); err != nil {
+			return nil, fmt.Errorf("invalid %s%s: %w", formatOptionsPrefix, format, err)
+		}
+	}
+	return result, nil
+}
+
+// splitList splits a comma-separated setting into trimmed, non-empty values
+func splitList(value string) []string {
+	parts := strings.Split(value, ",")
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// SetSummarizer wires a summarizer (typically provided by the daiv host) that
+// condenses each issue's activity into a one-line summary
+func (p *JiraPlugin) SetSummarizer(summarizer jira.Summarizer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.summarizer = summarizer
+	if p.service != nil {
+		p.service.SetSummarizer(summarizer)
+	}
+}
+
+// SetClock sets the clock reports read the current time from, such as a
+// jira.FrozenClock for tests or for replaying a past report; nil restores the
+// system clock
+func (p *JiraPlugin) SetClock(clock jira.Clock) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clock = clock
+	if p.service != nil {
+		p.service.SetClock(clock)
+	}
+}
+
+// Reload applies changed settings without restarting daiv. The new
+// configuration is validated and built first; if that fails the current one
+// stays in effect. Reports in flight finish with the previous configuration.
+func (p *JiraPlugin) Reload(settings map[string]interface{}) error {
+	p.mu.RLock()
+	next := &JiraPlugin{summarizer: p.summarizer, clock: p.clock}
+	p.mu.RUnlock()
+
+	if err := next.Initialize(settings); err != nil {
+		return fmt.Errorf("failed to reload configuration: %w", err)
+	}
+
+	p.mu.Lock()
+	previous := p.service
+	p.client = next.client
+	p.config = next.config
+	p.service = next.service
+	p.formatter = next.formatter
+	p.formatOptions = next.formatOptions
+	p.settings = next.settings
+	p.ignore = next.ignore
+	p.pins = next.pins
+	p.mu.Unlock()
+
+	if previous != nil {
+		return previous.Close()
+	}
+	return nil
+}
+
+// SwitchProfile makes the named profile active, reloading the configuration
+// with its keys. An empty name goes back to the top-level keys. The
+// environment takes precedence over the settings, so a profile chosen with
+// JIRA_ACTIVE_PROFILE cannot be switched away from.
+func (p *JiraPlugin) SwitchProfile(name string) error {
+	for _, key := range p.Manifest().ConfigKeys {
+		if key.Key != activeProfileKey {
+			continue
+		}
+		if pinned, ok := envOverrides([]plug.ConfigKey{key})[key.Key]; ok && pinned != name {
+			return fmt.Errorf("cannot switch to profile %q: %s selects profile %q", name, envVarName(key), pinned)
+		}
+	}
+
+	p.mu.RLock()
+	settings := make(map[string]interface{}, len(p.settings)+1)
+	for key, value := range p.settings {
+		settings[key] = value
+	}
+	p.mu.RUnlock()
+
+	settings[activeProfileKey] = name
+	return p.Reload(settings)
+}
+
+// Shutdown performs cleanup when the plugin is being disabled/removed. It waits
+// for reports in flight, then releases the stores and caches.
+func (p *JiraPlugin) Shutdown() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.service == nil {
+		return nil
+	}
+	err := p.service.Close()
+	p.client = nil
+	p.service = nil
+	p.formatter = nil
+	return err
+}
+
+// GetStandupContext implements the StandupPlugin interface. Its report is the
+// one posted to the configured chat services.
+func (p *JiraPlugin) GetStandupContext(timeRange plug.TimeRange) (plug.StandupContext, error) {
+	report, err := p.getReport(timeRange, "", true)
+	if err != nil {
+		return plug.StandupContext{}, err
+	}
+
+	return plug.StandupContext{
+		PluginName: report.PluginName,
+		Content:    report.Content,
+	}, nil
+}
+
+// GetStandupContextWithFormat produces the standup context in the given format
+// (json, markdown, xml, html, slack or teams) for this run only, e.g. JSON for machine
+// processing while the configured jira.format stays the default. An empty
+// format uses the configured one.
+func (p *JiraPlugin) GetStandupContextWithFormat(timeRange plug.TimeRange, format string) (plug.StandupContext, error) {
+	report, err := p.GetReport(timeRange, format)
+	if err != nil {
+		return plug.StandupContext{}, err
+	}
+
+	return plug.StandupContext{
+		PluginName: report.PluginName,
+		Content:    report.Content,
+	}, nil
+}
+
+// GetReportParts produces the report like GetReport, cut into parts of at
+// most maxBytes each, or jira.report.part_size when maxBytes is 0, for hosts
+// with message size limits to take in turn. Each part carries the metadata of
+// the report along with its number under MetadataPart and the number of parts
+// under MetadataParts; a report within the limit is a single part.
+func (p *JiraPlugin) GetReportParts(timeRange plug.TimeRange, format string, maxBytes int) ([]plug.Report, error) {
+	if maxBytes <= 0 {
+		p.mu.RLock()
+		if p.config != nil {
+			maxBytes = p.config.ReportOptions.PartSize
+		}
+		p.mu.RUnlock()
+	}
+
+	report, err := p.GetReport(timeRange, format)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, _ := report.Metadata[MetadataContentType].(string)
+	parts := jira.SplitContent(&jira.FormattedContent{ContentType: contentType, Content: report.Content}, maxBytes)
+
+	reports := make([]plug.Report, len(parts))
+	for i, part := range parts {
+		metadata := make(map[string]interface{}, len(report.Metadata)+2)
+		for key, value := range report.Metadata {
+			metadata[key] = value
+		}
+		metadata[MetadataPart] = i + 1
+		metadata[MetadataParts] = len(parts)
+
+		reports[i] = plug.Report{
+			PluginName: report.PluginName,
+			Content:    part.Content,
+			Metadata:   metadata,
+		}
+	}
+	return reports, nil
+}
+
+// SinceLastStandup returns the range a report without a time range covers
+// when generated now: since the last report, e.g. since Friday 09:30 on a
+// Monday, or since the same time on the previous working day
+func (p *JiraPlugin) SinceLastStandup() (plug.TimeRange, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.service == nil {
+		return plug.TimeRange{}, fmt.Errorf("the Jira plugin is not initialized")
+	}
+
+	timeRange := p.service.SinceLastStandupRange(p.service.Now())
+	return plug.TimeRange{Start: timeRange.Start, End: timeRange.End}, nil
+}
+
+// ParseTimeRange builds the range described by an expression such as
+// "yesterday", "last 3 days" or "2024-05-01..2024-05-07", relative to now; see
+// jira.ParseTimeRange. An empty expression gives the range of a report without
+// a time range, since the last standup.
+func (p *JiraPlugin) ParseTimeRange(expression string) (plug.TimeRange, error) {
+	if strings.TrimSpace(expression) == "" {
+		return p.SinceLastStandup()
+	}
+
+	p.mu.RLock()
+	now := jira.SystemClock.Now()
+	if p.service != nil {
+		now = p.service.Now()
+	}
+	p.mu.RUnlock()
+
+	timeRange, err := jira.ParseTimeRange(expression, now)
+	if err != nil {
+		return plug.TimeRange{}, err
+	}
+	return plug.TimeRange{Start: timeRange.Start, End: timeRange.End}, nil
+}
+
+// EstimateReport estimates the cost of the report over the time range before
+// generating it: the issues it covers, the API calls it makes and roughly how
+// long it takes, so that a long report can be confirmed first
+func (p *JiraPlugin) EstimateReport(timeRange plug.TimeRange) (*jira.ReportEstimate, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.service == nil {
+		return nil, fmt.Errorf("the Jira plugin is not initialized")
+	}
+
+	return p.service.EstimateReport(timeRange)
+}
+
+// AddNote pins a side note to an issue, such as "waiting on infra team", shown
+// with the issue in every subsequent report until the notes are cleared
+func (p *JiraPlugin) AddNote(issueKey, text string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.service == nil {
+		return fmt.Errorf("the Jira plugin is not initialized")
+	}
+	return p.service.AddNote(issueKey, text)
+}
+
+// ClearNotes removes the notes pinned to an issue
+func (p *JiraPlugin) ClearNotes(issueKey string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.service == nil {
+		return fmt.Errorf("the Jira plugin is not initialized")
+	}
+	return p.service.ClearNotes(issueKey)
+}
+
+// IgnoreIssue leaves an issue out of subsequent reports. The ignore list is
+// kept locally next to the configured jira.report.ignore_issues.
+func (p *JiraPlugin) IgnoreIssue(key string) error {
+	return p.updateLocalLists(key, func() error {
+		return jira.UpdateIgnoreList(p.ignore, func(list *jira.IgnoreList) { list.IgnoreIssue(key) })
+	})
+}
+
+// IgnoreEpic leaves an epic and every issue under it out of subsequent reports
+func (p *JiraPlugin) IgnoreEpic(key string) error {
+	return p.updateLocalLists(key, func() error {
+		return jira.UpdateIgnoreList(p.ignore, func(list *jira.IgnoreList) { list.IgnoreEpic(key) })
+	})
+}
+
+// Unignore brings an issue or epic ignored through the API back into reports;
+// keys in the configuration stay ignored
+func (p *JiraPlugin) Unignore(key string) error {
+	return p.updateLocalLists(key, func() error {
+		return jira.UpdateIgnoreList(p.ignore, func(list *jira.IgnoreList) { list.Unignore(key) })
+	})
+}
+
+// Pin always lists an issue with its latest status in subsequent reports,
+// whatever its activity and the query filters
+func (p *JiraPlugin) Pin(key string) error {
+	return p.updateLocalLists(key, func() error {
+		return jira.Pin(p.pins, key)
+	})
+}
+
+// Unpin stops listing an issue pinned through the API; keys in the
+// configuration stay pinned
+func (p *JiraPlugin) Unpin(key string) error {
+	return p.updateLocalLists(key, func() error {
+		return jira.Unpin(p.pins, key)
+	})
+}
+
+// updateLocalLists applies an update to the locally kept ignore or pin list,
+// then reloads the configuration so that the next report honors it
+func (p *JiraPlugin) updateLocalLists(key string, update func() error) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("an issue key is required")
+	}
+
+	p.mu.RLock()
+	if p.service == nil {
+		p.mu.RUnlock()
+		return fmt.Errorf("the Jira plugin is not initialized")
+	}
+	settings := p.settings
+	err := update()
+	p.mu.RUnlock()
+
+	if err != nil {
+		return err
+	}
+	return p.Reload(settings)
+}
+
+// SelfTest checks the setup step by step, answering "is my setup broken?": it
+// reaches Jira, authenticates, validates the configured JQL, fetches an issue,
+// then renders fixture data with each formatter. The result is a pass/fail
+// matrix whose String method renders it for the host to display.
+func (p *JiraPlugin) SelfTest() (*jira.SelfTestResult, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.client == nil {
+		return nil, fmt.Errorf("the Jira plugin is not initialized")
+	}
+
+	formatters := make([]jira.ReportFormatter, 0, 6)
+	for _, format := range []string{"json", "markdown", "xml", "html", "slack", "teams"} {
+		formatters = append(formatters, p.formatterFor(format))
+	}
+	return p.client.SelfTest(formatters), nil
+}
+
+// GetReport produces the report like GetStandupContextWithFormat, along with
+// metadata telling the host how to render it: the MIME type of the content
+// under MetadataContentType and the format name under MetadataFormat. When the
+// activity search was truncated, the metadata also discloses how many issues
+// were shown out of how many matched.
+func (p *JiraPlugin) GetReport(timeRange plug.TimeRange, format string) (plug.Report, error) {
+	return p.getReport(timeRange, format, false)
+}
+
+// getReport produces the report of GetReport, posting it to the configured
+// chat services when publish is set
+func (p *JiraPlugin) getReport(timeRange plug.TimeRange, format string, publish bool) (plug.Report, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.service == nil {
+		return plug.Report{}, fmt.Errorf("the Jira plugin is not initialized")
+	}
+
+	formatter := p.formatter
+	if format != "" {
+		if formatter = p.formatterFor(format); formatter == nil {
+			return plug.Report{}, fmt.Errorf("unknown format %q: expected json, markdown, xml, html, slack or teams", format)
+		}
+	}
+
+	// Get activity report from service
+	getActivityReport := p.service.GetActivityReport
+	if publish {
+		getActivityReport = p.service.GetStandupReport
+	}
+	report, err := getActivityReport(timeRange)
+	if err != nil {
+		return plug.Report{}, fmt.Errorf("failed to get activity report: %w", err)
+	}
+	
+	// An empty report omitted for the format has no content, which the host
+	// leaves out of the standup
+	if report.Omitted(formatter.Name()) {
+		return plug.Report{
+			PluginName: p.Name(),
+			Metadata:   map[string]interface{}{MetadataFormat: formatter.Name(), MetadataEmpty: true, MetadataPluginVersion: p.Version()},
+		}, nil
+	}
+
+	// Format the report using the selected formatter
+	formattedContent, err := formatter.Format(report)
+	if err != nil {
+		return plug.Report{}, fmt.Errorf("failed to format activity report: %w", err)
+	}
+
+	metadata := map[string]interface{}{
+		MetadataContentType:   formattedContent.ContentType,
+		MetadataFormat:        formatter.Name(),
+		MetadataEmpty:         report.IsEmpty(),
+		MetadataPluginVersion: p.Version(),
+	}
+	if report.Truncation != nil {
+		metadata[MetadataIssuesShown] = report.Truncation.Shown
+		metadata[MetadataIssuesTotal] = report.Truncation.Total
+		metadata[MetadataTruncationNotice] = report.Truncation.Notice()
+	}
+
+	return plug.Report{
 		PluginName: p.Name(),
 		Content:    formattedContent.Content,
+		Metadata:   metadata,
 	}, nil
 }
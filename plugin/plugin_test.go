@@ -0,0 +1,431 @@
+package plugin
+
+import (
+	"daiv-jira/plugin/jira"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	plug "github.com/iures/daivplug"
+)
+
+// stubRepository serves a single issue with a comment by the current user,
+// or no issues when idle, recording the time ranges searched
+type stubRepository struct {
+	searched []jira.TimeRange
+	idle     bool
+}
+
+func (r *stubRepository) GetUser() (*jira.User, error) {
+	return &jira.User{AccountID: "user123", DisplayName: "Test User"}, nil
+}
+
+func (r *stubRepository) GetIssues(timeRange jira.TimeRange, userID string) ([]jira.Issue, *jira.SearchTruncation, error) {
+	r.searched = append(r.searched, timeRange)
+	if r.idle {
+		return nil, nil, nil
+	}
+	return []jira.Issue{{
+		Key:     "TEST-1",
+		Summary: "Test issue",
+		Status:  "In Progress",
+		Comments: []jira.Comment{
+			{Timestamp: timeRange.Start.Add(time.Hour), Author: "Test User", AuthorAccountID: userID, Content: "Started"},
+		},
+	}}, nil, nil
+}
+
+func (r *stubRepository) GetSupplementaryIssues(kind jira.SupplementaryQuery, timeRange jira.TimeRange, userID string) ([]jira.Issue, error) {
+	return nil, nil
+}
+
+func (r *stubRepository) GetIssuesByKey(keys []string) ([]jira.Issue, error) {
+	return nil, nil
+}
+
+func (r *stubRepository) GetUsers(accountIDs []string) ([]jira.User, error) {
+	return nil, nil
+}
+
+func (r *stubRepository) GetStatusHistory(key string) ([]jira.Change, error) {
+	return nil, nil
+}
+
+func (r *stubRepository) GetFieldHistory(key string, fields ...string) ([]jira.Change, error) {
+	return nil, nil
+}
+
+func (r *stubRepository) GetAttention(key string) (jira.Attention, error) {
+	return jira.Attention{}, nil
+}
+
+func (r *stubRepository) GetRemoteLinks(key string) ([]jira.RemoteLink, error) {
+	return nil, nil
+}
+
+func (r *stubRepository) GetSprintScope(boardID int, timeRange jira.TimeRange) (*jira.SprintScope, error) {
+	return nil, nil
+}
+
+func (r *stubRepository) GetSprint(id int) (*jira.Sprint, error) {
+	return &jira.Sprint{ID: id}, nil
+}
+
+func (r *stubRepository) ActivityQuery(timeRange jira.TimeRange) (string, error) {
+	return "project = \"TEST\"", nil
+}
+
+func (r *stubRepository) EstimateSearch(timeRange jira.TimeRange) (jira.SearchEstimate, error) {
+	return jira.SearchEstimate{Matching: 3, Fetched: 3, Calls: 2}, nil
+}
+
+// newStubPlugin returns a plugin reporting from the stub repository in Markdown
+func newStubPlugin() (*JiraPlugin, *stubRepository) {
+	repository := &stubRepository{}
+	p := New()
+	p.config = &jira.JiraConfig{ReportOptions: jira.DefaultReportOptions()}
+	p.service = jira.NewActivityService(repository)
+	p.formatter = p.formatterFor("markdown")
+	return p, repository
+}
+
+func TestJiraPlugin_GetStandupContextWithFormat(t *testing.T) {
+	timeRange := plug.TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name           string
+		format         string
+		expectedPrefix string
+		expectError    bool
+	}{
+		{name: "Configured format", format: "", expectedPrefix: "# Jira Activity Report"},
+		{name: "JSON for this run", format: "json", expectedPrefix: "{"},
+		{name: "Case-insensitive", format: " HTML ", expectedPrefix: "<"},
+		{name: "Unknown format", format: "yaml", expectError: true},
+	}
+
+	// Run tests
+	p, _ := newStubPlugin()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			standupContext, err := p.GetStandupContextWithFormat(timeRange, tc.format)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(strings.TrimSpace(standupContext.Content), tc.expectedPrefix) {
+				t.Errorf("Expected content starting with '%s', got:\n%s", tc.expectedPrefix, standupContext.Content)
+			}
+		})
+	}
+
+	// The override does not change the configured format
+	standupContext, err := p.GetStandupContext(timeRange)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(standupContext.Content, "# Jira Activity Report") {
+		t.Errorf("Expected the configured Markdown format, got:\n%s", standupContext.Content)
+	}
+}
+
+func TestJiraPlugin_GetReport(t *testing.T) {
+	timeRange := plug.TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		format              string
+		expectedContentType string
+		expectedFormat      string
+	}{
+		{format: "", expectedContentType: "text/markdown", expectedFormat: "markdown"},
+		{format: "json", expectedContentType: "application/json", expectedFormat: "json"},
+		{format: "xml", expectedContentType: "application/xml", expectedFormat: "xml"},
+		{format: "html", expectedContentType: "text/html", expectedFormat: "html"},
+		{format: "slack", expectedContentType: "text/plain", expectedFormat: "slack"},
+		{format: "teams", expectedContentType: "application/vnd.microsoft.card.adaptive+json", expectedFormat: "teams"},
+	}
+
+	// Run tests
+	p, _ := newStubPlugin()
+	for _, tc := range testCases {
+		t.Run(tc.expectedFormat, func(t *testing.T) {
+			report, err := p.GetReport(timeRange, tc.format)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if report.PluginName != "daiv-jira" || report.Content == "" {
+				t.Errorf("Expected content from daiv-jira, got %+v", report)
+			}
+			if report.Metadata[MetadataContentType] != tc.expectedContentType {
+				t.Errorf("Expected content type '%s', got '%v'", tc.expectedContentType, report.Metadata[MetadataContentType])
+			}
+			if report.Metadata[MetadataFormat] != tc.expectedFormat {
+				t.Errorf("Expected format '%s', got '%v'", tc.expectedFormat, report.Metadata[MetadataFormat])
+			}
+			if report.Metadata[MetadataPluginVersion] != p.Version() {
+				t.Errorf("Expected plugin version '%s', got '%v'", p.Version(), report.Metadata[MetadataPluginVersion])
+			}
+		})
+	}
+}
+
+func TestJiraPlugin_GetReport_Empty(t *testing.T) {
+	timeRange := plug.TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	policy, err := jira.ParseEmptyReportPolicy("omit, json:document")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p, repository := newStubPlugin()
+	repository.idle = true
+	options := jira.DefaultReportOptions()
+	options.EmptyReports = policy
+	p.service.SetReportOptions(options)
+
+	// The configured Markdown leaves the plugin out of the standup
+	standupContext, err := p.GetStandupContextWithFormat(timeRange, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if standupContext.String() != "" {
+		t.Errorf("Expected the plugin to be left out of the standup, got %q", standupContext.String())
+	}
+
+	// JSON still produces a document, flagged as empty
+	report, err := p.GetReport(timeRange, "json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(report.Content, `"timeRange"`) || report.Metadata[MetadataEmpty] != true {
+		t.Errorf("Expected an empty JSON document flagged as empty, got %q with %v", report.Content, report.Metadata)
+	}
+}
+
+func TestJiraPlugin_GetReportParts(t *testing.T) {
+	timeRange := plug.TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	p, _ := newStubPlugin()
+
+	whole, err := p.GetReport(timeRange, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Within the configured size the report is a single part
+	p.config.ReportOptions.PartSize = len(whole.Content)
+	parts, err := p.GetReportParts(timeRange, "", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(parts) != 1 || parts[0].Content != whole.Content || parts[0].Metadata[MetadataParts] != 1 {
+		t.Fatalf("Expected the whole report as one part, got %+v", parts)
+	}
+
+	// A smaller limit from the host takes precedence
+	parts, err = p.GetReportParts(timeRange, "", len(whole.Content)/2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(parts) < 2 {
+		t.Fatalf("Expected several parts, got %d", len(parts))
+	}
+	for i, part := range parts {
+		if len(part.Content) > len(whole.Content)/2 {
+			t.Errorf("Expected part %d within %d bytes, got %d", i+1, len(whole.Content)/2, len(part.Content))
+		}
+		if part.Metadata[MetadataPart] != i+1 || part.Metadata[MetadataParts] != len(parts) || part.Metadata[MetadataFormat] != "markdown" {
+			t.Errorf("Expected the part number and report metadata, got %v", part.Metadata)
+		}
+	}
+}
+
+// recordingPublisher counts the reports posted to it
+type recordingPublisher struct {
+	published int
+}
+
+func (r *recordingPublisher) Publish(report *jira.ActivityReport) error {
+	r.published++
+	return nil
+}
+
+func (r *recordingPublisher) Name() string { return "recording" }
+
+func TestJiraPlugin_Publish(t *testing.T) {
+	timeRange := plug.TimeRange{
+		Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name              string
+		report            func(p *JiraPlugin) error
+		expectedPublished int
+	}{
+		{
+			name: "Standup context",
+			report: func(p *JiraPlugin) error {
+				_, err := p.GetStandupContext(timeRange)
+				return err
+			},
+			expectedPublished: 1,
+		},
+		{
+			name: "Standup context in another format",
+			report: func(p *JiraPlugin) error {
+				_, err := p.GetStandupContextWithFormat(timeRange, "json")
+				return err
+			},
+		},
+		{
+			name: "Report",
+			report: func(p *JiraPlugin) error {
+				_, err := p.GetReport(timeRange, "")
+				return err
+			},
+		},
+		{
+			name: "Report parts",
+			report: func(p *JiraPlugin) error {
+				_, err := p.GetReportParts(timeRange, "", 0)
+				return err
+			},
+		},
+		{
+			name: "Weekly context",
+			report: func(p *JiraPlugin) error {
+				_, err := p.GetWeeklyContext(timeRange.End)
+				return err
+			},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newStubPlugin()
+			publisher := &recordingPublisher{}
+			p.service.SetPublishers([]jira.ReportPublisher{publisher})
+
+			if err := tc.report(p); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if publisher.published != tc.expectedPublished {
+				t.Errorf("Expected %d published reports, got %d", tc.expectedPublished, publisher.published)
+			}
+		})
+	}
+}
+
+func TestJiraPlugin_Shutdown(t *testing.T) {
+	p, _ := newStubPlugin()
+
+	if err := p.Shutdown(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := p.GetStandupContext(plug.TimeRange{}); err == nil {
+		t.Errorf("Expected an error after shutdown but got nil")
+	}
+	if err := p.Shutdown(); err != nil {
+		t.Errorf("Expected a second shutdown to be a no-op, got %v", err)
+	}
+}
+
+func TestJiraPlugin_SinceLastStandup(t *testing.T) {
+	p, _ := newStubPlugin()
+	lastReport := time.Now().Add(-20 * time.Hour).Truncate(time.Minute)
+	store := jira.NewFileStandupStore(filepath.Join(t.TempDir(), "standup.json"))
+	if err := store.RecordReport(lastReport); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	p.service.SetStandupStore(store)
+
+	timeRange, err := p.SinceLastStandup()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !timeRange.Start.Equal(lastReport) || !timeRange.End.After(lastReport) {
+		t.Errorf("Expected the range to start at the last report, got %+v", timeRange)
+	}
+}
+
+func TestJiraPlugin_ParseTimeRange(t *testing.T) {
+	p, _ := newStubPlugin()
+	lastReport := time.Now().Add(-2 * time.Hour)
+	store := jira.NewFileStandupStore(filepath.Join(t.TempDir(), "standup.json"))
+	if err := store.RecordReport(lastReport); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	p.service.SetStandupStore(store)
+
+	// Without an expression the range is the default one, since the last standup
+	timeRange, err := p.ParseTimeRange(" ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !timeRange.Start.Equal(lastReport) {
+		t.Errorf("Expected the range to start at the last report, got %+v", timeRange)
+	}
+
+	timeRange, err = p.ParseTimeRange("2023-01-02..2023-01-06")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if days := timeRange.End.Sub(timeRange.Start); days != 5*24*time.Hour {
+		t.Errorf("Expected a range of 5 days, got %v", days)
+	}
+
+	if _, err := p.ParseTimeRange("next week"); err == nil {
+		t.Error("Expected an error for an unknown expression")
+	}
+}
+
+func TestJiraPlugin_SetClock(t *testing.T) {
+	p, _ := newStubPlugin()
+	p.SetClock(jira.FrozenClock(time.Date(2023, 1, 9, 9, 30, 0, 0, time.UTC)))
+
+	timeRange, err := p.ParseTimeRange("yesterday")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC); !timeRange.Start.Equal(expected) {
+		t.Errorf("Expected the range to start at %v, got %+v", expected, timeRange)
+	}
+
+	// Without a last standup the default range starts on the previous workday
+	timeRange, err = p.SinceLastStandup()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := time.Date(2023, 1, 6, 9, 30, 0, 0, time.UTC); !timeRange.Start.Equal(expected) {
+		t.Errorf("Expected the range to start at %v, got %+v", expected, timeRange)
+	}
+}
+
+func TestJiraPlugin_SelfTest_NotInitialized(t *testing.T) {
+	if _, err := New().SelfTest(); err == nil {
+		t.Error("Expected an error before the plugin is initialized")
+	}
+}
@@ -0,0 +1,66 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// profilesPrefix starts the keys of a named profile, e.g. jira.profiles.clientA.url
+const profilesPrefix = "jira.profiles."
+
+// activeProfileKey names the profile whose keys override the top-level ones
+const activeProfileKey = "jira.active_profile"
+
+// applyProfile returns the settings with the keys of the active profile laid
+// over the top-level jira.* keys, so that jira.profiles.clientA.project
+// replaces jira.project while clientA is active. Keys the profile leaves out
+// keep their top-level value. The settings are returned unchanged when no
+// profile is active.
+func applyProfile(settings map[string]interface{}) (map[string]interface{}, error) {
+	active, _ := settings[activeProfileKey].(string)
+	active = strings.TrimSpace(active)
+	if active == "" {
+		return settings, nil
+	}
+
+	prefix := profilesPrefix + active + "."
+	result := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		result[key] = value
+	}
+
+	found := false
+	for key, value := range settings {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			result["jira."+strings.TrimPrefix(key, prefix)] = value
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown profile %q in %s (known profiles: %s)", active, activeProfileKey, strings.Join(profileNames(settings), ", "))
+	}
+
+	return result, nil
+}
+
+// profileNames returns the names of the profiles defined in the settings, sorted
+func profileNames(settings map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	for key := range settings {
+		if !strings.HasPrefix(key, profilesPrefix) {
+			continue
+		}
+		name, _, ok := strings.Cut(strings.TrimPrefix(key, profilesPrefix), ".")
+		if ok && name != "" {
+			seen[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,94 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyProfile(t *testing.T) {
+	settings := map[string]interface{}{
+		"jira.url":                        "https://own.atlassian.net",
+		"jira.project":                    "OWN",
+		"jira.format":                     "markdown",
+		"jira.profiles.clientA.url":       "https://client-a.atlassian.net",
+		"jira.profiles.clientA.project":   "CA",
+		"jira.profiles.clientB.project":   "CB",
+		"jira.profiles.clientB.format":    "html",
+		"jira.profiles.clientB.query.jql": "project = CB",
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name        string
+		active      string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:     "No active profile",
+			active:   "",
+			expected: map[string]string{"jira.url": "https://own.atlassian.net", "jira.project": "OWN", "jira.format": "markdown"},
+		},
+		{
+			name:     "Profile overrides its keys",
+			active:   "clientA",
+			expected: map[string]string{"jira.url": "https://client-a.atlassian.net", "jira.project": "CA", "jira.format": "markdown"},
+		},
+		{
+			name:     "Nested keys",
+			active:   " clientB ",
+			expected: map[string]string{"jira.url": "https://own.atlassian.net", "jira.project": "CB", "jira.format": "html", "jira.query.jql": "project = CB"},
+		},
+		{
+			name:        "Unknown profile",
+			active:      "clientC",
+			expectError: true,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := make(map[string]interface{}, len(settings)+1)
+			for key, value := range settings {
+				input[key] = value
+			}
+			input[activeProfileKey] = tc.active
+
+			result, err := applyProfile(input)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for key, expected := range tc.expected {
+				if result[key] != expected {
+					t.Errorf("Expected %s to be '%s', got '%v'", key, expected, result[key])
+				}
+			}
+			if input["jira.project"] != "OWN" {
+				t.Errorf("Expected the input settings to be left unchanged, got %v", input["jira.project"])
+			}
+		})
+	}
+}
+
+func TestProfileNames(t *testing.T) {
+	settings := map[string]interface{}{
+		"jira.project":                  "OWN",
+		"jira.profiles.clientB.project": "CB",
+		"jira.profiles.clientA.url":     "https://client-a.atlassian.net",
+		"jira.profiles.clientA.project": "CA",
+		"jira.profiles.":                "ignored",
+	}
+
+	expected := []string{"clientA", "clientB"}
+	if names := profileNames(settings); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+}
@@ -0,0 +1,159 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// settingsReader reads typed values out of the settings map passed by the
+// host. Values may arrive as strings, booleans or numbers depending on how
+// they were set, and are coerced to the requested type. A missing key, a nil
+// value or an empty string leaves the target at its default; a value that
+// cannot be coerced is recorded as an error naming the key, so that every
+// invalid setting is reported at once by Err.
+type settingsReader struct {
+	values map[string]interface{}
+	errs   []error
+}
+
+// newSettingsReader creates a reader over the settings
+func newSettingsReader(values map[string]interface{}) *settingsReader {
+	return &settingsReader{values: values}
+}
+
+// Err returns the errors recorded so far, or nil
+func (s *settingsReader) Err() error {
+	return errors.Join(s.errs...)
+}
+
+// fail records an error for a key
+func (s *settingsReader) fail(key, format string, args ...interface{}) {
+	s.errs = append(s.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+}
+
+// raw returns the value of a key as a string, and whether it is set
+func (s *settingsReader) raw(key string) (string, bool) {
+	switch value := s.values[key].(type) {
+	case nil:
+		return "", false
+	case string:
+		return value, value != ""
+	case bool:
+		return strconv.FormatBool(value), true
+	case int:
+		return strconv.Itoa(value), true
+	case int64:
+		return strconv.FormatInt(value, 10), true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	default:
+		s.fail(key, "expected a string, got %T", value)
+		return "", false
+	}
+}
+
+// String returns the value of a key, or an empty string when it is not set
+func (s *settingsReader) String(key string) string {
+	value, _ := s.raw(key)
+	return value
+}
+
+// Required returns the value of a key, recording an error when it is not set
+func (s *settingsReader) Required(key string) string {
+	recorded := len(s.errs)
+	value, ok := s.raw(key)
+	if (!ok || strings.TrimSpace(value) == "") && len(s.errs) == recorded {
+		s.fail(key, "required setting is missing")
+	}
+	return value
+}
+
+// Bool sets the target from a boolean key such as true/false
+func (s *settingsReader) Bool(key string, target *bool) {
+	value, ok := s.raw(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		s.fail(key, "expected true or false, got %q", value)
+		return
+	}
+	*target = parsed
+}
+
+// Int sets the target from a whole number key, which must be at least min
+func (s *settingsReader) Int(key string, target *int, min int) {
+	var parsed int64
+	if s.int64(key, &parsed, int64(min)) {
+		if parsed > math.MaxInt {
+			s.fail(key, "%d is too large", parsed)
+			return
+		}
+		*target = int(parsed)
+	}
+}
+
+// Int64 sets the target from a whole number key, which must be at least min
+func (s *settingsReader) Int64(key string, target *int64, min int64) {
+	s.int64(key, target, min)
+}
+
+// int64 parses a whole number key into the target, reporting whether it was set
+func (s *settingsReader) int64(key string, target *int64, min int64) bool {
+	value, ok := s.raw(key)
+	if !ok {
+		return false
+	}
+	parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		s.fail(key, "expected a whole number, got %q", value)
+		return false
+	}
+	if parsed < min {
+		s.fail(key, "expected at least %d, got %d", min, parsed)
+		return false
+	}
+	*target = parsed
+	return true
+}
+
+// Duration sets the target from a duration key such as 12h
+func (s *settingsReader) Duration(key string, target *time.Duration) {
+	value, ok := s.raw(key)
+	if !ok {
+		return
+	}
+	parsed, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		s.fail(key, "expected a duration such as 12h, got %q", value)
+		return
+	}
+	*target = parsed
+}
+
+// List sets the target from a comma-separated key
+func (s *settingsReader) List(key string, target *[]string) {
+	if value, ok := s.raw(key); ok {
+		*target = splitList(value)
+	}
+}
+
+// Prefixed returns the values of the keys starting with the prefix by the
+// rest of the key, e.g. markdown.allow_raw under jira.format.options.
+func (s *settingsReader) Prefixed(prefix string) map[string]string {
+	result := make(map[string]string)
+	for key := range s.values {
+		if !strings.HasPrefix(key, prefix) || len(key) == len(prefix) {
+			continue
+		}
+		if value, ok := s.raw(key); ok {
+			result[strings.TrimPrefix(key, prefix)] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,413 @@
+package plugin
+
+import (
+	"daiv-jira/plugin/jira"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSettingsReader(t *testing.T) {
+	reader := newSettingsReader(map[string]interface{}{
+		"string":      "value",
+		"nil":         nil,
+		"bool":        true,
+		"bool_string": " TRUE ",
+		"int":         25,
+		"float":       float64(50),
+		"int_string":  "75",
+		"duration":    "12h",
+		"list":        "Bug, Task,,",
+	})
+
+	if value := reader.String("string"); value != "value" {
+		t.Errorf("Expected 'value', got '%s'", value)
+	}
+	if value := reader.String("nil"); value != "" {
+		t.Errorf("Expected nil to read as unset, got '%s'", value)
+	}
+
+	enabled, enabledString, unset := false, false, true
+	reader.Bool("bool", &enabled)
+	reader.Bool("bool_string", &enabledString)
+	reader.Bool("missing", &unset)
+	if !enabled || !enabledString || !unset {
+		t.Errorf("Expected booleans to be coerced and defaults kept, got %v, %v, %v", enabled, enabledString, unset)
+	}
+
+	var fromInt, fromFloat, fromString int
+	reader.Int("int", &fromInt, 0)
+	reader.Int("float", &fromFloat, 0)
+	reader.Int("int_string", &fromString, 0)
+	if fromInt != 25 || fromFloat != 50 || fromString != 75 {
+		t.Errorf("Expected numbers to be coerced, got %d, %d, %d", fromInt, fromFloat, fromString)
+	}
+
+	ttl := time.Hour
+	reader.Duration("duration", &ttl)
+	if ttl != 12*time.Hour {
+		t.Errorf("Expected 12h, got %v", ttl)
+	}
+
+	var list []string
+	reader.List("list", &list)
+	if !reflect.DeepEqual(list, []string{"Bug", "Task"}) {
+		t.Errorf("Expected [Bug Task], got %v", list)
+	}
+
+	prefixed := newSettingsReader(map[string]interface{}{
+		"jira.format.options.markdown.allow_raw": true,
+		"jira.format.options.html.theme":         "dark",
+		"jira.format.options.":                   "ignored",
+		"jira.format":                            "markdown",
+	}).Prefixed("jira.format.options.")
+	if !reflect.DeepEqual(prefixed, map[string]string{"markdown.allow_raw": "true", "html.theme": "dark"}) {
+		t.Errorf("Expected the keys under the prefix, got %v", prefixed)
+	}
+
+	if err := reader.Err(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestSettingsReader_Errors(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		value    interface{}
+		read     func(reader *settingsReader)
+		expected string
+	}{
+		{
+			name:     "Missing required",
+			value:    nil,
+			read:     func(reader *settingsReader) { reader.Required("key") },
+			expected: "key: required setting is missing",
+		},
+		{
+			name:     "Blank required",
+			value:    "  ",
+			read:     func(reader *settingsReader) { reader.Required("key") },
+			expected: "key: required setting is missing",
+		},
+		{
+			name:     "Wrong type",
+			value:    []string{"a"},
+			read:     func(reader *settingsReader) { reader.Required("key") },
+			expected: "key: expected a string, got []string",
+		},
+		{
+			name:     "Invalid boolean",
+			value:    "yes please",
+			read:     func(reader *settingsReader) { reader.Bool("key", new(bool)) },
+			expected: `key: expected true or false, got "yes please"`,
+		},
+		{
+			name:     "Invalid number",
+			value:    "ten",
+			read:     func(reader *settingsReader) { reader.Int("key", new(int), 0) },
+			expected: `key: expected a whole number, got "ten"`,
+		},
+		{
+			name:     "Fractional number",
+			value:    2.5,
+			read:     func(reader *settingsReader) { reader.Int("key", new(int), 0) },
+			expected: `key: expected a whole number, got "2.5"`,
+		},
+		{
+			name:     "Number below minimum",
+			value:    "0",
+			read:     func(reader *settingsReader) { reader.Int64("key", new(int64), 1) },
+			expected: "key: expected at least 1, got 0",
+		},
+		{
+			name:     "Invalid duration",
+			value:    "a day",
+			read:     func(reader *settingsReader) { reader.Duration("key", new(time.Duration)) },
+			expected: `key: expected a duration such as 12h, got "a day"`,
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := newSettingsReader(map[string]interface{}{"key": tc.value})
+			tc.read(reader)
+
+			err := reader.Err()
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("Expected error '%s', got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestJiraPlugin_Initialize_InvalidSettings(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		settings map[string]interface{}
+		expected []string
+	}{
+		{
+			name:     "No settings",
+			settings: map[string]interface{}{},
+			expected: []string{"jira.username", "jira.token", "jira.url", "jira.project"},
+		},
+		{
+			name: "Nil and invalid values",
+			settings: map[string]interface{}{
+				"jira.username":            nil,
+				"jira.token":               "secret",
+				"jira.url":                 42,
+				"jira.project":             "TEST",
+				"jira.query.max_results":   "lots",
+				"jira.report.heatmap":      "sometimes",
+				"jira.users.cache_ttl":     "a day",
+				"jira.http.max_concurrent": -1,
+			},
+			expected: []string{"jira.username: required", "jira.query.max_results", "jira.report.heatmap", "jira.users.cache_ttl", "jira.http.max_concurrent"},
+		},
+		{
+			name: "Unknown event sink",
+			settings: map[string]interface{}{
+				"jira.username": "user@example.com",
+				"jira.token":    "secret",
+				"jira.url":      "https://example.atlassian.net",
+				"jira.project":  "TEST",
+				"jira.sinks":    "stdout, kafka://events",
+			},
+			expected: []string{"invalid jira.sinks", `unknown event sink "kafka://events"`},
+		},
+		{
+			name: "Slack bot token without a channel",
+			settings: map[string]interface{}{
+				"jira.username":            "user@example.com",
+				"jira.token":               "secret",
+				"jira.url":                 "https://example.atlassian.net",
+				"jira.project":             "TEST",
+				"jira.publish.slack_token": "xoxb-secret",
+			},
+			expected: []string{"invalid jira.publish.slack_token", "jira.publish.slack_channel is required"},
+		},
+		{
+			name: "Slack webhook and bot token",
+			settings: map[string]interface{}{
+				"jira.username":              "user@example.com",
+				"jira.token":                 "secret",
+				"jira.url":                   "https://example.atlassian.net",
+				"jira.project":               "TEST",
+				"jira.publish.slack_webhook": "https://hooks.slack.com/services/T000/B000/secret",
+				"jira.publish.slack_token":   "xoxb-secret",
+			},
+			expected: []string{"invalid jira.publish.slack_webhook", "not both"},
+		},
+		{
+			name: "Teams webhook over plain HTTP",
+			settings: map[string]interface{}{
+				"jira.username":              "user@example.com",
+				"jira.token":                 "secret",
+				"jira.url":                   "https://example.atlassian.net",
+				"jira.project":               "TEST",
+				"jira.publish.teams_webhook": "http://example.webhook.office.com/webhookb2/secret",
+			},
+			expected: []string{"invalid jira.publish.teams_webhook", "https"},
+		},
+		{
+			name: "Unknown report section",
+			settings: map[string]interface{}{
+				"jira.username":        "user@example.com",
+				"jira.token":           "secret",
+				"jira.url":             "https://example.atlassian.net",
+				"jira.project":         "TEST",
+				"jira.report.sections": "comments, attachments",
+			},
+			expected: []string{"invalid jira.report.sections", `unknown section "attachments"`},
+		},
+		{
+			name: "Unknown log mode",
+			settings: map[string]interface{}{
+				"jira.username": "user@example.com",
+				"jira.token":    "secret",
+				"jira.url":      "https://example.atlassian.net",
+				"jira.project":  "TEST",
+				"jira.log.mode": "verbose",
+			},
+			expected: []string{"invalid jira.log.mode", `unknown log mode "verbose"`},
+		},
+		{
+			name: "Stdout sink in machine mode",
+			settings: map[string]interface{}{
+				"jira.username": "user@example.com",
+				"jira.token":    "secret",
+				"jira.url":      "https://example.atlassian.net",
+				"jira.project":  "TEST",
+				"jira.log.mode": "machine",
+				"jira.sinks":    "file:/tmp/events.ndjson, stdout",
+			},
+			expected: []string{"invalid jira.sinks", "machine mode"},
+		},
+		{
+			name: "Unknown formatter option",
+			settings: map[string]interface{}{
+				"jira.username":                        "user@example.com",
+				"jira.token":                           "secret",
+				"jira.url":                             "https://example.atlassian.net",
+				"jira.project":                         "TEST",
+				"jira.format.options.markdown.columns": "3",
+			},
+			expected: []string{"invalid jira.format.options.markdown", "unknown option columns"},
+		},
+		{
+			name: "Options for a format without options",
+			settings: map[string]interface{}{
+				"jira.username":                   "user@example.com",
+				"jira.token":                      "secret",
+				"jira.url":                        "https://example.atlassian.net",
+				"jira.project":                    "TEST",
+				"jira.format.options.json.indent": "4",
+			},
+			expected: []string{"invalid jira.format.options.json", "takes no options"},
+		},
+		{
+			name: "Invalid legacy allow raw",
+			settings: map[string]interface{}{
+				"jira.username":                  "user@example.com",
+				"jira.token":                     "secret",
+				"jira.url":                       "https://example.atlassian.net",
+				"jira.project":                   "TEST",
+				"jira.format.markdown.allow_raw": "sometimes",
+			},
+			expected: []string{"invalid jira.format.options.markdown", "allow_raw: expected true or false"},
+		},
+		{
+			name: "Reference lookups beyond the ceiling",
+			settings: map[string]interface{}{
+				"jira.username":                 "user@example.com",
+				"jira.token":                    "secret",
+				"jira.url":                      "https://example.atlassian.net",
+				"jira.project":                  "TEST",
+				"jira.report.reference_lookups": 1000,
+			},
+			expected: []string{"jira.report.reference_lookups", "more than the 200 allowed"},
+		},
+		{
+			name: "Unknown time zone",
+			settings: map[string]interface{}{
+				"jira.username":       "user@example.com",
+				"jira.token":          "secret",
+				"jira.url":            "https://example.atlassian.net",
+				"jira.project":        "TEST",
+				"jira.query.timezone": "Mars/Olympus_Mons",
+			},
+			expected: []string{"invalid jira.query.timezone", "Mars/Olympus_Mons"},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := New().Initialize(tc.settings)
+			if err == nil {
+				t.Fatalf("Expected an error but got nil")
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("Expected the error to mention '%s', got: %v", expected, err)
+				}
+			}
+		})
+	}
+}
+
+func TestReadFormatOptions(t *testing.T) {
+	reader := newSettingsReader(map[string]interface{}{
+		"jira.format.markdown.allow_raw":         "true",
+		"jira.format.options.Markdown.allow_raw": false,
+		"jira.format.options.html":               "ignored",
+		"jira.format.style":                      "compact",
+	})
+
+	options, err := readFormatOptions(reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The option wins over the shorthand
+	expected := map[string]jira.FormatterOptions{"markdown": {"allow_raw": "false", "style": "compact"}}
+	if !reflect.DeepEqual(options, expected) {
+		t.Errorf("Expected %v, got %v", expected, options)
+	}
+}
+
+func TestJiraPlugin_Initialize_WithoutLocalStores(t *testing.T) {
+	// A Jira that answers nothing leaves the instance and time zone undetected
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	// Neither a cache nor a config directory can be located, as in CI
+	t.Setenv("HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	corrupt := filepath.Join(t.TempDir(), "ignore.json")
+	if err := os.WriteFile(corrupt, []byte("{"), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name          string
+		settings      map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:          "No default location",
+			expectedError: "no pin store is configured",
+		},
+		{
+			name: "Unreadable ignore list",
+			settings: map[string]interface{}{
+				"jira.report.ignore.store_path": corrupt,
+				"jira.report.pins.store_path":   filepath.Join(t.TempDir(), "pins.json"),
+			},
+		},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := map[string]interface{}{
+				"jira.username": "user@example.com",
+				"jira.token":    "secret",
+				"jira.url":      server.URL,
+				"jira.project":  "TEST",
+				"jira.log.mode": "quiet",
+			}
+			for key, value := range tc.settings {
+				settings[key] = value
+			}
+
+			p := New()
+			if err := p.Initialize(settings); err != nil {
+				t.Fatalf("Expected the plugin to start without its local stores, got %v", err)
+			}
+			timeRange, err := p.SinceLastStandup()
+			if err != nil || timeRange.Start.IsZero() {
+				t.Errorf("Expected the range since the previous working day, got %+v and %v", timeRange, err)
+			}
+
+			err = p.Pin("TEST-1")
+			if tc.expectedError == "" && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if tc.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedError)) {
+				t.Errorf("Expected an error mentioning '%s', got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package plugin
+
+import (
+	"daiv-jira/plugin/jira"
+)
+
+// Build information, set at build time with
+// -ldflags "-X daiv-jira/plugin.version=v1.2.3 -X daiv-jira/plugin.commit=abc1234";
+// the Makefile sets both from git
+var (
+	version = "dev"
+	commit  = ""
+)
+
+// Version returns the version of the plugin and, when known, the commit it
+// was built from, e.g. "v1.2.3 (abc1234)"
+func (p *JiraPlugin) Version() string {
+	if commit == "" {
+		return version
+	}
+	return version + " (" + commit + ")"
+}
+
+// checkForUpgrade logs when a newer release of the plugin is published. A
+// failed lookup is logged too, but never fails the plugin.
+func (p *JiraPlugin) checkForUpgrade(logger jira.Logger, releaseURL string) {
+	release, err := jira.CheckForUpgrade(nil, releaseURL, version)
+	if err != nil {
+		logger.Printf("could not check for a newer release: %v", err)
+		return
+	}
+	if release != nil {
+		logger.Printf("daiv-jira %s is available, this is %s: %s", release.Version, version, release.URL)
+	}
+}
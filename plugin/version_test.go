@@ -0,0 +1,52 @@
+package plugin
+
+import (
+	"daiv-jira/plugin/jira"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestJiraPlugin_Version(t *testing.T) {
+	defer func(v, c string) { version, commit = v, c }(version, commit)
+
+	// Setup test cases
+	testCases := []struct {
+		version  string
+		commit   string
+		expected string
+	}{
+		{version: "dev", expected: "dev"},
+		{version: "v1.4.0", commit: "abc1234", expected: "v1.4.0 (abc1234)"},
+	}
+
+	// Run tests
+	for _, tc := range testCases {
+		version, commit = tc.version, tc.commit
+		if got := New().Version(); got != tc.expected {
+			t.Errorf("Expected version %q, got %q", tc.expected, got)
+		}
+	}
+}
+
+func TestJiraPlugin_CheckForUpgrade(t *testing.T) {
+	defer func(v string) { version = v }(version)
+	version = "v1.3.2"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name":"v1.4.0","html_url":"https://github.com/iures/daiv-jira/releases/tag/v1.4.0"}`))
+	}))
+	defer server.Close()
+
+	var logged []string
+	logger := jira.LoggerFunc(func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	})
+	New().checkForUpgrade(logger, server.URL)
+
+	if len(logged) != 1 || !strings.Contains(logged[0], "daiv-jira v1.4.0 is available, this is v1.3.2") {
+		t.Errorf("Expected the newer release to be logged, got %q", logged)
+	}
+}